@@ -258,6 +258,55 @@ func (m *Empty) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Empty proto.InternalMessageInfo
 
+// DriveEvent is sent by WatchDrives when the drive appears, disappears or its health is changed
+type DriveEvent struct {
+	// ADDED, REMOVED or CHANGED
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Drive                *Drive   `protobuf:"bytes,2,opt,name=drive,proto3" json:"drive,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DriveEvent) Reset()         { *m = DriveEvent{} }
+func (m *DriveEvent) String() string { return proto.CompactTextString(m) }
+func (*DriveEvent) ProtoMessage()    {}
+func (*DriveEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65bf77650f5c7dcf, []int{6}
+}
+
+func (m *DriveEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DriveEvent.Unmarshal(m, b)
+}
+func (m *DriveEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DriveEvent.Marshal(b, m, deterministic)
+}
+func (m *DriveEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DriveEvent.Merge(m, src)
+}
+func (m *DriveEvent) XXX_Size() int {
+	return xxx_messageInfo_DriveEvent.Size(m)
+}
+func (m *DriveEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_DriveEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DriveEvent proto.InternalMessageInfo
+
+func (m *DriveEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *DriveEvent) GetDrive() *Drive {
+	if m != nil {
+		return m.Drive
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*DrivesRequest)(nil), "v1api.DrivesRequest")
 	proto.RegisterType((*DrivesResponse)(nil), "v1api.DrivesResponse")
@@ -265,32 +314,35 @@ func init() {
 	proto.RegisterType((*DriveLocateResponse)(nil), "v1api.DriveLocateResponse")
 	proto.RegisterType((*NodeLocateRequest)(nil), "v1api.NodeLocateRequest")
 	proto.RegisterType((*Empty)(nil), "v1api.Empty")
+	proto.RegisterType((*DriveEvent)(nil), "v1api.DriveEvent")
 }
 
 func init() { proto.RegisterFile("drivemgrsvc.proto", fileDescriptor_65bf77650f5c7dcf) }
 
 var fileDescriptor_65bf77650f5c7dcf = []byte{
-	// 306 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xc1, 0x4b, 0xc3, 0x30,
-	0x14, 0xc6, 0x17, 0xa5, 0x1d, 0xbe, 0x6d, 0xc2, 0xa2, 0x8e, 0xda, 0xd3, 0xc8, 0xc5, 0x82, 0x5a,
-	0x70, 0x8a, 0x47, 0x41, 0x99, 0x88, 0x30, 0x76, 0xa8, 0xb7, 0xdd, 0xba, 0x36, 0x48, 0xd0, 0x36,
-	0xb5, 0x2f, 0x2d, 0xec, 0x4f, 0xf4, 0xbf, 0x92, 0x26, 0xa9, 0xae, 0x9b, 0xc7, 0xef, 0xbd, 0x8f,
-	0x2f, 0xbf, 0x2f, 0x09, 0x8c, 0xd3, 0x52, 0xd4, 0x3c, 0x7b, 0x2f, 0xb1, 0x4e, 0xc2, 0xa2, 0x94,
-	0x4a, 0x52, 0xa7, 0xbe, 0x89, 0x0b, 0xe1, 0x0f, 0xd4, 0xa6, 0xe0, 0x68, 0x66, 0xec, 0x02, 0x46,
-	0xf3, 0xc6, 0x88, 0x11, 0xff, 0xaa, 0x38, 0x2a, 0x3a, 0x01, 0x37, 0x97, 0x29, 0x7f, 0x4d, 0x3d,
-	0x32, 0x25, 0xc1, 0x51, 0x64, 0x15, 0xbb, 0x83, 0xe3, 0xd6, 0x88, 0x85, 0xcc, 0x91, 0x53, 0x06,
-	0x4e, 0x2a, 0xf0, 0x03, 0x3d, 0x32, 0x3d, 0x0c, 0x06, 0xb3, 0x61, 0xa8, 0xe3, 0x43, 0xed, 0x8a,
-	0xcc, 0x8a, 0xad, 0x80, 0x6a, 0xbd, 0x90, 0x49, 0xac, 0x78, 0x7b, 0xc6, 0x95, 0xa5, 0x7b, 0xe3,
-	0xa5, 0x88, 0x3f, 0x97, 0x55, 0xb6, 0xe6, 0xa5, 0x3d, 0x6e, 0x7f, 0xd1, 0x10, 0xc5, 0x89, 0x12,
-	0x32, 0xf7, 0x0e, 0xa6, 0x24, 0x70, 0x22, 0xab, 0xd8, 0x35, 0x9c, 0x74, 0xb2, 0x2d, 0xd6, 0x04,
-	0x5c, 0x54, 0xb1, 0xaa, 0x50, 0x27, 0x3a, 0x91, 0x55, 0xec, 0x12, 0xc6, 0x4b, 0x99, 0xee, 0x90,
-	0xfc, 0x65, 0x93, 0x4e, 0x76, 0x1f, 0x9c, 0xe7, 0xac, 0x50, 0x9b, 0xd9, 0x37, 0x81, 0xe1, 0xdc,
-	0x22, 0xd5, 0x22, 0xe1, 0xf4, 0x01, 0x46, 0x2f, 0x5c, 0x99, 0xab, 0x58, 0x08, 0x54, 0xf4, 0x74,
-	0xbb, 0x77, 0x7b, 0x8d, 0xfe, 0xd9, 0xce, 0xd4, 0xc0, 0xb1, 0x1e, 0x7d, 0x04, 0xd7, 0x20, 0xd0,
-	0xf3, 0x6d, 0x4b, 0x07, 0xcb, 0xf7, 0xff, 0x5b, 0xfd, 0x46, 0xdc, 0x03, 0x98, 0x59, 0xd3, 0x87,
-	0x7a, 0xd6, 0xbb, 0x57, 0xce, 0x6f, 0x5f, 0x44, 0x37, 0x61, 0xbd, 0xa7, 0xfe, 0xca, 0xfc, 0x80,
-	0xb5, 0xab, 0xdf, 0xfe, 0xf6, 0x27, 0x00, 0x00, 0xff, 0xff, 0x9e, 0x95, 0x3b, 0xed, 0x24, 0x02,
-	0x00, 0x00,
+	// 352 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0x4d, 0x4f, 0xc2, 0x40,
+	0x14, 0xa4, 0x68, 0x4b, 0x7c, 0x80, 0x09, 0xab, 0x92, 0xda, 0x13, 0xd9, 0x8b, 0x24, 0x2a, 0x51,
+	0x34, 0x1e, 0x3c, 0x98, 0x68, 0x20, 0xc6, 0x84, 0x70, 0xa8, 0x07, 0x13, 0x6e, 0xa5, 0xdd, 0xe8,
+	0x46, 0xe9, 0xd6, 0xee, 0xa3, 0x09, 0x3f, 0xc0, 0xff, 0x6d, 0xf6, 0xa3, 0x0a, 0x54, 0x4f, 0xed,
+	0x9b, 0x9d, 0xce, 0x9b, 0x99, 0x2e, 0x74, 0x92, 0x9c, 0x17, 0x6c, 0xf1, 0x9a, 0xcb, 0x22, 0x1e,
+	0x64, 0xb9, 0x40, 0x41, 0xdc, 0xe2, 0x32, 0xca, 0x78, 0xd0, 0xc4, 0x55, 0xc6, 0xa4, 0xc1, 0xe8,
+	0x09, 0xb4, 0x47, 0x8a, 0x28, 0x43, 0xf6, 0xb9, 0x64, 0x12, 0x49, 0x17, 0xbc, 0x54, 0x24, 0xec,
+	0x29, 0xf1, 0x9d, 0x9e, 0xd3, 0xdf, 0x0b, 0xed, 0x44, 0xaf, 0x61, 0xbf, 0x24, 0xca, 0x4c, 0xa4,
+	0x92, 0x11, 0x0a, 0x6e, 0xc2, 0xe5, 0xbb, 0xf4, 0x9d, 0xde, 0x4e, 0xbf, 0x39, 0x6c, 0x0d, 0xb4,
+	0xfc, 0x40, 0xb3, 0x42, 0x73, 0x44, 0x67, 0x40, 0xf4, 0x3c, 0x11, 0x71, 0x84, 0xac, 0xdc, 0x71,
+	0x66, 0xdd, 0x3d, 0xb3, 0x9c, 0x47, 0x1f, 0xd3, 0xe5, 0x62, 0xce, 0x72, 0xbb, 0xae, 0x7a, 0xa0,
+	0x1c, 0x45, 0x31, 0x72, 0x91, 0xfa, 0xf5, 0x9e, 0xd3, 0x77, 0x43, 0x3b, 0xd1, 0x73, 0x38, 0xd8,
+	0xd0, 0xb6, 0xb6, 0xba, 0xe0, 0x49, 0x8c, 0x70, 0x29, 0xb5, 0xa2, 0x1b, 0xda, 0x89, 0x9e, 0x42,
+	0x67, 0x2a, 0x92, 0x2d, 0x27, 0xbf, 0xda, 0xce, 0x86, 0x76, 0x03, 0xdc, 0xf1, 0x22, 0xc3, 0x15,
+	0x1d, 0x01, 0xe8, 0x25, 0xe3, 0x82, 0xa5, 0x48, 0x08, 0xec, 0xaa, 0xf2, 0xac, 0x57, 0xfd, 0xae,
+	0x6b, 0x50, 0x0c, 0xed, 0xae, 0x5a, 0x83, 0x7a, 0x0c, 0xbf, 0xea, 0xd0, 0x1a, 0xd9, 0x60, 0x05,
+	0x8f, 0x19, 0xb9, 0x83, 0xf6, 0x23, 0x43, 0x0d, 0xc9, 0x09, 0x97, 0x48, 0x0e, 0xd7, 0x3f, 0x2b,
+	0x7f, 0x46, 0x70, 0xb4, 0x85, 0x9a, 0x88, 0xb4, 0x46, 0xee, 0xc1, 0x33, 0x41, 0xc8, 0xf1, 0x3a,
+	0x65, 0x23, 0x5c, 0x10, 0xfc, 0x75, 0xf4, 0x23, 0x71, 0x03, 0x60, 0x30, 0xd5, 0x0a, 0xf1, 0x2d,
+	0xb7, 0x52, 0x51, 0x50, 0x06, 0x32, 0x7d, 0xd4, 0xc8, 0x2d, 0x34, 0x5f, 0x22, 0x8c, 0xdf, 0x8c,
+	0xa7, 0x7f, 0x8c, 0x77, 0xd6, 0x51, 0xdd, 0x1d, 0xad, 0x5d, 0x38, 0x0f, 0x8d, 0x99, 0xb9, 0x83,
+	0x73, 0x4f, 0xdf, 0xbe, 0xab, 0xef, 0x01, 0x00, 0xe8, 0x8c, 0xb2, 0xa9, 0xa6, 0x02, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -308,6 +360,7 @@ type DriveServiceClient interface {
 	GetDrivesList(ctx context.Context, in *DrivesRequest, opts ...grpc.CallOption) (*DrivesResponse, error)
 	Locate(ctx context.Context, in *DriveLocateRequest, opts ...grpc.CallOption) (*DriveLocateResponse, error)
 	LocateNode(ctx context.Context, in *NodeLocateRequest, opts ...grpc.CallOption) (*Empty, error)
+	WatchDrives(ctx context.Context, in *DrivesRequest, opts ...grpc.CallOption) (DriveService_WatchDrivesClient, error)
 }
 
 type driveServiceClient struct {
@@ -345,11 +398,44 @@ func (c *driveServiceClient) LocateNode(ctx context.Context, in *NodeLocateReque
 	return out, nil
 }
 
+func (c *driveServiceClient) WatchDrives(ctx context.Context, in *DrivesRequest, opts ...grpc.CallOption) (DriveService_WatchDrivesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DriveService_serviceDesc.Streams[0], "/v1api.DriveService/WatchDrives", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &driveServiceWatchDrivesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DriveService_WatchDrivesClient interface {
+	Recv() (*DriveEvent, error)
+	grpc.ClientStream
+}
+
+type driveServiceWatchDrivesClient struct {
+	grpc.ClientStream
+}
+
+func (x *driveServiceWatchDrivesClient) Recv() (*DriveEvent, error) {
+	m := new(DriveEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DriveServiceServer is the server API for DriveService service.
 type DriveServiceServer interface {
 	GetDrivesList(context.Context, *DrivesRequest) (*DrivesResponse, error)
 	Locate(context.Context, *DriveLocateRequest) (*DriveLocateResponse, error)
 	LocateNode(context.Context, *NodeLocateRequest) (*Empty, error)
+	WatchDrives(*DrivesRequest, DriveService_WatchDrivesServer) error
 }
 
 // UnimplementedDriveServiceServer can be embedded to have forward compatible implementations.
@@ -365,6 +451,9 @@ func (*UnimplementedDriveServiceServer) Locate(ctx context.Context, req *DriveLo
 func (*UnimplementedDriveServiceServer) LocateNode(ctx context.Context, req *NodeLocateRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method LocateNode not implemented")
 }
+func (*UnimplementedDriveServiceServer) WatchDrives(req *DrivesRequest, srv DriveService_WatchDrivesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDrives not implemented")
+}
 
 func RegisterDriveServiceServer(s *grpc.Server, srv DriveServiceServer) {
 	s.RegisterService(&_DriveService_serviceDesc, srv)
@@ -424,6 +513,27 @@ func _DriveService_LocateNode_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DriveService_WatchDrives_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DrivesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DriveServiceServer).WatchDrives(m, &driveServiceWatchDrivesServer{stream})
+}
+
+type DriveService_WatchDrivesServer interface {
+	Send(*DriveEvent) error
+	grpc.ServerStream
+}
+
+type driveServiceWatchDrivesServer struct {
+	grpc.ServerStream
+}
+
+func (x *driveServiceWatchDrivesServer) Send(m *DriveEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _DriveService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v1api.DriveService",
 	HandlerType: (*DriveServiceServer)(nil),
@@ -441,6 +551,12 @@ var _DriveService_serviceDesc = grpc.ServiceDesc{
 			Handler:    _DriveService_LocateNode_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchDrives",
+			Handler:       _DriveService_WatchDrives_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "drivemgrsvc.proto",
 }
@@ -33,15 +33,26 @@ type Drive struct {
 	Usage  string `protobuf:"bytes,9,opt,name=Usage,proto3" json:"Usage,omitempty"`
 	NodeId string `protobuf:"bytes,10,opt,name=NodeId,proto3" json:"NodeId,omitempty"`
 	// path to the device. may not be set by drivemgr.
-	Path                 string   `protobuf:"bytes,11,opt,name=Path,proto3" json:"Path,omitempty"`
-	Enclosure            string   `protobuf:"bytes,12,opt,name=Enclosure,proto3" json:"Enclosure,omitempty"`
-	Slot                 string   `protobuf:"bytes,13,opt,name=Slot,proto3" json:"Slot,omitempty"`
-	Bay                  string   `protobuf:"bytes,14,opt,name=Bay,proto3" json:"Bay,omitempty"`
-	Firmware             string   `protobuf:"bytes,15,opt,name=Firmware,proto3" json:"Firmware,omitempty"`
-	Endurance            int64    `protobuf:"varint,16,opt,name=Endurance,proto3" json:"Endurance,omitempty"`
-	LEDState             string   `protobuf:"bytes,17,opt,name=LEDState,proto3" json:"LEDState,omitempty"`
-	IsSystem             bool     `protobuf:"varint,18,opt,name=IsSystem,proto3" json:"IsSystem,omitempty"`
-	IsClean              bool     `protobuf:"varint,19,opt,name=IsClean,proto3" json:"IsClean,omitempty"`
+	Path      string `protobuf:"bytes,11,opt,name=Path,proto3" json:"Path,omitempty"`
+	Enclosure string `protobuf:"bytes,12,opt,name=Enclosure,proto3" json:"Enclosure,omitempty"`
+	Slot      string `protobuf:"bytes,13,opt,name=Slot,proto3" json:"Slot,omitempty"`
+	Bay       string `protobuf:"bytes,14,opt,name=Bay,proto3" json:"Bay,omitempty"`
+	Firmware  string `protobuf:"bytes,15,opt,name=Firmware,proto3" json:"Firmware,omitempty"`
+	// remaining rated endurance in percent, reported for NVMe drives
+	Endurance int64  `protobuf:"varint,16,opt,name=Endurance,proto3" json:"Endurance,omitempty"`
+	LEDState  string `protobuf:"bytes,17,opt,name=LEDState,proto3" json:"LEDState,omitempty"`
+	IsSystem  bool   `protobuf:"varint,18,opt,name=IsSystem,proto3" json:"IsSystem,omitempty"`
+	IsClean   bool   `protobuf:"varint,19,opt,name=IsClean,proto3" json:"IsClean,omitempty"`
+	// explanation of the drive health reported by drive manager, e.g. violated rules of SMART health policy
+	HealthReason string `protobuf:"bytes,20,opt,name=HealthReason,proto3" json:"HealthReason,omitempty"`
+	// telemetry reported by drive manager, exported as node metrics and not saved in Drive CR
+	// temperature in Celsius, 0 if not reported
+	Temperature  int64 `protobuf:"varint,21,opt,name=Temperature,proto3" json:"Temperature,omitempty"`
+	PowerOnHours int64 `protobuf:"varint,22,opt,name=PowerOnHours,proto3" json:"PowerOnHours,omitempty"`
+	// WWN of SCSI/SATA drive or EUI-64/NGUID of NVMe drive, doesn't change when the device gets another kernel name
+	WWN string `protobuf:"bytes,23,opt,name=WWN,proto3" json:"WWN,omitempty"`
+	// persistent /dev/disk/by-id link of the drive, resolved to the current kernel name when the drive is used
+	ByIdPath             string   `protobuf:"bytes,24,opt,name=ByIdPath,proto3" json:"ByIdPath,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -205,24 +216,87 @@ func (m *Drive) GetIsClean() bool {
 	return false
 }
 
+func (m *Drive) GetHealthReason() string {
+	if m != nil {
+		return m.HealthReason
+	}
+	return ""
+}
+
+func (m *Drive) GetTemperature() int64 {
+	if m != nil {
+		return m.Temperature
+	}
+	return 0
+}
+
+func (m *Drive) GetPowerOnHours() int64 {
+	if m != nil {
+		return m.PowerOnHours
+	}
+	return 0
+}
+
+func (m *Drive) GetWWN() string {
+	if m != nil {
+		return m.WWN
+	}
+	return ""
+}
+
+func (m *Drive) GetByIdPath() string {
+	if m != nil {
+		return m.ByIdPath
+	}
+	return ""
+}
+
 type Volume struct {
-	Id                   string   `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
-	Location             string   `protobuf:"bytes,2,opt,name=Location,proto3" json:"Location,omitempty"`
-	LocationType         string   `protobuf:"bytes,3,opt,name=LocationType,proto3" json:"LocationType,omitempty"`
-	StorageClass         string   `protobuf:"bytes,4,opt,name=StorageClass,proto3" json:"StorageClass,omitempty"`
-	NodeId               string   `protobuf:"bytes,5,opt,name=NodeId,proto3" json:"NodeId,omitempty"`
-	Owners               []string `protobuf:"bytes,6,rep,name=Owners,proto3" json:"Owners,omitempty"`
-	Size                 int64    `protobuf:"varint,7,opt,name=Size,proto3" json:"Size,omitempty"`
-	Mode                 string   `protobuf:"bytes,8,opt,name=Mode,proto3" json:"Mode,omitempty"`
-	Type                 string   `protobuf:"bytes,9,opt,name=Type,proto3" json:"Type,omitempty"`
-	Health               string   `protobuf:"bytes,10,opt,name=Health,proto3" json:"Health,omitempty"`
-	OperationalStatus    string   `protobuf:"bytes,11,opt,name=OperationalStatus,proto3" json:"OperationalStatus,omitempty"`
-	CSIStatus            string   `protobuf:"bytes,12,opt,name=CSIStatus,proto3" json:"CSIStatus,omitempty"`
-	Usage                string   `protobuf:"bytes,13,opt,name=Usage,proto3" json:"Usage,omitempty"`
-	Ephemeral            bool     `protobuf:"varint,14,opt,name=Ephemeral,proto3" json:"Ephemeral,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Id                string   `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	Location          string   `protobuf:"bytes,2,opt,name=Location,proto3" json:"Location,omitempty"`
+	LocationType      string   `protobuf:"bytes,3,opt,name=LocationType,proto3" json:"LocationType,omitempty"`
+	StorageClass      string   `protobuf:"bytes,4,opt,name=StorageClass,proto3" json:"StorageClass,omitempty"`
+	NodeId            string   `protobuf:"bytes,5,opt,name=NodeId,proto3" json:"NodeId,omitempty"`
+	Owners            []string `protobuf:"bytes,6,rep,name=Owners,proto3" json:"Owners,omitempty"`
+	Size              int64    `protobuf:"varint,7,opt,name=Size,proto3" json:"Size,omitempty"`
+	Mode              string   `protobuf:"bytes,8,opt,name=Mode,proto3" json:"Mode,omitempty"`
+	Type              string   `protobuf:"bytes,9,opt,name=Type,proto3" json:"Type,omitempty"`
+	Health            string   `protobuf:"bytes,10,opt,name=Health,proto3" json:"Health,omitempty"`
+	OperationalStatus string   `protobuf:"bytes,11,opt,name=OperationalStatus,proto3" json:"OperationalStatus,omitempty"`
+	CSIStatus         string   `protobuf:"bytes,12,opt,name=CSIStatus,proto3" json:"CSIStatus,omitempty"`
+	Usage             string   `protobuf:"bytes,13,opt,name=Usage,proto3" json:"Usage,omitempty"`
+	Ephemeral         bool     `protobuf:"varint,14,opt,name=Ephemeral,proto3" json:"Ephemeral,omitempty"`
+	// type of the data source (Snapshot or Volume), empty when volume is created empty
+	ContentSourceType string `protobuf:"bytes,15,opt,name=ContentSourceType,proto3" json:"ContentSourceType,omitempty"`
+	// ID of the Snapshot or Volume which data is copied to the volume
+	ContentSourceId string `protobuf:"bytes,16,opt,name=ContentSourceId,proto3" json:"ContentSourceId,omitempty"`
+	// percentage of data which has been already copied from the content source
+	CloneProgress int32 `protobuf:"varint,17,opt,name=CloneProgress,proto3" json:"CloneProgress,omitempty"`
+	// volume is encrypted with LUKS, passphrase is stored in the Secret
+	Encrypted bool `protobuf:"varint,18,opt,name=Encrypted,proto3" json:"Encrypted,omitempty"`
+	// name of the Secret which holds passphrase of the encrypted volume
+	EncryptionSecretName string `protobuf:"bytes,19,opt,name=EncryptionSecretName,proto3" json:"EncryptionSecretName,omitempty"`
+	// namespace of the Secret which holds passphrase of the encrypted volume
+	EncryptionSecretNamespace string `protobuf:"bytes,20,opt,name=EncryptionSecretNamespace,proto3" json:"EncryptionSecretNamespace,omitempty"`
+	// defines how drive is erased on volume release (none, wipefs, zero-fill, discard, nvme-format, nvme-sanitize, ata-secure-erase)
+	ErasePolicy string `protobuf:"bytes,21,opt,name=ErasePolicy,proto3" json:"ErasePolicy,omitempty"`
+	// level of the mdadm RAID which volume is assembled to (raid0, raid1, raid10), empty for non-RAID volume
+	RaidLevel string `protobuf:"bytes,22,opt,name=RaidLevel,proto3" json:"RaidLevel,omitempty"`
+	// number of drives in the RAID array
+	RaidDevices int32 `protobuf:"varint,23,opt,name=RaidDevices,proto3" json:"RaidDevices,omitempty"`
+	// UUIDs of the drives in the RAID array, Location holds the first of them
+	RaidLocations []string `protobuf:"bytes,24,rep,name=RaidLocations,proto3" json:"RaidLocations,omitempty"`
+	// options passed to mkfs on file system creation, validated for the volume file system
+	MkfsOptions string `protobuf:"bytes,25,opt,name=MkfsOptions,proto3" json:"MkfsOptions,omitempty"`
+	// IO limits requested for the volume in StorageClass parameters or PVC annotations
+	IoLimits *IOLimits `protobuf:"bytes,26,opt,name=IoLimits,proto3" json:"IoLimits,omitempty"`
+	// IO limits applied to the cgroup of the pod which uses the volume, empty when volume isn't published
+	AppliedIoLimits *IOLimits `protobuf:"bytes,27,opt,name=AppliedIoLimits,proto3" json:"AppliedIoLimits,omitempty"`
+	// block device queue settings applied by tuning profile, empty when volume isn't staged
+	BlockTuning          *BlockTuning `protobuf:"bytes,28,opt,name=BlockTuning,proto3" json:"BlockTuning,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *Volume) Reset()         { *m = Volume{} }
@@ -348,6 +422,246 @@ func (m *Volume) GetEphemeral() bool {
 	return false
 }
 
+func (m *Volume) GetContentSourceType() string {
+	if m != nil {
+		return m.ContentSourceType
+	}
+	return ""
+}
+
+func (m *Volume) GetContentSourceId() string {
+	if m != nil {
+		return m.ContentSourceId
+	}
+	return ""
+}
+
+func (m *Volume) GetCloneProgress() int32 {
+	if m != nil {
+		return m.CloneProgress
+	}
+	return 0
+}
+
+func (m *Volume) GetEncrypted() bool {
+	if m != nil {
+		return m.Encrypted
+	}
+	return false
+}
+
+func (m *Volume) GetEncryptionSecretName() string {
+	if m != nil {
+		return m.EncryptionSecretName
+	}
+	return ""
+}
+
+func (m *Volume) GetEncryptionSecretNamespace() string {
+	if m != nil {
+		return m.EncryptionSecretNamespace
+	}
+	return ""
+}
+
+func (m *Volume) GetErasePolicy() string {
+	if m != nil {
+		return m.ErasePolicy
+	}
+	return ""
+}
+
+func (m *Volume) GetRaidLevel() string {
+	if m != nil {
+		return m.RaidLevel
+	}
+	return ""
+}
+
+func (m *Volume) GetRaidDevices() int32 {
+	if m != nil {
+		return m.RaidDevices
+	}
+	return 0
+}
+
+func (m *Volume) GetRaidLocations() []string {
+	if m != nil {
+		return m.RaidLocations
+	}
+	return nil
+}
+
+func (m *Volume) GetMkfsOptions() string {
+	if m != nil {
+		return m.MkfsOptions
+	}
+	return ""
+}
+
+func (m *Volume) GetIoLimits() *IOLimits {
+	if m != nil {
+		return m.IoLimits
+	}
+	return nil
+}
+
+func (m *Volume) GetAppliedIoLimits() *IOLimits {
+	if m != nil {
+		return m.AppliedIoLimits
+	}
+	return nil
+}
+
+func (m *Volume) GetBlockTuning() *BlockTuning {
+	if m != nil {
+		return m.BlockTuning
+	}
+	return nil
+}
+
+// IOLimits holds cgroup v2 io.max and io.weight settings for the volume device, 0 means no limit
+type IOLimits struct {
+	ReadIOPS  int64 `protobuf:"varint,1,opt,name=ReadIOPS,proto3" json:"ReadIOPS,omitempty"`
+	WriteIOPS int64 `protobuf:"varint,2,opt,name=WriteIOPS,proto3" json:"WriteIOPS,omitempty"`
+	// bytes per second
+	ReadBPS  int64 `protobuf:"varint,3,opt,name=ReadBPS,proto3" json:"ReadBPS,omitempty"`
+	WriteBPS int64 `protobuf:"varint,4,opt,name=WriteBPS,proto3" json:"WriteBPS,omitempty"`
+	// proportional weight in range [1, 10000]
+	Weight               int32    `protobuf:"varint,5,opt,name=Weight,proto3" json:"Weight,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IOLimits) Reset()         { *m = IOLimits{} }
+func (m *IOLimits) String() string { return proto.CompactTextString(m) }
+func (*IOLimits) ProtoMessage()    {}
+func (*IOLimits) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{2}
+}
+
+func (m *IOLimits) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IOLimits.Unmarshal(m, b)
+}
+func (m *IOLimits) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IOLimits.Marshal(b, m, deterministic)
+}
+func (m *IOLimits) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IOLimits.Merge(m, src)
+}
+func (m *IOLimits) XXX_Size() int {
+	return xxx_messageInfo_IOLimits.Size(m)
+}
+func (m *IOLimits) XXX_DiscardUnknown() {
+	xxx_messageInfo_IOLimits.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IOLimits proto.InternalMessageInfo
+
+func (m *IOLimits) GetReadIOPS() int64 {
+	if m != nil {
+		return m.ReadIOPS
+	}
+	return 0
+}
+
+func (m *IOLimits) GetWriteIOPS() int64 {
+	if m != nil {
+		return m.WriteIOPS
+	}
+	return 0
+}
+
+func (m *IOLimits) GetReadBPS() int64 {
+	if m != nil {
+		return m.ReadBPS
+	}
+	return 0
+}
+
+func (m *IOLimits) GetWriteBPS() int64 {
+	if m != nil {
+		return m.WriteBPS
+	}
+	return 0
+}
+
+func (m *IOLimits) GetWeight() int32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+// BlockTuning holds queue attributes of the volume block device changed by tuning profile
+type BlockTuning struct {
+	// name of the applied profile
+	Profile string `protobuf:"bytes,1,opt,name=Profile,proto3" json:"Profile,omitempty"`
+	// block device name, e.g. sda
+	Device string `protobuf:"bytes,2,opt,name=Device,proto3" json:"Device,omitempty"`
+	// values written to /sys/block/<Device>/queue/<attribute>
+	Applied map[string]string `protobuf:"bytes,3,rep,name=Applied,proto3" json:"Applied,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// values which attributes had before applying, restored on unstage
+	Previous             map[string]string `protobuf:"bytes,4,rep,name=Previous,proto3" json:"Previous,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *BlockTuning) Reset()         { *m = BlockTuning{} }
+func (m *BlockTuning) String() string { return proto.CompactTextString(m) }
+func (*BlockTuning) ProtoMessage()    {}
+func (*BlockTuning) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{3}
+}
+
+func (m *BlockTuning) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockTuning.Unmarshal(m, b)
+}
+func (m *BlockTuning) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockTuning.Marshal(b, m, deterministic)
+}
+func (m *BlockTuning) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockTuning.Merge(m, src)
+}
+func (m *BlockTuning) XXX_Size() int {
+	return xxx_messageInfo_BlockTuning.Size(m)
+}
+func (m *BlockTuning) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockTuning.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockTuning proto.InternalMessageInfo
+
+func (m *BlockTuning) GetProfile() string {
+	if m != nil {
+		return m.Profile
+	}
+	return ""
+}
+
+func (m *BlockTuning) GetDevice() string {
+	if m != nil {
+		return m.Device
+	}
+	return ""
+}
+
+func (m *BlockTuning) GetApplied() map[string]string {
+	if m != nil {
+		return m.Applied
+	}
+	return nil
+}
+
+func (m *BlockTuning) GetPrevious() map[string]string {
+	if m != nil {
+		return m.Previous
+	}
+	return nil
+}
+
 type AvailableCapacity struct {
 	Location             string   `protobuf:"bytes,1,opt,name=Location,proto3" json:"Location,omitempty"`
 	NodeId               string   `protobuf:"bytes,2,opt,name=NodeId,proto3" json:"NodeId,omitempty"`
@@ -362,7 +676,7 @@ func (m *AvailableCapacity) Reset()         { *m = AvailableCapacity{} }
 func (m *AvailableCapacity) String() string { return proto.CompactTextString(m) }
 func (*AvailableCapacity) ProtoMessage()    {}
 func (*AvailableCapacity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{2}
+	return fileDescriptor_d938547f84707355, []int{4}
 }
 
 func (m *AvailableCapacity) XXX_Unmarshal(b []byte) error {
@@ -425,7 +739,7 @@ func (m *AvailableCapacityReservation) Reset()         { *m = AvailableCapacityR
 func (m *AvailableCapacityReservation) String() string { return proto.CompactTextString(m) }
 func (*AvailableCapacityReservation) ProtoMessage()    {}
 func (*AvailableCapacityReservation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{3}
+	return fileDescriptor_d938547f84707355, []int{5}
 }
 
 func (m *AvailableCapacityReservation) XXX_Unmarshal(b []byte) error {
@@ -488,7 +802,7 @@ func (m *NodeRequests) Reset()         { *m = NodeRequests{} }
 func (m *NodeRequests) String() string { return proto.CompactTextString(m) }
 func (*NodeRequests) ProtoMessage()    {}
 func (*NodeRequests) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{4}
+	return fileDescriptor_d938547f84707355, []int{6}
 }
 
 func (m *NodeRequests) XXX_Unmarshal(b []byte) error {
@@ -537,7 +851,7 @@ func (m *ReservationRequest) Reset()         { *m = ReservationRequest{} }
 func (m *ReservationRequest) String() string { return proto.CompactTextString(m) }
 func (*ReservationRequest) ProtoMessage()    {}
 func (*ReservationRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{5}
+	return fileDescriptor_d938547f84707355, []int{7}
 }
 
 func (m *ReservationRequest) XXX_Unmarshal(b []byte) error {
@@ -573,9 +887,15 @@ func (m *ReservationRequest) GetReservations() []string {
 }
 
 type CapacityRequest struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	StorageClass         string   `protobuf:"bytes,2,opt,name=StorageClass,proto3" json:"StorageClass,omitempty"`
-	Size                 int64    `protobuf:"varint,3,opt,name=Size,proto3" json:"Size,omitempty"`
+	Name         string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	StorageClass string `protobuf:"bytes,2,opt,name=StorageClass,proto3" json:"StorageClass,omitempty"`
+	Size         int64  `protobuf:"varint,3,opt,name=Size,proto3" json:"Size,omitempty"`
+	// node on which capacity must be reserved, empty means any of requested nodes
+	NodeId string `protobuf:"bytes,4,opt,name=NodeId,proto3" json:"NodeId,omitempty"`
+	// level of the mdadm RAID, empty means that single AC is reserved for the request
+	RaidLevel string `protobuf:"bytes,5,opt,name=RaidLevel,proto3" json:"RaidLevel,omitempty"`
+	// number of ACs on the same node which must be reserved for RAID
+	RaidDevices          int32    `protobuf:"varint,6,opt,name=RaidDevices,proto3" json:"RaidDevices,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -585,7 +905,7 @@ func (m *CapacityRequest) Reset()         { *m = CapacityRequest{} }
 func (m *CapacityRequest) String() string { return proto.CompactTextString(m) }
 func (*CapacityRequest) ProtoMessage()    {}
 func (*CapacityRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{6}
+	return fileDescriptor_d938547f84707355, []int{8}
 }
 
 func (m *CapacityRequest) XXX_Unmarshal(b []byte) error {
@@ -627,14 +947,42 @@ func (m *CapacityRequest) GetSize() int64 {
 	return 0
 }
 
+func (m *CapacityRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *CapacityRequest) GetRaidLevel() string {
+	if m != nil {
+		return m.RaidLevel
+	}
+	return ""
+}
+
+func (m *CapacityRequest) GetRaidDevices() int32 {
+	if m != nil {
+		return m.RaidDevices
+	}
+	return 0
+}
+
 type LogicalVolumeGroup struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
-	Node                 string   `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
-	Locations            []string `protobuf:"bytes,3,rep,name=Locations,proto3" json:"Locations,omitempty"`
-	Size                 int64    `protobuf:"varint,4,opt,name=Size,proto3" json:"Size,omitempty"`
-	VolumeRefs           []string `protobuf:"bytes,5,rep,name=VolumeRefs,proto3" json:"VolumeRefs,omitempty"`
-	Status               string   `protobuf:"bytes,6,opt,name=Status,proto3" json:"Status,omitempty"`
-	Health               string   `protobuf:"bytes,7,opt,name=Health,proto3" json:"Health,omitempty"`
+	Name       string   `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Node       string   `protobuf:"bytes,2,opt,name=Node,proto3" json:"Node,omitempty"`
+	Locations  []string `protobuf:"bytes,3,rep,name=Locations,proto3" json:"Locations,omitempty"`
+	Size       int64    `protobuf:"varint,4,opt,name=Size,proto3" json:"Size,omitempty"`
+	VolumeRefs []string `protobuf:"bytes,5,rep,name=VolumeRefs,proto3" json:"VolumeRefs,omitempty"`
+	Status     string   `protobuf:"bytes,6,opt,name=Status,proto3" json:"Status,omitempty"`
+	Health     string   `protobuf:"bytes,7,opt,name=Health,proto3" json:"Health,omitempty"`
+	// VG holds a thin pool and volumes are thin logical volumes in it
+	Thin bool `protobuf:"varint,8,opt,name=Thin,proto3" json:"Thin,omitempty"`
+	// Size available for thin volumes, it is Size multiplied by overcommit ratio
+	VirtualSize int64 `protobuf:"varint,9,opt,name=VirtualSize,proto3" json:"VirtualSize,omitempty"`
+	// percent of used data and metadata space of the thin pool
+	DataUsage            int32    `protobuf:"varint,10,opt,name=DataUsage,proto3" json:"DataUsage,omitempty"`
+	MetadataUsage        int32    `protobuf:"varint,11,opt,name=MetadataUsage,proto3" json:"MetadataUsage,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -644,7 +992,7 @@ func (m *LogicalVolumeGroup) Reset()         { *m = LogicalVolumeGroup{} }
 func (m *LogicalVolumeGroup) String() string { return proto.CompactTextString(m) }
 func (*LogicalVolumeGroup) ProtoMessage()    {}
 func (*LogicalVolumeGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{7}
+	return fileDescriptor_d938547f84707355, []int{9}
 }
 
 func (m *LogicalVolumeGroup) XXX_Unmarshal(b []byte) error {
@@ -714,6 +1062,34 @@ func (m *LogicalVolumeGroup) GetHealth() string {
 	return ""
 }
 
+func (m *LogicalVolumeGroup) GetThin() bool {
+	if m != nil {
+		return m.Thin
+	}
+	return false
+}
+
+func (m *LogicalVolumeGroup) GetVirtualSize() int64 {
+	if m != nil {
+		return m.VirtualSize
+	}
+	return 0
+}
+
+func (m *LogicalVolumeGroup) GetDataUsage() int32 {
+	if m != nil {
+		return m.DataUsage
+	}
+	return 0
+}
+
+func (m *LogicalVolumeGroup) GetMetadataUsage() int32 {
+	if m != nil {
+		return m.MetadataUsage
+	}
+	return 0
+}
+
 type Node struct {
 	UUID string `protobuf:"bytes,1,opt,name=UUID,proto3" json:"UUID,omitempty"`
 	// key - address type, value - address, align with NodeAddress struct from k8s.io/api/core/v1
@@ -727,7 +1103,7 @@ func (m *Node) Reset()         { *m = Node{} }
 func (m *Node) String() string { return proto.CompactTextString(m) }
 func (*Node) ProtoMessage()    {}
 func (*Node) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d938547f84707355, []int{8}
+	return fileDescriptor_d938547f84707355, []int{10}
 }
 
 func (m *Node) XXX_Unmarshal(b []byte) error {
@@ -762,9 +1138,120 @@ func (m *Node) GetAddresses() map[string]string {
 	return nil
 }
 
+type Snapshot struct {
+	Id string `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	// ID of the source volume
+	VolumeId string `protobuf:"bytes,2,opt,name=VolumeId,proto3" json:"VolumeId,omitempty"`
+	NodeId   string `protobuf:"bytes,3,opt,name=NodeId,proto3" json:"NodeId,omitempty"`
+	// LogicalVolumeGroup CR name of the source volume
+	Location     string `protobuf:"bytes,4,opt,name=Location,proto3" json:"Location,omitempty"`
+	StorageClass string `protobuf:"bytes,5,opt,name=StorageClass,proto3" json:"StorageClass,omitempty"`
+	// size in bytes reserved for the snapshot in LogicalVolumeGroup
+	Size      int64  `protobuf:"varint,6,opt,name=Size,proto3" json:"Size,omitempty"`
+	CSIStatus string `protobuf:"bytes,7,opt,name=CSIStatus,proto3" json:"CSIStatus,omitempty"`
+	Health    string `protobuf:"bytes,8,opt,name=Health,proto3" json:"Health,omitempty"`
+	// creation time in unix seconds
+	CreationTime         int64    `protobuf:"varint,9,opt,name=CreationTime,proto3" json:"CreationTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Snapshot) Reset()         { *m = Snapshot{} }
+func (m *Snapshot) String() string { return proto.CompactTextString(m) }
+func (*Snapshot) ProtoMessage()    {}
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d938547f84707355, []int{11}
+}
+
+func (m *Snapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Snapshot.Unmarshal(m, b)
+}
+func (m *Snapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Snapshot.Marshal(b, m, deterministic)
+}
+func (m *Snapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Snapshot.Merge(m, src)
+}
+func (m *Snapshot) XXX_Size() int {
+	return xxx_messageInfo_Snapshot.Size(m)
+}
+func (m *Snapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_Snapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Snapshot proto.InternalMessageInfo
+
+func (m *Snapshot) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Snapshot) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+func (m *Snapshot) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *Snapshot) GetLocation() string {
+	if m != nil {
+		return m.Location
+	}
+	return ""
+}
+
+func (m *Snapshot) GetStorageClass() string {
+	if m != nil {
+		return m.StorageClass
+	}
+	return ""
+}
+
+func (m *Snapshot) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *Snapshot) GetCSIStatus() string {
+	if m != nil {
+		return m.CSIStatus
+	}
+	return ""
+}
+
+func (m *Snapshot) GetHealth() string {
+	if m != nil {
+		return m.Health
+	}
+	return ""
+}
+
+func (m *Snapshot) GetCreationTime() int64 {
+	if m != nil {
+		return m.CreationTime
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Drive)(nil), "v1api.Drive")
 	proto.RegisterType((*Volume)(nil), "v1api.Volume")
+	proto.RegisterType((*IOLimits)(nil), "v1api.IOLimits")
+	proto.RegisterType((*BlockTuning)(nil), "v1api.BlockTuning")
+	proto.RegisterMapType((map[string]string)(nil), "v1api.BlockTuning.AppliedEntry")
+	proto.RegisterMapType((map[string]string)(nil), "v1api.BlockTuning.PreviousEntry")
 	proto.RegisterType((*AvailableCapacity)(nil), "v1api.AvailableCapacity")
 	proto.RegisterType((*AvailableCapacityReservation)(nil), "v1api.AvailableCapacityReservation")
 	proto.RegisterType((*NodeRequests)(nil), "v1api.NodeRequests")
@@ -773,60 +1260,97 @@ func init() {
 	proto.RegisterType((*LogicalVolumeGroup)(nil), "v1api.LogicalVolumeGroup")
 	proto.RegisterType((*Node)(nil), "v1api.Node")
 	proto.RegisterMapType((map[string]string)(nil), "v1api.Node.AddressesEntry")
+	proto.RegisterType((*Snapshot)(nil), "v1api.Snapshot")
 }
 
 func init() { proto.RegisterFile("types.proto", fileDescriptor_d938547f84707355) }
 
 var fileDescriptor_d938547f84707355 = []byte{
-	// 792 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x55, 0xcd, 0x6e, 0xd3, 0x4e,
-	0x10, 0x97, 0xed, 0x7c, 0x6e, 0xd2, 0xaf, 0xed, 0x5f, 0xd5, 0xfe, 0xab, 0x08, 0x45, 0x3e, 0xe5,
-	0x80, 0x22, 0x11, 0x0e, 0x54, 0x88, 0x03, 0x6d, 0x52, 0xa8, 0x45, 0x69, 0x2b, 0x87, 0xf6, 0x80,
-	0xc4, 0x61, 0x1b, 0x0f, 0xad, 0x85, 0x13, 0x9b, 0x5d, 0x3b, 0x95, 0x7b, 0x81, 0x67, 0xe0, 0x81,
-	0x78, 0x03, 0x5e, 0x80, 0xa7, 0x41, 0xb3, 0xeb, 0xf8, 0xa3, 0xc9, 0x6d, 0xe6, 0x37, 0x3b, 0x3b,
-	0xe3, 0xdf, 0xfc, 0x66, 0x4d, 0x3a, 0x71, 0x1a, 0x81, 0x1c, 0x46, 0x22, 0x8c, 0x43, 0x5a, 0x5f,
-	0xbe, 0xe0, 0x91, 0x6f, 0xff, 0xb1, 0x48, 0x7d, 0x22, 0xfc, 0x25, 0x50, 0x4a, 0x6a, 0xd7, 0xd7,
-	0xce, 0x84, 0x19, 0x7d, 0x63, 0xd0, 0x76, 0x95, 0x4d, 0x77, 0x89, 0x75, 0xe3, 0x4c, 0x98, 0xa9,
-	0x20, 0x34, 0x11, 0xb9, 0x72, 0x26, 0xcc, 0xd2, 0xc8, 0x95, 0x33, 0xa1, 0x36, 0xe9, 0x4e, 0x41,
-	0xf8, 0x3c, 0xb8, 0x48, 0xe6, 0xb7, 0x20, 0x58, 0x4d, 0x85, 0x2a, 0x18, 0x3d, 0x20, 0x8d, 0x33,
-	0xe0, 0x41, 0x7c, 0xcf, 0xea, 0x2a, 0x9a, 0x79, 0x58, 0xf3, 0x53, 0x1a, 0x01, 0x6b, 0xe8, 0x9a,
-	0x68, 0x23, 0x36, 0xf5, 0x1f, 0x81, 0x35, 0xfb, 0xc6, 0xc0, 0x72, 0x95, 0x8d, 0xf9, 0xd3, 0x98,
-	0xc7, 0x89, 0x64, 0x2d, 0x9d, 0xaf, 0x3d, 0xfa, 0x1f, 0xa9, 0x5f, 0x4b, 0x7e, 0x07, 0xac, 0xad,
-	0x60, 0xed, 0xe0, 0xe9, 0x8b, 0xd0, 0x03, 0xc7, 0x63, 0x44, 0x9f, 0xd6, 0x1e, 0xde, 0x7c, 0xc5,
-	0xe3, 0x7b, 0xd6, 0xd1, 0xd5, 0xd0, 0xa6, 0x3d, 0xd2, 0x3e, 0x5d, 0xcc, 0x82, 0x50, 0x26, 0x02,
-	0x58, 0x57, 0x05, 0x0a, 0x40, 0xf5, 0x12, 0x84, 0x31, 0xdb, 0xd2, 0x19, 0x68, 0x23, 0x03, 0x27,
-	0x3c, 0x65, 0xdb, 0x9a, 0x81, 0x13, 0x9e, 0xd2, 0x43, 0xd2, 0x7a, 0xe7, 0x8b, 0xf9, 0x03, 0x17,
-	0xc0, 0x76, 0x14, 0x9c, 0xfb, 0xfa, 0x7e, 0x2f, 0x11, 0x7c, 0x31, 0x03, 0xb6, 0xab, 0x3e, 0xa9,
-	0x00, 0x30, 0xf3, 0xfc, 0x74, 0x82, 0x1f, 0x03, 0x6c, 0x4f, 0x67, 0xae, 0x7c, 0x8c, 0x39, 0x72,
-	0x9a, 0xca, 0x18, 0xe6, 0x8c, 0xf6, 0x8d, 0x41, 0xcb, 0xcd, 0x7d, 0xca, 0x48, 0xd3, 0x91, 0xe3,
-	0x00, 0xf8, 0x82, 0xed, 0xab, 0xd0, 0xca, 0xb5, 0x7f, 0x5a, 0xa4, 0x71, 0x13, 0x06, 0xc9, 0x1c,
-	0xe8, 0x36, 0x31, 0x1d, 0x2f, 0x1b, 0xa7, 0xe9, 0x78, 0xaa, 0x58, 0x38, 0xe3, 0xb1, 0x1f, 0x2e,
-	0xb2, 0x89, 0xe6, 0x3e, 0x0e, 0x71, 0x65, 0xab, 0x81, 0xe8, 0xf9, 0x56, 0x30, 0x35, 0xe8, 0x38,
-	0x14, 0xfc, 0x0e, 0xc6, 0x01, 0x97, 0x32, 0x1f, 0x74, 0x09, 0x2b, 0x51, 0x5f, 0xaf, 0x50, 0x7f,
-	0x40, 0x1a, 0x97, 0x0f, 0x0b, 0x10, 0x92, 0x35, 0xfa, 0x16, 0xe2, 0xda, 0xdb, 0x38, 0x6c, 0x4a,
-	0x6a, 0x1f, 0x43, 0x0f, 0xb2, 0x51, 0x2b, 0x3b, 0x17, 0x4a, 0xbb, 0x24, 0x94, 0x42, 0x54, 0xa4,
-	0x22, 0xaa, 0xe7, 0x64, 0xef, 0x32, 0x02, 0xa1, 0x1a, 0xe7, 0x41, 0xa6, 0x1b, 0x3d, 0xf3, 0xf5,
-	0x00, 0x0e, 0x68, 0x3c, 0x75, 0xb2, 0x53, 0x99, 0x00, 0x72, 0xa0, 0x10, 0xd8, 0x56, 0x59, 0x60,
-	0x38, 0xd4, 0xe8, 0x1e, 0xe6, 0x20, 0x78, 0xa0, 0x84, 0xd0, 0x72, 0x0b, 0xc0, 0xfe, 0x41, 0xf6,
-	0x8e, 0x97, 0xdc, 0x0f, 0xf8, 0x6d, 0x00, 0x63, 0x1e, 0xf1, 0x99, 0x1f, 0xa7, 0x15, 0xf2, 0x8d,
-	0x27, 0xe4, 0x17, 0xa4, 0x99, 0x15, 0xd2, 0x6c, 0xd2, 0x95, 0x65, 0xc2, 0xb3, 0xa1, 0x94, 0xb1,
-	0x9c, 0xc0, 0x5a, 0x41, 0xa0, 0xfd, 0xd7, 0x20, 0xbd, 0xb5, 0x0e, 0x5c, 0x90, 0x20, 0x96, 0xba,
-	0x60, 0x8f, 0xb4, 0x2f, 0xf8, 0x1c, 0x64, 0xc4, 0x67, 0x90, 0x75, 0x53, 0x00, 0xa5, 0x65, 0x33,
-	0x2b, 0xcb, 0xf6, 0x8a, 0x74, 0xb1, 0x31, 0x17, 0xbe, 0x27, 0x20, 0x63, 0xdd, 0x4e, 0x67, 0xb4,
-	0x3f, 0x54, 0x0f, 0xc9, 0xb0, 0x1c, 0x72, 0x2b, 0x07, 0xe9, 0x07, 0xb2, 0x5f, 0xaa, 0x9e, 0xe7,
-	0xd7, 0xfa, 0xd6, 0xa0, 0x33, 0xfa, 0x3f, 0xcb, 0x5f, 0x3f, 0xe1, 0x6e, 0xca, 0xb2, 0xcf, 0xaa,
-	0x5d, 0xe0, 0xb7, 0x64, 0x36, 0xa0, 0xd8, 0x51, 0x5c, 0x05, 0x80, 0xb4, 0xeb, 0x4b, 0x00, 0xc9,
-	0xc5, 0x60, 0xee, 0xdb, 0x8f, 0x84, 0xae, 0x17, 0xa0, 0x6f, 0xc9, 0x4e, 0x41, 0x99, 0x82, 0x14,
-	0x43, 0x9d, 0xd1, 0x41, 0xd6, 0xe8, 0x93, 0xa8, 0xfb, 0xf4, 0x38, 0x8e, 0xad, 0x74, 0xaf, 0xcc,
-	0xea, 0x56, 0x30, 0xfb, 0xcb, 0x5a, 0x15, 0x9c, 0x24, 0xce, 0x60, 0xf5, 0xfe, 0xa2, 0xbd, 0xb6,
-	0x72, 0xe6, 0x86, 0x95, 0x5b, 0x29, 0xc0, 0x2a, 0x29, 0xe0, 0xb7, 0x41, 0xe8, 0x79, 0x78, 0xe7,
-	0xcf, 0x78, 0xa0, 0x1f, 0x83, 0xf7, 0x22, 0x4c, 0xa2, 0x8d, 0x25, 0x10, 0xc3, 0x6d, 0x33, 0x33,
-	0x0c, 0xb7, 0xad, 0x47, 0xda, 0x2b, 0x71, 0xe2, 0x98, 0x15, 0xa7, 0x39, 0xb0, 0x49, 0x72, 0xf4,
-	0x19, 0x21, 0xba, 0x90, 0x0b, 0x5f, 0x25, 0xab, 0xab, 0x94, 0x12, 0x52, 0xd2, 0x54, 0xa3, 0xa2,
-	0xa9, 0x62, 0x87, 0x9b, 0xe5, 0x1d, 0xb6, 0x7f, 0x19, 0xba, 0xad, 0x8d, 0x7f, 0xa5, 0x23, 0xd2,
-	0x3e, 0xf6, 0x3c, 0x01, 0x52, 0x82, 0x66, 0xb7, 0x33, 0x3a, 0x2c, 0xa9, 0x70, 0x98, 0x07, 0x4f,
-	0x17, 0xb1, 0x48, 0xdd, 0xe2, 0xf0, 0xe1, 0x1b, 0xb2, 0x5d, 0x0d, 0xe2, 0x6b, 0xfe, 0x0d, 0xd2,
-	0xec, 0x7a, 0x34, 0x71, 0xe5, 0x97, 0x3c, 0x48, 0x56, 0x8c, 0x68, 0xe7, 0xb5, 0x79, 0x64, 0x9c,
-	0x34, 0x3f, 0xeb, 0x9f, 0xe6, 0x6d, 0x43, 0xfd, 0x42, 0x5f, 0xfe, 0x0b, 0x00, 0x00, 0xff, 0xff,
-	0x4e, 0xad, 0x82, 0xa2, 0x51, 0x07, 0x00, 0x00,
+	// 1363 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0xcd, 0x6e, 0x1b, 0x37,
+	0x10, 0x86, 0xfe, 0x25, 0xca, 0x8e, 0x13, 0x26, 0x75, 0x19, 0xd7, 0x68, 0x05, 0xa1, 0x07, 0x03,
+	0x2d, 0x0c, 0xd4, 0x2d, 0xd0, 0x24, 0xcd, 0xa1, 0xb1, 0xe4, 0x36, 0x8b, 0x3a, 0xb6, 0x40, 0x25,
+	0x36, 0xd0, 0x1b, 0xa3, 0x9d, 0xd8, 0x8b, 0xac, 0x96, 0x5b, 0x72, 0x25, 0x43, 0xb9, 0xf4, 0x1d,
+	0xfa, 0x26, 0x3d, 0x14, 0xe8, 0x93, 0xf4, 0xd0, 0x7b, 0xdf, 0xa2, 0x87, 0x62, 0x48, 0xee, 0x0f,
+	0x2d, 0xb5, 0x45, 0x6e, 0x9c, 0x6f, 0x66, 0xc8, 0xd9, 0xe1, 0x37, 0xc3, 0x59, 0xd2, 0xcf, 0x56,
+	0x29, 0xe8, 0xc3, 0x54, 0xc9, 0x4c, 0xd2, 0xd6, 0xf2, 0x0b, 0x91, 0x46, 0xc3, 0xbf, 0x9a, 0xa4,
+	0x35, 0x56, 0xd1, 0x12, 0x28, 0x25, 0xcd, 0x57, 0xaf, 0x82, 0x31, 0xab, 0x0d, 0x6a, 0x07, 0x3d,
+	0x6e, 0xd6, 0xf4, 0x2e, 0x69, 0x5c, 0x04, 0x63, 0x56, 0x37, 0x50, 0xe3, 0xc2, 0x22, 0x93, 0x60,
+	0xcc, 0x1a, 0x16, 0x99, 0x04, 0x63, 0x3a, 0x24, 0x5b, 0x53, 0x50, 0x91, 0x88, 0xcf, 0x16, 0xf3,
+	0xd7, 0xa0, 0x58, 0xd3, 0xa8, 0x3c, 0x8c, 0xee, 0x92, 0xf6, 0x73, 0x10, 0x71, 0x76, 0xcd, 0x5a,
+	0x46, 0xeb, 0x24, 0x3c, 0xf3, 0xe5, 0x2a, 0x05, 0xd6, 0xb6, 0x67, 0xe2, 0x1a, 0xb1, 0x69, 0xf4,
+	0x0e, 0x58, 0x67, 0x50, 0x3b, 0x68, 0x70, 0xb3, 0x46, 0xff, 0x69, 0x26, 0xb2, 0x85, 0x66, 0x5d,
+	0xeb, 0x6f, 0x25, 0xfa, 0x80, 0xb4, 0x5e, 0x69, 0x71, 0x05, 0xac, 0x67, 0x60, 0x2b, 0xa0, 0xf5,
+	0x99, 0x0c, 0x21, 0x08, 0x19, 0xb1, 0xd6, 0x56, 0xc2, 0x9d, 0x27, 0x22, 0xbb, 0x66, 0x7d, 0x7b,
+	0x1a, 0xae, 0xe9, 0x3e, 0xe9, 0x9d, 0x24, 0xb3, 0x58, 0xea, 0x85, 0x02, 0xb6, 0x65, 0x14, 0x25,
+	0x60, 0x62, 0x89, 0x65, 0xc6, 0xb6, 0xad, 0x07, 0xae, 0x31, 0x03, 0xc7, 0x62, 0xc5, 0xee, 0xd8,
+	0x0c, 0x1c, 0x8b, 0x15, 0xdd, 0x23, 0xdd, 0xef, 0x22, 0x35, 0xbf, 0x11, 0x0a, 0xd8, 0x8e, 0x81,
+	0x0b, 0xd9, 0xee, 0x1f, 0x2e, 0x94, 0x48, 0x66, 0xc0, 0xee, 0x9a, 0x4f, 0x2a, 0x01, 0xf4, 0x3c,
+	0x3d, 0x19, 0xe3, 0xc7, 0x00, 0xbb, 0x67, 0x3d, 0x73, 0x19, 0x75, 0x81, 0x9e, 0xae, 0x74, 0x06,
+	0x73, 0x46, 0x07, 0xb5, 0x83, 0x2e, 0x2f, 0x64, 0xca, 0x48, 0x27, 0xd0, 0xa3, 0x18, 0x44, 0xc2,
+	0xee, 0x1b, 0x55, 0x2e, 0xe2, 0x6d, 0xd8, 0xdc, 0x72, 0x10, 0x5a, 0x26, 0xec, 0x81, 0xbd, 0x8d,
+	0x2a, 0x46, 0x07, 0xa4, 0xff, 0x12, 0xe6, 0x29, 0x28, 0x91, 0xe1, 0x57, 0x7f, 0x60, 0xa2, 0xaa,
+	0x42, 0xb8, 0xcb, 0x44, 0xde, 0x80, 0x3a, 0x4f, 0x9e, 0xcb, 0x85, 0xd2, 0x6c, 0xd7, 0x98, 0x78,
+	0x18, 0xe6, 0xe1, 0xf2, 0xf2, 0x8c, 0x7d, 0x68, 0xf3, 0x70, 0x79, 0x79, 0x86, 0x11, 0x1f, 0xaf,
+	0x82, 0xd0, 0xe4, 0x98, 0xd9, 0xaf, 0xc9, 0xe5, 0xe1, 0x1f, 0x1d, 0xd2, 0xbe, 0x90, 0xf1, 0x62,
+	0x0e, 0xf4, 0x0e, 0xa9, 0x07, 0xa1, 0xa3, 0x59, 0x3d, 0x08, 0x4d, 0x12, 0xe4, 0x4c, 0x64, 0x91,
+	0x4c, 0x1c, 0xd3, 0x0a, 0x19, 0x03, 0xc9, 0xd7, 0x86, 0x28, 0x96, 0x77, 0x1e, 0x66, 0x08, 0x98,
+	0x49, 0x25, 0xae, 0x60, 0x14, 0x0b, 0xad, 0x0b, 0x02, 0x56, 0xb0, 0x0a, 0x25, 0x5a, 0x1e, 0x25,
+	0x76, 0x49, 0xfb, 0xfc, 0x26, 0x01, 0xa5, 0x59, 0x7b, 0xd0, 0x40, 0xdc, 0x4a, 0x1b, 0x49, 0x48,
+	0x49, 0xf3, 0x85, 0x0c, 0xc1, 0x51, 0xd0, 0xac, 0x0b, 0x02, 0xf7, 0x2a, 0x04, 0x2e, 0xc9, 0x4e,
+	0x3c, 0xb2, 0x7f, 0x4e, 0xee, 0x9d, 0x9b, 0x0c, 0x47, 0x32, 0x11, 0xb1, 0xe3, 0xb3, 0xe5, 0xe2,
+	0xba, 0x02, 0x89, 0x33, 0x9a, 0x06, 0xce, 0xca, 0x11, 0xb3, 0x00, 0x4a, 0xe2, 0x6f, 0x57, 0x89,
+	0x8f, 0x64, 0x4b, 0xaf, 0x61, 0x0e, 0x4a, 0xc4, 0x86, 0xa0, 0x5d, 0x5e, 0x02, 0x78, 0xfe, 0x48,
+	0x26, 0x19, 0x24, 0xd9, 0x54, 0x2e, 0xd4, 0x0c, 0x4c, 0xe0, 0x96, 0xaf, 0xeb, 0x0a, 0x7a, 0x40,
+	0x76, 0x3c, 0x30, 0x08, 0x0d, 0x7d, 0x7b, 0xfc, 0x36, 0x4c, 0x3f, 0x25, 0xdb, 0xa3, 0x58, 0x26,
+	0x30, 0x51, 0xf2, 0x4a, 0x81, 0xd6, 0x86, 0xc9, 0x2d, 0xee, 0x83, 0xae, 0xd0, 0xd4, 0x2a, 0xcd,
+	0x20, 0x74, 0x7c, 0x2e, 0x01, 0x7a, 0x44, 0x1e, 0x38, 0x21, 0x92, 0xc9, 0x14, 0x66, 0x0a, 0xb2,
+	0x33, 0x31, 0x07, 0xc3, 0xee, 0x1e, 0xdf, 0xa8, 0xa3, 0x4f, 0xc9, 0xc3, 0x4d, 0xb8, 0x4e, 0xc5,
+	0x0c, 0x1c, 0xef, 0xff, 0xdd, 0x00, 0x8b, 0xe0, 0x44, 0x09, 0x0d, 0x13, 0x19, 0x47, 0xb3, 0x95,
+	0x29, 0x82, 0x1e, 0xaf, 0x42, 0x18, 0x31, 0x17, 0x51, 0x78, 0x0a, 0x4b, 0x88, 0x4d, 0x05, 0xf4,
+	0x78, 0x09, 0xa0, 0x3f, 0x0a, 0x63, 0x58, 0x46, 0x33, 0xd0, 0xa6, 0x0c, 0x5a, 0xbc, 0x0a, 0x61,
+	0x5e, 0x8c, 0xb9, 0xe3, 0xaa, 0x66, 0xcc, 0x50, 0xcc, 0x07, 0x71, 0x9f, 0x17, 0x6f, 0xdf, 0xe8,
+	0xf3, 0xd4, 0xda, 0x3c, 0xb4, 0x71, 0x54, 0x20, 0xfa, 0x19, 0xe9, 0x06, 0xf2, 0x34, 0x9a, 0x47,
+	0x99, 0x66, 0x7b, 0x83, 0xda, 0x41, 0xff, 0x68, 0xe7, 0xd0, 0x34, 0xef, 0xc3, 0xe0, 0xdc, 0xc2,
+	0xbc, 0x30, 0xa0, 0x8f, 0xc9, 0xce, 0xb3, 0x34, 0x8d, 0x23, 0x08, 0x0b, 0x9f, 0x8f, 0x36, 0xfb,
+	0xdc, 0xb6, 0xa3, 0x5f, 0x91, 0xfe, 0x71, 0x2c, 0x67, 0x6f, 0x5f, 0x2e, 0x92, 0x28, 0xb9, 0x62,
+	0xfb, 0xc6, 0x8d, 0x3a, 0xb7, 0x8a, 0x86, 0x57, 0xcd, 0x86, 0xbf, 0xd4, 0x48, 0x37, 0xdf, 0x13,
+	0x4b, 0x99, 0x83, 0x08, 0x83, 0xf3, 0xc9, 0xd4, 0x14, 0x78, 0x83, 0x17, 0x32, 0xa6, 0xf3, 0x52,
+	0x45, 0x19, 0x18, 0x65, 0xdd, 0x28, 0x4b, 0x00, 0x3b, 0x1a, 0x5a, 0x1e, 0x4f, 0xa6, 0xa6, 0xc6,
+	0x1b, 0x3c, 0x17, 0x71, 0x4f, 0x63, 0x86, 0xaa, 0xa6, 0xdd, 0x33, 0x97, 0xb1, 0xd4, 0x2e, 0x21,
+	0xba, 0xba, 0xce, 0x4c, 0x59, 0xb7, 0xb8, 0x93, 0x86, 0xbf, 0xd6, 0xbd, 0x6f, 0xc1, 0xdd, 0x27,
+	0x4a, 0xbe, 0x89, 0x62, 0x70, 0x7d, 0x27, 0x17, 0x71, 0x07, 0x7b, 0x5f, 0xae, 0xf5, 0x38, 0x89,
+	0x3e, 0x26, 0x1d, 0x97, 0x1f, 0xd6, 0x18, 0x34, 0x0e, 0xfa, 0x47, 0x9f, 0xac, 0x27, 0xe2, 0xd0,
+	0x59, 0x9c, 0x24, 0x99, 0x5a, 0xf1, 0xdc, 0x9e, 0x3e, 0x25, 0xdd, 0x89, 0x82, 0x65, 0x24, 0x17,
+	0xd8, 0x8b, 0xd0, 0x77, 0xb0, 0xc1, 0x37, 0x37, 0xb1, 0xce, 0x85, 0xc7, 0xde, 0x13, 0xb2, 0x55,
+	0xdd, 0x16, 0xdb, 0xec, 0x5b, 0x58, 0xb9, 0xb0, 0x71, 0x89, 0xb5, 0xbf, 0x14, 0xf1, 0x22, 0x8f,
+	0xd8, 0x0a, 0x4f, 0xea, 0x8f, 0x6a, 0x7b, 0xdf, 0x90, 0x6d, 0x6f, 0xdb, 0xf7, 0x71, 0x1e, 0xfe,
+	0x4c, 0xee, 0x3d, 0x5b, 0x8a, 0x28, 0x16, 0xaf, 0x63, 0x18, 0x89, 0x54, 0xcc, 0xa2, 0x6c, 0xe5,
+	0xf5, 0xe6, 0xda, 0xad, 0xde, 0x5c, 0xf6, 0xd4, 0xba, 0xd7, 0x53, 0x87, 0x64, 0x4b, 0x57, 0xfb,
+	0xb1, 0xeb, 0xd9, 0x55, 0xac, 0xe8, 0xaf, 0xcd, 0xb2, 0xbf, 0x0e, 0xff, 0xac, 0x91, 0xfd, 0xb5,
+	0x08, 0x38, 0x68, 0x50, 0x4b, 0x7b, 0xe0, 0x3e, 0xe9, 0x95, 0x05, 0x6e, 0xa3, 0x29, 0x81, 0xca,
+	0x8c, 0x50, 0xf7, 0x66, 0x84, 0xaf, 0xc9, 0x16, 0x06, 0xc6, 0xe1, 0xa7, 0x05, 0xe8, 0xcc, 0x86,
+	0xd3, 0x3f, 0xba, 0xef, 0xae, 0xa4, 0xaa, 0xe2, 0x9e, 0x21, 0xfd, 0x81, 0xdc, 0xaf, 0x9c, 0x5e,
+	0xf8, 0xdb, 0x2b, 0x7d, 0xe8, 0xfc, 0xd7, 0x2d, 0xf8, 0x26, 0xaf, 0xe1, 0x73, 0x3f, 0x0a, 0xd3,
+	0x5c, 0xec, 0x1a, 0xf0, 0x2d, 0x6c, 0x98, 0xe6, 0x92, 0x03, 0xb6, 0x8e, 0x70, 0x13, 0xc0, 0xe4,
+	0xa2, 0xb2, 0x90, 0x87, 0xef, 0x08, 0x5d, 0x3f, 0x80, 0x7e, 0x4b, 0x76, 0xca, 0x94, 0x19, 0xc8,
+	0x64, 0xa8, 0x7f, 0xb4, 0xeb, 0x02, 0xbd, 0xa5, 0xe5, 0xb7, 0xcd, 0xf1, 0xda, 0x2a, 0xfb, 0x6a,
+	0x77, 0xae, 0x87, 0x0d, 0x7f, 0xab, 0xad, 0x1d, 0x83, 0x57, 0x69, 0x5a, 0xb5, 0x9b, 0x1b, 0x71,
+	0xbd, 0xf6, 0x24, 0xd7, 0x37, 0x3c, 0xc9, 0x39, 0x05, 0x1a, 0xfe, 0x9c, 0xe7, 0x28, 0xd5, 0xf4,
+	0x28, 0xe5, 0xb5, 0xe2, 0xd6, 0xff, 0xb4, 0xe2, 0xf6, 0x5a, 0x2b, 0x1e, 0xfe, 0x5e, 0x27, 0xf4,
+	0x54, 0x5e, 0x45, 0x33, 0x11, 0xdb, 0x21, 0xe4, 0x7b, 0x25, 0x17, 0xe9, 0xc6, 0xd0, 0x11, 0xc3,
+	0x57, 0xbe, 0xee, 0x30, 0x7c, 0xe5, 0xf7, 0x49, 0xaf, 0xec, 0xe2, 0x0d, 0x7b, 0x59, 0x05, 0xb0,
+	0x89, 0xcb, 0xf4, 0x63, 0x42, 0xec, 0x41, 0x1c, 0xde, 0x68, 0xd6, 0x32, 0x2e, 0x15, 0xa4, 0x42,
+	0xd6, 0xb6, 0x47, 0xd6, 0x72, 0x76, 0xe8, 0xac, 0x0d, 0xca, 0xd7, 0x51, 0x62, 0x66, 0x8f, 0x2e,
+	0x37, 0x6b, 0xfc, 0xec, 0x8b, 0x48, 0x65, 0x0b, 0x11, 0x9b, 0xe3, 0x7b, 0x76, 0x8c, 0xab, 0x40,
+	0x18, 0xf7, 0x58, 0x64, 0xc2, 0x4e, 0x0a, 0xc4, 0xa4, 0xa5, 0x04, 0xf0, 0x7d, 0x7a, 0x01, 0x99,
+	0x08, 0x0b, 0x8b, 0xbe, 0x7d, 0xb7, 0x3d, 0x10, 0xfb, 0xbb, 0x4d, 0xc2, 0xa6, 0xff, 0x83, 0x47,
+	0xa4, 0xf7, 0x2c, 0x0c, 0xf1, 0x7d, 0x07, 0x4b, 0x98, 0xfe, 0xd1, 0x5e, 0xa5, 0xb0, 0x0e, 0x0b,
+	0xa5, 0xed, 0x72, 0xa5, 0xf1, 0xde, 0x53, 0x72, 0xc7, 0x57, 0xbe, 0x57, 0xaf, 0xfa, 0xbb, 0x46,
+	0xba, 0xd3, 0x44, 0xa4, 0xfa, 0x5a, 0x66, 0x9b, 0xe6, 0x49, 0x9b, 0xe9, 0xa2, 0x33, 0x15, 0x72,
+	0x85, 0x60, 0x0d, 0x8f, 0x60, 0xd5, 0x3e, 0xd7, 0x5c, 0x9f, 0x41, 0x3d, 0x32, 0xb7, 0xfe, 0x83,
+	0xcc, 0xed, 0x0a, 0x07, 0xbc, 0x09, 0xae, 0x73, 0x7b, 0x82, 0x2b, 0x6f, 0xba, 0xeb, 0xdd, 0xf4,
+	0x90, 0x6c, 0x8d, 0x14, 0xd8, 0xe9, 0x36, 0x9a, 0xe7, 0xd7, 0xea, 0x61, 0xc7, 0x9d, 0x1f, 0xed,
+	0xdf, 0xdb, 0xeb, 0xb6, 0xf9, 0x97, 0xfb, 0xf2, 0x9f, 0x01, 0x00, 0x3a, 0x81, 0xe1, 0x17, 0xda,
+	0x0d, 0x00, 0x00,
 }
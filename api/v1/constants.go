@@ -23,6 +23,7 @@ const (
 	LVGKind                          = "LogicalVolumeGroup"
 	DriveKind                        = "Drive"
 	CSIBMNodeKind                    = "Node"
+	SnapshotKind                     = "Snapshot"
 
 	Version            = "v1"
 	CSICRsGroupVersion = "csi-baremetal.dell.com"
@@ -51,6 +52,11 @@ const (
 	DriveStatusOnline  = "ONLINE"
 	DriveStatusOffline = "OFFLINE"
 
+	// Drive event types of WatchDrives stream
+	DriveEventAdded   = "ADDED"
+	DriveEventRemoved = "REMOVED"
+	DriveEventChanged = "CHANGED"
+
 	// Drive Usage status
 	DriveUsageInUse     = "IN_USE"
 	DriveUsageReleasing = "RELEASING"
@@ -100,6 +106,19 @@ const (
 	ModeRAWPART = "RAW_PART"
 	ModeFS      = "FS"
 
+	// Volume content source type
+	ContentSourceSnapshot = "SNAPSHOT"
+	ContentSourceVolume   = "VOLUME"
+
+	// Erase policies of drive based volume, they are applied to the drive on volume release
+	ErasePolicyNone           = "none"
+	ErasePolicyWipeFS         = "wipefs"
+	ErasePolicyZeroFill       = "zero-fill"
+	ErasePolicyDiscard        = "discard"
+	ErasePolicyNVMeFormat     = "nvme-format"
+	ErasePolicyNVMeSanitize   = "nvme-sanitize"
+	ErasePolicyATASecureErase = "ata-secure-erase"
+
 	//LVG annotations
 	LVGFreeSpaceAnnotation = "lvg/free-space"
 
@@ -107,6 +126,12 @@ const (
 	LocationTypeDrive = "DRIVE"
 	LocationTypeLVM   = "LVM"
 	LocationTypeNVMe  = "NVME"
+	LocationTypeRAID  = "RAID"
+
+	// Levels of mdadm RAID which volume could be assembled to
+	RaidLevel0  = "raid0"
+	RaidLevel1  = "raid1"
+	RaidLevel10 = "raid10"
 
 	// Available Capacity Reservation statuses
 	ReservationRequested = "REQUESTED"
@@ -124,6 +149,10 @@ const (
 	StorageClassSSDLVG    = "SSDLVG"
 	StorageClassNVMeLVG   = "NVMELVG"
 	StorageClassSystemLVG = "SYSLVG"
+	// Volumes with thin LVG storage classes are thin logical volumes in the thin pool of LVG
+	StorageClassHDDLVGThin  = "HDDLVGTHIN"
+	StorageClassSSDLVGThin  = "SSDLVGTHIN"
+	StorageClassNVMeLVGThin = "NVMELVGTHIN"
 
 	LocateStart  = int32(0)
 	LocateStop   = int32(1)
@@ -77,9 +77,16 @@ func (in *Drive) Equals(drive *api.Drive) bool {
 		in.Spec.VID == drive.VID &&
 		in.Spec.Status == drive.Status &&
 		in.Spec.Health == drive.Health &&
+		in.Spec.HealthReason == drive.HealthReason &&
 		in.Spec.Type == drive.Type &&
 		in.Spec.Size == drive.Size &&
-		in.Spec.Path == drive.Path
+		in.Spec.Endurance == drive.Endurance &&
+		in.Spec.Enclosure == drive.Enclosure &&
+		in.Spec.Slot == drive.Slot &&
+		in.Spec.Bay == drive.Bay &&
+		in.Spec.Path == drive.Path &&
+		in.Spec.WWN == drive.WWN &&
+		in.Spec.ByIdPath == drive.ByIdPath
 }
 
 func (in *Drive) GetDriveDescription() string {
@@ -0,0 +1,61 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshotcrd
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// Snapshot is the Schema for the snapshots API
+// +kubebuilder:resource:scope=Cluster,shortName={snap,snaps}
+// +kubebuilder:printcolumn:name="SIZE",type="string",JSONPath=".spec.Size",description="Snapshot reserved size"
+// +kubebuilder:printcolumn:name="VOLUME",type="string",JSONPath=".spec.VolumeId",description="Source volume ID"
+// +kubebuilder:printcolumn:name="CSI_STATUS",type="string",JSONPath=".spec.CSIStatus",description="Snapshot internal CSI status"
+// +kubebuilder:printcolumn:name="HEALTH",type="string",JSONPath=".spec.Health",description="Snapshot health status"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.Location",description="Snapshot LVG location",priority=1
+// +kubebuilder:printcolumn:name="NODE",type="string",JSONPath=".spec.NodeId",description="Snapshot node location"
+type Snapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              api.Snapshot `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SnapshotList contains a list of Snapshot
+//+kubebuilder:object:generate=true
+type SnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Snapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilderSnapshot.Register(&Snapshot{}, &SnapshotList{})
+}
+
+// Need to declare this method because api.Snapshot doesn't have DeepCopyInto
+func (in *Snapshot) DeepCopyInto(out *Snapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
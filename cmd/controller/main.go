@@ -48,10 +48,13 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/logger/objects"
 	"github.com/dell/csi-baremetal/pkg/base/rpc"
 	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/common"
 	"github.com/dell/csi-baremetal/pkg/controller"
 	"github.com/dell/csi-baremetal/pkg/controller/capacitycontroller"
+	"github.com/dell/csi-baremetal/pkg/controller/mountoptions"
 	"github.com/dell/csi-baremetal/pkg/crcontrollers/reservation"
 	"github.com/dell/csi-baremetal/pkg/metrics"
+	"github.com/dell/csi-baremetal/pkg/metrics/inventory"
 )
 
 var (
@@ -68,6 +71,12 @@ var (
 		"(example: :8080 which corresponds to port 8080 on local host). The default is empty string, which means metrics endpoint is disabled.")
 	metricspath              = flag.String("metrics-path", "/metrics", "The HTTP path where prometheus metrics will be exposed. Default is /metrics.")
 	sequentialLVGReservation = flag.Bool("sequential-lvg-reservation", false, "disable concurrent reservations for cases with LVG Volumes")
+	thinOvercommitRatio      = flag.Float64("thin-overcommit-ratio", common.ThinOvercommitRatio,
+		"Ratio of capacity available for thin volumes of LVG to its physical size, should be >= 1")
+	publishStorageCapacity = flag.Bool("publish-storage-capacity", false,
+		"Whether controller should maintain CSIStorageCapacity objects for each node and StorageClass or not")
+	mountOptionsConfig = flag.String("mount-options-config", mountoptions.DefaultConfigPath,
+		"Path to the config with supported mount options, the default options are used if it doesn't exist")
 )
 
 func main() {
@@ -86,6 +95,11 @@ func main() {
 		logger.Warnf("Can't set logger's output to %s. Using stdout instead.\n", *logPath)
 	}
 
+	if *thinOvercommitRatio < 1 {
+		logger.Fatalf("thin-overcommit-ratio should be >= 1, got %v", *thinOvercommitRatio)
+	}
+	common.ThinOvercommitRatio = *thinOvercommitRatio
+
 	logger.Info("Starting controller ...")
 
 	csiControllerServer := rpc.NewServerRunner(nil, *endpoint, enableMetrics, logger)
@@ -95,6 +109,8 @@ func main() {
 		logger.Fatalf("fail to create kubernetes client, error: %v", err)
 	}
 	kubeClient := k8s.NewKubeClient(k8SClient, logger, objects.NewObjectLogger(), *namespace)
+	// start to updating supported mount options
+	mountoptions.NewConfigWatcher(*mountOptionsConfig, logger).StartWatch()
 	controllerService := controller.NewControllerService(kubeClient, logger, featureConf)
 	handler := util.NewSignalHandler(logger)
 	go handler.SetupSIGTERMHandler(csiControllerServer)
@@ -107,6 +123,7 @@ func main() {
 		grpc_prometheus.EnableHandlingTimeHistogram()
 		grpc_prometheus.EnableClientHandlingTimeHistogram()
 		prometheus.MustRegister(metrics.BuildInfo)
+		prometheus.MustRegister(inventory.NewCollector(kubeClient, logger))
 
 		go func() {
 			http.Handle(*metricspath, promhttp.Handler())
@@ -196,5 +213,12 @@ func createManager(ctx context.Context, client *k8s.KubeClient, log *logrus.Logg
 	if err = capacityController.SetupWithManager(mgr); err != nil {
 		return nil, err
 	}
+
+	if *publishStorageCapacity {
+		storageCapacityController := capacitycontroller.NewStorageCapacityController(wrappedK8SClient, log)
+		if err = storageCapacityController.SetupWithManager(mgr); err != nil {
+			return nil, err
+		}
+	}
 	return mgr, nil
 }
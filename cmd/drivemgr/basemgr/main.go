@@ -23,9 +23,12 @@ import (
 	dmsetup "github.com/dell/csi-baremetal/cmd/drivemgr"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/uevent"
 	"github.com/dell/csi-baremetal/pkg/base/logger"
 	"github.com/dell/csi-baremetal/pkg/base/rpc"
+	"github.com/dell/csi-baremetal/pkg/drivemgr"
 	"github.com/dell/csi-baremetal/pkg/drivemgr/basemgr"
+	"github.com/dell/csi-baremetal/pkg/drivemgr/healthpolicy"
 )
 
 var (
@@ -33,6 +36,12 @@ var (
 	logPath  = flag.String("logpath", "", "log path for DriveManager")
 	logLevel = flag.String("loglevel", logger.InfoLevel,
 		fmt.Sprintf("Log level, support values are %s, %s, %s", logger.InfoLevel, logger.DebugLevel, logger.TraceLevel))
+	healthPolicy = flag.String("healthpolicy", "",
+		"Path to the SMART health policy file, failure prediction is disabled if not set")
+	resyncInterval = flag.Duration("resyncinterval", drivemgr.DefaultResyncInterval,
+		"Interval of drives rediscovery for drive events stream, catches changes which udev doesn't notify about")
+	healthCheckInterval = flag.Duration("healthcheckinterval", drivemgr.DefaultHealthCheckInterval,
+		"Interval of drives health refreshing from SMART for drive events stream")
 )
 
 func main() {
@@ -49,6 +58,21 @@ func main() {
 	e := command.NewExecutor(logger)
 
 	driveMgr := basemgr.New(e, logger)
+	policy, err := healthpolicy.LoadPolicy(*healthPolicy)
+	if err != nil {
+		logger.Fatalf("Failed to load SMART health policy: %v", err)
+	}
+	driveMgr.SetHealthPolicy(policy)
+
+	// udev events make WatchDrives stream react on hot-plug, drives are rediscovered periodically without them
+	monitor, err := uevent.NewMonitor(uevent.UdevGroup, logger)
+	if err != nil {
+		logger.Warnf("Failed to subscribe to udev events: %v", err)
+	} else {
+		//nolint:errcheck
+		defer monitor.Close()
+		go driveMgr.HandleUevents(monitor.Start())
+	}
 
-	dmsetup.SetupAndRunDriveMgr(driveMgr, serverRunner, nil, logger)
+	dmsetup.SetupAndRunDriveMgr(driveMgr, serverRunner, nil, logger, *resyncInterval, *healthCheckInterval)
 }
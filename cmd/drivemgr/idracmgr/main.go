@@ -27,6 +27,7 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/ipmi"
 	"github.com/dell/csi-baremetal/pkg/base/logger"
 	"github.com/dell/csi-baremetal/pkg/base/rpc"
+	"github.com/dell/csi-baremetal/pkg/drivemgr"
 	"github.com/dell/csi-baremetal/pkg/drivemgr/idracmgr"
 )
 
@@ -58,5 +59,7 @@ func main() {
 
 	driveMgr := idracmgr.NewIDRACManager(logger, 10*time.Second, "root", "passwd", ip)
 
-	dmsetup.SetupAndRunDriveMgr(driveMgr, serverRunner, nil, logger)
+	// drives aren't watched, intervals aren't used
+	dmsetup.SetupAndRunDriveMgr(driveMgr, serverRunner, nil, logger,
+		drivemgr.DefaultResyncInterval, drivemgr.DefaultHealthCheckInterval)
 }
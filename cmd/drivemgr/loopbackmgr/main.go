@@ -31,6 +31,7 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/logger"
 	"github.com/dell/csi-baremetal/pkg/base/rpc"
 	annotations "github.com/dell/csi-baremetal/pkg/crcontrollers/node/common"
+	"github.com/dell/csi-baremetal/pkg/drivemgr"
 	"github.com/dell/csi-baremetal/pkg/drivemgr/loopbackmgr"
 )
 
@@ -45,6 +46,8 @@ var (
 		"Whether node should read id from external annotation. It should exist before deployment. Use if \"usenodeannotation\" is True")
 	nodeIDAnnotation = flag.String("nodeidannotation", "",
 		"Custom node annotation name. Use if \"useexternalannotation\" is True")
+	resyncInterval = flag.Duration("resyncinterval", drivemgr.DefaultResyncInterval,
+		"Interval of drives rediscovery for drive events stream, catches changes which aren't notified about")
 )
 
 func main() {
@@ -87,5 +90,6 @@ func main() {
 	driveMgr := loopbackmgr.NewLoopBackManager(e, nodeID, nodeName, logger)
 
 	go driveMgr.UpdateOnConfigChange(watcher)
-	dmsetup.SetupAndRunDriveMgr(driveMgr, serverRunner, driveMgr.CleanupLoopDevices, logger)
+	dmsetup.SetupAndRunDriveMgr(driveMgr, serverRunner, driveMgr.CleanupLoopDevices, logger,
+		*resyncInterval, drivemgr.DefaultHealthCheckInterval)
 }
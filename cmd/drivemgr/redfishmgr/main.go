@@ -0,0 +1,88 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	dmsetup "github.com/dell/csi-baremetal/cmd/drivemgr"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/ipmi"
+	"github.com/dell/csi-baremetal/pkg/base/logger"
+	"github.com/dell/csi-baremetal/pkg/base/rpc"
+	"github.com/dell/csi-baremetal/pkg/drivemgr"
+	"github.com/dell/csi-baremetal/pkg/drivemgr/redfishmgr"
+)
+
+const (
+	// environment variables with BMC credentials, they are expected to be set from Secret
+	userEnv     = "REDFISH_USER"
+	passwordEnv = "REDFISH_PASSWORD"
+)
+
+var (
+	endpoint = flag.String("drivemgrendpoint", base.DefaultDriveMgrEndpoint, "DriveManager Endpoint")
+	logPath  = flag.String("logpath", "", "log path for DriveManager")
+	logLevel = flag.String("loglevel", logger.InfoLevel,
+		fmt.Sprintf("Log level, support values are %s, %s, %s", logger.InfoLevel, logger.DebugLevel, logger.TraceLevel))
+	bmcEndpoint = flag.String("bmcendpoint", "",
+		"URL of Redfish service, e.g. https://10.0.0.1, BMC IP is detected with ipmitool if not set")
+	caFile  = flag.String("cafile", "", "Path to PEM encoded CA certificates of BMC, system CAs are used if not set")
+	timeout = flag.Duration("timeout", redfishmgr.DefaultTimeout, "Timeout of requests to Redfish service")
+)
+
+func main() {
+	flag.Parse()
+
+	logger, err := logger.InitLogger(*logPath, *logLevel)
+	if err != nil {
+		logger.Warnf("Can't set logger's output to %s. Using stdout instead.\n", *logPath)
+	}
+
+	// Server is insecure for now because credentials are nil
+	serverRunner := rpc.NewServerRunner(nil, *endpoint, false, logger)
+
+	url := *bmcEndpoint
+	if url == "" {
+		ip := ipmi.NewIPMI(command.NewExecutor(logger)).GetBmcIP()
+		if ip == "" {
+			logger.Fatal("BMC IP is not found")
+		}
+		url = "https://" + ip
+	}
+
+	driveMgr, err := redfishmgr.NewRedfishManager(logger, redfishmgr.Config{
+		Endpoint: url,
+		User:     os.Getenv(userEnv),
+		Password: os.Getenv(passwordEnv),
+		CAFile:   *caFile,
+		Timeout:  *timeout,
+	})
+	if err != nil {
+		logger.Fatalf("Failed to create Redfish drive manager: %v", err)
+	}
+
+	// drives aren't watched, intervals aren't used
+	dmsetup.SetupAndRunDriveMgr(driveMgr, serverRunner, nil, logger,
+		drivemgr.DefaultResyncInterval, drivemgr.DefaultHealthCheckInterval)
+	if err = driveMgr.Close(); err != nil {
+		logger.Errorf("Failed to close Redfish session: %v", err)
+	}
+}
@@ -18,6 +18,8 @@ limitations under the License.
 package dmsetup
 
 import (
+	"time"
+
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 
@@ -28,10 +30,14 @@ import (
 )
 
 // SetupAndRunDriveMgr setups and start/stop particular drive manager
-func SetupAndRunDriveMgr(d drivemgr.DriveManager, sr *rpc.ServerRunner, cleanupFn func(), logger *logrus.Logger) {
+// Intervals of drives rediscovery and health refreshing are used by WatchDrives if drive manager supports it
+func SetupAndRunDriveMgr(d drivemgr.DriveManager, sr *rpc.ServerRunner, cleanupFn func(), logger *logrus.Logger,
+	resyncInterval, healthCheckInterval time.Duration) {
 	logger.Info("Start DriveManager")
 
 	driveServiceServer := drivemgr.NewDriveServer(logger, d)
+	driveServiceServer.SetResyncInterval(resyncInterval)
+	driveServiceServer.SetHealthCheckInterval(healthCheckInterval)
 
 	api.RegisterDriveServiceServer(sr.GRPCServer, &driveServiceServer)
 
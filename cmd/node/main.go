@@ -27,7 +27,9 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -41,6 +43,7 @@ import (
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
@@ -49,13 +52,14 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/logger/objects"
 	"github.com/dell/csi-baremetal/pkg/base/rpc"
 	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/controller/mountoptions"
 	"github.com/dell/csi-baremetal/pkg/crcontrollers/drive"
 	"github.com/dell/csi-baremetal/pkg/crcontrollers/lvg"
 	annotations "github.com/dell/csi-baremetal/pkg/crcontrollers/node/common"
 	"github.com/dell/csi-baremetal/pkg/events"
 	"github.com/dell/csi-baremetal/pkg/metrics"
 	"github.com/dell/csi-baremetal/pkg/node"
-	"github.com/dell/csi-baremetal/pkg/node/wbt"
+	"github.com/dell/csi-baremetal/pkg/node/blocktuning"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -67,6 +71,10 @@ const (
 	// on loaded system drive manager might response with the delay
 	numberOfRetries  = 20
 	delayBeforeRetry = 5
+	// Discover is performed with this interval while drive events stream is open
+	resyncWaitTime = 5 * time.Minute
+	// drive telemetry, RAID arrays and thin pools are checked with this interval
+	monitorWaitTime = 30 * time.Second
 )
 
 var (
@@ -88,7 +96,11 @@ var (
 		fmt.Sprintf("Log level, support values are %s, %s, %s", logger.InfoLevel, logger.DebugLevel, logger.TraceLevel))
 	metricsAddress = flag.String("metrics-address", "", "The TCP network address where the prometheus metrics endpoint will run"+
 		"(example: :8080 which corresponds to port 8080 on local host). The default is empty string, which means metrics endpoint is disabled.")
-	metricspath = flag.String("metrics-path", "/metrics", "The HTTP path where prometheus metrics will be exposed. Default is /metrics.")
+	metricspath            = flag.String("metrics-path", "/metrics", "The HTTP path where prometheus metrics will be exposed. Default is /metrics.")
+	thinPoolUsageThreshold = flag.Float64("thin-pool-usage-threshold", node.ThinPoolUsageThreshold,
+		"Percent of thin pool data or metadata usage after which LVG health becomes SUSPECT")
+	mountOptionsConfig = flag.String("mount-options-config", mountoptions.DefaultConfigPath,
+		"Path to the config with supported mount options, the default options are used if it doesn't exist")
 )
 
 func main() {
@@ -109,6 +121,8 @@ func main() {
 		logger.Warnf("Can't set logger's output to %s. Using stdout instead.\n", *logPath)
 	}
 
+	node.ThinPoolUsageThreshold = *thinPoolUsageThreshold
+
 	logger.Info("Starting Node Service")
 
 	stopCH := ctrl.SetupSignalHandler()
@@ -144,9 +158,9 @@ func main() {
 		logger.Fatalf("fail to prepare event recorder: %v", err)
 	}
 
-	wbtWatcher, err := prepareWbtWatcher(k8SClient, eventRecorder, *nodeName, logger)
+	tuningWatcher, err := prepareBlockTuningWatcher(k8SClient, eventRecorder, *nodeName, logger)
 	if err != nil {
-		logger.Fatalf("fail to prepare wbt watcher: %v", err)
+		logger.Fatalf("fail to prepare block tuning watcher: %v", err)
 	}
 
 	// Wait till all events are sent/handled
@@ -200,8 +214,10 @@ func main() {
 	// wait for readiness
 	waitForVolumeManagerReadiness(csiNodeService, logger)
 
-	// start to updating Wbt Config
-	wbtWatcher.StartWatch(csiNodeService)
+	// start to updating block tuning profiles
+	tuningWatcher.StartWatch(csiNodeService)
+	// start to updating supported mount options
+	mountoptions.NewConfigWatcher(*mountOptionsConfig, logger).StartWatch()
 
 	logger.Info("Starting handle CSI calls ...")
 	if err := csiUDSServer.RunServer(); err != nil && err != grpc.ErrServerStopped {
@@ -249,14 +265,42 @@ func waitForVolumeManagerReadiness(c *node.CSINodeService, logger *logrus.Logger
 	logger.Fatalf("Number of retries %d exceeded. Exiting...", numberOfRetries)
 }
 
-// Discovering performs Discover method of the Node each 30 seconds
+// Discovering performs Discover method of the Node each 30 seconds if drive events stream isn't available
+// and each 5 minutes otherwise, drive events are handled one by one without Discover.
+// Monitor method is performed each 30 seconds independently of the stream
 func Discovering(c *node.CSINodeService, logger *logrus.Logger) {
-	var err error
+	var (
+		err    error
+		events <-chan *api.DriveEvent
+		// drive manager may not support drive events stream, it isn't reopened then
+		watch = true
+	)
 	// set initial delay
 	discoveringWaitTime := 10 * time.Second
+	discover := time.After(discoveringWaitTime)
+	monitor := time.NewTicker(monitorWaitTime)
+	defer monitor.Stop()
 	checker := c.GetLivenessHelper()
 	for {
-		time.Sleep(discoveringWaitTime)
+		select {
+		case <-discover:
+		case <-monitor.C:
+			if err = c.Monitor(); err != nil {
+				logger.Errorf("Monitor finished with error: %v", err)
+			}
+			continue
+		case event, ok := <-events:
+			if !ok {
+				logger.Warn("Drive events stream is closed, fall back to polling")
+				events = nil
+				discover = time.After(discoveringWaitTime)
+				continue
+			}
+			if err = c.HandleDriveEvent(event); err != nil {
+				logger.Errorf("Failed to handle drive event: %v", err)
+			}
+			continue
+		}
 		if err = c.Discover(); err != nil {
 			checker.Fail()
 			logger.Errorf("Discover finished with error: %v", err)
@@ -265,6 +309,21 @@ func Discovering(c *node.CSINodeService, logger *logrus.Logger) {
 			logger.Tracef("Discover finished successful")
 			// Increase wait time, because we don't need to call API often after node initialization
 			discoveringWaitTime = 30 * time.Second
+			if events == nil && watch {
+				if events, err = c.WatchDriveEvents(context.Background()); err != nil {
+					if status.Code(err) == codes.Unimplemented {
+						logger.Infof("Drive manager doesn't support drive events, polling is used")
+						watch = false
+					} else {
+						logger.Warnf("Unable to watch drive events, polling is used: %v", err)
+					}
+				}
+			}
+		}
+		if events != nil && err == nil {
+			discover = time.After(resyncWaitTime)
+		} else {
+			discover = time.After(discoveringWaitTime)
 		}
 	}
 }
@@ -295,6 +354,11 @@ func prepareCRDControllerManagers(volumeCtrl *node.CSINodeService, lvgCtrl *lvg.
 		logrus.Fatal(err)
 	}
 
+	// register Snapshot crd
+	if err = snapshotcrd.AddToSchemeSnapshot(scheme); err != nil {
+		logrus.Fatal(err)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
 	})
@@ -342,7 +406,8 @@ func prepareEventRecorder(nodeName string, logger *logrus.Logger) (*events.Recor
 	return eventRecorder, nil
 }
 
-func prepareWbtWatcher(client k8sClient.Client, eventsRecorder *events.Recorder, nodeName string, logger *logrus.Logger) (*wbt.ConfWatcher, error) {
+func prepareBlockTuningWatcher(client k8sClient.Client, eventsRecorder *events.Recorder, nodeName string,
+	logger *logrus.Logger) (*blocktuning.ConfWatcher, error) {
 	k8sNode := &corev1.Node{}
 	err := client.Get(context.Background(), k8sClient.ObjectKey{Name: nodeName}, k8sNode)
 	if err != nil {
@@ -350,7 +415,7 @@ func prepareWbtWatcher(client k8sClient.Client, eventsRecorder *events.Recorder,
 	}
 
 	nodeKernel := k8sNode.Status.NodeInfo.KernelVersion
-	ll := logger.WithField("componentName", "WbtWatcher")
+	ll := logger.WithField("componentName", "BlockTuningWatcher")
 
-	return wbt.NewConfWatcher(client, eventsRecorder, ll, nodeKernel), nil
+	return blocktuning.NewConfWatcher(client, eventsRecorder, ll, nodeKernel), nil
 }
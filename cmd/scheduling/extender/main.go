@@ -60,6 +60,10 @@ var (
 	healthIP          = flag.String("healthip", base.DefaultHealthIP, "IP for health service")
 	healthPort        = flag.Int("healthport", base.DefaultHealthPort, "Port for health service")
 	isPatchingEnabled = flag.Bool("isPatchingEnabled", false, "should enable readiness probe")
+	scoringStrategy   = flag.String("scoringstrategy", extender.VolumeCountStrategy,
+		"Strategy of nodes scoring: volume-count, least-allocated, most-allocated, best-fit or enclosure-spread")
+	scoringConfig = flag.String("scoringconfig", "",
+		"Path to the scoring config file mounted from ConfigMap. Strategy from this file overrides \"scoringstrategy\"")
 )
 
 // TODO should be passed as parameters https://github.com/dell/csi-baremetal/issues/78
@@ -122,6 +126,9 @@ func main() {
 	if err != nil {
 		logger.Fatalf("Fail to create extender: %v", err)
 	}
+	if err = newExtender.SetScoringStrategy(*scoringStrategy, *scoringConfig); err != nil {
+		logger.Fatalf("Fail to set scoring strategy: %v", err)
+	}
 
 	logger.Infof("Starting extender on port %d ...", *port)
 	// filter stage
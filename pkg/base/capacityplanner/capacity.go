@@ -0,0 +1,58 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityplanner
+
+import (
+	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+// GetFreeCapacity returns free capacity of the node for the storage class and size of the largest volume
+// which could be created on that node. ACs are selected the same way as for volume placing,
+// capacity held by confirmed AvailableCapacityReservations is excluded
+func GetFreeCapacity(node, sc string, acs []accrd.AvailableCapacity,
+	acrs []acrcrd.AvailableCapacityReservation) (total int64, maximum int64) {
+	nc := newNodeCapacity(node, acs, acrs)
+	if nc == nil {
+		return 0, 0
+	}
+	return nc.getFreeCapacity(sc)
+}
+
+// getFreeCapacity sums sizes of ACs which could be selected for the storage class
+func (nc *nodeCapacity) getFreeCapacity(sc string) (total int64, maximum int64) {
+	for _, acName := range nc.acsOrder[sc] {
+		free := nc.acs[acName].Spec.Size
+		if reservation, ok := nc.reservedACs[acName]; ok {
+			// the same rules as in selectACForVolume, only LVG reservations could share AC
+			if !util.IsStorageClassLVG(sc) || !util.IsStorageClassLVG(reservation.StorageClass) ||
+				util.IsStorageClassLVGThin(sc) != util.IsStorageClassLVGThin(reservation.StorageClass) {
+				continue
+			}
+			free -= reservation.Size
+		}
+		if free <= 0 {
+			continue
+		}
+		total += free
+		if free > maximum {
+			maximum = free
+		}
+	}
+	return total, maximum
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityplanner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+)
+
+func TestGetFreeCapacity(t *testing.T) {
+	var (
+		acHDD1    = *getTestAC(nodeName, testSmallSize, apiV1.StorageClassHDD)
+		acHDD2    = *getTestAC(nodeName, testLargeSize, apiV1.StorageClassHDD)
+		acSSD     = *getTestAC(nodeName, testSmallSize, apiV1.StorageClassSSD)
+		acHDDLVG  = *getTestAC(nodeName, testLargeSize, apiV1.StorageClassHDDLVG)
+		acAnother = *getTestAC(nodeName+"-another", testLargeSize, apiV1.StorageClassHDD)
+		acs       = []accrd.AvailableCapacity{acHDD1, acHDD2, acSSD, acHDDLVG, acAnother}
+	)
+
+	t.Run("No ACs", func(t *testing.T) {
+		total, maximum := GetFreeCapacity(nodeName, apiV1.StorageClassHDD, nil, nil)
+		assert.Equal(t, int64(0), total)
+		assert.Equal(t, int64(0), maximum)
+	})
+
+	t.Run("Without reservations", func(t *testing.T) {
+		total, maximum := GetFreeCapacity(nodeName, apiV1.StorageClassHDD, acs, nil)
+		assert.Equal(t, testSmallSize+testLargeSize, total)
+		assert.Equal(t, testLargeSize, maximum)
+
+		total, maximum = GetFreeCapacity(nodeName, apiV1.StorageClassAny, acs, nil)
+		assert.Equal(t, 2*testSmallSize+testLargeSize, total)
+		assert.Equal(t, testLargeSize, maximum)
+
+		total, maximum = GetFreeCapacity(nodeName, apiV1.StorageClassHDDLVG, acs, nil)
+		assert.Equal(t, testSmallSize+2*testLargeSize, total)
+		assert.Equal(t, testLargeSize, maximum)
+
+		total, maximum = GetFreeCapacity(nodeName, apiV1.StorageClassNVMe, acs, nil)
+		assert.Equal(t, int64(0), total)
+		assert.Equal(t, int64(0), maximum)
+	})
+
+	t.Run("Confirmed reservations are excluded", func(t *testing.T) {
+		acrs := []acrcrd.AvailableCapacityReservation{
+			*getTestACR(testSmallSize, apiV1.StorageClassHDD, []*accrd.AvailableCapacity{&acHDD2}),
+			*getTestACR(testSmallSize, apiV1.StorageClassHDDLVG, []*accrd.AvailableCapacity{&acHDDLVG}),
+		}
+		rejected := getTestACR(testSmallSize, apiV1.StorageClassHDD, []*accrd.AvailableCapacity{&acHDD1})
+		rejected.Spec.Status = apiV1.ReservationRejected
+		acrs = append(acrs, *rejected)
+
+		// drive AC reserved for non-LVG volume is fully consumed
+		total, maximum := GetFreeCapacity(nodeName, apiV1.StorageClassHDD, acs, acrs)
+		assert.Equal(t, testSmallSize, total)
+		assert.Equal(t, testSmallSize, maximum)
+
+		// LVG AC could be shared between LVG volumes
+		total, maximum = GetFreeCapacity(nodeName, apiV1.StorageClassHDDLVG, acs, acrs)
+		assert.Equal(t, testLargeSize, total)
+		assert.Equal(t, testLargeSize-testSmallSize, maximum)
+
+		// thin volumes can't share drive with thick ones
+		total, _ = GetFreeCapacity(nodeName, apiV1.StorageClassHDDLVGThin, acs, acrs)
+		assert.Equal(t, testSmallSize, total)
+	})
+}
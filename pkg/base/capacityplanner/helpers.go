@@ -56,9 +56,42 @@ func (rh *ReservationHelper) UpdateReservation(ctx context.Context, placingPlan
 	defer rh.metric.EvaluateDurationForMethod("UpdateReservation")()
 	logger := util.AddCommonFields(ctx, rh.logger, "ReservationHelper.UpdateReservation")
 
+	confirmReservation(placingPlan, nodes, reservation)
+	if err := rh.client.UpdateCR(ctx, reservation); err != nil {
+		logger.Errorf("Unable to update reservation %s: %v", reservation.Name, err)
+		return err
+	}
+
+	return nil
+}
+
+// CreateReservation creates reservation CR which is already confirmed with ACs from placing plan
+func (rh *ReservationHelper) CreateReservation(ctx context.Context, placingPlan *VolumesPlacingPlan,
+	nodes []string, reservation *acrcrd.AvailableCapacityReservation) error {
+	defer rh.metric.EvaluateDurationForMethod("CreateReservation")()
+	logger := util.AddCommonFields(ctx, rh.logger, "ReservationHelper.CreateReservation")
+
+	confirmReservation(placingPlan, nodes, reservation)
+	if err := rh.client.CreateCR(ctx, reservation.Name, reservation); err != nil {
+		logger.Errorf("Unable to create reservation %s: %v", reservation.Name, err)
+		return err
+	}
+
+	return nil
+}
+
+// RemoveReservation removes reservation CR with all its reservation requests
+func (rh *ReservationHelper) RemoveReservation(ctx context.Context, reservation *acrcrd.AvailableCapacityReservation) error {
+	defer rh.metric.EvaluateDurationForMethod("RemoveReservation")()
+	return rh.removeACR(ctx, reservation)
+}
+
+// confirmReservation fills reservation requests with ACs from placing plan, sets reserved nodes and confirmed status
+func confirmReservation(placingPlan *VolumesPlacingPlan, nodes []string, reservation *acrcrd.AvailableCapacityReservation) {
 	nameToCapacity := map[string][]*accrd.AvailableCapacity{}
 	for volume, capacity := range placingPlan.GetACsForVolumes() {
-		nameToCapacity[volume.Id] = capacity
+		// RAID volume has several members with the same ID
+		nameToCapacity[volume.Id] = append(nameToCapacity[volume.Id], capacity...)
 	}
 
 	for _, request := range reservation.Spec.ReservationRequests {
@@ -73,12 +106,6 @@ func (rh *ReservationHelper) UpdateReservation(ctx context.Context, placingPlan
 	reservation.Spec.NodeRequests.Reserved = nodes
 	// confirm reservation
 	reservation.Spec.Status = v1.ReservationConfirmed
-	if err := rh.client.UpdateCR(ctx, reservation); err != nil {
-		logger.Errorf("Unable to update reservation %s: %v", reservation.Name, err)
-		return err
-	}
-
-	return nil
 }
 
 // ReleaseReservation removes AC from ACR or ACR completely when one volume requested or left
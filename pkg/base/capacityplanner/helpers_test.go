@@ -72,6 +72,53 @@ func TestReservationHelper_CreateReservation(t *testing.T) {
 	assert.Equal(t, acrList.Items[0].Spec.Status, apiV1.ReservationConfirmed)
 }
 
+func TestReservationHelper_CreateConfirmedReservation(t *testing.T) {
+	logger := testLogger.WithField("component", "test")
+	ctx := context.Background()
+	rh := createReservationHelper(t, logger, nil, getKubeClient(t))
+	plan := getSimpleVolumePlacingPlan()
+
+	reservation := genV1.AvailableCapacityReservation{}
+	for volume := range plan.GetACsForVolumes() {
+		reservation.ReservationRequests = append(reservation.ReservationRequests, &genV1.ReservationRequest{
+			CapacityRequest: &genV1.CapacityRequest{Name: volume.Id, Size: volume.Size, StorageClass: volume.StorageClass},
+		})
+	}
+	reservation.NodeRequests = &genV1.NodeRequests{Requested: []string{testNode1}}
+	name := "test"
+	reservationResource := &acrcrd.AvailableCapacityReservation{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       reservation,
+	}
+	err := rh.CreateReservation(ctx, plan, []string{testNode1}, reservationResource)
+	assert.Nil(t, err)
+
+	acr := &acrcrd.AvailableCapacityReservation{}
+	err = rh.client.ReadCR(ctx, name, "", acr)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.ReservationConfirmed, acr.Spec.Status)
+	assert.Equal(t, []string{testNode1}, acr.Spec.NodeRequests.Reserved)
+	assert.Len(t, acr.Spec.ReservationRequests[0].Reservations, 2)
+
+	// reservation already exists
+	err = rh.CreateReservation(ctx, plan, []string{testNode1}, reservationResource)
+	assert.NotNil(t, err)
+}
+
+func TestReservationHelper_RemoveReservation(t *testing.T) {
+	logger := testLogger.WithField("component", "test")
+	ctx := context.Background()
+	client := getKubeClient(t)
+	rh := createReservationHelper(t, logger, nil, client)
+
+	reservation := &acrcrd.AvailableCapacityReservation{ObjectMeta: metav1.ObjectMeta{Name: "test-reservation"}}
+	createACRsInAPi(t, client, []*acrcrd.AvailableCapacityReservation{reservation})
+
+	err := rh.RemoveReservation(ctx, reservation)
+	assert.Nil(t, err)
+	checkACRNotExist(t, client, reservation)
+}
+
 func TestReservationHelper_ReleaseReservation(t *testing.T) {
 	logger := testLogger.WithField("component", "test")
 	ctx := context.Background()
@@ -104,6 +104,9 @@ func newNodeCapacity(node string, acs []accrd.AvailableCapacity, acrs []acrcrd.A
 	acsOrder[v1.StorageClassHDDLVG] = append(acsOrder[v1.StorageClassHDDLVG], acsOrder[v1.StorageClassHDD]...)
 	acsOrder[v1.StorageClassSSDLVG] = append(acsOrder[v1.StorageClassSSDLVG], acsOrder[v1.StorageClassSSD]...)
 	acsOrder[v1.StorageClassNVMeLVG] = append(acsOrder[v1.StorageClassNVMeLVG], acsOrder[v1.StorageClassNVMe]...)
+	acsOrder[v1.StorageClassHDDLVGThin] = append(acsOrder[v1.StorageClassHDDLVGThin], acsOrder[v1.StorageClassHDD]...)
+	acsOrder[v1.StorageClassSSDLVGThin] = append(acsOrder[v1.StorageClassSSDLVGThin], acsOrder[v1.StorageClassSSD]...)
+	acsOrder[v1.StorageClassNVMeLVGThin] = append(acsOrder[v1.StorageClassNVMeLVGThin], acsOrder[v1.StorageClassNVMe]...)
 
 	acMap := buildACMap(acs)
 
@@ -138,12 +141,7 @@ func newNodeCapacity(node string, acs []accrd.AvailableCapacity, acrs []acrcrd.A
 }
 
 func (nc *nodeCapacity) selectACForVolume(vol *genV1.Volume) *accrd.AvailableCapacity {
-	requiredSize := vol.GetSize()
-	if util.IsStorageClassLVG(vol.StorageClass) {
-		// we should round up volume size, it should be aligned with LVM PE size
-		// TODO: use non default PE size - https://github.com/dell/csi-baremetal/issues/85
-		requiredSize = AlignSizeByPE(vol.GetSize())
-	}
+	requiredSize := getRequiredSize(vol)
 
 	for _, ac := range nc.acsOrder[vol.StorageClass] {
 		if requiredSize <= nc.acs[ac].Spec.Size {
@@ -170,6 +168,11 @@ func (nc *nodeCapacity) selectACForVolume(vol *genV1.Volume) *accrd.AvailableCap
 				continue
 			}
 
+			// skip AC, if thick and thin LVG volumes are placed on the same drive
+			if util.IsStorageClassLVGThin(vol.StorageClass) != util.IsStorageClassLVGThin(reservation.StorageClass) {
+				continue
+			}
+
 			// select AC, if it has enough capacity
 			if reservation.Size+requiredSize <= nc.acs[ac].Spec.Size {
 				foundAC := nc.acs[ac]
@@ -189,3 +192,17 @@ func buildACMap(acs []accrd.AvailableCapacity) ACMap {
 	}
 	return acMap
 }
+
+// getRequiredSize returns size which AC must have to hold the volume,
+// for RAID volume it is the size of each drive in the array
+func getRequiredSize(vol *genV1.Volume) int64 {
+	if util.IsStorageClassLVG(vol.StorageClass) {
+		// we should round up volume size, it should be aligned with LVM PE size
+		// TODO: use non default PE size - https://github.com/dell/csi-baremetal/issues/85
+		return AlignSizeByPE(vol.GetSize())
+	}
+	if vol.RaidLevel != "" {
+		return util.GetRaidMemberSize(vol.RaidLevel, vol.RaidDevices, vol.GetSize())
+	}
+	return vol.GetSize()
+}
@@ -67,6 +67,12 @@ type ReservationReader interface {
 	ReadReservations(ctx context.Context) ([]acrcrd.AvailableCapacityReservation, error)
 }
 
+// EnclosureReader methods to read enclosures of capacity locations
+type EnclosureReader interface {
+	// ReadEnclosures read AC location to enclosure mapping
+	ReadEnclosures(ctx context.Context) (map[string]string, error)
+}
+
 // CapacityPlaner describes interface for volumes placing planing
 type CapacityPlaner interface {
 	// PlanVolumesPlacing plan volumes placing on nodes
@@ -198,18 +204,36 @@ func (cm *CapacityManager) selectCapacityOnNode(ctx context.Context, node string
 	result := VolToACMap{}
 
 	for _, vol := range volumes {
-		ac := nodeCap.selectACForVolume(vol)
-		if ac == nil {
-			logger.Debugf("AC for vol: %s not found on node %s", vol.Id, node)
+		// volume might be pinned to the particular node, e.g. when it is cloned from another volume
+		if vol.NodeId != "" && vol.NodeId != node {
+			logger.Debugf("Vol: %s must be placed on node %s, skip node %s", vol.Id, vol.NodeId, node)
 			return nil
 		}
-		logger.Debugf("AC %s selected for vol: %s found on node %s", ac.Name, vol.Id, node)
-		result[vol] = ac
+		for _, member := range getRaidMembers(vol) {
+			ac := nodeCap.selectACForVolume(member)
+			if ac == nil {
+				logger.Debugf("AC for vol: %s not found on node %s", vol.Id, node)
+				return nil
+			}
+			logger.Debugf("AC %s selected for vol: %s found on node %s", ac.Name, vol.Id, node)
+			result[member] = ac
+		}
 	}
 	logger.Debugf("AC for all volumes found on node %s", node)
 	return result
 }
 
+// getRaidMembers returns volumes for which ACs must be selected, RAID volume requires separate AC for each drive.
+// The first member is the volume itself, so AC of non-RAID volume could be found in the plan by the volume
+func getRaidMembers(vol *genV1.Volume) []*genV1.Volume {
+	members := []*genV1.Volume{vol}
+	for i := int32(1); i < vol.RaidDevices; i++ {
+		member := *vol
+		members = append(members, &member)
+	}
+	return members
+}
+
 // check for existing ACR in RESERVED state with the same LVG SC
 // need to skip new reservation for LVG requests to avoid usage extra non-LVG AC for LVG
 func (cm *CapacityManager) isLVGCapacityReserved(ctx context.Context, volumes []*genV1.Volume, acrs []acrcrd.AvailableCapacityReservation) bool {
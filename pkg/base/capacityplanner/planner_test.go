@@ -158,6 +158,38 @@ func TestCapacityManager(t *testing.T) {
 		assert.NotNil(t, plan)
 		assert.Nil(t, err)
 	})
+	t.Run("RAID volume", func(t *testing.T) {
+		vol := getTestVol(testNode1, testLargeSize, apiV1.StorageClassHDD)
+		vol.RaidLevel = apiV1.RaidLevel0
+		vol.RaidDevices = 2
+		testACs := []*accrd.AvailableCapacity{
+			getTestAC(testNode1, testSmallSize, apiV1.StorageClassHDD),
+			getTestAC(testNode1, testSmallSize, apiV1.StorageClassSSD),
+		}
+		// only one HDD AC on node
+		plan, err := callPlanVolumesPlacing(getCapReaderMock(testACs, nil), getResReaderMock(nil, nil),
+			[]*genV1.Volume{vol}, []string{testNode1})
+		assert.Nil(t, plan)
+		assert.Nil(t, err)
+
+		// each drive of RAID0 holds half of volume size
+		testACs = append(testACs, getTestAC(testNode1, testSmallSize, apiV1.StorageClassHDD))
+		plan, err = callPlanVolumesPlacing(getCapReaderMock(testACs, nil), getResReaderMock(nil, nil),
+			[]*genV1.Volume{vol}, []string{testNode1})
+		assert.Nil(t, err)
+		assert.NotNil(t, plan)
+		assert.Equal(t, apiV1.StorageClassHDD, plan.GetACForVolume(testNode1, vol).Spec.StorageClass)
+		acs := plan.GetACsForVolumes()
+		assert.Len(t, acs, 2)
+		usedAC := map[string]struct{}{}
+		for member, memberACs := range acs {
+			assert.Equal(t, vol.Id, member.Id)
+			for _, ac := range memberACs {
+				usedAC[ac.Name] = struct{}{}
+			}
+		}
+		assert.Equal(t, map[string]struct{}{testACs[0].Name: {}, testACs[2].Name: {}}, usedAC)
+	})
 	t.Run("ANY StorageClass", func(t *testing.T) {
 		testVols := []*genV1.Volume{
 			getTestVol(testNode1, testSmallSize, apiV1.StorageClassAny),
@@ -210,6 +242,28 @@ func TestCapacityManager(t *testing.T) {
 			assert.ElementsMatch(t, testACS, plan.GetACsForVolumes()[testVols[0]])
 		}
 	})
+	t.Run("Volume pinned to node", func(t *testing.T) {
+		testVols := []*genV1.Volume{
+			getTestVol(testNode2, testSmallSize, apiV1.StorageClassHDD),
+		}
+		testACS := []*accrd.AvailableCapacity{
+			getTestAC(testNode1, testSmallSize, apiV1.StorageClassHDD),
+			getTestAC(testNode2, testSmallSize, apiV1.StorageClassHDD),
+		}
+		plan, err := callPlanVolumesPlacing(getCapReaderMock(testACS, nil), getResReaderMock(nil, nil), testVols,
+			[]string{testNode1, testNode2})
+		assert.NotNil(t, plan)
+		assert.Nil(t, err)
+		if plan != nil {
+			assert.Nil(t, plan.GetVolumesToACMapping(testNode1))
+			assert.Equal(t, testACS[1], plan.GetACForVolume(testNode2, testVols[0]))
+		}
+
+		plan, err = callPlanVolumesPlacing(getCapReaderMock(testACS, nil), getResReaderMock(nil, nil), testVols,
+			[]string{testNode1})
+		assert.Nil(t, plan)
+		assert.Nil(t, err)
+	})
 	t.Run("Using sub class for LVG", func(t *testing.T) {
 		testVols := []*genV1.Volume{
 			getTestVol("", testSmallSize, apiV1.StorageClassHDDLVG),
@@ -240,6 +294,27 @@ func TestCapacityManager(t *testing.T) {
 			assert.Equal(t, testACS[0], plan.GetACForVolume(testNode1, testVols[1]))
 		}
 	})
+	t.Run("Thin and thick LVM volumes on different drives", func(t *testing.T) {
+		testVols := []*genV1.Volume{
+			getTestVol("", testSmallSize, apiV1.StorageClassHDDLVG),
+			getTestVol("", testSmallSize, apiV1.StorageClassHDDLVGThin),
+		}
+		testACS := []*accrd.AvailableCapacity{
+			getTestAC(testNode1, (testSmallSize*2)+LvgDefaultMetadataSize, apiV1.StorageClassHDD),
+		}
+		plan, err := callPlanVolumesPlacing(getCapReaderMock(testACS, nil), getResReaderMock(nil, nil), testVols, []string{testNode1})
+		assert.Nil(t, plan)
+		assert.Nil(t, err)
+
+		testACS = append(testACS, getTestAC(testNode1, testSmallSize+LvgDefaultMetadataSize, apiV1.StorageClassHDD))
+		plan, err = callPlanVolumesPlacing(getCapReaderMock(testACS, nil), getResReaderMock(nil, nil), testVols, []string{testNode1})
+		assert.NotNil(t, plan)
+		assert.Nil(t, err)
+		if plan != nil {
+			assert.NotEqual(t, plan.GetACForVolume(testNode1, testVols[0]).Name,
+				plan.GetACForVolume(testNode1, testVols[1]).Name)
+		}
+	})
 	t.Run("Node selection", func(t *testing.T) {
 		testVols := []*genV1.Volume{
 			getTestVol("", testSmallSize, apiV1.StorageClassHDDLVG),
@@ -24,6 +24,8 @@ import (
 
 	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 )
@@ -169,3 +171,71 @@ func (rar *UnreservedACReader) ReadCapacity(ctx context.Context) ([]accrd.Availa
 	logger.Tracef("Read AvailableCapacity: %+v", reservedAC)
 	return reservedAC, nil
 }
+
+// NewDriveEnclosureReader returns instance of DriveEnclosureReader
+func NewDriveEnclosureReader(client *k8s.KubeClient, logger *logrus.Entry) *DriveEnclosureReader {
+	return &DriveEnclosureReader{
+		client: client,
+		logger: logger,
+	}
+}
+
+// DriveEnclosureReader reads enclosures of AC locations from Drive and LogicalVolumeGroup CRs
+type DriveEnclosureReader struct {
+	client *k8s.KubeClient
+	logger *logrus.Entry
+}
+
+// ReadEnclosures returns AC location to enclosure mapping,
+// LogicalVolumeGroup location is mapped to enclosure of its first drive
+func (der *DriveEnclosureReader) ReadEnclosures(ctx context.Context) (map[string]string, error) {
+	logger := util.AddCommonFields(ctx, der.logger, "DriveEnclosureReader.ReadEnclosures")
+
+	driveList := &drivecrd.DriveList{}
+	if err := der.client.ReadList(ctx, driveList); err != nil {
+		logger.Errorf("failed to read drive list: %s", err.Error())
+		return nil, err
+	}
+	lvgList := &lvgcrd.LogicalVolumeGroupList{}
+	if err := der.client.ReadList(ctx, lvgList); err != nil {
+		logger.Errorf("failed to read LVG list: %s", err.Error())
+		return nil, err
+	}
+
+	enclosures := make(map[string]string, len(driveList.Items)+len(lvgList.Items))
+	for _, drive := range driveList.Items {
+		enclosures[drive.Spec.UUID] = drive.Spec.Enclosure
+	}
+	for _, lvg := range lvgList.Items {
+		if len(lvg.Spec.Locations) > 0 {
+			enclosures[lvg.Name] = enclosures[lvg.Spec.Locations[0]]
+		}
+	}
+	logger.Tracef("Read enclosures: %+v", enclosures)
+	return enclosures, nil
+}
+
+// NewSkipReservationReader returns instance of SkipReservationReader
+func NewSkipReservationReader(reader ReservationReader, name string) *SkipReservationReader {
+	return &SkipReservationReader{
+		reader: reader,
+		name:   name,
+	}
+}
+
+// SkipReservationReader is a ReservationReader which skips reservation with the provided name
+type SkipReservationReader struct {
+	reader ReservationReader
+	name   string
+}
+
+// ReadReservations returns ACR list without reservation with SkipReservationReader.name
+func (s *SkipReservationReader) ReadReservations(ctx context.Context) ([]acrcrd.AvailableCapacityReservation, error) {
+	acrs, err := s.reader.ReadReservations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return FilterACRList(acrs, func(acr acrcrd.AvailableCapacityReservation) bool {
+		return acr.Name != s.name
+	}), nil
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	genV1 "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
@@ -59,6 +60,22 @@ func TestACRReader(t *testing.T) {
 	assert.Len(t, resp, len(testACRs))
 }
 
+func TestDriveEnclosureReader(t *testing.T) {
+	ctx := context.Background()
+	logger := testLogger.WithField("component", "test")
+	client := getKubeClient(t)
+
+	drive := client.ConstructDriveCR("drive-1", genV1.Drive{UUID: "drive-1", Enclosure: "enclosure-1"})
+	assert.Nil(t, client.CreateCR(ctx, drive.Name, drive))
+	lvg := client.ConstructLVGCR("lvg-1", genV1.LogicalVolumeGroup{Name: "lvg-1", Locations: []string{"drive-1"}})
+	assert.Nil(t, client.CreateCR(ctx, lvg.Name, lvg))
+
+	reader := NewDriveEnclosureReader(client, logger)
+	resp, err := reader.ReadEnclosures(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"drive-1": "enclosure-1", "lvg-1": "enclosure-1"}, resp)
+}
+
 // TODO refactor UT - https://github.com/dell/csi-baremetal/issues/371
 /*func TestUnreservedACReader(t *testing.T) {
 	ctx := context.Background()
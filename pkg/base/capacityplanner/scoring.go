@@ -0,0 +1,225 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityplanner
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	genV1 "github.com/dell/csi-baremetal/api/generated/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+// ScoringStrategy defines how nodes are ranked based on their available capacity
+type ScoringStrategy string
+
+const (
+	// LeastAllocatedStrategy prefers nodes with the largest amount of free capacity
+	LeastAllocatedStrategy ScoringStrategy = "least-allocated"
+	// MostAllocatedStrategy prefers nodes with the smallest amount of free capacity (bin-packing)
+	MostAllocatedStrategy ScoringStrategy = "most-allocated"
+	// BestFitStrategy prefers nodes where the smallest capacity is left in selected ACs after volumes placing
+	BestFitStrategy ScoringStrategy = "best-fit"
+	// EnclosureSpreadStrategy prefers nodes where selected ACs belong to enclosures with the most free ACs
+	EnclosureSpreadStrategy ScoringStrategy = "enclosure-spread"
+)
+
+// IsCapacityScoringStrategy checks whether strategy is calculated from AvailableCapacity
+func IsCapacityScoringStrategy(strategy string) bool {
+	switch ScoringStrategy(strategy) {
+	case LeastAllocatedStrategy, MostAllocatedStrategy, BestFitStrategy, EnclosureSpreadStrategy:
+		return true
+	}
+	return false
+}
+
+// NodeScorer describes interface for nodes scoring
+type NodeScorer interface {
+	// ScoreNodes returns nodeID to score mapping for nodes which are able to place volumes,
+	// scores are in range [0, maxScore]
+	ScoreNodes(ctx context.Context, volumes []*genV1.Volume, nodes []string, maxScore int64) (map[string]int64, error)
+}
+
+// NewCapacityScorer returns new instance of CapacityScorer
+func NewCapacityScorer(logger *logrus.Entry, strategy ScoringStrategy, capReader CapacityReader,
+	resReader ReservationReader, enclosureReader EnclosureReader) (*CapacityScorer, error) {
+	if !IsCapacityScoringStrategy(string(strategy)) {
+		return nil, fmt.Errorf("unknown scoring strategy %s", strategy)
+	}
+	return &CapacityScorer{
+		logger:          logger,
+		strategy:        strategy,
+		capReader:       capReader,
+		resReader:       resReader,
+		enclosureReader: enclosureReader,
+	}, nil
+}
+
+// CapacityScorer scores nodes based on the ACs and ACRs which are used by CapacityManager
+type CapacityScorer struct {
+	logger          *logrus.Entry
+	strategy        ScoringStrategy
+	capReader       CapacityReader
+	resReader       ReservationReader
+	enclosureReader EnclosureReader
+}
+
+// ScoreNodes plans volumes placing on nodes and scores nodes according to the strategy
+func (cs *CapacityScorer) ScoreNodes(ctx context.Context, volumes []*genV1.Volume, nodes []string,
+	maxScore int64) (map[string]int64, error) {
+	logger := util.AddCommonFields(ctx, cs.logger, "CapacityScorer.ScoreNodes")
+
+	acList, err := cs.capReader.ReadCapacity(ctx)
+	if err != nil {
+		logger.Errorf("failed to read AC list: %s", err.Error())
+		return nil, err
+	}
+	acrList, err := cs.resReader.ReadReservations(ctx)
+	if err != nil {
+		logger.Errorf("failed to read ACR list: %s", err.Error())
+		return nil, err
+	}
+
+	plan, err := NewCapacityManager(cs.logger, cs.capReader, cs.resReader, false).
+		PlanVolumesPlacing(ctx, volumes, nodes)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return map[string]int64{}, nil
+	}
+
+	var enclosures map[string]string
+	if cs.strategy == EnclosureSpreadStrategy {
+		if enclosures, err = cs.enclosureReader.ReadEnclosures(ctx); err != nil {
+			logger.Errorf("failed to read enclosures: %s", err.Error())
+			return nil, err
+		}
+	}
+
+	values := make(map[string]int64, len(nodes))
+	for _, node := range nodes {
+		volToAC := plan.GetVolumesToACMapping(node)
+		if volToAC == nil {
+			continue
+		}
+		// free capacity is calculated from the original reservations, not from the ones which are done by plan
+		nodeCap := newNodeCapacity(node, acList, acrList)
+		switch cs.strategy {
+		case LeastAllocatedStrategy:
+			values[node] = nodeCap.freeSize()
+		case MostAllocatedStrategy:
+			values[node] = -nodeCap.freeSize()
+		case BestFitStrategy:
+			values[node] = -nodeCap.leftoverSize(volToAC)
+		case EnclosureSpreadStrategy:
+			values[node] = nodeCap.minFreeACsInEnclosures(volToAC, enclosures)
+		}
+	}
+	logger.Debugf("Strategy %s values: %v", cs.strategy, values)
+
+	return normalizeScores(values, maxScore), nil
+}
+
+// normalizeScores scales values to range [0, maxScore], the highest value gets maxScore
+func normalizeScores(values map[string]int64, maxScore int64) map[string]int64 {
+	var minValue, maxValue int64
+	first := true
+	for _, value := range values {
+		if first || value < minValue {
+			minValue = value
+		}
+		if first || value > maxValue {
+			maxValue = value
+		}
+		first = false
+	}
+
+	scores := make(map[string]int64, len(values))
+	for node, value := range values {
+		if maxValue == minValue {
+			scores[node] = maxScore
+			continue
+		}
+		// use float to avoid overflow on the large sizes
+		scores[node] = int64(float64(value-minValue) / float64(maxValue-minValue) * float64(maxScore))
+	}
+	return scores
+}
+
+// acFreeSize returns size of AC which isn't reserved by ACRs
+func (nc *nodeCapacity) acFreeSize(ac *accrd.AvailableCapacity) int64 {
+	reservation, ok := nc.reservedACs[ac.Name]
+	if !ok {
+		return ac.Spec.Size
+	}
+	// AC which is reserved for non-LVG volume can't be used by other volumes
+	if !util.IsStorageClassLVG(reservation.StorageClass) {
+		return 0
+	}
+	if reservation.Size >= ac.Spec.Size {
+		return 0
+	}
+	return ac.Spec.Size - reservation.Size
+}
+
+// freeSize returns total size of ACs on node which aren't reserved
+func (nc *nodeCapacity) freeSize() int64 {
+	var size int64
+	for _, ac := range nc.acs {
+		size += nc.acFreeSize(ac)
+	}
+	return size
+}
+
+// leftoverSize returns capacity which is left in ACs selected for volumes after volumes placing
+func (nc *nodeCapacity) leftoverSize(volToAC VolToACMap) int64 {
+	used := map[string]int64{}
+	for vol, ac := range volToAC {
+		used[ac.Name] += getRequiredSize(vol)
+	}
+
+	var leftover int64
+	for name, size := range used {
+		if ac, ok := nc.acs[name]; ok {
+			leftover += nc.acFreeSize(ac) - size
+		}
+	}
+	return leftover
+}
+
+// minFreeACsInEnclosures returns the minimal number of free ACs in enclosures of ACs selected for volumes
+func (nc *nodeCapacity) minFreeACsInEnclosures(volToAC VolToACMap, enclosures map[string]string) int64 {
+	freeACs := map[string]int64{}
+	for _, ac := range nc.acs {
+		if nc.acFreeSize(ac) > 0 {
+			freeACs[enclosures[ac.Spec.Location]]++
+		}
+	}
+
+	var minFree int64 = -1
+	for _, ac := range volToAC {
+		count := freeACs[enclosures[ac.Spec.Location]]
+		if minFree == -1 || count < minFree {
+			minFree = count
+		}
+	}
+	return minFree
+}
@@ -0,0 +1,133 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacityplanner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	genV1 "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+)
+
+// enclosureReaderMock returns predefined AC location to enclosure mapping
+type enclosureReaderMock map[string]string
+
+func (erm enclosureReaderMock) ReadEnclosures(_ context.Context) (map[string]string, error) {
+	return erm, nil
+}
+
+func getTestACWithLocation(nodeID string, size int64, location string) *accrd.AvailableCapacity {
+	ac := getTestAC(nodeID, size, apiV1.StorageClassHDD)
+	ac.Spec.Location = location
+	return ac
+}
+
+func TestCapacityScorer_ScoreNodes(t *testing.T) {
+	logger := testLogger.WithField("component", "test")
+	ctx := context.Background()
+	testNode3 := "node-without-capacity"
+	nodes := []string{testNode1, testNode2, testNode3}
+
+	// node1 - 30Gb in one enclosure, node2 - 60Gb in one enclosure
+	testACs := []*accrd.AvailableCapacity{
+		getTestACWithLocation(testNode1, testSmallSize, "drive-1"),
+		getTestACWithLocation(testNode1, testLargeSize, "drive-2"),
+		getTestACWithLocation(testNode2, testLargeSize, "drive-3"),
+		getTestACWithLocation(testNode2, testLargeSize, "drive-4"),
+		getTestACWithLocation(testNode2, testLargeSize, "drive-5"),
+	}
+	enclosures := enclosureReaderMock{
+		"drive-1": "enclosure-1", "drive-2": "enclosure-1",
+		"drive-3": "enclosure-2", "drive-4": "enclosure-2", "drive-5": "enclosure-2",
+	}
+
+	tests := []struct {
+		strategy ScoringStrategy
+		expected map[string]int64
+	}{
+		{strategy: LeastAllocatedStrategy, expected: map[string]int64{testNode1: 0, testNode2: 10}},
+		{strategy: MostAllocatedStrategy, expected: map[string]int64{testNode1: 10, testNode2: 0}},
+		{strategy: BestFitStrategy, expected: map[string]int64{testNode1: 10, testNode2: 0}},
+		{strategy: EnclosureSpreadStrategy, expected: map[string]int64{testNode1: 0, testNode2: 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			scorer, err := NewCapacityScorer(logger, tt.strategy, getCapReaderMock(testACs, nil),
+				getResReaderMock(nil, nil), enclosures)
+			assert.Nil(t, err)
+
+			volumes := []*genV1.Volume{getTestVol("", testSmallSize, apiV1.StorageClassHDD)}
+			scores, err := scorer.ScoreNodes(ctx, volumes, nodes, 10)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, scores)
+		})
+	}
+
+	t.Run("Reserved capacity isn't free", func(t *testing.T) {
+		// two of three ACs on node2 are reserved, so node2 has 20Gb free
+		acrs := []*acrcrd.AvailableCapacityReservation{
+			getTestACR(testLargeSize, apiV1.StorageClassHDD, testACs[2:4]),
+		}
+		scorer, err := NewCapacityScorer(logger, LeastAllocatedStrategy, getCapReaderMock(testACs, nil),
+			getResReaderMock(acrs, nil), enclosures)
+		assert.Nil(t, err)
+
+		volumes := []*genV1.Volume{getTestVol("", testSmallSize, apiV1.StorageClassHDD)}
+		scores, err := scorer.ScoreNodes(ctx, volumes, nodes, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int64{testNode1: 10, testNode2: 0}, scores)
+	})
+
+	t.Run("No capacity", func(t *testing.T) {
+		scorer, err := NewCapacityScorer(logger, BestFitStrategy, getCapReaderMock(testACs, nil),
+			getResReaderMock(nil, nil), enclosures)
+		assert.Nil(t, err)
+
+		volumes := []*genV1.Volume{getTestVol("", testLargeSize*2, apiV1.StorageClassHDD)}
+		scores, err := scorer.ScoreNodes(ctx, volumes, nodes, 10)
+		assert.Nil(t, err)
+		assert.Empty(t, scores)
+	})
+
+	t.Run("Unable to read AC", func(t *testing.T) {
+		scorer, err := NewCapacityScorer(logger, BestFitStrategy, getCapReaderMock(nil, testErr),
+			getResReaderMock(nil, nil), enclosures)
+		assert.Nil(t, err)
+
+		_, err = scorer.ScoreNodes(ctx, nil, nodes, 10)
+		assert.Equal(t, testErr, err)
+	})
+
+	t.Run("Unknown strategy", func(t *testing.T) {
+		_, err := NewCapacityScorer(logger, "unknown", nil, nil, nil)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestNormalizeScores(t *testing.T) {
+	assert.Equal(t, map[string]int64{"n1": 0, "n2": 5, "n3": 10},
+		normalizeScores(map[string]int64{"n1": -20, "n2": -10, "n3": 0}, 10))
+	assert.Equal(t, map[string]int64{"n1": 10, "n2": 10},
+		normalizeScores(map[string]int64{"n1": 5, "n2": 5}, 10))
+	assert.Empty(t, normalizeScores(map[string]int64{}, 10))
+}
@@ -60,4 +60,37 @@ const (
 	SizeKey = "size"
 	// DefaultNamespace represents default namespace in Kubernetes
 	DefaultNamespace = "default"
+
+	// EncryptedKey key from StorageClass parameters, volume is encrypted with LUKS when it is set to "true"
+	EncryptedKey = "encrypted"
+	// EncryptionSecretNameKey key from StorageClass parameters, name of the Secret with passphrase for LUKS
+	EncryptionSecretNameKey = "encryptionSecretName"
+	// EncryptionSecretNamespaceKey key from StorageClass parameters, namespace of the Secret with passphrase for LUKS
+	EncryptionSecretNamespaceKey = "encryptionSecretNamespace"
+	// EncryptionPassphraseKey key in the Secret data which holds passphrase for LUKS
+	EncryptionPassphraseKey = "passphrase"
+
+	// ErasePolicyKey key from StorageClass parameters, defines how drive is erased on release of drive based volume
+	ErasePolicyKey = "erasePolicy"
+	// MkfsOptionsKey key from StorageClass parameters, options passed to mkfs on file system creation
+	MkfsOptionsKey = "mkfsOptions"
+
+	// IOReadIOPSKey key from StorageClass parameters, read IOPS limit of the volume
+	IOReadIOPSKey = "ioReadIOPS"
+	// IOWriteIOPSKey key from StorageClass parameters, write IOPS limit of the volume
+	IOWriteIOPSKey = "ioWriteIOPS"
+	// IOReadBPSKey key from StorageClass parameters, read bytes per second limit of the volume, e.g. 100Mi
+	IOReadBPSKey = "ioReadBPS"
+	// IOWriteBPSKey key from StorageClass parameters, write bytes per second limit of the volume
+	IOWriteBPSKey = "ioWriteBPS"
+	// IOWeightKey key from StorageClass parameters, proportional IO weight of the volume in range [1, 10000]
+	IOWeightKey = "ioWeight"
+	// IOLimitsAnnotationPrefix is a prefix of PVC annotations which override IO limits from StorageClass parameters,
+	// e.g. csi-baremetal/ioReadIOPS
+	IOLimitsAnnotationPrefix = "csi-baremetal/"
+
+	// RaidLevelKey key from StorageClass parameters, volume is assembled to mdadm RAID of provided level
+	RaidLevelKey = "raidLevel"
+	// RaidDevicesKey key from StorageClass parameters, number of drives in the RAID array
+	RaidDevicesKey = "raidDevices"
 )
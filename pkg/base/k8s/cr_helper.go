@@ -31,6 +31,7 @@ import (
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	errTypes "github.com/dell/csi-baremetal/pkg/base/error"
@@ -117,7 +118,8 @@ func (cs *CRHelper) DeleteACsByNodeID(nodeID string) error {
 }
 
 // GetVolumesByLocation reads the whole list of Volume CRs from a cluster and searches the volume with provided location
-// Receives golang context and location name which should be equal to Volume.Spec.Location
+// Receives golang context and location name which should be equal to Volume.Spec.Location or
+// to one of Volume.Spec.RaidLocations for RAID volume
 // Returns a list of a pointers to volumes which are belong to the location and error
 func (cs *CRHelper) GetVolumesByLocation(ctx context.Context, location string) ([]*volumecrd.Volume, error) {
 	ll := cs.log.WithFields(logrus.Fields{
@@ -143,7 +145,7 @@ func (cs *CRHelper) GetVolumesByLocation(ctx context.Context, location string) (
 
 	for _, v := range volList.Items {
 		v := v
-		if strings.EqualFold(v.Spec.Location, location) {
+		if strings.EqualFold(v.Spec.Location, location) || isRaidMember(&v, location) {
 			volumes = append(volumes, &v)
 			if v.Spec.LocationType == apiV1.LocationTypeDrive {
 				// only one volume with LocationTypeDrive can exist on drive
@@ -157,6 +159,19 @@ func (cs *CRHelper) GetVolumesByLocation(ctx context.Context, location string) (
 	return volumes, nil
 }
 
+// isRaidMember checks whether drive with provided location is a member of RAID volume
+func isRaidMember(volume *volumecrd.Volume, location string) bool {
+	if volume.Spec.LocationType != apiV1.LocationTypeRAID {
+		return false
+	}
+	for _, member := range volume.Spec.RaidLocations {
+		if strings.EqualFold(member, location) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetLVGByDrive reads list of LogicalVolumeGroup CRs from a cluster and searches the lvg with provided location
 // Receives golang context and drive uuid
 // Returns found lvg and error
@@ -415,6 +430,33 @@ func (cs *CRHelper) GetLVGCRs(node ...string) ([]lvgcrd.LogicalVolumeGroup, erro
 	return res, nil
 }
 
+// GetSnapshotCRs collect Snapshot CRs that are based on volume with ID volumeID, use just volumeID[0] element
+// if volumeID isn't provided - return all Snapshot CRs
+// if error occurs - return nil and error
+func (cs *CRHelper) GetSnapshotCRs(volumeID ...string) ([]snapshotcrd.Snapshot, error) {
+	var (
+		snapshotList = &snapshotcrd.SnapshotList{}
+		err          error
+	)
+
+	if err = cs.reader.ReadList(context.Background(), snapshotList); err != nil {
+		return nil, err
+	}
+
+	if len(volumeID) == 0 {
+		return snapshotList.Items, nil
+	}
+
+	// if volume ID was provided, collect snapshots of that volume
+	res := make([]snapshotcrd.Snapshot, 0)
+	for _, s := range snapshotList.Items {
+		if s.Spec.VolumeId == volumeID[0] {
+			res = append(res, s)
+		}
+	}
+	return res, nil
+}
+
 // UpdateVolumeCRSpec reads volume CR with name volName and update it's spec to newSpec
 // returns nil or error in case of error
 func (cs *CRHelper) UpdateVolumeCRSpec(volName string, namespace string, newSpec api.Volume) error {
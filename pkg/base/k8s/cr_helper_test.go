@@ -22,6 +22,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	api "github.com/dell/csi-baremetal/api/generated/v1"
 	v1 "github.com/dell/csi-baremetal/api/v1"
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
@@ -77,6 +78,21 @@ func TestCRHelper_GetVolumeByLocation(t *testing.T) {
 	assert.Nil(t, err)
 	currentVols, _ = ch.GetVolumesByLocation(ctx, testDriveLocation1)
 	assert.NotEmpty(t, currentVols)
+
+	// raid, volume is found by each member drive
+	ch = setup()
+	testVolume = testVolumeCR.DeepCopy()
+	testVolume.Spec.LocationType = v1.LocationTypeRAID
+	testVolume.Spec.Location = "member-1"
+	testVolume.Spec.RaidLocations = []string{"member-1", "member-2"}
+	err = ch.k8sClient.CreateCR(testCtx, testVolume.Name, testVolume)
+	assert.Nil(t, err)
+	for _, member := range testVolume.Spec.RaidLocations {
+		currentVols, _ = ch.GetVolumesByLocation(ctx, member)
+		assert.Len(t, currentVols, 1)
+	}
+	currentVols, _ = ch.GetVolumesByLocation(ctx, "member-3")
+	assert.Empty(t, currentVols)
 }
 
 func TestCRHelper_GetVolumeByID(t *testing.T) {
@@ -164,6 +180,26 @@ func TestCRHelper_GetDriveCRs(t *testing.T) {
 	assert.Equal(t, d1.Spec, currentDs[0].Spec)
 }
 
+func TestCRHelper_GetSnapshotCRs(t *testing.T) {
+	ch := setup()
+	s1 := ch.k8sClient.ConstructSnapshotCR("snapshot-1", api.Snapshot{Id: "snapshot-1", VolumeId: testVolumeCR.Spec.Id})
+	s2 := ch.k8sClient.ConstructSnapshotCR("snapshot-2", api.Snapshot{Id: "snapshot-2", VolumeId: "anotherVolume"})
+
+	assert.Nil(t, ch.k8sClient.CreateCR(testCtx, s1.Name, s1))
+	assert.Nil(t, ch.k8sClient.CreateCR(testCtx, s2.Name, s2))
+
+	// volume ID isn't provided - expected all snapshots
+	currentSs, err := ch.GetSnapshotCRs()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(currentSs))
+
+	// expected one snapshot
+	currentSs, err = ch.GetSnapshotCRs(testVolumeCR.Spec.Id)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(currentSs))
+	assert.Equal(t, s1.Spec, currentSs[0].Spec)
+}
+
 func TestCRHelper_GetVGNameByLVGCRName(t *testing.T) {
 	ch := setup()
 	lvgCR := testLVGCR
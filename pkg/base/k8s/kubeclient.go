@@ -38,6 +38,7 @@ import (
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	"github.com/dell/csi-baremetal/api/v1/nodecrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/logger/objects"
@@ -282,6 +283,23 @@ func (k *KubeClient) ConstructCSIBMNodeCR(name string, csiNode api.Node) *nodecr
 	}
 }
 
+// ConstructSnapshotCR constructs Snapshot custom resource from api.Snapshot struct
+// Receives a name for k8s ObjectMeta and an instance of api.Snapshot struct
+// Returns an instance of Snapshot CR struct
+func (k *KubeClient) ConstructSnapshotCR(name string, apiSnapshot api.Snapshot) *snapshotcrd.Snapshot {
+	return &snapshotcrd.Snapshot{
+		TypeMeta: apisV1.TypeMeta{
+			Kind:       crdV1.SnapshotKind,
+			APIVersion: crdV1.APIV1Version,
+		},
+		ObjectMeta: apisV1.ObjectMeta{
+			Name:   name,
+			Labels: constructDefaultAppMap(),
+		},
+		Spec: apiSnapshot,
+	}
+}
+
 // ReadCRWithAttempts reads specified resource from k8s cluster into a pointer of struct that implements runtime.Object
 // with specified amount of attempts. Fails right away if resource is not found
 // Receives golang context, name of the read object, and object pointer where to read
@@ -454,6 +472,11 @@ func PrepareScheme() (*runtime.Scheme, error) {
 		return nil, err
 	}
 
+	// register snapshot crd
+	if err := snapshotcrd.AddToSchemeSnapshot(scheme); err != nil {
+		return nil, err
+	}
+
 	return scheme, nil
 }
 
@@ -0,0 +1,53 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package blkdiscard contains code for running system blkdiscard util
+package blkdiscard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dell/csi-baremetal/pkg/base/command"
+)
+
+// DiscardCmdTmpl discards all sectors of the device
+const DiscardCmdTmpl = "blkdiscard %s" // add device path
+
+// WrapBlkdiscard is an interface that encapsulates operation with system blkdiscard util
+type WrapBlkdiscard interface {
+	Discard(device string) error
+}
+
+// Blkdiscard is an implementation of WrapBlkdiscard interface
+type Blkdiscard struct {
+	e command.CmdExecutor
+}
+
+// NewBlkdiscard is a constructor for Blkdiscard struct
+func NewBlkdiscard(e command.CmdExecutor) *Blkdiscard {
+	return &Blkdiscard{e: e}
+}
+
+// Discard informs the device that all its sectors are unused (TRIM), data can't be read from the device after that
+// Receives path of the device
+// Returns error if device doesn't support discard or something went wrong
+func (b *Blkdiscard) Discard(device string) error {
+	_, _, err := b.e.RunCmd(fmt.Sprintf(DiscardCmdTmpl, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(DiscardCmdTmpl, ""))))
+	return err
+}
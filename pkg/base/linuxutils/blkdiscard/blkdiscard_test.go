@@ -0,0 +1,43 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blkdiscard
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+func TestBlkdiscard_Discard(t *testing.T) {
+	var (
+		e      = &mocks.GoMockExecutor{}
+		device = "/dev/sdb"
+		cmd    = fmt.Sprintf(DiscardCmdTmpl, device)
+		b      = NewBlkdiscard(e)
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, b.Discard(device))
+
+	expectedErr := errors.New("discard isn't supported")
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, b.Discard(device))
+}
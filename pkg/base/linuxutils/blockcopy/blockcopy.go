@@ -0,0 +1,169 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package blockcopy contains code for copying data between block devices and overwriting them
+package blockcopy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+)
+
+// DefaultBufferSize is the size of the chunk which is copied at once
+const DefaultBufferSize = 4 * 1024 * 1024
+
+// ProgressFunc is called after each copied chunk with amount of copied and total bytes
+type ProgressFunc func(copied, total int64)
+
+// WrapBlockCopy is an interface that encapsulates operation of copying data between block devices
+type WrapBlockCopy interface {
+	Copy(ctx context.Context, src, dst string, progress ProgressFunc) error
+	ZeroFill(ctx context.Context, dst string, progress ProgressFunc) error
+}
+
+// BlockCopy is the implementation of WrapBlockCopy interface
+type BlockCopy struct {
+	bufferSize int
+}
+
+// NewBlockCopy is the constructor for BlockCopy struct
+// Returns an instance of BlockCopy
+func NewBlockCopy() *BlockCopy {
+	return &BlockCopy{bufferSize: DefaultBufferSize}
+}
+
+// Copy copies the whole content of src device to dst device, dst device must be not smaller than src device
+// Receives golang context, paths of the source and destination devices and optional progress callback
+// Returns error if something went wrong or context was done before copying was finished
+func (b *BlockCopy) Copy(ctx context.Context, src, dst string, progress ProgressFunc) error {
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("unable to open source device %s: %w", src, err)
+	}
+	defer srcFile.Close()
+
+	dstFile, err := os.OpenFile(dst, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("unable to open destination device %s: %w", dst, err)
+	}
+	defer dstFile.Close()
+
+	srcSize, err := getSize(srcFile)
+	if err != nil {
+		return err
+	}
+	dstSize, err := getSize(dstFile)
+	if err != nil {
+		return err
+	}
+	if dstSize < srcSize {
+		return fmt.Errorf("destination device %s is smaller than source device %s: %d < %d",
+			dst, src, dstSize, srcSize)
+	}
+
+	var (
+		buf    = make([]byte, b.bufferSize)
+		copied int64
+	)
+	for copied < srcSize {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("copying from %s to %s was interrupted: %w", src, dst, ctx.Err())
+		default:
+		}
+
+		n, readErr := srcFile.Read(buf)
+		if n > 0 {
+			if _, err = dstFile.Write(buf[:n]); err != nil {
+				return fmt.Errorf("unable to write to %s: %w", dst, err)
+			}
+			copied += int64(n)
+			if progress != nil {
+				progress(copied, srcSize)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("unable to read from %s: %w", src, readErr)
+		}
+	}
+
+	if err = dstFile.Sync(); err != nil {
+		return fmt.Errorf("unable to sync %s: %w", dst, err)
+	}
+	return nil
+}
+
+// ZeroFill overwrites the whole content of dst device with zeroes
+// Receives golang context, path of the device and optional progress callback
+// Returns error if something went wrong or context was done before device was filled
+func (b *BlockCopy) ZeroFill(ctx context.Context, dst string, progress ProgressFunc) error {
+	dstFile, err := os.OpenFile(dst, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("unable to open device %s: %w", dst, err)
+	}
+	defer dstFile.Close()
+
+	size, err := getSize(dstFile)
+	if err != nil {
+		return err
+	}
+
+	var (
+		buf     = make([]byte, b.bufferSize)
+		written int64
+	)
+	for written < size {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("filling of %s was interrupted: %w", dst, ctx.Err())
+		default:
+		}
+
+		chunk := buf
+		if left := size - written; left < int64(len(chunk)) {
+			chunk = chunk[:left]
+		}
+		if _, err = dstFile.Write(chunk); err != nil {
+			return fmt.Errorf("unable to write to %s: %w", dst, err)
+		}
+		written += int64(len(chunk))
+		if progress != nil {
+			progress(written, size)
+		}
+	}
+
+	if err = dstFile.Sync(); err != nil {
+		return fmt.Errorf("unable to sync %s: %w", dst, err)
+	}
+	return nil
+}
+
+// getSize returns size of the opened device and rewinds it to the beginning
+func getSize(f *os.File) (int64, error) {
+	size, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("unable to determine size of %s: %w", f.Name(), err)
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("unable to rewind %s: %w", f.Name(), err)
+	}
+	return size, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blockcopy
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createFile(t *testing.T, dir, name string, content []byte, size int64) string {
+	path := filepath.Join(dir, name)
+	assert.Nil(t, os.WriteFile(path, content, 0600))
+	assert.Nil(t, os.Truncate(path, size))
+	return path
+}
+
+func TestBlockCopy_Copy(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		content = bytes.Repeat([]byte("csi-baremetal"), 1000)
+		size    = int64(len(content))
+		bc      = &BlockCopy{bufferSize: 1024}
+	)
+
+	t.Run("Success", func(t *testing.T) {
+		src := createFile(t, dir, "src", content, size)
+		dst := createFile(t, dir, "dst", nil, size+1)
+
+		var lastCopied, lastTotal int64
+		err := bc.Copy(context.Background(), src, dst, func(copied, total int64) {
+			assert.True(t, copied > lastCopied)
+			lastCopied, lastTotal = copied, total
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, size, lastCopied)
+		assert.Equal(t, size, lastTotal)
+
+		data, err := os.ReadFile(dst)
+		assert.Nil(t, err)
+		assert.Equal(t, content, data[:size])
+	})
+
+	t.Run("Destination is smaller", func(t *testing.T) {
+		src := createFile(t, dir, "src", content, size)
+		dst := createFile(t, dir, "dst", nil, size-1)
+
+		assert.NotNil(t, bc.Copy(context.Background(), src, dst, nil))
+	})
+
+	t.Run("Source doesn't exist", func(t *testing.T) {
+		dst := createFile(t, dir, "dst", nil, size)
+
+		assert.NotNil(t, bc.Copy(context.Background(), filepath.Join(dir, "not-exist"), dst, nil))
+	})
+
+	t.Run("Context is done", func(t *testing.T) {
+		src := createFile(t, dir, "src", content, size)
+		dst := createFile(t, dir, "dst", nil, size)
+		ctx, cancelFn := context.WithCancel(context.Background())
+		cancelFn()
+
+		assert.NotNil(t, bc.Copy(ctx, src, dst, nil))
+	})
+}
+
+func TestBlockCopy_ZeroFill(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		content = bytes.Repeat([]byte("csi-baremetal"), 1000)
+		size    = int64(len(content))
+		bc      = &BlockCopy{bufferSize: 1024}
+	)
+
+	t.Run("Success", func(t *testing.T) {
+		dst := createFile(t, dir, "dst", content, size)
+
+		var lastWritten, lastTotal int64
+		err := bc.ZeroFill(context.Background(), dst, func(written, total int64) {
+			assert.True(t, written > lastWritten)
+			lastWritten, lastTotal = written, total
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, size, lastWritten)
+		assert.Equal(t, size, lastTotal)
+
+		data, err := os.ReadFile(dst)
+		assert.Nil(t, err)
+		assert.Equal(t, make([]byte, size), data)
+	})
+
+	t.Run("Device doesn't exist", func(t *testing.T) {
+		assert.NotNil(t, bc.ZeroFill(context.Background(), filepath.Join(dir, "not-exist"), nil))
+	})
+
+	t.Run("Context is done", func(t *testing.T) {
+		dst := createFile(t, dir, "dst", content, size)
+		ctx, cancelFn := context.WithCancel(context.Background())
+		cancelFn()
+
+		assert.NotNil(t, bc.ZeroFill(ctx, dst, nil))
+	})
+}
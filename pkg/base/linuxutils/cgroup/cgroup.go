@@ -0,0 +1,195 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cgroup contains code for configuring IO limits of the pods with cgroup v2 io controller
+package cgroup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+)
+
+const (
+	// DefaultRoot is the mount point of the cgroup v2 hierarchy
+	DefaultRoot = "/sys/fs/cgroup"
+
+	controllersFile = "cgroup.controllers"
+	ioMaxFile       = "io.max"
+	ioWeightFile    = "io.weight"
+	ioController    = "io"
+	kubepodsPrefix  = "kubepods"
+
+	noLimit       = "max"
+	defaultWeight = "default"
+)
+
+// qosClasses are cgroups of the pods QoS classes created by cgroupfs driver
+var qosClasses = map[string]bool{"burstable": true, "besteffort": true}
+
+// errFound is used to stop walking of the cgroup hierarchy
+var errFound = errors.New("found")
+
+// WrapCgroup is an interface that encapsulates operations with cgroup v2 io controller
+type WrapCgroup interface {
+	GetPodCgroupPath(podUID string) (string, error)
+	GetDeviceNumber(device string) (string, error)
+	SetIOLimits(cgroupPath, devNumber string, limits *api.IOLimits) (*api.IOLimits, error)
+	RemoveIOLimits(cgroupPath, devNumber string) error
+}
+
+// Cgroup is the implementation of WrapCgroup interface
+type Cgroup struct {
+	root string
+}
+
+// NewCgroup is the constructor for Cgroup struct
+// Receives mount point of the cgroup v2 hierarchy
+// Returns an instance of Cgroup
+func NewCgroup(root string) *Cgroup {
+	return &Cgroup{root: root}
+}
+
+// GetPodCgroupPath searches cgroup of the pod with provided UID in kubepods hierarchy,
+// both systemd (kubepods-burstable-pod<uid>.slice) and cgroupfs (pod<uid>) cgroup drivers are supported
+// Returns path of the pod cgroup or error if cgroup v2 with io controller isn't available or cgroup isn't found
+func (c *Cgroup) GetPodCgroupPath(podUID string) (string, error) {
+	controllers, err := os.ReadFile(filepath.Join(c.root, controllersFile))
+	if err != nil {
+		return "", fmt.Errorf("cgroup v2 isn't mounted to %s: %w", c.root, err)
+	}
+	if !containsField(string(controllers), ioController) {
+		return "", fmt.Errorf("io controller isn't available in %s", c.root)
+	}
+
+	names := []string{"pod" + podUID, "pod" + strings.ReplaceAll(podUID, "-", "_")}
+	var podPath string
+	err = filepath.Walk(c.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return nil
+		}
+		name := info.Name()
+		for _, podName := range names {
+			if name == podName || strings.HasSuffix(strings.TrimSuffix(name, ".slice"), "-"+podName) {
+				podPath = path
+				return errFound
+			}
+		}
+		// go through kubepods and QoS classes cgroups only
+		if path == c.root || strings.HasPrefix(name, kubepodsPrefix) || qosClasses[name] {
+			return nil
+		}
+		return filepath.SkipDir
+	})
+	if err != nil && !errors.Is(err, errFound) {
+		return "", err
+	}
+	if podPath == "" {
+		return "", fmt.Errorf("cgroup of the pod %s isn't found", podUID)
+	}
+	return podPath, nil
+}
+
+// GetDeviceNumber returns major:minor numbers of the device which are used as a key in io.max and io.weight
+func (c *Cgroup) GetDeviceNumber(device string) (string, error) {
+	var stat unix.Stat_t
+	if err := unix.Stat(device, &stat); err != nil {
+		return "", fmt.Errorf("unable to stat device %s: %w", device, err)
+	}
+	if stat.Mode&unix.S_IFMT != unix.S_IFBLK && stat.Mode&unix.S_IFMT != unix.S_IFCHR {
+		return "", fmt.Errorf("%s isn't a device", device)
+	}
+	rdev := uint64(stat.Rdev)
+	return fmt.Sprintf("%d:%d", unix.Major(rdev), unix.Minor(rdev)), nil
+}
+
+// SetIOLimits writes io.max and io.weight settings for the device to the cgroup,
+// 0 value of the limit means no limit
+// Returns limits which were applied, io.weight requires io.cost or BFQ and could fail when io.max is applied
+func (c *Cgroup) SetIOLimits(cgroupPath, devNumber string, limits *api.IOLimits) (*api.IOLimits, error) {
+	applied := &api.IOLimits{}
+	ioMax := fmt.Sprintf("%s riops=%s wiops=%s rbps=%s wbps=%s", devNumber,
+		formatLimit(limits.GetReadIOPS()), formatLimit(limits.GetWriteIOPS()),
+		formatLimit(limits.GetReadBPS()), formatLimit(limits.GetWriteBPS()))
+	if err := writeFile(filepath.Join(cgroupPath, ioMaxFile), ioMax); err != nil {
+		return applied, err
+	}
+	applied.ReadIOPS, applied.WriteIOPS = limits.GetReadIOPS(), limits.GetWriteIOPS()
+	applied.ReadBPS, applied.WriteBPS = limits.GetReadBPS(), limits.GetWriteBPS()
+
+	if limits.GetWeight() == 0 {
+		return applied, nil
+	}
+	ioWeight := fmt.Sprintf("%s %d", devNumber, limits.GetWeight())
+	if err := writeFile(filepath.Join(cgroupPath, ioWeightFile), ioWeight); err != nil {
+		return applied, err
+	}
+	applied.Weight = limits.GetWeight()
+	return applied, nil
+}
+
+// RemoveIOLimits resets io.max and io.weight settings of the device in the cgroup,
+// cgroup which doesn't exist anymore (pod was removed) isn't considered as an error
+func (c *Cgroup) RemoveIOLimits(cgroupPath, devNumber string) error {
+	if _, err := os.Stat(cgroupPath); os.IsNotExist(err) {
+		return nil
+	}
+	ioMax := fmt.Sprintf("%s riops=%s wiops=%s rbps=%s wbps=%s", devNumber, noLimit, noLimit, noLimit, noLimit)
+	if err := writeFile(filepath.Join(cgroupPath, ioMaxFile), ioMax); err != nil {
+		return err
+	}
+	weightPath := filepath.Join(cgroupPath, ioWeightFile)
+	if _, err := os.Stat(weightPath); os.IsNotExist(err) {
+		return nil
+	}
+	return writeFile(weightPath, fmt.Sprintf("%s %s", devNumber, defaultWeight))
+}
+
+func writeFile(path, value string) error {
+	// cgroup files must be written without truncation and with a single write
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("unable to open %s: %w", path, err)
+	}
+	defer file.Close()
+	if _, err = file.WriteString(value); err != nil {
+		return fmt.Errorf("unable to write %q to %s: %w", value, path, err)
+	}
+	return nil
+}
+
+func formatLimit(limit int64) string {
+	if limit == 0 {
+		return noLimit
+	}
+	return strconv.FormatInt(limit, 10)
+}
+
+func containsField(str, field string) bool {
+	for _, f := range strings.Fields(str) {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
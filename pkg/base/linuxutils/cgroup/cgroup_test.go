@@ -0,0 +1,144 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cgroup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+)
+
+var testPodUID = "7f6a1c2e-0b3d-4c5e-9f8a-1b2c3d4e5f60"
+
+func createCgroup(t *testing.T, path string) string {
+	assert.Nil(t, os.MkdirAll(path, 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(path, ioMaxFile), nil, 0600))
+	assert.Nil(t, os.WriteFile(filepath.Join(path, ioWeightFile), nil, 0600))
+	return path
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	return string(data)
+}
+
+func TestCgroup_GetPodCgroupPath(t *testing.T) {
+	t.Run("systemd driver", func(t *testing.T) {
+		root := t.TempDir()
+		assert.Nil(t, os.WriteFile(filepath.Join(root, controllersFile), []byte("cpuset cpu io memory pids\n"), 0600))
+		createCgroup(t, filepath.Join(root, "system.slice", "kubelet.service"))
+		createCgroup(t, filepath.Join(root, "kubepods.slice", "kubepods-besteffort.slice",
+			"kubepods-besteffort-pod1a2b.slice", "cri-containerd-1.scope"))
+		expected := createCgroup(t, filepath.Join(root, "kubepods.slice", "kubepods-burstable.slice",
+			"kubepods-burstable-pod7f6a1c2e_0b3d_4c5e_9f8a_1b2c3d4e5f60.slice"))
+
+		path, err := NewCgroup(root).GetPodCgroupPath(testPodUID)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, path)
+	})
+
+	t.Run("cgroupfs driver", func(t *testing.T) {
+		root := t.TempDir()
+		assert.Nil(t, os.WriteFile(filepath.Join(root, controllersFile), []byte("io memory"), 0600))
+		expected := createCgroup(t, filepath.Join(root, "kubepods", "besteffort", "pod"+testPodUID))
+
+		path, err := NewCgroup(root).GetPodCgroupPath(testPodUID)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, path)
+	})
+
+	t.Run("pod cgroup isn't found", func(t *testing.T) {
+		root := t.TempDir()
+		assert.Nil(t, os.WriteFile(filepath.Join(root, controllersFile), []byte("io memory"), 0600))
+		createCgroup(t, filepath.Join(root, "kubepods", "burstable", "pod1a2b"))
+
+		_, err := NewCgroup(root).GetPodCgroupPath(testPodUID)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("io controller isn't available", func(t *testing.T) {
+		root := t.TempDir()
+		assert.Nil(t, os.WriteFile(filepath.Join(root, controllersFile), []byte("cpu memory"), 0600))
+
+		_, err := NewCgroup(root).GetPodCgroupPath(testPodUID)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("cgroup v1", func(t *testing.T) {
+		_, err := NewCgroup(t.TempDir()).GetPodCgroupPath(testPodUID)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestCgroup_GetDeviceNumber(t *testing.T) {
+	c := NewCgroup(DefaultRoot)
+	devNumber, err := c.GetDeviceNumber("/dev/null")
+	assert.Nil(t, err)
+	assert.Equal(t, "1:3", devNumber)
+
+	_, err = c.GetDeviceNumber(t.TempDir())
+	assert.NotNil(t, err)
+	_, err = c.GetDeviceNumber("/dev/not-exist")
+	assert.NotNil(t, err)
+}
+
+func TestCgroup_SetIOLimits(t *testing.T) {
+	c := NewCgroup(DefaultRoot)
+
+	t.Run("Success", func(t *testing.T) {
+		path := createCgroup(t, filepath.Join(t.TempDir(), "pod"))
+		limits := &api.IOLimits{ReadIOPS: 100, WriteBPS: 1024, Weight: 50}
+
+		applied, err := c.SetIOLimits(path, "8:16", limits)
+		assert.Nil(t, err)
+		assert.Equal(t, limits, applied)
+		assert.Equal(t, "8:16 riops=100 wiops=max rbps=max wbps=1024", readFile(t, filepath.Join(path, ioMaxFile)))
+		assert.Equal(t, "8:16 50", readFile(t, filepath.Join(path, ioWeightFile)))
+	})
+
+	t.Run("io.weight isn't supported", func(t *testing.T) {
+		path := createCgroup(t, filepath.Join(t.TempDir(), "pod"))
+		assert.Nil(t, os.Remove(filepath.Join(path, ioWeightFile)))
+
+		applied, err := c.SetIOLimits(path, "8:16", &api.IOLimits{WriteIOPS: 10, Weight: 50})
+		assert.NotNil(t, err)
+		assert.Equal(t, &api.IOLimits{WriteIOPS: 10}, applied)
+	})
+
+	t.Run("Cgroup doesn't exist", func(t *testing.T) {
+		applied, err := c.SetIOLimits(filepath.Join(t.TempDir(), "pod"), "8:16", &api.IOLimits{WriteIOPS: 10})
+		assert.NotNil(t, err)
+		assert.Equal(t, &api.IOLimits{}, applied)
+	})
+}
+
+func TestCgroup_RemoveIOLimits(t *testing.T) {
+	c := NewCgroup(DefaultRoot)
+
+	path := createCgroup(t, filepath.Join(t.TempDir(), "pod"))
+	assert.Nil(t, c.RemoveIOLimits(path, "8:16"))
+	assert.Equal(t, "8:16 riops=max wiops=max rbps=max wbps=max", readFile(t, filepath.Join(path, ioMaxFile)))
+	assert.Equal(t, "8:16 default", readFile(t, filepath.Join(path, ioWeightFile)))
+
+	// pod was removed
+	assert.Nil(t, c.RemoveIOLimits(filepath.Join(t.TempDir(), "pod"), "8:16"))
+}
@@ -0,0 +1,170 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cryptsetup contains code for running and interpreting output of system cryptsetup util
+package cryptsetup
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dell/csi-baremetal/pkg/base/command"
+)
+
+const (
+	// cryptsetupCmd is a system util for managing dm-crypt and LUKS encrypted devices
+	cryptsetupCmd = "cryptsetup"
+	// MapperDir is a directory where opened encrypted devices are placed
+	MapperDir = "/dev/mapper"
+	// LuksType is a type of LUKS header which is used for formatting of devices
+	LuksType = "luks2"
+	// IsLuksCmdTmpl checks whether device has LUKS header or not, exit code 1 means that device isn't LUKS
+	IsLuksCmdTmpl = cryptsetupCmd + " isLuks %s" // add device path
+	// StatusCmdTmpl prints status of the mapping
+	StatusCmdTmpl = cryptsetupCmd + " status %s" // add mapping name
+	// LuksCloseCmdTmpl removes the mapping
+	LuksCloseCmdTmpl = cryptsetupCmd + " luksClose %s" // add mapping name
+	// LuksEraseCmdTmpl wipes all key slots of LUKS header, data on the device becomes inaccessible forever
+	LuksEraseCmdTmpl = cryptsetupCmd + " luksErase --batch-mode %s" // add device path
+	// keyFromStdinOption makes cryptsetup to read passphrase from stdin
+	keyFromStdinOption = "--key-file=-"
+	// notLuksExitCode is an exit code of isLuks command for devices without LUKS header
+	notLuksExitCode = 1
+	// activeStatus is a part of status command output for opened mapping
+	activeStatus = "is active"
+)
+
+// WrapCryptsetup is an interface that encapsulates operation with system cryptsetup util
+type WrapCryptsetup interface {
+	IsLuks(device string) (bool, error)
+	LuksFormat(device, passphrase string) error
+	LuksOpen(device, name, passphrase string) error
+	LuksClose(name string) error
+	LuksErase(device string) error
+	IsOpened(name string) (bool, error)
+}
+
+// Cryptsetup is an implementation of WrapCryptsetup interface
+type Cryptsetup struct {
+	e   command.CmdExecutor
+	log *logrus.Entry
+}
+
+// NewCryptsetup is a constructor for Cryptsetup struct
+func NewCryptsetup(e command.CmdExecutor, log *logrus.Logger) *Cryptsetup {
+	return &Cryptsetup{
+		e:   e,
+		log: log.WithField("component", "Cryptsetup"),
+	}
+}
+
+// MapperPath returns path of the device which is created on opening of the mapping with provided name
+func MapperPath(name string) string {
+	return path.Join(MapperDir, name)
+}
+
+// IsLuks checks whether device has LUKS header or not
+// Receives path of the device
+// Returns true if device is LUKS device or error if cryptsetup failed
+func (c *Cryptsetup) IsLuks(device string) (bool, error) {
+	_, stdErr, err := c.e.RunCmd(fmt.Sprintf(IsLuksCmdTmpl, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(IsLuksCmdTmpl, ""))))
+	if err == nil {
+		return true, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == notLuksExitCode {
+		return false, nil
+	}
+	return false, fmt.Errorf("unable to check LUKS header on %s: %v, stderr: %s", device, err, stdErr)
+}
+
+// LuksFormat initializes LUKS header on the device, all data on the device is lost
+// Receives path of the device and passphrase which is passed to cryptsetup through stdin
+// Returns error if something went wrong
+func (c *Cryptsetup) LuksFormat(device, passphrase string) error {
+	cmd := exec.Command(cryptsetupCmd, "luksFormat", "--type", LuksType, "--batch-mode", keyFromStdinOption, device)
+	cmd.Stdin = strings.NewReader(passphrase)
+	_, _, err := c.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(cryptsetupCmd+" luksFormat"))
+	return err
+}
+
+// LuksOpen opens LUKS device and creates mapping /dev/mapper/<name>
+// Receives path of the device, name of the mapping and passphrase which is passed to cryptsetup through stdin
+// Returns error if something went wrong
+func (c *Cryptsetup) LuksOpen(device, name, passphrase string) error {
+	cmd := exec.Command(cryptsetupCmd, "luksOpen", keyFromStdinOption, device, name)
+	cmd.Stdin = strings.NewReader(passphrase)
+	_, _, err := c.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(cryptsetupCmd+" luksOpen"))
+	return err
+}
+
+// LuksClose removes mapping with provided name
+// Receives name of the mapping
+// Returns error if something went wrong
+func (c *Cryptsetup) LuksClose(name string) error {
+	_, _, err := c.e.RunCmd(fmt.Sprintf(LuksCloseCmdTmpl, name),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(LuksCloseCmdTmpl, ""))))
+	return err
+}
+
+// LuksErase wipes all key slots of the LUKS device, after that the data can't be decrypted anymore
+// Receives path of the device
+// Returns error if something went wrong
+func (c *Cryptsetup) LuksErase(device string) error {
+	_, _, err := c.e.RunCmd(fmt.Sprintf(LuksEraseCmdTmpl, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(LuksEraseCmdTmpl, ""))))
+	return err
+}
+
+// IsOpened checks whether mapping with provided name is active or not
+// Receives name of the mapping
+// Returns true if mapping is active or error if status can't be determined
+func (c *Cryptsetup) IsOpened(name string) (bool, error) {
+	/*
+		Example of output:
+			~# cryptsetup status luks-pvc-1
+			/dev/mapper/luks-pvc-1 is active.
+			  type:    LUKS2
+			  ...
+			~# cryptsetup status luks-pvc-2
+			/dev/mapper/luks-pvc-2 is inactive.
+	*/
+	stdout, stdErr, err := c.e.RunCmd(fmt.Sprintf(StatusCmdTmpl, name),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(StatusCmdTmpl, ""))))
+	switch {
+	case strings.Contains(stdout, activeStatus):
+		return true, nil
+	case strings.Contains(stdout, "inactive"):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("unable to get status of %s: %v, stderr: %s", name, err, stdErr)
+	}
+	return false, nil
+}
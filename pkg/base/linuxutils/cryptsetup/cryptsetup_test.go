@@ -0,0 +1,148 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cryptsetup
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+var (
+	testLogger     = logrus.New()
+	testErr        = errors.New("error")
+	testDevice     = "/dev/sda1"
+	testName       = "luks-pvc-1"
+	testPassphrase = "secret"
+)
+
+func TestMapperPath(t *testing.T) {
+	assert.Equal(t, "/dev/mapper/"+testName, MapperPath(testName))
+}
+
+func TestCryptsetup_IsLuks(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		c   = NewCryptsetup(e, testLogger)
+		cmd = fmt.Sprintf(IsLuksCmdTmpl, testDevice)
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	isLuks, err := c.IsLuks(testDevice)
+	assert.Nil(t, err)
+	assert.True(t, isLuks)
+
+	// exit code 1 means that device isn't LUKS
+	exitErr := exec.Command("false").Run()
+	e.OnCommand(cmd).Return("", "", exitErr).Times(1)
+	isLuks, err = c.IsLuks(testDevice)
+	assert.Nil(t, err)
+	assert.False(t, isLuks)
+
+	e.OnCommand(cmd).Return("", "Device /dev/sda1 does not exist", testErr).Times(1)
+	isLuks, err = c.IsLuks(testDevice)
+	assert.NotNil(t, err)
+	assert.False(t, isLuks)
+}
+
+func TestCryptsetup_LuksFormat(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		c   = NewCryptsetup(e, testLogger)
+		cmd = exec.Command(cryptsetupCmd, "luksFormat", "--type", LuksType, "--batch-mode", keyFromStdinOption, testDevice)
+	)
+	cmd.Stdin = strings.NewReader(testPassphrase)
+
+	e.On(mocks.RunCmd, cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, c.LuksFormat(testDevice, testPassphrase))
+
+	e.On(mocks.RunCmd, cmd).Return("", "", testErr).Times(1)
+	assert.Equal(t, testErr, c.LuksFormat(testDevice, testPassphrase))
+}
+
+func TestCryptsetup_LuksOpen(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		c   = NewCryptsetup(e, testLogger)
+		cmd = exec.Command(cryptsetupCmd, "luksOpen", keyFromStdinOption, testDevice, testName)
+	)
+	cmd.Stdin = strings.NewReader(testPassphrase)
+
+	e.On(mocks.RunCmd, cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, c.LuksOpen(testDevice, testName, testPassphrase))
+
+	e.On(mocks.RunCmd, cmd).Return("", "", testErr).Times(1)
+	assert.Equal(t, testErr, c.LuksOpen(testDevice, testName, testPassphrase))
+}
+
+func TestCryptsetup_LuksClose(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		c   = NewCryptsetup(e, testLogger)
+		cmd = fmt.Sprintf(LuksCloseCmdTmpl, testName)
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, c.LuksClose(testName))
+
+	e.OnCommand(cmd).Return("", "", testErr).Times(1)
+	assert.Equal(t, testErr, c.LuksClose(testName))
+}
+
+func TestCryptsetup_LuksErase(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		c   = NewCryptsetup(e, testLogger)
+		cmd = fmt.Sprintf(LuksEraseCmdTmpl, testDevice)
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, c.LuksErase(testDevice))
+
+	e.OnCommand(cmd).Return("", "", testErr).Times(1)
+	assert.Equal(t, testErr, c.LuksErase(testDevice))
+}
+
+func TestCryptsetup_IsOpened(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		c   = NewCryptsetup(e, testLogger)
+		cmd = fmt.Sprintf(StatusCmdTmpl, testName)
+	)
+
+	e.OnCommand(cmd).Return("/dev/mapper/luks-pvc-1 is active.\n  type:    LUKS2", "", nil).Times(1)
+	opened, err := c.IsOpened(testName)
+	assert.Nil(t, err)
+	assert.True(t, opened)
+
+	e.OnCommand(cmd).Return("/dev/mapper/luks-pvc-1 is inactive.", "", testErr).Times(1)
+	opened, err = c.IsOpened(testName)
+	assert.Nil(t, err)
+	assert.False(t, opened)
+
+	e.OnCommand(cmd).Return("", "", testErr).Times(1)
+	opened, err = c.IsOpened(testName)
+	assert.NotNil(t, err)
+	assert.False(t, opened)
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -39,15 +40,33 @@ const (
 	EXT4 FileSystem = "ext4"
 	// EXT3 file system
 	EXT3 FileSystem = "ext3"
+	// BTRFS file system
+	BTRFS FileSystem = "btrfs"
+	// F2FS file system
+	F2FS FileSystem = "f2fs"
 
 	// wipefs is a system utility
 	wipefs = "wipefs "
 	// CheckSpaceCmdImpl cmd for getting space on the mounted FS, produce output in megabytes (--block-size=M)
 	CheckSpaceCmdImpl = "df %s --output=target,avail --block-size=M" // add mounted fs part
+	// FSStatsCmdTmpl cmd for getting capacity and inodes usage of the mounted FS, produce output in bytes
+	FSStatsCmdTmpl = "df %s --output=size,used,avail,itotal,iused,iavail --block-size=1" // add mounted fs path
 	// MkFSCmdTmpl mkfs command template
 	MkFSCmdTmpl = "mkfs.%s %s" // add fs type and device/path
+	// MkFSWithOptionsCmdTmpl mkfs command template with options
+	MkFSWithOptionsCmdTmpl = "mkfs.%s %s %s" // add fs type, options and device/path
+	// GrowXFSCmdTmpl extends mounted xfs file system up to the size of the device, fill mount point
+	GrowXFSCmdTmpl = "xfs_growfs %s"
+	// GrowExtFSCmdTmpl extends ext3 or ext4 file system up to the size of the device, fill device
+	GrowExtFSCmdTmpl = "resize2fs %s"
+	// GrowBtrfsCmdTmpl extends mounted btrfs file system up to the size of the device, fill mount point
+	GrowBtrfsCmdTmpl = "btrfs filesystem resize max %s"
 	// SpeedUpFsCreationOpts options that could be used for speeds up creation of ext3 and ext4 FS
-	SpeedUpFsCreationOpts = " -E lazy_journal_init=1,lazy_itable_init=1,discard"
+	SpeedUpFsCreationOpts = " " + extendedOpt + " " + speedUpExtendedOpts
+	// speedUpExtendedOpts extended options of mke2fs used in SpeedUpFsCreationOpts
+	speedUpExtendedOpts = "lazy_journal_init=1,lazy_itable_init=1,discard"
+	// extendedOpt is mke2fs flag of extended options, only the last one is used by mke2fs if it's repeated
+	extendedOpt = "-E"
 	// MkDirCmdTmpl mkdir template
 	MkDirCmdTmpl = "mkdir -p %s"
 	// RmDirCmdTmpl rm template
@@ -70,13 +89,25 @@ const (
 	MountOptionsFlag = "-o"
 )
 
+// FSStats holds capacity (in bytes) and inodes usage of the mounted file system
+type FSStats struct {
+	TotalBytes      int64
+	UsedBytes       int64
+	AvailableBytes  int64
+	TotalInodes     int64
+	UsedInodes      int64
+	AvailableInodes int64
+}
+
 // WrapFS is an interface that encapsulates operation with file systems
 type WrapFS interface {
 	GetFSSpace(src string) (int64, error)
+	GetFSStats(path string) (*FSStats, error)
 	MkDir(src string) error
 	MkFile(src string) error
 	RmDir(src string) error
-	CreateFS(fsType FileSystem, device string) error
+	CreateFS(fsType FileSystem, device, options string) error
+	GrowFS(fsType FileSystem, device, mountPoint string) error
 	WipeFS(device string) error
 	GetFSType(device string) (string, error)
 	// Mount operations
@@ -132,6 +163,55 @@ func (h *WrapFSImpl) GetFSSpace(src string) (int64, error) {
 	return 0, fmt.Errorf("wrong df output %s", stdout)
 }
 
+// GetFSStats calls df command and returns capacity and inodes usage of the file system mounted to path
+// Returns FSStats or error if something went wrong
+func (h *WrapFSImpl) GetFSStats(path string) (*FSStats, error) {
+	/*
+		Example of output:
+			~# df /var/lib/kubelet/pods/<uuid>/volumes/kubernetes.io~csi/<pv>/mount \
+				--output=size,used,avail,itotal,iused,iavail --block-size=1
+				  1B-blocks      Used       Avail   Inodes IUsed    IFree
+				10726932480 109318144 10617614336  5242880     3  5242877
+	*/
+
+	stdout, _, err := h.e.RunCmd(fmt.Sprintf(FSStatsCmdTmpl, path),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(FSStatsCmdTmpl, ""))))
+	if err != nil {
+		return nil, err
+	}
+
+	lines := util.SplitAndTrimSpace(stdout, "\n")
+	// skip header
+	if len(lines) < 2 {
+		return nil, fmt.Errorf("wrong df output %s", stdout)
+	}
+	fields := strings.Fields(lines[1])
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("wrong df output %s", stdout)
+	}
+
+	values := make([]int64, len(fields))
+	for i, field := range fields {
+		// some file systems (e.g. vfat) don't have inodes, df prints "-" for them
+		if field == "-" {
+			continue
+		}
+		if values[i], err = strconv.ParseInt(field, 10, 64); err != nil {
+			return nil, fmt.Errorf("unable to parse df output %s: %w", stdout, err)
+		}
+	}
+
+	return &FSStats{
+		TotalBytes:      values[0],
+		UsedBytes:       values[1],
+		AvailableBytes:  values[2],
+		TotalInodes:     values[3],
+		UsedInodes:      values[4],
+		AvailableInodes: values[5],
+	}, nil
+}
+
 // MkDir creates specified path using mkdir if it doesn't exist
 // Receives directory path to create as a string
 // Returns error if something went wrong
@@ -187,23 +267,91 @@ func (h *WrapFSImpl) RmDir(src string) error {
 }
 
 // CreateFS creates specified file system on the provided device using mkfs
-// Receives file system as a var of FileSystem type and path of the device as a string
+// Receives file system as a var of FileSystem type, path of the device and additional mkfs options as a string,
+// options are validated with ValidateMkFSOptions
 // Returns error if something went wrong
-func (h *WrapFSImpl) CreateFS(fsType FileSystem, device string) error {
-	var cmd string
+func (h *WrapFSImpl) CreateFS(fsType FileSystem, device, options string) error {
+	if err := ValidateMkFSOptions(fsType, options); err != nil {
+		return err
+	}
+
+	fields := strings.Fields(options)
+	var extended string
+	if fsType == EXT3 || fsType == EXT4 {
+		fields, extended = mergeExtendedOptions(fields)
+	}
+
+	cmd := fmt.Sprintf(MkFSCmdTmpl, fsType, device)
+	if len(fields) > 0 {
+		cmd = fmt.Sprintf(MkFSWithOptionsCmdTmpl, fsType, strings.Join(fields, " "), device)
+	}
+	if extended != "" {
+		cmd += " " + extendedOpt + " " + extended
+	}
+
+	if _, _, err := h.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(MkFSCmdTmpl, "", "")))); err != nil {
+		return fmt.Errorf("failed to create file system on %s: %w", device, err)
+	}
+	return nil
+}
+
+// mergeExtendedOptions removes -E options from validated mke2fs options and merges their extended options
+// with speedUpExtendedOpts, since mke2fs uses only the last -E. Extended options set by user take precedence
+// Returns the rest of options and comma separated extended options
+func mergeExtendedOptions(fields []string) ([]string, string) {
+	var (
+		rest     = make([]string, 0, len(fields))
+		extended []string
+		userKeys = make(map[string]bool)
+	)
+	for i := 0; i < len(fields); i++ {
+		if fields[i] != extendedOpt || i+1 == len(fields) {
+			rest = append(rest, fields[i])
+			continue
+		}
+		i++
+		for _, opt := range strings.Split(fields[i], ",") {
+			if opt == "" {
+				continue
+			}
+			extended = append(extended, opt)
+			userKeys[strings.SplitN(opt, "=", 2)[0]] = true
+		}
+	}
+	for _, opt := range strings.Split(speedUpExtendedOpts, ",") {
+		if !userKeys[strings.SplitN(opt, "=", 2)[0]] {
+			extended = append(extended, opt)
+		}
+	}
+	return rest, strings.Join(extended, ",")
+}
+
+// GrowFS extends file system on the provided device up to the size of the device, file system must be mounted
+// xfs_growfs is used for xfs and works with mount point, resize2fs is used for ext3/ext4 and works with device,
+// btrfs is resized with mount point. f2fs can't be resized online
+// Receives file system as a var of FileSystem type, path of the device and mount point of the file system
+// Returns error if something went wrong
+func (h *WrapFSImpl) GrowFS(fsType FileSystem, device, mountPoint string) error {
+	var cmd, cmdName string
 	switch fsType {
 	case XFS:
-		cmd = fmt.Sprintf(MkFSCmdTmpl, fsType, device)
+		cmd, cmdName = fmt.Sprintf(GrowXFSCmdTmpl, mountPoint), fmt.Sprintf(GrowXFSCmdTmpl, "")
 	case EXT3, EXT4:
-		cmd = fmt.Sprintf(MkFSCmdTmpl, fsType, device) + SpeedUpFsCreationOpts
+		cmd, cmdName = fmt.Sprintf(GrowExtFSCmdTmpl, device), fmt.Sprintf(GrowExtFSCmdTmpl, "")
+	case BTRFS:
+		cmd, cmdName = fmt.Sprintf(GrowBtrfsCmdTmpl, mountPoint), fmt.Sprintf(GrowBtrfsCmdTmpl, "")
+	case F2FS:
+		return fmt.Errorf("online resize of %v isn't supported", fsType)
 	default:
 		return fmt.Errorf("unsupported file system %v", fsType)
 	}
 
 	if _, _, err := h.e.RunCmd(cmd,
 		command.UseMetrics(true),
-		command.CmdName(strings.TrimSpace(fmt.Sprintf(MkFSCmdTmpl, "", "")))); err != nil {
-		return fmt.Errorf("failed to create file system on %s: %w", device, err)
+		command.CmdName(strings.TrimSpace(cmdName))); err != nil {
+		return fmt.Errorf("failed to grow file system on %s: %w", device, err)
 	}
 	return nil
 }
@@ -106,6 +106,54 @@ func TestGetFSSpace_Success(t *testing.T) {
 	assert.Equal(t, expectedRes, freeBytes)
 }
 
+func TestGetFSStats(t *testing.T) {
+	var (
+		mockexec = &mocks.GoMockExecutor{}
+		fh       = NewFSImpl(mockexec)
+		path     = "/mnt/volume"
+		cmd      = fmt.Sprintf(FSStatsCmdTmpl, path)
+		header   = "  1B-blocks      Used       Avail   Inodes IUsed    IFree\n"
+	)
+
+	// success
+	mockexec.OnCommand(cmd).
+		Return(header+"10726932480 109318144 10617614336  5242880     3  5242877\n", "", nil).Times(1)
+	stats, err := fh.GetFSStats(path)
+	assert.Nil(t, err)
+	assert.Equal(t, &FSStats{
+		TotalBytes:      10726932480,
+		UsedBytes:       109318144,
+		AvailableBytes:  10617614336,
+		TotalInodes:     5242880,
+		UsedInodes:      3,
+		AvailableInodes: 5242877,
+	}, stats)
+
+	// file system without inodes
+	mockexec.OnCommand(cmd).
+		Return(header+"1048576 1024 1047552 - - -", "", nil).Times(1)
+	stats, err = fh.GetFSStats(path)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1048576), stats.TotalBytes)
+	assert.Equal(t, int64(0), stats.TotalInodes)
+
+	// wrong output
+	mockexec.OnCommand(cmd).Return(header, "", nil).Times(1)
+	_, err = fh.GetFSStats(path)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "wrong df output")
+
+	// unable to parse output
+	mockexec.OnCommand(cmd).Return(header+"1G 1M 1023M 10 1 9", "", nil).Times(1)
+	_, err = fh.GetFSStats(path)
+	assert.NotNil(t, err)
+
+	// command error
+	mockexec.OnCommand(cmd).Return("", "", testError).Times(1)
+	_, err = fh.GetFSStats(path)
+	assert.Equal(t, testError, err)
+}
+
 func TestMkDir(t *testing.T) {
 	var (
 		e   = &mocks.GoMockExecutor{}
@@ -155,16 +203,99 @@ func TestCreateFS(t *testing.T) {
 	)
 
 	e.OnCommand(cmd).Return("", "", nil).Times(1)
-	err = fh.CreateFS(fsType, device)
+	err = fh.CreateFS(fsType, device, "")
 	assert.Nil(t, err)
 
 	// cmd failed
 	e.OnCommand(cmd).Return("", "", testError).Times(1)
-	err = fh.CreateFS(fsType, device)
+	err = fh.CreateFS(fsType, device, "")
 	assert.NotNil(t, err)
 
+	// with options
+	e.OnCommand(fmt.Sprintf(MkFSWithOptionsCmdTmpl, fsType, "-b size=4096 -K", device)).Return("", "", nil).Times(1)
+	err = fh.CreateFS(fsType, device, " -b size=4096  -K")
+	assert.Nil(t, err)
+
+	// ext4 with options
+	e.OnCommand(fmt.Sprintf(MkFSWithOptionsCmdTmpl, EXT4, "-m 1", device)+SpeedUpFsCreationOpts).
+		Return("", "", nil).Times(1)
+	err = fh.CreateFS(EXT4, device, "-m 1")
+	assert.Nil(t, err)
+
+	// ext4 with user extended options, they are merged with speed up options since mke2fs uses only the last -E
+	e.OnCommand(fmt.Sprintf(MkFSWithOptionsCmdTmpl, EXT4, "-m 1", device)+
+		" -E stride=16,stripe_width=64,lazy_journal_init=1,lazy_itable_init=1,discard").
+		Return("", "", nil).Times(1)
+	assert.Nil(t, fh.CreateFS(EXT4, device, "-E stride=16,stripe_width=64 -m 1"))
+
+	// user extended option overrides speed up one
+	e.OnCommand(fmt.Sprintf(MkFSCmdTmpl, EXT3, device)+" -E lazy_itable_init=0,lazy_journal_init=1,discard").
+		Return("", "", nil).Times(1)
+	assert.Nil(t, fh.CreateFS(EXT3, device, "-E lazy_itable_init=0"))
+
+	// btrfs and f2fs
+	e.OnCommand(fmt.Sprintf(MkFSCmdTmpl, BTRFS, device)).Return("", "", nil).Times(1)
+	assert.Nil(t, fh.CreateFS(BTRFS, device, ""))
+	e.OnCommand(fmt.Sprintf(MkFSWithOptionsCmdTmpl, F2FS, "-O extra_attr,compression", device)).
+		Return("", "", nil).Times(1)
+	assert.Nil(t, fh.CreateFS(F2FS, device, "-O extra_attr,compression"))
+
+	// invalid options
+	err = fh.CreateFS(fsType, device, "-f")
+	assert.NotNil(t, err)
+
+	// unsupported FS
+	err = fh.CreateFS("anotherFS", device, "")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unsupported file system")
+}
+
+func TestValidateMkFSOptions(t *testing.T) {
+	assert.Nil(t, ValidateMkFSOptions(XFS, ""))
+	assert.Nil(t, ValidateMkFSOptions(XFS, "-m crc=1,reflink=1 -K"))
+	assert.Nil(t, ValidateMkFSOptions(EXT4, "-O ^has_journal -E lazy_itable_init=0"))
+	assert.Nil(t, ValidateMkFSOptions(BTRFS, "-m single -d single"))
+	assert.Nil(t, ValidateMkFSOptions(F2FS, "-O extra_attr,compression"))
+
+	// option of another file system
+	assert.NotNil(t, ValidateMkFSOptions(EXT4, "-K"))
+	// argument is missing
+	assert.NotNil(t, ValidateMkFSOptions(XFS, "-b"))
+	// invalid argument
+	assert.NotNil(t, ValidateMkFSOptions(XFS, "-b size=4096;reboot"))
+	assert.NotNil(t, ValidateMkFSOptions(EXT4, "-L $(id)"))
+	// unsupported file system
+	assert.NotNil(t, ValidateMkFSOptions("anotherFS", ""))
+}
+
+func TestGrowFS(t *testing.T) {
+	var (
+		e          = &mocks.GoMockExecutor{}
+		fh         = NewFSImpl(e)
+		device     = "/dev/sda1"
+		mountPoint = "/mnt/volume"
+		xfsCmd     = fmt.Sprintf(GrowXFSCmdTmpl, mountPoint)
+		extCmd     = fmt.Sprintf(GrowExtFSCmdTmpl, device)
+	)
+
+	e.OnCommand(xfsCmd).Return("", "", nil).Times(1)
+	assert.Nil(t, fh.GrowFS(XFS, device, mountPoint))
+
+	e.OnCommand(extCmd).Return("", "", nil).Times(1)
+	assert.Nil(t, fh.GrowFS(EXT4, device, mountPoint))
+
+	e.OnCommand(fmt.Sprintf(GrowBtrfsCmdTmpl, mountPoint)).Return("", "", nil).Times(1)
+	assert.Nil(t, fh.GrowFS(BTRFS, device, mountPoint))
+
+	// f2fs can't be resized online
+	assert.NotNil(t, fh.GrowFS(F2FS, device, mountPoint))
+
+	// cmd failed
+	e.OnCommand(xfsCmd).Return("", "", testError).Times(1)
+	assert.NotNil(t, fh.GrowFS(XFS, device, mountPoint))
+
 	// unsupported FS
-	err = fh.CreateFS("anotherFS", device)
+	err := fh.GrowFS("anotherFS", device, mountPoint)
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "unsupported file system")
 }
@@ -0,0 +1,100 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// mkfsArgRegexp restricts symbols of the mkfs option argument, shell special symbols aren't allowed
+var mkfsArgRegexp = regexp.MustCompile(`^[A-Za-z0-9_=,.:+^-]+$`)
+
+// supportedMkFSOptions contains options allowed to be passed to mkfs for each file system,
+// value defines whether option requires an argument
+var supportedMkFSOptions = map[FileSystem]map[string]bool{
+	XFS: {
+		"-b": true, // block size
+		"-i": true, // inode options
+		"-m": true, // metadata options, e.g. crc=1,reflink=1
+		"-d": true, // data section options
+		"-l": true, // log section options
+		"-n": true, // naming options
+		"-K": false,
+	},
+	EXT3: extMkFSOptions,
+	EXT4: extMkFSOptions,
+	BTRFS: {
+		"-m": true, // metadata profile
+		"-d": true, // data profile
+		"-n": true, // node size
+		"-s": true, // sector size
+		"-O": true, // features
+		"-K": false,
+	},
+	F2FS: {
+		"-O": true, // features, e.g. extra_attr,compression
+		"-o": true, // overprovision ratio
+		"-s": true, // segments per section
+		"-z": true, // sections per zone
+		"-a": true, // heap-based allocation
+		"-t": true, // discard
+	},
+}
+
+var extMkFSOptions = map[string]bool{
+	"-b": true, // block size
+	"-i": true, // bytes per inode
+	"-I": true, // inode size
+	"-m": true, // reserved blocks percentage
+	"-N": true, // number of inodes
+	"-E": true, // extended options
+	"-O": true, // features
+	"-T": true, // usage type
+}
+
+// ValidateMkFSOptions checks that options string contains only supported mkfs options for provided file system
+// Receives file system as a var of FileSystem type and options as a space separated string, e.g. "-b 4096 -K"
+// Returns error if file system isn't supported, option is unknown or argument is missing or invalid
+func ValidateMkFSOptions(fsType FileSystem, options string) error {
+	supported, ok := supportedMkFSOptions[fsType]
+	if !ok {
+		return fmt.Errorf("unsupported file system %v", fsType)
+	}
+
+	fields := strings.Fields(options)
+	for i := 0; i < len(fields); i++ {
+		opt := fields[i]
+		withArg, ok := supported[opt]
+		if !ok {
+			return fmt.Errorf("mkfs option %s isn't supported for %v", opt, fsType)
+		}
+		if !withArg {
+			continue
+		}
+		i++
+		if i == len(fields) {
+			return fmt.Errorf("mkfs option %s requires an argument", opt)
+		}
+		if !mkfsArgRegexp.MatchString(fields[i]) {
+			return fmt.Errorf("argument %s of mkfs option %s is invalid", fields[i], opt)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hdparm contains code for running and interpreting output of system hdparm util
+package hdparm
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dell/csi-baremetal/pkg/base/command"
+)
+
+const (
+	hdparmCmd = "hdparm"
+	// IdentifyCmdTmpl prints identification information of ATA device
+	IdentifyCmdTmpl = hdparmCmd + " -I %s" // add device path
+	// SecuritySetPassCmdTmpl sets user password which is required for security erase
+	SecuritySetPassCmdTmpl = hdparmCmd + " --user-master u --security-set-pass %s %s" // add password and device path
+	// SecurityEraseCmdTmpl issues ATA security erase, password is cleared by the device on success
+	SecurityEraseCmdTmpl = hdparmCmd + " --user-master u --security-erase %s %s" // add password and device path
+	// erasePassword is a temporary password which is set to the device only for the time of erase
+	erasePassword = "csi-baremetal"
+	// securitySection is a header of security section in identification output
+	securitySection = "Security:"
+)
+
+// WrapHdparm is an interface that encapsulates operation with system hdparm util
+type WrapHdparm interface {
+	SecurityErase(device string) error
+}
+
+// HDParm is an implementation of WrapHdparm interface
+type HDParm struct {
+	e   command.CmdExecutor
+	log *logrus.Entry
+}
+
+// NewHDParm is a constructor for HDParm struct
+func NewHDParm(e command.CmdExecutor, log *logrus.Logger) *HDParm {
+	return &HDParm{
+		e:   e,
+		log: log.WithField("component", "HDParm"),
+	}
+}
+
+// SecurityErase performs ATA secure erase of the device, all data on the device is lost.
+// Device must support security feature set and mustn't be frozen
+// Receives path of the device
+// Returns error if something went wrong
+func (h *HDParm) SecurityErase(device string) error {
+	ll := h.log.WithFields(logrus.Fields{
+		"method": "SecurityErase",
+		"device": device,
+	})
+
+	if err := h.checkSecurityState(device); err != nil {
+		return err
+	}
+
+	ll.Info("Setting temporary password")
+	if _, _, err := h.e.RunCmd(fmt.Sprintf(SecuritySetPassCmdTmpl, erasePassword, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(SecuritySetPassCmdTmpl, "", "")))); err != nil {
+		return fmt.Errorf("unable to set security password on %s: %v", device, err)
+	}
+
+	ll.Info("Erasing device")
+	if _, _, err := h.e.RunCmd(fmt.Sprintf(SecurityEraseCmdTmpl, erasePassword, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(SecurityEraseCmdTmpl, "", "")))); err != nil {
+		return fmt.Errorf("unable to perform security erase of %s: %v", device, err)
+	}
+	return nil
+}
+
+// checkSecurityState returns error if device doesn't support security feature set or it is frozen
+func (h *HDParm) checkSecurityState(device string) error {
+	/*
+		Example of output:
+			~# hdparm -I /dev/sdb
+			...
+			Security:
+				Master password revision code = 65534
+					supported
+				not	enabled
+				not	locked
+				not	frozen
+				not	expired: security count
+					supported: enhanced erase
+			...
+	*/
+	stdout, _, err := h.e.RunCmd(fmt.Sprintf(IdentifyCmdTmpl, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(IdentifyCmdTmpl, ""))))
+	if err != nil {
+		return fmt.Errorf("unable to identify device %s: %v", device, err)
+	}
+
+	var (
+		scanner              = bufio.NewScanner(strings.NewReader(stdout))
+		inSection            bool
+		supported, notFrozen bool
+	)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, securitySection) {
+			inSection = true
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		// security section ends with the first not indented line
+		if line != "" && !strings.HasPrefix(line, "\t") && !strings.HasPrefix(line, " ") {
+			break
+		}
+		switch strings.Join(strings.Fields(line), " ") {
+		case "supported":
+			supported = true
+		case "not frozen":
+			notFrozen = true
+		}
+	}
+
+	if !supported {
+		return fmt.Errorf("device %s doesn't support ATA security feature set", device)
+	}
+	if !notFrozen {
+		return fmt.Errorf("security of device %s is frozen", device)
+	}
+	return nil
+}
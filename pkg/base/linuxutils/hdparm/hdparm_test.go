@@ -0,0 +1,95 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hdparm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+var (
+	testLogger = logrus.New()
+	testErr    = errors.New("error")
+	testDevice = "/dev/sdb"
+
+	identifyTmpl = "/dev/sdb:\n\nATA device, with non-removable media\n" +
+		"Security: \n\tMaster password revision code = 65534\n\t\t%s\n\tnot\tenabled\n\tnot\tlocked\n\t%s\n" +
+		"\tnot\texpired: security count\n\t\tsupported: enhanced erase\nLogical Unit WWN Device Identifier: 5000c500a0b1c2d3\n"
+	identifyReady       = fmt.Sprintf(identifyTmpl, "supported", "not\tfrozen")
+	identifyFrozen      = fmt.Sprintf(identifyTmpl, "supported", "\tfrozen")
+	identifyUnsupported = fmt.Sprintf(identifyTmpl, "", "not\tfrozen")
+)
+
+func TestHDParm_SecurityErase(t *testing.T) {
+	var (
+		identifyCmd = fmt.Sprintf(IdentifyCmdTmpl, testDevice)
+		setPassCmd  = fmt.Sprintf(SecuritySetPassCmdTmpl, erasePassword, testDevice)
+		eraseCmd    = fmt.Sprintf(SecurityEraseCmdTmpl, erasePassword, testDevice)
+	)
+
+	t.Run("Success", func(t *testing.T) {
+		e := &mocks.GoMockExecutor{}
+		e.OnCommand(identifyCmd).Return(identifyReady, "", nil).Times(1)
+		e.OnCommand(setPassCmd).Return("", "", nil).Times(1)
+		e.OnCommand(eraseCmd).Return("", "", nil).Times(1)
+
+		assert.Nil(t, NewHDParm(e, testLogger).SecurityErase(testDevice))
+		e.AssertExpectations(t)
+	})
+
+	t.Run("Device is frozen", func(t *testing.T) {
+		e := &mocks.GoMockExecutor{}
+		e.OnCommand(identifyCmd).Return(identifyFrozen, "", nil).Times(1)
+
+		err := NewHDParm(e, testLogger).SecurityErase(testDevice)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "frozen")
+	})
+
+	t.Run("Security isn't supported", func(t *testing.T) {
+		e := &mocks.GoMockExecutor{}
+		e.OnCommand(identifyCmd).Return(identifyUnsupported, "", nil).Times(1)
+
+		err := NewHDParm(e, testLogger).SecurityErase(testDevice)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "doesn't support")
+	})
+
+	t.Run("Identify failed", func(t *testing.T) {
+		e := &mocks.GoMockExecutor{}
+		e.OnCommand(identifyCmd).Return("", "", testErr).Times(1)
+
+		assert.NotNil(t, NewHDParm(e, testLogger).SecurityErase(testDevice))
+	})
+
+	t.Run("Erase failed", func(t *testing.T) {
+		e := &mocks.GoMockExecutor{}
+		e.OnCommand(identifyCmd).Return(identifyReady, "", nil).Times(1)
+		e.OnCommand(setPassCmd).Return("", "", nil).Times(1)
+		e.OnCommand(eraseCmd).Return("", "", testErr).Times(1)
+
+		err := NewHDParm(e, testLogger).SecurityErase(testDevice)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "unable to perform security erase")
+	})
+}
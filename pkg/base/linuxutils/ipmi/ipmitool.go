@@ -18,6 +18,7 @@ limitations under the License.
 package ipmi
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -27,11 +28,16 @@ import (
 const (
 	// LanPrintCmd print bmc ip cmd with ipmitool
 	LanPrintCmd = " ipmitool lan print"
+	// ChassisIdentifyOnCmd turns on chassis identify LED until it is turned off
+	ChassisIdentifyOnCmd = "ipmitool chassis identify force"
+	// ChassisIdentifyOffCmd turns off chassis identify LED
+	ChassisIdentifyOffCmd = "ipmitool chassis identify 0"
 )
 
 // WrapIpmi is an interface that encapsulates operation with system ipmi util
 type WrapIpmi interface {
 	GetBmcIP() string
+	ChassisIdentify(on bool) error
 }
 
 // IPMI is implementation for WrapImpi interface
@@ -76,3 +82,17 @@ func (i *IPMI) GetBmcIP() string {
 	}
 	return ip
 }
+
+// ChassisIdentify turns on or off chassis identify LED using ipmitool
+func (i *IPMI) ChassisIdentify(on bool) error {
+	cmd := ChassisIdentifyOffCmd
+	if on {
+		cmd = ChassisIdentifyOnCmd
+	}
+	if _, stderr, err := i.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(cmd)); err != nil {
+		return fmt.Errorf("unable to change chassis identify LED: %v, stderr: %s", err, stderr)
+	}
+	return nil
+}
@@ -45,3 +45,14 @@ func TestIPMI_GetBmcIP(t *testing.T) {
 	ip = l.GetBmcIP()
 	assert.Equal(t, "", ip)
 }
+
+func TestIPMI_ChassisIdentify(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	l := NewIPMI(e)
+
+	e.On(mocks.RunCmd, ChassisIdentifyOnCmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.ChassisIdentify(true))
+
+	e.On(mocks.RunCmd, ChassisIdentifyOffCmd).Return("", "", errors.New("ipmitool failed")).Times(1)
+	assert.NotNil(t, l.ChassisIdentify(false))
+}
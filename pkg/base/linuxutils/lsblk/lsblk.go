@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -157,13 +158,20 @@ func (l *LSBLK) GetBlockDevices(device string) ([]BlockDevice, error) {
 	return res, nil
 }
 
-// SearchDrivePath if not defined returns drive path based on drive S/N, VID and PID.
+// SearchDrivePath returns current path of the drive. Drive with persistent identifiers is resolved by its by-id link
+// or WWN because kernel name of the device might be changed after reboot, path of other drives is used if defined,
+// otherwise path is searched based on drive S/N, VID and PID.
 // Receives an instance of drivecrd.Drive struct
 // Returns drive's path based on provided drivecrd.Drive or error if something went wrong
 func (l *LSBLK) SearchDrivePath(drive *api.Drive) (string, error) {
+	if drive.ByIdPath != "" {
+		if device, err := filepath.EvalSymlinks(drive.ByIdPath); err == nil {
+			return device, nil
+		}
+	}
 	// device path might be already set by hwmgr
 	device := drive.Path
-	if device != "" {
+	if device != "" && drive.ByIdPath == "" && drive.WWN == "" {
 		return device, nil
 	}
 
@@ -177,7 +185,12 @@ func (l *LSBLK) SearchDrivePath(drive *api.Drive) (string, error) {
 	sn := drive.SerialNumber
 	vid := drive.VID
 	pid := drive.PID
+	device = ""
 	for _, l := range lsblkOut {
+		if drive.WWN != "" && strings.EqualFold(l.WWN, drive.WWN) {
+			device = l.Name
+			break
+		}
 		if strings.EqualFold(l.Serial, sn) && strings.EqualFold(l.Vendor, vid) &&
 			strings.EqualFold(l.Model, pid) {
 			device = l.Name
@@ -186,9 +199,18 @@ func (l *LSBLK) SearchDrivePath(drive *api.Drive) (string, error) {
 	}
 
 	if device == "" {
-		errMsg := fmt.Errorf("unable to find drive path by SN %s, VID %s, PID %s", sn, vid, pid)
+		errMsg := fmt.Errorf("unable to find drive path by WWN %s, SN %s, VID %s, PID %s", drive.WWN, sn, vid, pid)
 		return "", errMsg
 	}
 
 	return device, nil
 }
+
+// ResolveDevicePath returns current kernel name of the device if provided path is a persistent link,
+// e.g. /dev/disk/by-id/wwn-<WWN>, provided path is returned if it isn't a link or can't be resolved
+func ResolveDevicePath(path string) string {
+	if device, err := filepath.EvalSymlinks(path); err == nil {
+		return device
+	}
+	return path
+}
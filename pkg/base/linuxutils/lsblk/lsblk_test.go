@@ -19,6 +19,9 @@ package lsblk
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -105,6 +108,43 @@ func TestLSBLK_SearchDrivePath_Success(t *testing.T) {
 	assert.Equal(t, expectedDevice, res)
 }
 
+func TestLSBLK_SearchDrivePath_PersistentIdentifiers(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	l := NewLSBLK(testLogger)
+	l.e = e
+
+	// by-id link is resolved to the current kernel name
+	dir := t.TempDir()
+	device := filepath.Join(dir, "sdc")
+	link := filepath.Join(dir, "wwn-0x5000c500a0b1c2d3")
+	assert.Nil(t, ioutil.WriteFile(device, nil, 0600))
+	assert.Nil(t, os.Symlink(device, link))
+	dCR := testDriveCR
+	dCR.Spec.Path = "/dev/sda"
+	dCR.Spec.ByIdPath = link
+	res, err := l.SearchDrivePath(&dCR.Spec)
+	assert.Nil(t, err)
+	assert.Equal(t, device, res)
+	assert.Equal(t, device, ResolveDevicePath(link))
+	assert.Equal(t, "/dev/sda", ResolveDevicePath("/dev/sda"))
+
+	// stale path isn't used if link is broken, drive is found by WWN
+	e.On("RunCmd", allDevicesCmd).Return(`{"blockdevices":[
+		{"name": "/dev/sda", "type": "disk", "serial": "hdd2", "wwn": "0x5000c500a0b1c2d4"},
+		{"name": "/dev/sdb", "type": "disk", "serial": "hdd1", "wwn": "0x5000c500a0b1c2d3"}]}`, "", nil)
+	dCR.Spec.ByIdPath = filepath.Join(dir, "wwn-0x5000c500a0b1c2d5")
+	dCR.Spec.WWN = "0x5000C500A0B1C2D3"
+	res, err = l.SearchDrivePath(&dCR.Spec)
+	assert.Nil(t, err)
+	assert.Equal(t, "/dev/sdb", res)
+
+	// drive isn't found
+	dCR.Spec.WWN = "0x5000c500a0b1c2d5"
+	dCR.Spec.SerialNumber = "hdd3"
+	_, err = l.SearchDrivePath(&dCR.Spec)
+	assert.NotNil(t, err)
+}
+
 func TestLSBLK_SearchDrivePath(t *testing.T) {
 	e := &mocks.GoMockExecutor{}
 	l := NewLSBLK(testLogger)
@@ -42,6 +42,7 @@ const (
 // WrapLsscsi is an interface that encapsulates operation with system lsscsi util
 type WrapLsscsi interface {
 	GetSCSIDevices() ([]*SCSIDevice, error)
+	GetSCSIDevice(path string) (*SCSIDevice, error)
 }
 
 // LSSCSI is a wrap for system lsscsi util
@@ -83,6 +84,28 @@ func (la *LSSCSI) GetSCSIDevices() ([]*SCSIDevice, error) {
 	return devices, nil
 }
 
+// GetSCSIDevice gets information about SCSIDevice with provided path, e.g. /dev/sda, using lsscsi util
+// Returns nil if lsscsi doesn't list the device
+func (la *LSSCSI) GetSCSIDevice(path string) (*SCSIDevice, error) {
+	devices, err := la.getSCSIDevicesBasicInfo()
+	if err != nil {
+		return nil, err
+	}
+	for _, device := range devices {
+		if device.Path != path {
+			continue
+		}
+		if err := la.fillDeviceSize(device); err != nil {
+			return nil, err
+		}
+		if err := la.fillDeviceInfo(device); err != nil {
+			return nil, err
+		}
+		return device, nil
+	}
+	return nil, nil
+}
+
 // getSCSIDevicesBasicInfo returns information about device path and id, We call lsscsi --no-nvme.
 // Using this command we can get list of all SCSI device and their Path and Id from the output of this command
 // The output is easy to parse, because we know, that the Path and Id are on the last and the first positions in the output
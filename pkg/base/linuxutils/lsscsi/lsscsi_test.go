@@ -171,3 +171,31 @@ func TestLSSCSI_GetSCSIDevicesSizeCMDFail(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 2, len(devs))
 }
+
+func TestLSSCSI_GetSCSIDevice(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	l := NewLSSCSI(e, testLogger)
+
+	output := `	[0:0:0:0]    disk    VMware   Virtual disk     2.0   /dev/sda
+		[0:0:1:0]    disk    VMware   Virtual disk     2.0   /dev/sdb`
+	e.On("RunCmd", LsscsiCmdImpl).Return(output, "", nil)
+	// only requested device is inspected
+	e.On("RunCmd", fmt.Sprintf(SCSIDeviceSizeCmdImpl, "[0:0:1:0]")).
+		Return("[0:0:1:0]    /dev/sdb   32.3GB", "", nil)
+	e.On("RunCmd", fmt.Sprintf(SCSIDeviceCmdImpl, "[0:0:1:0]")).
+		Return("Vendor: VMware   Model: Virtual disk    Rev: 2.0", "", nil)
+
+	device, err := l.GetSCSIDevice("/dev/sdb")
+	assert.Nil(t, err)
+	assert.Equal(t, "/dev/sdb", device.Path)
+	assert.Equal(t, int64(34681860915), device.Size)
+	assert.Equal(t, "VMware", device.Vendor)
+
+	device, err = l.GetSCSIDevice("/dev/sdc")
+	assert.Nil(t, err)
+	assert.Nil(t, device)
+
+	e.On("RunCmd", fmt.Sprintf(SCSIDeviceSizeCmdImpl, "[0:0:0:0]")).Return("", "", fmt.Errorf("error"))
+	_, err = l.GetSCSIDevice("/dev/sda")
+	assert.NotNil(t, err)
+}
@@ -68,6 +68,18 @@ const (
 	PVInfoCmdTmpl = lvmPath + "pvdisplay %s --colon" // add PV name
 	// LVExpandCmdTmpl expand LV
 	LVExpandCmdTmpl = lvmPath + "lvextend --size %sb --resizefs %s" // add full LV name
+	// LVSnapshotCreateCmdTmpl create snapshot of LV cmd
+	LVSnapshotCreateCmdTmpl = lvmPath + "lvcreate --yes --snapshot --name %s --size %s %s" // add snapshot name, size and full LV name
+	// LVSnapshotUsageCmdTmpl print percent of snapshot COW space usage cmd
+	LVSnapshotUsageCmdTmpl = lvmPath + "lvs --options data_percent --noheadings %s" // add full snapshot name
+	// ThinPoolCreateCmdTmpl create thin pool on all free space of VG cmd
+	ThinPoolCreateCmdTmpl = lvmPath + "lvcreate --yes --type thin-pool --extents 100%%FREE --name %s %s" // add pool name and VG name
+	// ThinLVCreateCmdTmpl create thin LV in the thin pool cmd
+	ThinLVCreateCmdTmpl = lvmPath + "lvcreate --yes --name %s --virtualsize %s --thinpool %s %s" // add LV name, size, pool name and VG name
+	// ThinPoolUsageCmdTmpl print percent of thin pool data and metadata usage cmd
+	ThinPoolUsageCmdTmpl = lvmPath + "lvs --options data_percent,metadata_percent --noheadings %s" // add full pool name
+	// ThinPoolName is a name of the thin pool LV which is created in VG of thin LVG
+	ThinPoolName = "csi-thin-pool"
 	// timeoutBetweenAttempts used for RunCmdWithAttempts as a timeout between calling lvremove
 	timeoutBetweenAttempts = 500 * time.Millisecond
 )
@@ -89,6 +101,12 @@ type WrapLVM interface {
 	GetLVsInVG(vgName string) ([]string, error)
 	GetVGNameByPVName(pvName string) (string, error)
 	ExpandLV(lvName string, requiredSize int64) error
+	LVSnapshotCreate(name, size, fullLVName string) error
+	LVSnapshotRemove(fullSnapshotName string) error
+	GetLVSnapshotUsage(fullSnapshotName string) (float64, error)
+	ThinPoolCreate(name, vgName string) error
+	ThinLVCreate(name, size, vgName, poolName string) error
+	GetThinPoolUsage(fullPoolName string) (float64, float64, error)
 }
 
 // LVM is an implementation of WrapLVM interface and is a wrap for system /sbin/lvm util in
@@ -379,3 +397,110 @@ func (l *LVM) GetVGNameByPVName(pvName string) (string, error) {
 
 	return splitted[1], nil
 }
+
+// LVSnapshotCreate creates snapshot of the logical volume, ignore error if snapshot already exists
+// Receives name of the snapshot, size which is a string like 1.2G, 100M and full name of origin LV
+// Returns error if something went wrong
+func (l *LVM) LVSnapshotCreate(name, size, fullLVName string) error {
+	cmd := fmt.Sprintf(LVSnapshotCreateCmdTmpl, name, size, fullLVName)
+	_, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(LVSnapshotCreateCmdTmpl, "", "", ""))))
+	if err != nil && strings.Contains(stdErr, "already exists") {
+		return nil
+	}
+	return err
+}
+
+// LVSnapshotRemove removes snapshot of the logical volume, ignore error if snapshot doesn't exist
+// Receives fullSnapshotName that is a path to the snapshot LV
+// Returns error if something went wrong
+func (l *LVM) LVSnapshotRemove(fullSnapshotName string) error {
+	return l.LVRemove(fullSnapshotName)
+}
+
+// GetLVSnapshotUsage returns percent of COW space which is used by the snapshot
+// Receives fullSnapshotName that is a path to the snapshot LV
+// Returns -1 in case of error and error
+func (l *LVM) GetLVSnapshotUsage(fullSnapshotName string) (float64, error) {
+	/*
+		Example of output:
+		root@provo-goop:~# lvm lvs --options data_percent --noheadings /dev/vg/snapshot-1
+		  12.31
+	*/
+	cmd := fmt.Sprintf(LVSnapshotUsageCmdTmpl, fullSnapshotName)
+	stdOut, _, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(LVSnapshotUsageCmdTmpl, ""))))
+	if err != nil {
+		return -1, err
+	}
+
+	usage, err := strconv.ParseFloat(strings.TrimSpace(stdOut), 64)
+	if err != nil {
+		return -1, fmt.Errorf("unable to parse snapshot %s usage %s: %v", fullSnapshotName, stdOut, err)
+	}
+
+	return usage, nil
+}
+
+// ThinPoolCreate creates thin pool which takes all free space of the volume group, ignore error if pool already exists
+// Receives name of the pool and name of VG which pool should be based on
+// Returns error if something went wrong
+func (l *LVM) ThinPoolCreate(name, vgName string) error {
+	cmd := fmt.Sprintf(ThinPoolCreateCmdTmpl, name, vgName)
+	_, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(ThinPoolCreateCmdTmpl, "", ""))))
+	if err != nil && strings.Contains(stdErr, "already exists") {
+		return nil
+	}
+	return err
+}
+
+// ThinLVCreate creates thin logical volume in the thin pool, ignore error if LV already exists
+// Receives name of created LV, virtual size which is a string like 1.2G, 100M, name of VG and name of the thin pool
+// Returns error if something went wrong
+func (l *LVM) ThinLVCreate(name, size, vgName, poolName string) error {
+	cmd := fmt.Sprintf(ThinLVCreateCmdTmpl, name, size, poolName, vgName)
+	_, stdErr, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(ThinLVCreateCmdTmpl, "", "", "", ""))))
+	if err != nil && strings.Contains(stdErr, "already exists") {
+		return nil
+	}
+	return err
+}
+
+// GetThinPoolUsage returns percents of data and metadata space which are used in the thin pool
+// Receives fullPoolName that is a path to the thin pool LV
+// Returns -1, -1 in case of error and error
+func (l *LVM) GetThinPoolUsage(fullPoolName string) (float64, float64, error) {
+	/*
+		Example of output:
+		root@provo-goop:~# lvm lvs --options data_percent,metadata_percent --noheadings /dev/vg/csi-thin-pool
+		  42.17  10.03
+	*/
+	cmd := fmt.Sprintf(ThinPoolUsageCmdTmpl, fullPoolName)
+	stdOut, _, err := l.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(ThinPoolUsageCmdTmpl, ""))))
+	if err != nil {
+		return -1, -1, err
+	}
+
+	fields := strings.Fields(stdOut)
+	if len(fields) != 2 {
+		return -1, -1, fmt.Errorf("unable to parse thin pool %s usage %s", fullPoolName, stdOut)
+	}
+	data, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return -1, -1, fmt.Errorf("unable to parse thin pool %s data usage %s: %v", fullPoolName, fields[0], err)
+	}
+	metadata, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return -1, -1, fmt.Errorf("unable to parse thin pool %s metadata usage %s: %v", fullPoolName, fields[1], err)
+	}
+
+	return data, metadata, nil
+}
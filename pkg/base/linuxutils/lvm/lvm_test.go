@@ -399,3 +399,140 @@ func TestLinuxUtils_GetVGNameByPVName(t *testing.T) {
 		assert.Contains(t, err.Error(), "unable to find VG name for PV")
 	})
 }
+
+func TestLinuxUtils_LVSnapshotCreate(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		snapshot    = "test-snapshot"
+		size        = "9g"
+		fullLVName  = "/dev/test-lvg/test-lv"
+		cmd         = fmt.Sprintf(LVSnapshotCreateCmdTmpl, snapshot, size, fullLVName)
+		err         error
+		expectedErr = errors.New("error")
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	err = l.LVSnapshotCreate(snapshot, size, fullLVName)
+	assert.Nil(t, err)
+
+	e.OnCommand(cmd).Return("", "already exists", expectedErr).Times(1)
+	err = l.LVSnapshotCreate(snapshot, size, fullLVName)
+	assert.Nil(t, err)
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	err = l.LVSnapshotCreate(snapshot, size, fullLVName)
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestLinuxUtils_LVSnapshotRemove(t *testing.T) {
+	var (
+		e                = &mocks.GoMockExecutor{}
+		l                = NewLVM(e, testLogger)
+		fullSnapshotName = "/dev/test-lvg/test-snapshot"
+		cmd              = fmt.Sprintf(LVRemoveCmdTmpl, fullSnapshotName)
+		expectedErr      = errors.New("error")
+	)
+
+	e.OnCommandWithAttempts(cmd, 5, timeoutBetweenAttempts).Return("", "", nil).Times(1)
+	assert.Nil(t, l.LVSnapshotRemove(fullSnapshotName))
+
+	e.OnCommandWithAttempts(cmd, 5, timeoutBetweenAttempts).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.LVSnapshotRemove(fullSnapshotName))
+}
+
+func TestLinuxUtils_GetLVSnapshotUsage(t *testing.T) {
+	var (
+		e                = &mocks.GoMockExecutor{}
+		l                = NewLVM(e, testLogger)
+		fullSnapshotName = "/dev/test-lvg/test-snapshot"
+		cmd              = fmt.Sprintf(LVSnapshotUsageCmdTmpl, fullSnapshotName)
+		expectedErr      = errors.New("error")
+	)
+
+	e.OnCommand(cmd).Return("  12.31\n", "", nil).Times(1)
+	usage, err := l.GetLVSnapshotUsage(fullSnapshotName)
+	assert.Nil(t, err)
+	assert.Equal(t, 12.31, usage)
+
+	e.OnCommand(cmd).Return("  \n", "", nil).Times(1)
+	usage, err = l.GetLVSnapshotUsage(fullSnapshotName)
+	assert.NotNil(t, err)
+	assert.Equal(t, float64(-1), usage)
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	usage, err = l.GetLVSnapshotUsage(fullSnapshotName)
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, float64(-1), usage)
+}
+
+func TestLinuxUtils_ThinPoolCreate(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		vgName      = "test-lvg"
+		cmd         = fmt.Sprintf(ThinPoolCreateCmdTmpl, ThinPoolName, vgName)
+		expectedErr = errors.New("error")
+	)
+	assert.Equal(t, "/sbin/lvm lvcreate --yes --type thin-pool --extents 100%FREE --name csi-thin-pool test-lvg", cmd)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.ThinPoolCreate(ThinPoolName, vgName))
+
+	e.OnCommand(cmd).Return("", "already exists", expectedErr).Times(1)
+	assert.Nil(t, l.ThinPoolCreate(ThinPoolName, vgName))
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.ThinPoolCreate(ThinPoolName, vgName))
+}
+
+func TestLinuxUtils_ThinLVCreate(t *testing.T) {
+	var (
+		e           = &mocks.GoMockExecutor{}
+		l           = NewLVM(e, testLogger)
+		lvName      = "test-lv"
+		size        = "100m"
+		vgName      = "test-lvg"
+		cmd         = fmt.Sprintf(ThinLVCreateCmdTmpl, lvName, size, ThinPoolName, vgName)
+		expectedErr = errors.New("error")
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, l.ThinLVCreate(lvName, size, vgName, ThinPoolName))
+
+	e.OnCommand(cmd).Return("", "already exists", expectedErr).Times(1)
+	assert.Nil(t, l.ThinLVCreate(lvName, size, vgName, ThinPoolName))
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	assert.Equal(t, expectedErr, l.ThinLVCreate(lvName, size, vgName, ThinPoolName))
+}
+
+func TestLinuxUtils_GetThinPoolUsage(t *testing.T) {
+	var (
+		e            = &mocks.GoMockExecutor{}
+		l            = NewLVM(e, testLogger)
+		fullPoolName = "/dev/test-lvg/" + ThinPoolName
+		cmd          = fmt.Sprintf(ThinPoolUsageCmdTmpl, fullPoolName)
+		expectedErr  = errors.New("error")
+	)
+
+	e.OnCommand(cmd).Return("  42.17  10.03\n", "", nil).Times(1)
+	data, metadata, err := l.GetThinPoolUsage(fullPoolName)
+	assert.Nil(t, err)
+	assert.Equal(t, 42.17, data)
+	assert.Equal(t, 10.03, metadata)
+
+	e.OnCommand(cmd).Return("  42.17\n", "", nil).Times(1)
+	data, _, err = l.GetThinPoolUsage(fullPoolName)
+	assert.NotNil(t, err)
+	assert.Equal(t, float64(-1), data)
+
+	e.OnCommand(cmd).Return("  42.17  abc\n", "", nil).Times(1)
+	_, metadata, err = l.GetThinPoolUsage(fullPoolName)
+	assert.NotNil(t, err)
+	assert.Equal(t, float64(-1), metadata)
+
+	e.OnCommand(cmd).Return("", "", expectedErr).Times(1)
+	_, _, err = l.GetThinPoolUsage(fullPoolName)
+	assert.Equal(t, expectedErr, err)
+}
@@ -0,0 +1,207 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package mdadm contains code for running and interpreting output of system mdadm util
+package mdadm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+const (
+	// mdadmCmd is a system util for managing Linux software RAID devices
+	mdadmCmd = "mdadm"
+	// CreateCmdTmpl creates and starts RAID array from member devices
+	CreateCmdTmpl = mdadmCmd + " --create %s --run --metadata=1.2 --level=%s --raid-devices=%d %s" // add device, level, count and members
+	// AssembleCmdTmpl starts previously created RAID array
+	AssembleCmdTmpl = mdadmCmd + " --assemble --run %s %s" // add device and members
+	// StopCmdTmpl stops RAID array, member devices are released
+	StopCmdTmpl = mdadmCmd + " --stop %s" // add device
+	// ZeroSuperblockCmdTmpl removes RAID metadata from the member device
+	ZeroSuperblockCmdTmpl = mdadmCmd + " --zero-superblock %s" // add member device
+	// DetailCmdTmpl prints detailed information about RAID array
+	DetailCmdTmpl = mdadmCmd + " --detail %s" // add device
+
+	// degradedState is a part of array state when one of redundant members is missing
+	degradedState = "degraded"
+	// failedState is a part of array state when array can't serve IO
+	failedState = "FAILED"
+	// inactiveState is a part of array state when array isn't running
+	inactiveState = "inactive"
+	// faultyMember is a state of the member device which was marked as failed
+	faultyMember = "faulty"
+	// activeMember is a state of the member device which serves IO of the array
+	activeMember = "active"
+)
+
+// ArrayState holds information about RAID array parsed from mdadm --detail output
+type ArrayState struct {
+	State          string
+	RaidDevices    int
+	ActiveDevices  int
+	FailedDevices  int
+	Degraded       bool
+	Failed         bool
+	FailedMembers  []string
+	ActiveMembers  []string
+	RebuildPercent int
+}
+
+// WrapMdadm is an interface that encapsulates operation with system mdadm util
+type WrapMdadm interface {
+	Create(device, level string, members []string) error
+	Assemble(device string, members []string) error
+	Stop(device string) error
+	ZeroSuperblock(member string) error
+	GetArrayState(device string) (*ArrayState, error)
+}
+
+// Mdadm is an implementation of WrapMdadm interface
+type Mdadm struct {
+	e   command.CmdExecutor
+	log *logrus.Entry
+}
+
+// NewMdadm is a constructor for Mdadm struct
+func NewMdadm(e command.CmdExecutor, log *logrus.Logger) *Mdadm {
+	return &Mdadm{
+		e:   e,
+		log: log.WithField("component", "Mdadm"),
+	}
+}
+
+// Create creates and starts RAID array, existing data on members is lost
+// Receives path of the array device, RAID level and paths of the member devices
+// Returns error if something went wrong
+func (m *Mdadm) Create(device, level string, members []string) error {
+	cmd := fmt.Sprintf(CreateCmdTmpl, device, level, len(members), strings.Join(members, " "))
+	_, stdErr, err := m.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(mdadmCmd+" --create"))
+	if err != nil {
+		return fmt.Errorf("unable to create array %s: %v, stderr: %s", device, err, stdErr)
+	}
+	return nil
+}
+
+// Assemble starts previously created RAID array, array is started even if some members are missing
+// Receives path of the array device and paths of the member devices
+// Returns error if something went wrong
+func (m *Mdadm) Assemble(device string, members []string) error {
+	cmd := fmt.Sprintf(AssembleCmdTmpl, device, strings.Join(members, " "))
+	_, stdErr, err := m.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(mdadmCmd+" --assemble"))
+	if err != nil {
+		return fmt.Errorf("unable to assemble array %s: %v, stderr: %s", device, err, stdErr)
+	}
+	return nil
+}
+
+// Stop stops RAID array
+// Receives path of the array device
+// Returns error if something went wrong
+func (m *Mdadm) Stop(device string) error {
+	_, _, err := m.e.RunCmd(fmt.Sprintf(StopCmdTmpl, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(StopCmdTmpl, ""))))
+	return err
+}
+
+// ZeroSuperblock removes RAID metadata from the member device
+// Receives path of the member device
+// Returns error if something went wrong
+func (m *Mdadm) ZeroSuperblock(member string) error {
+	_, _, err := m.e.RunCmd(fmt.Sprintf(ZeroSuperblockCmdTmpl, member),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(ZeroSuperblockCmdTmpl, ""))))
+	return err
+}
+
+// GetArrayState reads state of the RAID array
+// Receives path of the array device
+// Returns ArrayState or error if something went wrong
+func (m *Mdadm) GetArrayState(device string) (*ArrayState, error) {
+	/*
+		Example of output:
+			~# mdadm --detail /dev/md/csi-1
+			/dev/md/csi-1:
+			           Version : 1.2
+			        Raid Level : raid1
+			      Raid Devices : 2
+			             State : clean, degraded
+			    Active Devices : 1
+			    Failed Devices : 1
+			    Rebuild Status : 10% complete
+
+			    Number   Major   Minor   RaidDevice State
+			       0       8       16        0      active sync   /dev/sdb
+			       -       0        0        1      removed
+			       1       8       32        -      faulty   /dev/sdc
+	*/
+	stdout, stdErr, err := m.e.RunCmd(fmt.Sprintf(DetailCmdTmpl, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(DetailCmdTmpl, ""))))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get details of array %s: %v, stderr: %s", device, err, stdErr)
+	}
+
+	state := &ArrayState{}
+	for _, line := range strings.Split(stdout, "\n") {
+		fields := strings.Fields(line)
+		if util.ContainsString(fields, faultyMember) {
+			state.FailedMembers = append(state.FailedMembers, fields[len(fields)-1])
+			continue
+		}
+		if util.ContainsString(fields, activeMember) && strings.HasPrefix(fields[len(fields)-1], "/dev/") {
+			state.ActiveMembers = append(state.ActiveMembers, fields[len(fields)-1])
+			continue
+		}
+		kv := strings.SplitN(line, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(kv[1])
+		switch strings.TrimSpace(kv[0]) {
+		case "State":
+			state.State = value
+		case "Raid Devices":
+			state.RaidDevices, _ = strconv.Atoi(value)
+		case "Active Devices":
+			state.ActiveDevices, _ = strconv.Atoi(value)
+		case "Failed Devices":
+			state.FailedDevices, _ = strconv.Atoi(value)
+		case "Rebuild Status":
+			// e.g. "10% complete"
+			state.RebuildPercent, _ = strconv.Atoi(strings.SplitN(value, "%", 2)[0])
+		}
+	}
+	if state.State == "" {
+		return nil, fmt.Errorf("unable to determine state of array %s from output: %s", device, stdout)
+	}
+
+	state.Failed = strings.Contains(state.State, failedState) || strings.Contains(state.State, inactiveState)
+	state.Degraded = !state.Failed && (strings.Contains(state.State, degradedState) ||
+		state.ActiveDevices < state.RaidDevices)
+	return state, nil
+}
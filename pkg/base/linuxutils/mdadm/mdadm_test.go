@@ -0,0 +1,145 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mdadm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+var (
+	testLogger  = logrus.New()
+	testErr     = errors.New("error")
+	testDevice  = "/dev/md/csi-1"
+	testMembers = []string{"/dev/sdb", "/dev/sdc"}
+
+	detailTmpl = `/dev/md/csi-1:
+           Version : 1.2
+        Raid Level : raid1
+      Raid Devices : 2
+             State : %s
+    Active Devices : %d
+    Failed Devices : %d
+    Rebuild Status : 10%% complete
+
+    Number   Major   Minor   RaidDevice State
+       0       8       16        0      active sync   /dev/sdb
+%s`
+	detailClean    = fmt.Sprintf(detailTmpl, "clean", 2, 0, "       1       8       32        1      active sync   /dev/sdc\n")
+	detailDegraded = fmt.Sprintf(detailTmpl, "clean, degraded", 1, 1,
+		"       -       0        0        1      removed\n       1       8       32        -      faulty   /dev/sdc\n")
+	detailFailed = fmt.Sprintf(detailTmpl, "clean, FAILED", 1, 1, "")
+)
+
+func TestMdadm_Create(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		m   = NewMdadm(e, testLogger)
+		cmd = fmt.Sprintf(CreateCmdTmpl, testDevice, "raid1", 2, "/dev/sdb /dev/sdc")
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, m.Create(testDevice, "raid1", testMembers))
+
+	e.OnCommand(cmd).Return("", "device busy", testErr).Times(1)
+	assert.NotNil(t, m.Create(testDevice, "raid1", testMembers))
+}
+
+func TestMdadm_Assemble(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		m   = NewMdadm(e, testLogger)
+		cmd = fmt.Sprintf(AssembleCmdTmpl, testDevice, "/dev/sdb /dev/sdc")
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, m.Assemble(testDevice, testMembers))
+
+	e.OnCommand(cmd).Return("", "", testErr).Times(1)
+	assert.NotNil(t, m.Assemble(testDevice, testMembers))
+}
+
+func TestMdadm_Stop(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		m   = NewMdadm(e, testLogger)
+		cmd = fmt.Sprintf(StopCmdTmpl, testDevice)
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, m.Stop(testDevice))
+
+	e.OnCommand(cmd).Return("", "", testErr).Times(1)
+	assert.Equal(t, testErr, m.Stop(testDevice))
+}
+
+func TestMdadm_ZeroSuperblock(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		m   = NewMdadm(e, testLogger)
+		cmd = fmt.Sprintf(ZeroSuperblockCmdTmpl, testMembers[0])
+	)
+
+	e.OnCommand(cmd).Return("", "", nil).Times(1)
+	assert.Nil(t, m.ZeroSuperblock(testMembers[0]))
+
+	e.OnCommand(cmd).Return("", "", testErr).Times(1)
+	assert.Equal(t, testErr, m.ZeroSuperblock(testMembers[0]))
+}
+
+func TestMdadm_GetArrayState(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		m   = NewMdadm(e, testLogger)
+		cmd = fmt.Sprintf(DetailCmdTmpl, testDevice)
+	)
+
+	e.OnCommand(cmd).Return(detailClean, "", nil).Times(1)
+	state, err := m.GetArrayState(testDevice)
+	assert.Nil(t, err)
+	assert.Equal(t, &ArrayState{State: "clean", RaidDevices: 2, ActiveDevices: 2, RebuildPercent: 10,
+		ActiveMembers: []string{"/dev/sdb", "/dev/sdc"}}, state)
+
+	e.OnCommand(cmd).Return(detailDegraded, "", nil).Times(1)
+	state, err = m.GetArrayState(testDevice)
+	assert.Nil(t, err)
+	assert.True(t, state.Degraded)
+	assert.False(t, state.Failed)
+	assert.Equal(t, 1, state.FailedDevices)
+	assert.Equal(t, []string{"/dev/sdc"}, state.FailedMembers)
+	assert.Equal(t, []string{"/dev/sdb"}, state.ActiveMembers)
+
+	e.OnCommand(cmd).Return(detailFailed, "", nil).Times(1)
+	state, err = m.GetArrayState(testDevice)
+	assert.Nil(t, err)
+	assert.True(t, state.Failed)
+	assert.False(t, state.Degraded)
+
+	e.OnCommand(cmd).Return("unexpected output", "", nil).Times(1)
+	_, err = m.GetArrayState(testDevice)
+	assert.NotNil(t, err)
+
+	e.OnCommand(cmd).Return("", "cannot open", testErr).Times(1)
+	_, err = m.GetArrayState(testDevice)
+	assert.NotNil(t, err)
+}
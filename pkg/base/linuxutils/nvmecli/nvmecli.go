@@ -19,6 +19,8 @@ package nvmecli
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -36,13 +38,45 @@ const (
 	NVMeHealthCmdImpl = NVMCliCmdImpl + " smart-log %s --output-format=json"
 	// NVMeVendorCmdImpl is a CMD to get SMART information about NVMe device in JSON format
 	NVMeVendorCmdImpl = NVMCliCmdImpl + " id-ctrl %s --output-format=json"
+	// NVMeFormatCmdTmpl is a CMD to format NVMe namespace with user data erase
+	NVMeFormatCmdTmpl = NVMCliCmdImpl + " format %s --ses=1"
+	// NVMeSanitizeCmdTmpl is a CMD to start block erase sanitize operation on NVMe device
+	NVMeSanitizeCmdTmpl = NVMCliCmdImpl + " sanitize %s --sanact=2"
+	// NVMeSanitizeLogCmdTmpl is a CMD to get status of sanitize operation in JSON format
+	NVMeSanitizeLogCmdTmpl = NVMCliCmdImpl + " sanitize-log %s --output-format=json"
 	// DevicesKey is the key to find NVMe devices in nvme json output
 	DevicesKey = "Devices"
+
+	// sanitize status codes, bits 2:0 of SSTAT field
+	sanitizeStatusMask       = 0x7
+	sanitizeStatusInProgress = 0x2
+	sanitizeStatusFailed     = 0x3
+	// maxSanitizeProgress is a value of SPROG field which means that sanitize is finished
+	maxSanitizeProgress = 65535
+
+	// kelvinOffset is used to convert temperature reported in Kelvins to Celsius
+	kelvinOffset = 273
+)
+
+// Names of the NVMe SMART counters which could be checked by drive health policy
+const (
+	PercentageUsed   = "percentage_used"
+	DataUnitsWritten = "data_units_written"
+	MediaErrors      = "media_errors"
+	UnsafeShutdowns  = "unsafe_shutdowns"
+	Temperature      = "temperature"
 )
 
+// Counters is the list of all supported NVMe SMART counters
+var Counters = []string{PercentageUsed, DataUnitsWritten, MediaErrors, UnsafeShutdowns, Temperature}
+
 // WrapNvmecli is an interface that encapsulates operation with system nvme util
 type WrapNvmecli interface {
 	GetNVMDevices() ([]NVMDevice, error)
+	GetNVMDevice(path string) (*NVMDevice, error)
+	Format(device string) error
+	Sanitize(device string) error
+	GetSanitizeStatus(device string) (*SanitizeStatus, error)
 }
 
 // NVMDevice represents devices from nvme list output
@@ -55,11 +89,89 @@ type NVMDevice struct {
 	// Can VID be string for nvme?
 	Vendor int `json:"vid,omitempty"`
 	Health string
+	// nil if SMART log isn't available
+	SMARTLog *SMARTLog `json:"-"`
 }
 
 // SMARTLog represents SMART information for NVMe devices
 type SMARTLog struct {
 	CriticalWarning int `json:"critical_warning,omitempty"`
+	// composite temperature in Kelvins
+	Temperature    Counter `json:"temperature"`
+	AvailableSpare Counter `json:"avail_spare"`
+	SpareThreshold Counter `json:"spare_thresh"`
+	// estimate of used life in percent, could exceed 100
+	PercentageUsed Counter `json:"percent_used"`
+	// number of 512 bytes data units written in thousands
+	DataUnitsWritten Counter `json:"data_units_written"`
+	MediaErrors      Counter `json:"media_errors"`
+	UnsafeShutdowns  Counter `json:"unsafe_shutdowns"`
+	PowerOnHours     Counter `json:"power_on_hours"`
+}
+
+// Counter is a SMART log value, 128 bit values are printed by nvme-cli as float or string
+// and are saturated to math.MaxInt64
+type Counter int64
+
+// UnmarshalJSON parses number or quoted number
+func (c *Counter) UnmarshalJSON(data []byte) error {
+	str := strings.Trim(strings.TrimSpace(string(data)), `"`)
+	if str == "" || str == "null" {
+		*c = 0
+		return nil
+	}
+	if value, err := strconv.ParseInt(str, 10, 64); err == nil {
+		*c = Counter(value)
+		return nil
+	}
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil {
+		return fmt.Errorf("unable to parse SMART log value %s: %v", str, err)
+	}
+	if value >= math.MaxInt64 {
+		*c = math.MaxInt64
+	} else {
+		*c = Counter(value)
+	}
+	return nil
+}
+
+// Endurance returns remaining rated endurance of the device in percent
+func (l *SMARTLog) Endurance() int64 {
+	if l.PercentageUsed >= 100 {
+		return 0
+	}
+	return int64(100 - l.PercentageUsed)
+}
+
+// TemperatureCelsius returns composite temperature of the device in Celsius
+func (l *SMARTLog) TemperatureCelsius() int64 {
+	return int64(l.Temperature) - kelvinOffset
+}
+
+// Counters returns values of SMART counters, temperature is converted to Celsius
+func (l *SMARTLog) Counters() map[string]int64 {
+	return map[string]int64{
+		PercentageUsed:   int64(l.PercentageUsed),
+		DataUnitsWritten: int64(l.DataUnitsWritten),
+		MediaErrors:      int64(l.MediaErrors),
+		UnsafeShutdowns:  int64(l.UnsafeShutdowns),
+		Temperature:      l.TemperatureCelsius(),
+	}
+}
+
+// sanitizeLog represents sanitize log of NVMe device
+type sanitizeLog struct {
+	Progress int `json:"sprog"`
+	Status   int `json:"sstat"`
+}
+
+// SanitizeStatus represents state of the last sanitize operation
+type SanitizeStatus struct {
+	// percentage of completion of the sanitize operation
+	Progress   int32
+	InProgress bool
+	Failed     bool
 }
 
 // NVMECLI is a wrap for system nvem_cli util
@@ -75,7 +187,38 @@ func NewNVMECLI(e command.CmdExecutor, logger *logrus.Logger) *NVMECLI {
 
 // GetNVMDevices gets information about NVMDevice using nvme_cli util
 func (na *NVMECLI) GetNVMDevices() ([]NVMDevice, error) {
-	ll := na.log.WithField("method", "GetNVMDevices")
+	devs, err := na.listNVMDevices()
+	if err != nil {
+		return nil, err
+	}
+	for i, d := range devs {
+		devs[i].Health, devs[i].SMARTLog = na.getNVMDeviceHealth(d.DevicePath)
+		na.fillNVMDeviceVendor(&devs[i])
+	}
+	return devs, nil
+}
+
+// GetNVMDevice gets information about NVMDevice with provided path, e.g. /dev/nvme0n1, using nvme_cli util
+// SMART log is read for this device only, returns nil if nvme list doesn't contain the device
+func (na *NVMECLI) GetNVMDevice(path string) (*NVMDevice, error) {
+	devs, err := na.listNVMDevices()
+	if err != nil {
+		return nil, err
+	}
+	for i := range devs {
+		if devs[i].DevicePath != path {
+			continue
+		}
+		devs[i].Health, devs[i].SMARTLog = na.getNVMDeviceHealth(path)
+		na.fillNVMDeviceVendor(&devs[i])
+		return &devs[i], nil
+	}
+	return nil, nil
+}
+
+// listNVMDevices returns devices from nvme list output without health and vendor
+func (na *NVMECLI) listNVMDevices() ([]NVMDevice, error) {
+	ll := na.log.WithField("method", "listNVMDevices")
 	strOut, _, err := na.e.RunCmd(NVMeDeviceCmdImpl,
 		command.UseMetrics(true),
 		command.CmdName(NVMeDeviceCmdImpl))
@@ -95,15 +238,69 @@ func (na *NVMECLI) GetNVMDevices() ([]NVMDevice, error) {
 		ll.Errorf("key \"%s\" is not in map %v", DevicesKey, rawOut)
 		return nil, fmt.Errorf("unexpected nvme list output format")
 	}
-	for i, d := range devs {
-		devs[i].Health = na.getNVMDeviceHealth(d.DevicePath)
-		na.fillNVMDeviceVendor(&devs[i])
-	}
 	return devs, nil
 }
 
+// Format formats NVMe namespace with user data erase, all data on the device is lost
+// Receives path of the NVMe device
+// Returns error if something went wrong
+func (na *NVMECLI) Format(device string) error {
+	_, _, err := na.e.RunCmd(fmt.Sprintf(NVMeFormatCmdTmpl, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(NVMeFormatCmdTmpl, ""))))
+	return err
+}
+
+// Sanitize starts block erase sanitize operation, operation is performed by the device in background
+// and its state could be checked with GetSanitizeStatus
+// Receives path of the NVMe device
+// Returns error if operation wasn't started
+func (na *NVMECLI) Sanitize(device string) error {
+	_, _, err := na.e.RunCmd(fmt.Sprintf(NVMeSanitizeCmdTmpl, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(NVMeSanitizeCmdTmpl, ""))))
+	return err
+}
+
+// GetSanitizeStatus reads sanitize log of the device and returns state of the last sanitize operation
+// Receives path of the NVMe device
+// Returns SanitizeStatus or error if something went wrong
+func (na *NVMECLI) GetSanitizeStatus(device string) (*SanitizeStatus, error) {
+	strOut, _, err := na.e.RunCmd(fmt.Sprintf(NVMeSanitizeLogCmdTmpl, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(NVMeSanitizeLogCmdTmpl, ""))))
+	if err != nil {
+		return nil, err
+	}
+
+	// depending on nvme-cli version log fields are placed on the top level or under the device name
+	rawOut := make(map[string]json.RawMessage)
+	if err = json.Unmarshal([]byte(strOut), &rawOut); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal sanitize log: %v", err)
+	}
+	logData := []byte(strOut)
+	if _, ok := rawOut["sprog"]; !ok {
+		for _, data := range rawOut {
+			logData = data
+			break
+		}
+	}
+	sLog := &sanitizeLog{}
+	if err = json.Unmarshal(logData, sLog); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal sanitize log: %v", err)
+	}
+
+	status := &SanitizeStatus{
+		Progress:   int32(sLog.Progress * 100 / maxSanitizeProgress),
+		InProgress: sLog.Status&sanitizeStatusMask == sanitizeStatusInProgress,
+		Failed:     sLog.Status&sanitizeStatusMask == sanitizeStatusFailed,
+	}
+	return status, nil
+}
+
 // getNVMDeviceHealth gets information about device health based on critical_warning SMART attribute using nvme_cli smart-log util
-func (na *NVMECLI) getNVMDeviceHealth(path string) string {
+// Returns health and parsed SMART log, SMART log is nil if it isn't available
+func (na *NVMECLI) getNVMDeviceHealth(path string) (string, *SMARTLog) {
 	ll := na.log.WithField("method", "getNVMDeviceHealth")
 	cmd := fmt.Sprintf(NVMeHealthCmdImpl, path)
 	strOut, _, err := na.e.RunCmd(cmd,
@@ -111,22 +308,22 @@ func (na *NVMECLI) getNVMDeviceHealth(path string) string {
 		command.CmdName(strings.TrimSpace(fmt.Sprintf(NVMeHealthCmdImpl, ""))))
 	if err != nil {
 		ll.Errorf("%s failed, set health as %s", cmd, apiV1.HealthUnknown)
-		return apiV1.HealthUnknown
+		return apiV1.HealthUnknown, nil
 	}
 	smartLog := &SMARTLog{}
 	err = json.Unmarshal([]byte(strOut), &smartLog)
 	if err != nil {
-		ll.Errorf("unable to unmarshal output to SMARTLog, set health as %s", apiV1.HealthUnknown)
-		return apiV1.HealthUnknown
+		ll.Errorf("unable to unmarshal output to SMARTLog: %v, set health as %s", err, apiV1.HealthUnknown)
+		return apiV1.HealthUnknown, nil
 	}
 	health := smartLog.CriticalWarning
 	if na.isOneOfBitsSet(uint64(health), 0, 3) {
-		return apiV1.HealthSuspect
+		return apiV1.HealthSuspect, smartLog
 	}
 	if na.isOneOfBitsSet(uint64(health), 2, 4, 5) {
-		return apiV1.HealthBad
+		return apiV1.HealthBad, smartLog
 	}
-	return apiV1.HealthGood
+	return apiV1.HealthGood, smartLog
 }
 
 // fillNVMDeviceVendor gets information about device vendor id
@@ -18,6 +18,7 @@ package nvmecli
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -161,9 +162,43 @@ func TestNVMECLI_getNVMDeviceHealthBad(t *testing.T) {
 	}
 	`
 	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return(health, "", nil)
-	deviceHealth := l.getNVMDeviceHealth(testPath)
+	deviceHealth, _ := l.getNVMDeviceHealth(testPath)
 	assert.Equal(t, apiV1.HealthBad, deviceHealth)
 }
+
+func TestNVMECLI_getNVMDeviceHealthSMARTLog(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	l := NewNVMECLI(e, testLogger)
+	// 128 bit counters could be printed as float numbers or strings
+	health := `{
+  		"critical_warning" : 0,
+  		"temperature" : 310,
+  		"avail_spare" : 100,
+  		"spare_thresh" : 10,
+  		"percent_used" : 7,
+  		"data_units_read" : 1.2e3,
+  		"data_units_written" : 5.5e+30,
+  		"media_errors" : "3",
+  		"unsafe_shutdowns" : 42
+	}
+	`
+	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return(health, "", nil)
+	deviceHealth, smartLog := l.getNVMDeviceHealth(testPath)
+	assert.Equal(t, apiV1.HealthGood, deviceHealth)
+	assert.NotNil(t, smartLog)
+	assert.Equal(t, int64(93), smartLog.Endurance())
+	assert.Equal(t, map[string]int64{
+		PercentageUsed:   7,
+		DataUnitsWritten: math.MaxInt64,
+		MediaErrors:      3,
+		UnsafeShutdowns:  42,
+		Temperature:      37,
+	}, smartLog.Counters())
+
+	smartLog.PercentageUsed = 255
+	assert.Equal(t, int64(0), smartLog.Endurance())
+}
+
 func TestNVMECLI_getNVMDeviceHealthSuspect(t *testing.T) {
 	e := &mocks.GoMockExecutor{}
 	l := NewNVMECLI(e, testLogger)
@@ -172,7 +207,7 @@ func TestNVMECLI_getNVMDeviceHealthSuspect(t *testing.T) {
 	}
 	`
 	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return(health, "", nil)
-	deviceHealth := l.getNVMDeviceHealth(testPath)
+	deviceHealth, _ := l.getNVMDeviceHealth(testPath)
 	assert.Equal(t, apiV1.HealthSuspect, deviceHealth)
 }
 
@@ -184,7 +219,7 @@ func TestNVMECLI_getNVMDeviceHealthGood(t *testing.T) {
 	}
 	`
 	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return(health, "", nil)
-	deviceHealth := l.getNVMDeviceHealth(testPath)
+	deviceHealth, _ := l.getNVMDeviceHealth(testPath)
 	assert.Equal(t, apiV1.HealthGood, deviceHealth)
 }
 
@@ -197,7 +232,7 @@ func TestNVMECLI_getNVMDeviceHealthUnmarshallError(t *testing.T) {
 	}
 	`
 	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return(health, "", nil)
-	deviceHealth := l.getNVMDeviceHealth(testPath)
+	deviceHealth, _ := l.getNVMDeviceHealth(testPath)
 	assert.Equal(t, apiV1.HealthUnknown, deviceHealth)
 }
 
@@ -205,8 +240,9 @@ func TestNVMECLI_getNVMDeviceHealthCMDError(t *testing.T) {
 	e := &mocks.GoMockExecutor{}
 	l := NewNVMECLI(e, testLogger)
 	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return("", "", fmt.Errorf("error"))
-	deviceHealth := l.getNVMDeviceHealth(testPath)
+	deviceHealth, smartLog := l.getNVMDeviceHealth(testPath)
 	assert.Equal(t, apiV1.HealthUnknown, deviceHealth)
+	assert.Nil(t, smartLog)
 }
 
 func TestNVMECLI_getNVMDeviceVendorFail(t *testing.T) {
@@ -245,3 +281,78 @@ func TestNVMECLI_isOneOfBitsSet(t *testing.T) {
 	set = l.isOneOfBitsSet(5, 64)
 	assert.False(t, set)
 }
+
+func TestNVMECLI_Format(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	e.OnCommand(fmt.Sprintf(NVMeFormatCmdTmpl, testPath)).Return("", "", nil).Times(1)
+	cli := NewNVMECLI(e, testLogger)
+	assert.Nil(t, cli.Format(testPath))
+
+	e.OnCommand(fmt.Sprintf(NVMeFormatCmdTmpl, testPath)).Return("", "", fmt.Errorf("error")).Times(1)
+	assert.NotNil(t, cli.Format(testPath))
+}
+
+func TestNVMECLI_Sanitize(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	e.OnCommand(fmt.Sprintf(NVMeSanitizeCmdTmpl, testPath)).Return("", "", nil).Times(1)
+	cli := NewNVMECLI(e, testLogger)
+	assert.Nil(t, cli.Sanitize(testPath))
+}
+
+func TestNVMECLI_GetSanitizeStatus(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		cli = NewNVMECLI(e, testLogger)
+		cmd = fmt.Sprintf(NVMeSanitizeLogCmdTmpl, testPath)
+	)
+
+	// in progress, fields are placed on the top level
+	e.OnCommand(cmd).Return(`{"sprog" : 32767, "sstat" : 2, "cdw10_info" : 0}`, "", nil).Times(1)
+	status, err := cli.GetSanitizeStatus(testPath)
+	assert.Nil(t, err)
+	assert.Equal(t, &SanitizeStatus{Progress: 49, InProgress: true}, status)
+
+	// completed, fields are placed under the device name
+	e.OnCommand(cmd).Return(`{"nvme9" : {"sprog" : 65535, "sstat" : 257}}`, "", nil).Times(1)
+	status, err = cli.GetSanitizeStatus(testPath)
+	assert.Nil(t, err)
+	assert.Equal(t, &SanitizeStatus{Progress: 100}, status)
+
+	// failed
+	e.OnCommand(cmd).Return(`{"sprog" : 0, "sstat" : 3}`, "", nil).Times(1)
+	status, err = cli.GetSanitizeStatus(testPath)
+	assert.Nil(t, err)
+	assert.True(t, status.Failed)
+
+	e.OnCommand(cmd).Return("not json", "", nil).Times(1)
+	_, err = cli.GetSanitizeStatus(testPath)
+	assert.NotNil(t, err)
+
+	e.OnCommand(cmd).Return("", "", fmt.Errorf("error")).Times(1)
+	_, err = cli.GetSanitizeStatus(testPath)
+	assert.NotNil(t, err)
+}
+
+func TestNVMECLI_GetNVMDevice(t *testing.T) {
+	output := `{"Devices" : [
+		{"DevicePath" : "/dev/nvme0n1", "SerialNumber" : "SN0", "ModelNumber" : "model"},
+		{"DevicePath" : "/dev/nvme9n1", "SerialNumber" : "SN9", "ModelNumber" : "model"}
+	]}`
+	e := &mocks.GoMockExecutor{}
+	l := NewNVMECLI(e, testLogger)
+
+	e.On("RunCmd", NVMeDeviceCmdImpl).Return(output, "", nil)
+	// SMART log and vendor are read for requested device only
+	e.On("RunCmd", fmt.Sprintf(NVMeHealthCmdImpl, testPath)).Return(`{"critical_warning" : 4}`, "", nil)
+	e.On("RunCmd", fmt.Sprintf(NVMeVendorCmdImpl, testPath)).Return(`{"vid" : 32902}`, "", nil)
+
+	device, err := l.GetNVMDevice(testPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "SN9", device.SerialNumber)
+	assert.Equal(t, apiV1.HealthBad, device.Health)
+	assert.Equal(t, 32902, device.Vendor)
+
+	device, err = l.GetNVMDevice("/dev/nvme1n1")
+	assert.Nil(t, err)
+	assert.Nil(t, device)
+}
@@ -42,6 +42,7 @@ type WrapPartition interface {
 	GetPartitionNameByUUID(device, partUUID string) (string, error)
 	DeviceHasPartitionTable(device string) (bool, error)
 	DeviceHasPartitions(device string) (bool, error)
+	GrowPartition(device, partNum string) error
 }
 
 const (
@@ -55,6 +56,8 @@ const (
 	fdisk = "fdisk "
 	// blockdev is a name of system util
 	blockdev = "blockdev "
+	// growpart is a name of system util
+	growpart = "growpart "
 
 	// PartprobeDeviceCmdTmpl check that device has partition cmd
 	PartprobeDeviceCmdTmpl = partprobe + "-d -s %s"
@@ -76,6 +79,11 @@ const (
 
 	// GetPartitionUUIDCmdTmpl command for read GUID of the first partition, fill device and part number
 	GetPartitionUUIDCmdTmpl = sgdisk + "%s --info=%s"
+
+	// GrowPartitionCmdTmpl extends partition up to the end of the device, fill device and partition number
+	GrowPartitionCmdTmpl = growpart + "%s %s"
+	// growPartitionNoChange is printed by growpart when partition already occupies all available space
+	growPartitionNoChange = "NOCHANGE"
 )
 
 // supportedTypes list of supported partition table types
@@ -213,6 +221,28 @@ func (p *WrapPartitionImpl) DeletePartition(device, partNum string) error {
 	return nil
 }
 
+// GrowPartition extends partition partNum of a provided device up to the end of the device,
+// partition could be in use during this operation
+// Receives device path and number of partition which should be extended
+// Returns error if something went wrong
+func (p *WrapPartitionImpl) GrowPartition(device, partNum string) error {
+	cmd := fmt.Sprintf(GrowPartitionCmdTmpl, device, partNum)
+
+	p.opMutex.Lock()
+	stdout, stderr, err := p.e.RunCmd(cmd,
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(GrowPartitionCmdTmpl, "", ""))))
+	p.opMutex.Unlock()
+
+	// growpart exits with non-zero code when there is no space to extend partition
+	if err != nil && !strings.Contains(stdout, growPartitionNoChange) {
+		return fmt.Errorf("unable to grow partition %#v on device %s: %s, error: %v",
+			partNum, device, stderr, err)
+	}
+
+	return nil
+}
+
 // GetPartitionUUID reads partition unique GUID from the partition partNum of a provided device
 // Receives device path from which to read
 // Returns unique GUID as a string or error if something went wrong
@@ -101,6 +101,24 @@ func TestDeletePartitionFail(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestGrowPartition(t *testing.T) {
+	var (
+		e   = &mocks.GoMockExecutor{}
+		ph  = NewWrapPartitionImpl(e, testLogger)
+		cmd = fmt.Sprintf(GrowPartitionCmdTmpl, "/dev/sda", testPartNum)
+	)
+
+	e.OnCommand(cmd).Return("CHANGED: partition=1", "", nil).Times(1)
+	assert.Nil(t, ph.GrowPartition("/dev/sda", testPartNum))
+
+	// partition already occupies the whole device
+	e.OnCommand(cmd).Return("NOCHANGE: partition 1 could only be grown by 0", "", errors.New("exit status 1")).Times(1)
+	assert.Nil(t, ph.GrowPartition("/dev/sda", testPartNum))
+
+	e.OnCommand(cmd).Return("", "error", errors.New("exit status 2")).Times(1)
+	assert.NotNil(t, ph.GrowPartition("/dev/sda", testPartNum))
+}
+
 func TestGetPartitionUUID(t *testing.T) {
 	uuid, err := testPartitioner.GetPartitionUUID("/dev/sda", testPartNum)
 	assert.Equal(t, "64be631b-62a5-11e9-a756-00505680d67f", uuid)
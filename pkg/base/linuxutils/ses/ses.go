@@ -0,0 +1,189 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ses contains code for locating drives in SCSI enclosures using sysfs enclosure class and system ledctl util
+package ses
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/command"
+)
+
+const (
+	ledctlCmd = "ledctl"
+	// LedctlLocateCmdTmpl turns on locate LED of the device with ledctl
+	LedctlLocateCmdTmpl = ledctlCmd + " locate=%s" // add device path
+	// LedctlLocateOffCmdTmpl turns off locate LED of the device with ledctl
+	LedctlLocateOffCmdTmpl = ledctlCmd + " locate_off=%s" // add device path
+
+	// DefaultSysfsRoot is the root of sysfs on the node
+	DefaultSysfsRoot = "/sys"
+	// enclosureClassDir contains enclosures registered by ses kernel driver relative to sysfs root,
+	// each enclosure contains directories of its components (slots) with device link and locate attribute
+	enclosureClassDir = "class/enclosure"
+
+	locateOn  = "1"
+	locateOff = "0"
+)
+
+// Slot is a location of the drive in SCSI enclosure
+type Slot struct {
+	// logical identifier of the enclosure, e.g. SAS address, or its SCSI address if identifier isn't reported
+	Enclosure string
+	// slot number
+	Slot string
+	// name of the enclosure component, e.g. "Slot 01"
+	Bay string
+	// sysfs directory of the component
+	dir string
+}
+
+// WrapSES is an interface that encapsulates operations with drive location in enclosure
+type WrapSES interface {
+	GetSlot(device string) (*Slot, error)
+	Locate(device string, on bool) error
+	LocateStatus(device string) (int32, error)
+}
+
+// SES is an implementation of WrapSES interface, it uses sysfs enclosure class
+// and falls back to ledctl util for the drives which aren't found there, e.g. NVMe drives behind VMD
+type SES struct {
+	e         command.CmdExecutor
+	log       *logrus.Entry
+	sysfsRoot string
+}
+
+// NewSES is a constructor for SES struct
+func NewSES(e command.CmdExecutor, log *logrus.Logger) *SES {
+	return &SES{
+		e:         e,
+		log:       log.WithField("component", "SES"),
+		sysfsRoot: DefaultSysfsRoot,
+	}
+}
+
+// GetSlot returns slot of the enclosure where device (e.g. /dev/sda) is inserted
+// Returns nil if device isn't found in enclosures
+func (s *SES) GetSlot(device string) (*Slot, error) {
+	name := filepath.Base(device)
+	// <enclosure>/<component>/device/block/<name>
+	matches, err := filepath.Glob(filepath.Join(s.sysfsRoot, enclosureClassDir, "*", "*", "device", "block", name))
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, nil
+	}
+	componentDir := filepath.Dir(filepath.Dir(filepath.Dir(matches[0])))
+	enclosureDir := filepath.Dir(componentDir)
+
+	slot := &Slot{
+		Enclosure: readAttr(enclosureDir, "id"),
+		Slot:      readAttr(componentDir, "slot"),
+		Bay:       filepath.Base(componentDir),
+		dir:       componentDir,
+	}
+	if slot.Enclosure == "" {
+		slot.Enclosure = filepath.Base(enclosureDir)
+	}
+	if slot.Slot == "" {
+		slot.Slot = slot.Bay
+	}
+	return slot, nil
+}
+
+// Locate turns on or off locate LED of the enclosure slot where device is inserted,
+// ledctl is used if device isn't found in enclosures or enclosure doesn't support locate
+func (s *SES) Locate(device string, on bool) error {
+	ll := s.log.WithFields(logrus.Fields{
+		"method": "Locate",
+		"device": device,
+	})
+
+	slot, err := s.GetSlot(device)
+	if err != nil {
+		ll.Warnf("Unable to find enclosure slot: %v", err)
+	}
+	if slot != nil {
+		value := locateOff
+		if on {
+			value = locateOn
+		}
+		if err = writeAttr(slot.dir, "locate", value); err == nil {
+			return nil
+		}
+		ll.Warnf("Unable to set locate of %s: %v, trying %s", slot.dir, err, ledctlCmd)
+	}
+
+	cmdTmpl := LedctlLocateOffCmdTmpl
+	if on {
+		cmdTmpl = LedctlLocateCmdTmpl
+	}
+	if _, stderr, err := s.e.RunCmd(fmt.Sprintf(cmdTmpl, device),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(cmdTmpl, "")))); err != nil {
+		return fmt.Errorf("unable to change locate LED of %s: %v, stderr: %s", device, err, stderr)
+	}
+	return nil
+}
+
+// LocateStatus returns status of locate LED of the enclosure slot where device is inserted,
+// status isn't available for the devices which aren't found in enclosures, ledctl doesn't report it
+func (s *SES) LocateStatus(device string) (int32, error) {
+	slot, err := s.GetSlot(device)
+	if err != nil {
+		return -1, err
+	}
+	if slot == nil {
+		return apiV1.LocateStatusNotAvailable, nil
+	}
+	switch readAttr(slot.dir, "locate") {
+	case locateOn:
+		return apiV1.LocateStatusOn, nil
+	case locateOff:
+		return apiV1.LocateStatusOff, nil
+	default:
+		return apiV1.LocateStatusNotAvailable, nil
+	}
+}
+
+// readAttr returns trimmed value of sysfs attribute or empty string if it can't be read
+func readAttr(dir, attr string) string {
+	data, err := ioutil.ReadFile(filepath.Join(dir, attr))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+func writeAttr(dir, attr, value string) error {
+	// attribute isn't created if it doesn't exist
+	f, err := os.OpenFile(filepath.Join(dir, attr), os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(value)
+	return err
+}
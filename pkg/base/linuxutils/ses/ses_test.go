@@ -0,0 +1,101 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ses
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+var testLogger = logrus.New()
+
+// prepareSysfs creates enclosure with id and two slots, sda is in "Slot 01" which has slot attribute,
+// sdb is in "Slot 02" without slot and locate attributes
+func prepareSysfs(t *testing.T) (root string, slotDir string) {
+	root = t.TempDir()
+	enclosureDir := filepath.Join(root, enclosureClassDir, "0:0:8:0")
+	slotDir = filepath.Join(enclosureDir, "Slot 01")
+	assert.Nil(t, os.MkdirAll(filepath.Join(slotDir, "device", "block", "sda"), 0755))
+	assert.Nil(t, os.MkdirAll(filepath.Join(enclosureDir, "Slot 02", "device", "block", "sdb"), 0755))
+	assert.Nil(t, os.WriteFile(filepath.Join(enclosureDir, "id"), []byte("0x500056b36789abff\n"), 0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(slotDir, "slot"), []byte("1\n"), 0644))
+	assert.Nil(t, os.WriteFile(filepath.Join(slotDir, "locate"), []byte("0\n"), 0644))
+	return root, slotDir
+}
+
+func TestSES_GetSlot(t *testing.T) {
+	s := NewSES(&mocks.GoMockExecutor{}, testLogger)
+	s.sysfsRoot, _ = prepareSysfs(t)
+
+	slot, err := s.GetSlot("/dev/sda")
+	assert.Nil(t, err)
+	assert.Equal(t, "0x500056b36789abff", slot.Enclosure)
+	assert.Equal(t, "1", slot.Slot)
+	assert.Equal(t, "Slot 01", slot.Bay)
+
+	slot, err = s.GetSlot("/dev/sdb")
+	assert.Nil(t, err)
+	assert.Equal(t, "Slot 02", slot.Slot)
+
+	slot, err = s.GetSlot("/dev/nvme0n1")
+	assert.Nil(t, err)
+	assert.Nil(t, slot)
+}
+
+func TestSES_Locate(t *testing.T) {
+	e := &mocks.GoMockExecutor{}
+	s := NewSES(e, testLogger)
+	var slotDir string
+	s.sysfsRoot, slotDir = prepareSysfs(t)
+
+	// enclosure slot
+	assert.Nil(t, s.Locate("/dev/sda", true))
+	status, err := s.LocateStatus("/dev/sda")
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOn, status)
+	assert.Nil(t, s.Locate("/dev/sda", false))
+	data, err := os.ReadFile(filepath.Join(slotDir, "locate"))
+	assert.Nil(t, err)
+	assert.Equal(t, "0", string(data))
+
+	// slot doesn't support locate, ledctl is used
+	e.On(mocks.RunCmd, fmt.Sprintf(LedctlLocateCmdTmpl, "/dev/sdb")).Return("", "", nil).Once()
+	assert.Nil(t, s.Locate("/dev/sdb", true))
+	status, err = s.LocateStatus("/dev/sdb")
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusNotAvailable, status)
+
+	// device isn't in enclosure
+	e.On(mocks.RunCmd, fmt.Sprintf(LedctlLocateOffCmdTmpl, "/dev/nvme0n1")).Return("", "", nil).Once()
+	assert.Nil(t, s.Locate("/dev/nvme0n1", false))
+	e.On(mocks.RunCmd, fmt.Sprintf(LedctlLocateCmdTmpl, "/dev/nvme0n1")).
+		Return("", "ledctl: unsupported", errors.New("error")).Once()
+	assert.NotNil(t, s.Locate("/dev/nvme0n1", true))
+	status, err = s.LocateStatus("/dev/nvme0n1")
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusNotAvailable, status)
+	e.AssertExpectations(t)
+}
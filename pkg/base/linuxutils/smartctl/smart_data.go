@@ -0,0 +1,189 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package smartctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dell/csi-baremetal/pkg/base/command"
+)
+
+// SmartctlAllCmdImpl is a CMD to get SMART attributes, error and self-test logs of device in JSON format
+const SmartctlAllCmdImpl = SmartctlCmdImpl + " --all --json %s"
+
+// Names of the SMART counters which are calculated from attributes and logs of ATA and SCSI devices
+const (
+	ReallocatedSectors   = "reallocated_sectors"
+	PendingSectors       = "pending_sectors"
+	UncorrectableSectors = "uncorrectable_sectors"
+	CRCErrors            = "crc_errors"
+	ErrorLogCount        = "error_log_count"
+	SelfTestFailures     = "self_test_failures"
+)
+
+// IDs of ATA SMART attributes
+const (
+	ataReallocatedSectorCt  = 5
+	ataCurrentPendingSector = 197
+	ataOfflineUncorrectable = 198
+	ataUDMACRCErrorCount    = 199
+
+	// vendors could use high bytes of raw value for other information
+	ataRawCounterMask = 0xFFFFFFFF
+)
+
+// Counters is the list of all supported SMART counters
+var Counters = []string{
+	ReallocatedSectors, PendingSectors, UncorrectableSectors, CRCErrors, ErrorLogCount, SelfTestFailures,
+}
+
+// DeviceSMARTData represents SMART attributes, error and self-test logs of device
+type DeviceSMARTData struct {
+	ATAAttributes       ATAAttributes               `json:"ata_smart_attributes"`
+	ATAErrorLog         ATAErrorLog                 `json:"ata_smart_error_log"`
+	ATASelfTestLog      ATASelfTestLog              `json:"ata_smart_self_test_log"`
+	SCSIGrownDefectList *int64                      `json:"scsi_grown_defect_list"`
+	SCSIErrorCounterLog map[string]SCSIErrorCounter `json:"scsi_error_counter_log"`
+	// current temperature in Celsius, isn't set if drive doesn't report it
+	Temperature struct {
+		Current int64 `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime struct {
+		Hours int64 `json:"hours"`
+	} `json:"power_on_time"`
+}
+
+// ATAAttributes is a table of ATA SMART attributes
+type ATAAttributes struct {
+	Table []ATAAttribute `json:"table"`
+}
+
+// ATAAttribute is a single ATA SMART attribute
+type ATAAttribute struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Value  int    `json:"value"`
+	Worst  int    `json:"worst"`
+	Thresh int    `json:"thresh"`
+	// "now" if normalized value is less or equal to threshold, "past" if it was
+	WhenFailed string `json:"when_failed"`
+	Raw        struct {
+		Value int64 `json:"value"`
+	} `json:"raw"`
+}
+
+// ATAErrorLog is a summary of ATA error log
+type ATAErrorLog struct {
+	Summary struct {
+		Count int64 `json:"count"`
+	} `json:"summary"`
+}
+
+// ATASelfTestLog is a standard ATA self-test log
+type ATASelfTestLog struct {
+	Standard struct {
+		Table []SelfTest `json:"table"`
+	} `json:"standard"`
+}
+
+// SelfTest is a single entry of self-test log
+type SelfTest struct {
+	Type struct {
+		String string `json:"string"`
+	} `json:"type"`
+	Status struct {
+		String string `json:"string"`
+		// isn't set for self-test in progress
+		Passed *bool `json:"passed"`
+	} `json:"status"`
+	LifetimeHours int64 `json:"lifetime_hours"`
+}
+
+// SCSIErrorCounter is an entry of SCSI error counter log for read, write or verify operations
+type SCSIErrorCounter struct {
+	TotalUncorrectedErrors int64 `json:"total_uncorrected_errors"`
+}
+
+// GetSMARTDataByPath gets SMART attributes, error and self-test logs of device by its Path using smartctl util.
+// smartctl exit status is a bit mask which is not zero when error or self-test logs contain errors,
+// so error is returned only if output can't be parsed
+func (sa *SMARTCTL) GetSMARTDataByPath(path string) (*DeviceSMARTData, error) {
+	strOut, _, err := sa.e.RunCmd(fmt.Sprintf(SmartctlAllCmdImpl, path),
+		command.UseMetrics(true),
+		command.CmdName(strings.TrimSpace(fmt.Sprintf(SmartctlAllCmdImpl, ""))))
+	if err != nil && strings.TrimSpace(strOut) == "" {
+		return nil, err
+	}
+	data := &DeviceSMARTData{}
+	if jsonErr := json.Unmarshal([]byte(strOut), data); jsonErr != nil {
+		return nil, fmt.Errorf("unable to unmarshal output to DeviceSMARTData instance, error: %v", jsonErr)
+	}
+	return data, nil
+}
+
+// Counters returns values of SMART counters which are reported by device
+func (d *DeviceSMARTData) Counters() map[string]int64 {
+	counters := map[string]int64{}
+	for _, attr := range d.ATAAttributes.Table {
+		value := attr.Raw.Value & ataRawCounterMask
+		switch attr.ID {
+		case ataReallocatedSectorCt:
+			counters[ReallocatedSectors] = value
+		case ataCurrentPendingSector:
+			counters[PendingSectors] = value
+		case ataOfflineUncorrectable:
+			counters[UncorrectableSectors] = value
+		case ataUDMACRCErrorCount:
+			counters[CRCErrors] = value
+		}
+	}
+	if len(d.ATAAttributes.Table) > 0 {
+		counters[ErrorLogCount] = d.ATAErrorLog.Summary.Count
+		var failures int64
+		for _, test := range d.ATASelfTestLog.Standard.Table {
+			if test.Status.Passed != nil && !*test.Status.Passed {
+				failures++
+			}
+		}
+		counters[SelfTestFailures] = failures
+	}
+
+	if d.SCSIGrownDefectList != nil {
+		counters[ReallocatedSectors] = *d.SCSIGrownDefectList
+	}
+	if len(d.SCSIErrorCounterLog) > 0 {
+		var uncorrected int64
+		for _, counter := range d.SCSIErrorCounterLog {
+			uncorrected += counter.TotalUncorrectedErrors
+		}
+		counters[UncorrectableSectors] = uncorrected
+	}
+	return counters
+}
+
+// FailingAttributes returns names of ATA attributes which normalized value is less or equal to threshold
+func (d *DeviceSMARTData) FailingAttributes() []string {
+	var failing []string
+	for _, attr := range d.ATAAttributes.Table {
+		if attr.WhenFailed == "now" || (attr.Thresh > 0 && attr.Value <= attr.Thresh) {
+			failing = append(failing, attr.Name)
+		}
+	}
+	return failing
+}
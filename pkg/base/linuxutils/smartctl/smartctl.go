@@ -36,6 +36,7 @@ const (
 // WrapSmartctl is an interface that encapsulates operation with system smartctl util
 type WrapSmartctl interface {
 	GetDriveInfoByPath(path string) (*DeviceSMARTInfo, error)
+	GetSMARTDataByPath(path string) (*DeviceSMARTData, error)
 }
 
 // DeviceSMARTInfo represents SMART information about device
@@ -112,3 +112,91 @@ func TestSMARCTL_fillSmartStatusUnmarshallError(t *testing.T) {
 	err := l.fillSmartStatus(&DeviceSMARTInfo{}, "/dev/sdd")
 	assert.NotNil(t, err)
 }
+
+func TestSMARCTL_GetSMARTDataByPath(t *testing.T) {
+	output := `{
+					"ata_smart_attributes": {
+						"table": [
+							{"id": 5, "name": "Reallocated_Sector_Ct", "value": 100, "worst": 100, "thresh": 10,
+								"when_failed": "", "raw": {"value": 8, "string": "8"}},
+							{"id": 9, "name": "Power_On_Hours", "value": 90, "worst": 90, "thresh": 0,
+								"when_failed": "", "raw": {"value": 9000, "string": "9000"}},
+							{"id": 197, "name": "Current_Pending_Sector", "value": 100, "worst": 100, "thresh": 0,
+								"when_failed": "", "raw": {"value": 2, "string": "2"}},
+							{"id": 198, "name": "Offline_Uncorrectable", "value": 100, "worst": 100, "thresh": 0,
+								"when_failed": "", "raw": {"value": 0, "string": "0"}},
+							{"id": 199, "name": "UDMA_CRC_Error_Count", "value": 200, "worst": 200, "thresh": 0,
+								"when_failed": "", "raw": {"value": 4294967299, "string": "3"}},
+							{"id": 10, "name": "Spin_Retry_Count", "value": 50, "worst": 50, "thresh": 51,
+								"when_failed": "now", "raw": {"value": 12, "string": "12"}}
+						]
+					},
+					"ata_smart_error_log": {"summary": {"revision": 1, "count": 4}},
+					"ata_smart_self_test_log": {
+						"standard": {
+							"table": [
+								{"type": {"value": 1, "string": "Short offline"},
+									"status": {"value": 121, "string": "Completed: read failure", "passed": false},
+									"lifetime_hours": 8990},
+								{"type": {"value": 1, "string": "Short offline"},
+									"status": {"value": 0, "string": "Completed without error", "passed": true},
+									"lifetime_hours": 8000},
+								{"type": {"value": 2, "string": "Extended offline"},
+									"status": {"value": 249, "string": "Self-test routine in progress"},
+									"lifetime_hours": 9000}
+							]
+						}
+					}
+				}`
+	cmd := fmt.Sprintf(SmartctlAllCmdImpl, "/dev/sdd")
+	e := &mocks.GoMockExecutor{}
+	l := NewSMARTCTL(e)
+
+	// smartctl returns not zero exit status when error log has entries
+	e.On("RunCmd", cmd).Return(output, "", fmt.Errorf("exit status 64"))
+	data, err := l.GetSMARTDataByPath("/dev/sdd")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{
+		ReallocatedSectors:   8,
+		PendingSectors:       2,
+		UncorrectableSectors: 0,
+		CRCErrors:            3,
+		ErrorLogCount:        4,
+		SelfTestFailures:     1,
+	}, data.Counters())
+	assert.Equal(t, []string{"Spin_Retry_Count"}, data.FailingAttributes())
+}
+
+func TestSMARCTL_GetSMARTDataByPathSCSI(t *testing.T) {
+	output := `{
+					"scsi_grown_defect_list": 3,
+					"scsi_error_counter_log": {
+						"read": {"total_errors_corrected": 10, "total_uncorrected_errors": 1},
+						"write": {"total_errors_corrected": 0, "total_uncorrected_errors": 0},
+						"verify": {"total_errors_corrected": 0, "total_uncorrected_errors": 2}
+					}
+				}`
+	cmd := fmt.Sprintf(SmartctlAllCmdImpl, "/dev/sdd")
+	e := &mocks.GoMockExecutor{}
+	l := NewSMARTCTL(e)
+
+	e.On("RunCmd", cmd).Return(output, "", nil)
+	data, err := l.GetSMARTDataByPath("/dev/sdd")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{ReallocatedSectors: 3, UncorrectableSectors: 3}, data.Counters())
+	assert.Empty(t, data.FailingAttributes())
+}
+
+func TestSMARCTL_GetSMARTDataByPathFails(t *testing.T) {
+	cmd := fmt.Sprintf(SmartctlAllCmdImpl, "/dev/sdd")
+	e := &mocks.GoMockExecutor{}
+	l := NewSMARTCTL(e)
+
+	e.On("RunCmd", cmd).Return("", "", fmt.Errorf("error")).Once()
+	_, err := l.GetSMARTDataByPath("/dev/sdd")
+	assert.NotNil(t, err)
+
+	e.On("RunCmd", cmd).Return("{", "", nil).Once()
+	_, err = l.GetSMARTDataByPath("/dev/sdd")
+	assert.NotNil(t, err)
+}
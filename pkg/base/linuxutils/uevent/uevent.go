@@ -0,0 +1,244 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package uevent contains code for receiving device events which udev sends over netlink socket
+package uevent
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// KernelGroup is netlink multicast group of the events sent by kernel
+	KernelGroup = 1
+	// UdevGroup is netlink multicast group of the events sent by udev after their processing,
+	// /dev nodes and udev database are up to date when they come
+	UdevGroup = 2
+
+	// Actions of the events
+	ActionAdd    = "add"
+	ActionRemove = "remove"
+	ActionChange = "change"
+
+	// SubsystemBlock is the subsystem of block devices
+	SubsystemBlock = "block"
+	// DevTypeDisk is the type of the whole block device, partitions have "partition" type
+	DevTypeDisk = "disk"
+
+	// udevMonitorPrefix and udevMonitorMagic start header of the events sent by udev
+	udevMonitorPrefix = "libudev\x00"
+	udevMonitorMagic  = 0xfeedcafe
+	// udevHeaderSize is the size of the header fields which are used: prefix, magic, header size,
+	// properties offset and properties length
+	udevHeaderSize = 24
+
+	// events are limited by the kernel with this size
+	bufferSize = 64 * 1024
+)
+
+// nativeEndian is byte order of the header fields which udev writes in host order
+var nativeEndian binary.ByteOrder = binary.LittleEndian
+
+func init() {
+	i := uint16(1)
+	if *(*byte)(unsafe.Pointer(&i)) == 0 {
+		nativeEndian = binary.BigEndian
+	}
+}
+
+// Event is a device event
+type Event struct {
+	Action    string
+	DevPath   string
+	Subsystem string
+	DevType   string
+	// full path of the device node, e.g. /dev/sda
+	DevName string
+	// all properties of the event
+	Env map[string]string
+}
+
+// IsDisk returns true if event is sent for the whole block device
+func (e *Event) IsDisk() bool {
+	return e.Subsystem == SubsystemBlock && e.DevType == DevTypeDisk
+}
+
+// Monitor receives events from netlink socket
+type Monitor struct {
+	socket *os.File
+	log    *logrus.Entry
+}
+
+// NewMonitor opens netlink socket subscribed to the provided group, KernelGroup or UdevGroup
+// Netlink events are sent to the host network namespace only
+func NewMonitor(group uint32, logger *logrus.Logger) (*Monitor, error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_RAW|syscall.SOCK_CLOEXEC|syscall.SOCK_NONBLOCK,
+		syscall.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create netlink socket: %v", err)
+	}
+	if err = syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK, Groups: group}); err != nil {
+		_ = syscall.Close(fd)
+		return nil, fmt.Errorf("unable to bind netlink socket to group %d: %v", group, err)
+	}
+	return &Monitor{
+		// non-blocking socket is handled by runtime poller, so Close interrupts Read
+		socket: os.NewFile(uintptr(fd), "uevent"),
+		log:    logger.WithField("component", "UeventMonitor"),
+	}, nil
+}
+
+// Start reads events in background and sends them to the returned channel,
+// the channel is closed when the monitor is closed
+func (m *Monitor) Start() <-chan *Event {
+	events := make(chan *Event)
+	go func() {
+		defer close(events)
+		buf := make([]byte, bufferSize)
+		for {
+			n, err := m.socket.Read(buf)
+			if errors.Is(err, syscall.ENOBUFS) {
+				// socket buffer is overflowed, reading could be continued
+				m.log.Warnf("Uevents are lost: %v", err)
+				continue
+			}
+			if err != nil {
+				if !errors.Is(err, os.ErrClosed) {
+					m.log.Errorf("Unable to read uevent: %v", err)
+				}
+				return
+			}
+			event, err := ParseEvent(buf[:n])
+			if err != nil {
+				m.log.Warnf("Unable to parse uevent: %v", err)
+				continue
+			}
+			m.log.Debugf("Event %s of %s", event.Action, event.DevPath)
+			events <- event
+		}
+	}()
+	return events
+}
+
+// Close closes netlink socket
+func (m *Monitor) Close() error {
+	return m.socket.Close()
+}
+
+// Debounce groups events which come with interval less than period, e.g. during enclosure reset,
+// the batch is sent when there are no events during period or when maxDelay passed since its first event
+// Events of the same device are merged into the last one in place of the first one
+// Returned channel is closed when events channel is closed
+func Debounce(events <-chan *Event, period, maxDelay time.Duration) <-chan []*Event {
+	batches := make(chan []*Event)
+	go func() {
+		defer close(batches)
+		var (
+			batch []*Event
+			// DevPath -> index in batch
+			index    map[string]int
+			quiet    <-chan time.Time
+			deadline <-chan time.Time
+		)
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					if len(batch) > 0 {
+						batches <- batch
+					}
+					return
+				}
+				if batch == nil {
+					index = make(map[string]int)
+					deadline = time.After(maxDelay)
+				}
+				if i, ok := index[event.DevPath]; ok {
+					batch[i] = event
+				} else {
+					index[event.DevPath] = len(batch)
+					batch = append(batch, event)
+				}
+				quiet = time.After(period)
+				continue
+			case <-quiet:
+			case <-deadline:
+			}
+			batches <- batch
+			batch, index, quiet, deadline = nil, nil, nil, nil
+		}
+	}()
+	return batches
+}
+
+// ParseEvent parses event sent by kernel ("action@devpath" header) or by udev ("libudev" header),
+// both of them contain properties as null-terminated KEY=VALUE strings
+func ParseEvent(msg []byte) (*Event, error) {
+	var properties []byte
+	if bytes.HasPrefix(msg, []byte(udevMonitorPrefix)) {
+		if len(msg) < udevHeaderSize {
+			return nil, fmt.Errorf("udev event is too short: %d bytes", len(msg))
+		}
+		if magic := binary.BigEndian.Uint32(msg[8:12]); magic != udevMonitorMagic {
+			return nil, fmt.Errorf("udev event has wrong magic 0x%x", magic)
+		}
+		offset, length := nativeEndian.Uint32(msg[16:20]), nativeEndian.Uint32(msg[20:24])
+		if uint64(offset)+uint64(length) > uint64(len(msg)) {
+			return nil, fmt.Errorf("udev event properties are out of bounds")
+		}
+		properties = msg[offset : offset+length]
+	} else {
+		end := bytes.IndexByte(msg, 0)
+		if end < 0 || !bytes.Contains(msg[:end], []byte("@")) {
+			return nil, fmt.Errorf("kernel event has wrong header")
+		}
+		properties = msg[end+1:]
+	}
+
+	event := &Event{Env: make(map[string]string)}
+	for _, property := range bytes.Split(properties, []byte{0}) {
+		kv := strings.SplitN(string(property), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		event.Env[kv[0]] = kv[1]
+	}
+	event.Action = event.Env["ACTION"]
+	event.DevPath = event.Env["DEVPATH"]
+	event.Subsystem = event.Env["SUBSYSTEM"]
+	event.DevType = event.Env["DEVTYPE"]
+	if devName := event.Env["DEVNAME"]; devName != "" {
+		// kernel sends name relative to /dev
+		if !strings.HasPrefix(devName, "/") {
+			devName = "/dev/" + devName
+		}
+		event.DevName = devName
+	}
+	if event.Action == "" || event.DevPath == "" {
+		return nil, fmt.Errorf("event doesn't contain ACTION or DEVPATH")
+	}
+	return event, nil
+}
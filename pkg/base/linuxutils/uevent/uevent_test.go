@@ -0,0 +1,142 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uevent
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+var diskProperties = []string{
+	"ACTION=add",
+	"DEVPATH=/devices/pci0000:00/0000:00:1f.2/ata1/host0/target0:0:0/0:0:0:0/block/sdb",
+	"SUBSYSTEM=block",
+	"DEVNAME=sdb",
+	"DEVTYPE=disk",
+	"SEQNUM=4242",
+}
+
+func kernelEvent(properties []string) []byte {
+	header := "add@/devices/pci0000:00/0000:00:1f.2/ata1/host0/target0:0:0/0:0:0:0/block/sdb\x00"
+	return []byte(header + strings.Join(properties, "\x00") + "\x00")
+}
+
+func udevEvent(properties []string) []byte {
+	body := []byte(strings.Join(properties, "\x00") + "\x00")
+	header := make([]byte, 40)
+	copy(header, udevMonitorPrefix)
+	binary.BigEndian.PutUint32(header[8:], udevMonitorMagic)
+	nativeEndian.PutUint32(header[12:], uint32(len(header)))
+	nativeEndian.PutUint32(header[16:], uint32(len(header)))
+	nativeEndian.PutUint32(header[20:], uint32(len(body)))
+	return append(header, body...)
+}
+
+func TestParseEvent(t *testing.T) {
+	for name, msg := range map[string][]byte{
+		"kernel": kernelEvent(diskProperties),
+		"udev":   udevEvent(append(diskProperties[:3:3], "DEVNAME=/dev/sdb", "DEVTYPE=disk")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			event, err := ParseEvent(msg)
+			assert.Nil(t, err)
+			assert.Equal(t, ActionAdd, event.Action)
+			assert.Equal(t, "/dev/sdb", event.DevName)
+			assert.True(t, strings.HasSuffix(event.DevPath, "/block/sdb"))
+			assert.True(t, event.IsDisk())
+		})
+	}
+
+	t.Run("partition", func(t *testing.T) {
+		event, err := ParseEvent(kernelEvent([]string{"ACTION=remove", "DEVPATH=/block/sdb/sdb1",
+			"SUBSYSTEM=block", "DEVTYPE=partition"}))
+		assert.Nil(t, err)
+		assert.Equal(t, ActionRemove, event.Action)
+		assert.False(t, event.IsDisk())
+	})
+
+	t.Run("wrong events", func(t *testing.T) {
+		wrongMagic := udevEvent(diskProperties)
+		binary.BigEndian.PutUint32(wrongMagic[8:], 0)
+		outOfBounds := udevEvent(diskProperties)
+		nativeEndian.PutUint32(outOfBounds[20:], uint32(len(outOfBounds)))
+		for _, msg := range [][]byte{
+			[]byte("libudev\x00"),
+			wrongMagic,
+			outOfBounds,
+			[]byte("no header"),
+			kernelEvent([]string{"SUBSYSTEM=block"}),
+			bytes.Repeat([]byte{0}, 10),
+		} {
+			_, err := ParseEvent(msg)
+			assert.NotNil(t, err)
+		}
+	})
+}
+
+func TestMonitor(t *testing.T) {
+	m, err := NewMonitor(KernelGroup, logrus.New())
+	if err != nil {
+		t.Skipf("netlink socket isn't available: %v", err)
+	}
+	events := m.Start()
+	assert.Nil(t, m.Close())
+	// channel is closed after monitor is closed
+	for range events {
+	}
+}
+
+func TestDebounce(t *testing.T) {
+	events := make(chan *Event)
+	batches := Debounce(events, 50*time.Millisecond, time.Hour)
+
+	// enclosure reset removes and adds drives back
+	for _, action := range []string{ActionRemove, ActionAdd} {
+		for _, dev := range []string{"sdb", "sdc"} {
+			events <- &Event{Action: action, DevPath: "/block/" + dev}
+		}
+	}
+	batch := <-batches
+	assert.Len(t, batch, 2)
+	assert.Equal(t, "/block/sdb", batch[0].DevPath)
+	assert.Equal(t, ActionAdd, batch[0].Action)
+	assert.Equal(t, ActionAdd, batch[1].Action)
+
+	// the last batch is sent when events channel is closed
+	events <- &Event{Action: ActionRemove, DevPath: "/block/sdd"}
+	close(events)
+	batch = <-batches
+	assert.Len(t, batch, 1)
+	_, ok := <-batches
+	assert.False(t, ok)
+}
+
+func TestDebounceMaxDelay(t *testing.T) {
+	events := make(chan *Event)
+	batches := Debounce(events, time.Hour, 50*time.Millisecond)
+
+	events <- &Event{Action: ActionChange, DevPath: "/block/sdb"}
+	batch := <-batches
+	assert.Len(t, batch, 1)
+	close(events)
+}
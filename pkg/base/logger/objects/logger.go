@@ -10,18 +10,20 @@ import (
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	"github.com/dell/csi-baremetal/api/v1/nodecrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 )
 
 const (
 	csiGroup = "csi-baremetal.dell.com"
 
-	acKind     = "AvailableCapacity"
-	acrKind    = "AvailableCapacityKind"
-	driveKind  = "Drive"
-	lvgKind    = "LogicalVolumeGroup"
-	nodeKind   = "Node"
-	volumeKind = "Volume"
+	acKind       = "AvailableCapacity"
+	acrKind      = "AvailableCapacityKind"
+	driveKind    = "Drive"
+	lvgKind      = "LogicalVolumeGroup"
+	nodeKind     = "Node"
+	volumeKind   = "Volume"
+	snapshotKind = "Snapshot"
 )
 
 // ObjectLogger is center logging object for CSI Driver crd objects
@@ -30,12 +32,13 @@ type ObjectLogger interface {
 }
 
 type objectLogger struct {
-	acLogger     *availableCapacity
-	acrLogger    *availableCapacityReservation
-	driveLogger  *drive
-	lvgLogger    *logicalVolumeGroup
-	nodeLogger   *node
-	volumeLogger *volume
+	acLogger       *availableCapacity
+	acrLogger      *availableCapacityReservation
+	driveLogger    *drive
+	lvgLogger      *logicalVolumeGroup
+	nodeLogger     *node
+	volumeLogger   *volume
+	snapshotLogger *snapshot
 }
 
 func (l *objectLogger) Log(object runtime.Object) string {
@@ -58,6 +61,8 @@ func (l *objectLogger) Log(object runtime.Object) string {
 		return l.nodeLogger.Log(object.(*nodecrd.Node))
 	case gvk.Kind == volumeKind:
 		return l.volumeLogger.Log(object.(*volumecrd.Volume))
+	case gvk.Kind == snapshotKind:
+		return l.snapshotLogger.Log(object.(*snapshotcrd.Snapshot))
 	}
 	return fmt.Sprintf("%+v", object)
 }
@@ -65,11 +70,12 @@ func (l *objectLogger) Log(object runtime.Object) string {
 // NewObjectLogger is the constructor for ObjectLogger
 func NewObjectLogger() ObjectLogger {
 	return &objectLogger{
-		acLogger:     newAvailableCapacity(),
-		acrLogger:    newAvailableCapacityReservation(),
-		driveLogger:  newDrive(),
-		lvgLogger:    newLogicalVolumeGroup(),
-		nodeLogger:   newNode(),
-		volumeLogger: newVolume(),
+		acLogger:       newAvailableCapacity(),
+		acrLogger:      newAvailableCapacityReservation(),
+		driveLogger:    newDrive(),
+		lvgLogger:      newLogicalVolumeGroup(),
+		nodeLogger:     newNode(),
+		volumeLogger:   newVolume(),
+		snapshotLogger: newSnapshot(),
 	}
 }
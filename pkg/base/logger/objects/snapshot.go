@@ -0,0 +1,34 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objects
+
+import (
+	"fmt"
+
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
+)
+
+type snapshot struct{}
+
+func (l *snapshot) Log(object *snapshotcrd.Snapshot) (str string) {
+	return fmt.Sprintf("Labels: %+v, Annotations: %+v, Spec: %+v",
+		object.ObjectMeta.Labels, object.ObjectMeta.Annotations, object.Spec)
+}
+
+func newSnapshot() *snapshot {
+	return &snapshot{}
+}
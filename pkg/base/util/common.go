@@ -68,6 +68,9 @@ func ConvertStorageClass(strSC string) string {
 		api.StorageClassSSDLVG,
 		api.StorageClassNVMeLVG,
 		api.StorageClassSystemLVG,
+		api.StorageClassHDDLVGThin,
+		api.StorageClassSSDLVGThin,
+		api.StorageClassNVMeLVGThin,
 		api.StorageClassAny:
 		return sc
 	}
@@ -95,11 +98,11 @@ func ConvertDriveTypeToStorageClass(driveType string) string {
 // storage classes that are based on LVM, or empty string
 func GetSubStorageClass(sc string) string {
 	switch sc {
-	case api.StorageClassHDDLVG:
+	case api.StorageClassHDDLVG, api.StorageClassHDDLVGThin:
 		return api.StorageClassHDD
-	case api.StorageClassSSDLVG:
+	case api.StorageClassSSDLVG, api.StorageClassSSDLVGThin:
 		return api.StorageClassSSD
-	case api.StorageClassNVMeLVG:
+	case api.StorageClassNVMeLVG, api.StorageClassNVMeLVGThin:
 		return api.StorageClassNVMe
 	default:
 		return ""
@@ -111,7 +114,44 @@ func IsStorageClassLVG(sc string) bool {
 	return sc == api.StorageClassHDDLVG ||
 		sc == api.StorageClassSSDLVG ||
 		sc == api.StorageClassNVMeLVG ||
-		sc == api.StorageClassSystemLVG
+		sc == api.StorageClassSystemLVG ||
+		IsStorageClassLVGThin(sc)
+}
+
+// IsStorageClassLVGThin returns whether provided sc relates to LVG with thin pool or no
+func IsStorageClassLVGThin(sc string) bool {
+	return sc == api.StorageClassHDDLVGThin ||
+		sc == api.StorageClassSSDLVGThin ||
+		sc == api.StorageClassNVMeLVGThin
+}
+
+// IsDriveErasePolicy returns whether provided erase policy is applied to the whole drive after volume release
+func IsDriveErasePolicy(policy string) bool {
+	switch policy {
+	case api.ErasePolicyZeroFill,
+		api.ErasePolicyDiscard,
+		api.ErasePolicyNVMeFormat,
+		api.ErasePolicyNVMeSanitize,
+		api.ErasePolicyATASecureErase:
+		return true
+	}
+	return false
+}
+
+// IsErasePolicySupported returns whether provided erase policy could be applied to volumes of StorageClass sc,
+// empty policy means default one (wipefs). Volumes based on LVG support only default policy
+func IsErasePolicySupported(policy, sc string) bool {
+	switch policy {
+	case "", api.ErasePolicyWipeFS:
+		return true
+	case api.ErasePolicyNone, api.ErasePolicyZeroFill, api.ErasePolicyDiscard:
+		return !IsStorageClassLVG(sc)
+	case api.ErasePolicyNVMeFormat, api.ErasePolicyNVMeSanitize:
+		return sc == api.StorageClassNVMe
+	case api.ErasePolicyATASecureErase:
+		return sc == api.StorageClassHDD || sc == api.StorageClassSSD
+	}
+	return false
 }
 
 // ContainsString return true if slice contains string str
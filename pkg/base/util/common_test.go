@@ -109,6 +109,9 @@ var strToSC = []struct {
 	{"ssdlvg", api.StorageClassSSDLVG},
 	{"nvmelvg", api.StorageClassNVMeLVG},
 	{"syslVg", api.StorageClassSystemLVG},
+	{"hddlvgthin", api.StorageClassHDDLVGThin},
+	{"ssdlvgthin", api.StorageClassSSDLVGThin},
+	{"nvmelvgthin", api.StorageClassNVMeLVGThin},
 	{"any", api.StorageClassAny},
 	{"random", api.StorageClassAny},
 }
@@ -144,6 +147,35 @@ func TestConvertDriveTypeToStorageClass(t *testing.T) {
 	}
 }
 
+func TestIsStorageClassLVGThin(t *testing.T) {
+	assert.True(t, IsStorageClassLVGThin(api.StorageClassHDDLVGThin))
+	assert.True(t, IsStorageClassLVG(api.StorageClassNVMeLVGThin))
+	assert.False(t, IsStorageClassLVGThin(api.StorageClassHDDLVG))
+	assert.Equal(t, api.StorageClassSSD, GetSubStorageClass(api.StorageClassSSDLVGThin))
+}
+
+func TestIsErasePolicySupported(t *testing.T) {
+	assert.True(t, IsErasePolicySupported("", api.StorageClassHDDLVG))
+	assert.True(t, IsErasePolicySupported(api.ErasePolicyWipeFS, api.StorageClassSSDLVG))
+	assert.False(t, IsErasePolicySupported(api.ErasePolicyNone, api.StorageClassHDDLVG))
+	assert.True(t, IsErasePolicySupported(api.ErasePolicyNone, api.StorageClassHDD))
+	assert.True(t, IsErasePolicySupported(api.ErasePolicyZeroFill, api.StorageClassAny))
+	assert.False(t, IsErasePolicySupported(api.ErasePolicyDiscard, api.StorageClassNVMeLVG))
+	assert.True(t, IsErasePolicySupported(api.ErasePolicyNVMeSanitize, api.StorageClassNVMe))
+	assert.False(t, IsErasePolicySupported(api.ErasePolicyNVMeFormat, api.StorageClassSSD))
+	assert.True(t, IsErasePolicySupported(api.ErasePolicyATASecureErase, api.StorageClassSSD))
+	assert.False(t, IsErasePolicySupported(api.ErasePolicyATASecureErase, api.StorageClassNVMe))
+	assert.False(t, IsErasePolicySupported("unknown", api.StorageClassHDD))
+}
+
+func TestIsDriveErasePolicy(t *testing.T) {
+	assert.False(t, IsDriveErasePolicy(""))
+	assert.False(t, IsDriveErasePolicy(api.ErasePolicyNone))
+	assert.False(t, IsDriveErasePolicy(api.ErasePolicyWipeFS))
+	assert.True(t, IsDriveErasePolicy(api.ErasePolicyZeroFill))
+	assert.True(t, IsDriveErasePolicy(api.ErasePolicyNVMeSanitize))
+}
+
 func TestContainsString(t *testing.T) {
 	var containsStringScenarios = []struct {
 		slice  []string
@@ -0,0 +1,96 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/pkg/base"
+)
+
+const (
+	minIOWeight = 1
+	maxIOWeight = 10000
+)
+
+// ParseIOLimits reads IO limits of the volume from StorageClass parameters,
+// PVC annotations with base.IOLimitsAnnotationPrefix override parameters with the same key
+// Returns nil if limits aren't set or error if one of the values is invalid
+func ParseIOLimits(params, pvcAnnotations map[string]string) (*api.IOLimits, error) {
+	getValue := func(key string) string {
+		if value, ok := pvcAnnotations[base.IOLimitsAnnotationPrefix+key]; ok {
+			return value
+		}
+		return params[key]
+	}
+
+	var (
+		limits = &api.IOLimits{}
+		err    error
+	)
+	if limits.ReadIOPS, err = parseIOLimit(base.IOReadIOPSKey, getValue(base.IOReadIOPSKey), false); err != nil {
+		return nil, err
+	}
+	if limits.WriteIOPS, err = parseIOLimit(base.IOWriteIOPSKey, getValue(base.IOWriteIOPSKey), false); err != nil {
+		return nil, err
+	}
+	if limits.ReadBPS, err = parseIOLimit(base.IOReadBPSKey, getValue(base.IOReadBPSKey), true); err != nil {
+		return nil, err
+	}
+	if limits.WriteBPS, err = parseIOLimit(base.IOWriteBPSKey, getValue(base.IOWriteBPSKey), true); err != nil {
+		return nil, err
+	}
+	weight, err := parseIOLimit(base.IOWeightKey, getValue(base.IOWeightKey), false)
+	if err != nil {
+		return nil, err
+	}
+	if weight != 0 && (weight < minIOWeight || weight > maxIOWeight) {
+		return nil, fmt.Errorf("%s must be in range [%d, %d], got %d", base.IOWeightKey, minIOWeight, maxIOWeight, weight)
+	}
+	limits.Weight = int32(weight)
+
+	if IsIOLimitsEmpty(limits) {
+		return nil, nil
+	}
+	return limits, nil
+}
+
+// IsIOLimitsEmpty returns true if none of the limits is set
+func IsIOLimitsEmpty(limits *api.IOLimits) bool {
+	return limits == nil || (limits.ReadIOPS == 0 && limits.WriteIOPS == 0 &&
+		limits.ReadBPS == 0 && limits.WriteBPS == 0 && limits.Weight == 0)
+}
+
+// parseIOLimit parses non-negative limit value, size units (e.g. 100Mi) are allowed if withUnits is true
+func parseIOLimit(key, value string, withUnits bool) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	limit, err := strconv.ParseInt(value, 10, 64)
+	if err != nil && withUnits {
+		limit, err = StrToBytes(value)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %s parameter: %v", key, err)
+	}
+	if limit < 0 {
+		return 0, fmt.Errorf("%s must not be negative, got %d", key, limit)
+	}
+	return limit, nil
+}
@@ -0,0 +1,62 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/pkg/base"
+)
+
+func TestParseIOLimits(t *testing.T) {
+	limits, err := ParseIOLimits(map[string]string{}, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, limits)
+
+	limits, err = ParseIOLimits(map[string]string{
+		base.IOReadIOPSKey:  "500",
+		base.IOWriteIOPSKey: "200",
+		base.IOReadBPSKey:   "1048576",
+		base.IOWriteBPSKey:  "1Mi",
+		base.IOWeightKey:    "100",
+	}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, &api.IOLimits{ReadIOPS: 500, WriteIOPS: 200, ReadBPS: 1048576, WriteBPS: 1048576, Weight: 100}, limits)
+
+	// PVC annotation overrides parameter
+	limits, err = ParseIOLimits(map[string]string{base.IOReadIOPSKey: "500"},
+		map[string]string{base.IOLimitsAnnotationPrefix + base.IOReadIOPSKey: "1000", "another": "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, &api.IOLimits{ReadIOPS: 1000}, limits)
+
+	// units aren't allowed for IOPS
+	_, err = ParseIOLimits(map[string]string{base.IOReadIOPSKey: "1Mi"}, nil)
+	assert.NotNil(t, err)
+	_, err = ParseIOLimits(map[string]string{base.IOWriteBPSKey: "-1"}, nil)
+	assert.NotNil(t, err)
+	_, err = ParseIOLimits(nil, map[string]string{base.IOLimitsAnnotationPrefix + base.IOWeightKey: "10001"})
+	assert.NotNil(t, err)
+}
+
+func TestIsIOLimitsEmpty(t *testing.T) {
+	assert.True(t, IsIOLimitsEmpty(nil))
+	assert.True(t, IsIOLimitsEmpty(&api.IOLimits{}))
+	assert.False(t, IsIOLimitsEmpty(&api.IOLimits{Weight: 1}))
+}
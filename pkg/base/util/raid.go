@@ -0,0 +1,97 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"strconv"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base"
+)
+
+// minRaidDevices holds minimal number of drives for each supported RAID level
+var minRaidDevices = map[string]int32{
+	apiV1.RaidLevel0:  2,
+	apiV1.RaidLevel1:  2,
+	apiV1.RaidLevel10: 4,
+}
+
+// ParseRaidParameters reads RAID level and number of drives from StorageClass parameters
+// Returns empty level for non-RAID volume or error if parameters are invalid
+func ParseRaidParameters(params map[string]string) (string, int32, error) {
+	level, ok := params[base.RaidLevelKey]
+	if !ok || level == "" {
+		return "", 0, nil
+	}
+
+	minDevices, ok := minRaidDevices[level]
+	if !ok {
+		return "", 0, fmt.Errorf("RAID level %s isn't supported", level)
+	}
+	devices, err := strconv.ParseInt(params[base.RaidDevicesKey], 10, 32)
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to parse %s parameter: %v", base.RaidDevicesKey, err)
+	}
+	if int32(devices) < minDevices {
+		return "", 0, fmt.Errorf("%s requires at least %d drives, got %d", level, minDevices, devices)
+	}
+	if level == apiV1.RaidLevel10 && devices%2 != 0 {
+		return "", 0, fmt.Errorf("%s requires even number of drives, got %d", level, devices)
+	}
+
+	return level, int32(devices), nil
+}
+
+// IsRaidSupported checks whether RAID volume could be created with storage class sc
+// RAID is assembled from whole drives, so only drive based storage classes are supported
+func IsRaidSupported(sc string) bool {
+	switch sc {
+	case apiV1.StorageClassHDD, apiV1.StorageClassSSD, apiV1.StorageClassNVMe:
+		return true
+	}
+	return false
+}
+
+// GetRaidMemberSize returns size which each drive of the RAID must have to provide size bytes of usable capacity
+func GetRaidMemberSize(level string, devices int32, size int64) int64 {
+	if devices <= 0 {
+		return size
+	}
+	switch level {
+	case apiV1.RaidLevel0:
+		return divRoundUp(size, int64(devices))
+	case apiV1.RaidLevel10:
+		return divRoundUp(size*2, int64(devices))
+	}
+	return size
+}
+
+// GetRaidCapacity returns usable capacity of the RAID assembled from devices drives of memberSize bytes
+func GetRaidCapacity(level string, devices int32, memberSize int64) int64 {
+	switch level {
+	case apiV1.RaidLevel0:
+		return memberSize * int64(devices)
+	case apiV1.RaidLevel10:
+		return memberSize * int64(devices) / 2
+	}
+	return memberSize
+}
+
+func divRoundUp(a, b int64) int64 {
+	return (a + b - 1) / b
+}
@@ -0,0 +1,71 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base"
+)
+
+func TestParseRaidParameters(t *testing.T) {
+	level, devices, err := ParseRaidParameters(map[string]string{})
+	assert.Nil(t, err)
+	assert.Equal(t, "", level)
+	assert.Equal(t, int32(0), devices)
+
+	level, devices, err = ParseRaidParameters(map[string]string{
+		base.RaidLevelKey: apiV1.RaidLevel1, base.RaidDevicesKey: "2"})
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.RaidLevel1, level)
+	assert.Equal(t, int32(2), devices)
+
+	for _, params := range []map[string]string{
+		{base.RaidLevelKey: "raid5", base.RaidDevicesKey: "3"},
+		{base.RaidLevelKey: apiV1.RaidLevel0},
+		{base.RaidLevelKey: apiV1.RaidLevel0, base.RaidDevicesKey: "1"},
+		{base.RaidLevelKey: apiV1.RaidLevel10, base.RaidDevicesKey: "2"},
+		{base.RaidLevelKey: apiV1.RaidLevel10, base.RaidDevicesKey: "5"},
+	} {
+		_, _, err = ParseRaidParameters(params)
+		assert.NotNil(t, err, params)
+	}
+}
+
+func TestGetRaidMemberSize(t *testing.T) {
+	assert.Equal(t, int64(50), GetRaidMemberSize(apiV1.RaidLevel0, 2, 100))
+	assert.Equal(t, int64(34), GetRaidMemberSize(apiV1.RaidLevel0, 3, 100))
+	assert.Equal(t, int64(100), GetRaidMemberSize(apiV1.RaidLevel1, 2, 100))
+	assert.Equal(t, int64(50), GetRaidMemberSize(apiV1.RaidLevel10, 4, 100))
+	assert.Equal(t, int64(100), GetRaidMemberSize("", 0, 100))
+}
+
+func TestGetRaidCapacity(t *testing.T) {
+	assert.Equal(t, int64(200), GetRaidCapacity(apiV1.RaidLevel0, 2, 100))
+	assert.Equal(t, int64(100), GetRaidCapacity(apiV1.RaidLevel1, 3, 100))
+	assert.Equal(t, int64(200), GetRaidCapacity(apiV1.RaidLevel10, 4, 100))
+}
+
+func TestIsRaidSupported(t *testing.T) {
+	assert.True(t, IsRaidSupported(apiV1.StorageClassHDD))
+	assert.True(t, IsRaidSupported(apiV1.StorageClassNVMe))
+	assert.False(t, IsRaidSupported(apiV1.StorageClassAny))
+	assert.False(t, IsRaidSupported(apiV1.StorageClassHDDLVG))
+}
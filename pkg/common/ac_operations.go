@@ -29,8 +29,13 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/util"
 )
 
+// ThinOvercommitRatio is a ratio of capacity available for thin volumes of LVG to its physical size,
+// AC of thin LVG is created with size of LVG multiplied by that ratio
+var ThinOvercommitRatio = 1.0
+
 // AvailableCapacityOperations is the interface for interact with AvailableCapacity CRs from Controller
 type AvailableCapacityOperations interface {
 	RecreateACToLVGSC(ctx context.Context, sc string, acs ...accrd.AvailableCapacity) *accrd.AvailableCapacity
@@ -53,7 +58,7 @@ func NewACOperationsImpl(k8sClient *k8s.KubeClient, l *logrus.Logger) *ACOperati
 }
 
 // RecreateACToLVGSC creates new LVG using locations from provided ACs.
-// Concerts first AC to LVG SC and set size of remaining to 0
+// Concerts first AC to LVG SC and set size of remaining to 0, for thin LVG size of AC is overcommitted
 // Receives newSC as string (e.g. HDDLVG) and AvailableCapacities where LVG should be based
 // Returns created AC or nil
 func (a *ACOperationsImpl) RecreateACToLVGSC(ctx context.Context, newSC string,
@@ -87,8 +92,15 @@ func (a *ACOperationsImpl) RecreateACToLVGSC(ctx context.Context, newSC string,
 			Status:    apiV1.Creating,
 			Health:    apiV1.HealthGood,
 		}
+		acSize = lvgSize
 	)
 
+	if util.IsStorageClassLVGThin(newSC) {
+		acSize = int64(float64(lvgSize) * ThinOvercommitRatio)
+		apiLVG.Thin = true
+		apiLVG.VirtualSize = acSize
+	}
+
 	// create LVG CR based on ACs
 	lvg := a.k8sClient.ConstructLVGCR(name, apiLVG)
 	if err = a.k8sClient.CreateCR(ctx, name, lvg); err != nil {
@@ -99,7 +111,7 @@ func (a *ACOperationsImpl) RecreateACToLVGSC(ctx context.Context, newSC string,
 
 	// convert first AC to LVG type
 	updatedAC := &acs[0]
-	updatedAC.Spec.Size = lvgSize
+	updatedAC.Spec.Size = acSize
 	updatedAC.Spec.Location = lvg.Name
 	updatedAC.Spec.StorageClass = newSC
 	if err = a.k8sClient.UpdateCR(ctx, updatedAC); err != nil {
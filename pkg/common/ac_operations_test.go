@@ -19,7 +19,12 @@ package common
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
 )
 
 var DefaultPESize = capacityplanner.DefaultPESize
@@ -87,6 +92,28 @@ var DefaultPESize = capacityplanner.DefaultPESize
 	return NewACOperationsImpl(k8sClient, testLogger)
 }*/
 
+func Test_recreateACToLVGSC_Thin(t *testing.T) {
+	k8sClient, err := k8s.GetFakeKubeClient(testNS, testLogger)
+	assert.Nil(t, err)
+	ac := testAC1.DeepCopy()
+	assert.Nil(t, k8sClient.CreateCR(testCtx, ac.Name, ac))
+
+	defer func() { ThinOvercommitRatio = 1 }()
+	ThinOvercommitRatio = 2.5
+	lvgSize := capacityplanner.SubtractLVMMetadataSize(ac.Spec.Size)
+
+	newAC := NewACOperationsImpl(k8sClient, testLogger).RecreateACToLVGSC(testCtx, apiV1.StorageClassHDDLVGThin, *ac)
+	assert.NotNil(t, newAC)
+	assert.Equal(t, apiV1.StorageClassHDDLVGThin, newAC.Spec.StorageClass)
+	assert.Equal(t, int64(float64(lvgSize)*2.5), newAC.Spec.Size)
+
+	lvg := &lvgcrd.LogicalVolumeGroup{}
+	assert.Nil(t, k8sClient.ReadCR(testCtx, newAC.Spec.Location, "", lvg))
+	assert.True(t, lvg.Spec.Thin)
+	assert.Equal(t, lvgSize, lvg.Spec.Size)
+	assert.Equal(t, newAC.Spec.Size, lvg.Spec.VirtualSize)
+}
+
 func Test_AlignSizeByPE(t *testing.T) {
 	type args struct {
 		size int64
@@ -0,0 +1,272 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/metrics"
+)
+
+// SnapshotOperations is the interface that unites common Snapshot CRs operations
+type SnapshotOperations interface {
+	CreateSnapshot(ctx context.Context, s api.Snapshot) (*api.Snapshot, error)
+	DeleteSnapshot(ctx context.Context, snapshotID string) error
+	UpdateCRsAfterSnapshotDeletion(ctx context.Context, snapshotID string)
+	WaitStatus(ctx context.Context, snapshotID string, statuses ...string) error
+}
+
+// SnapshotOperationsImpl is the basic implementation of SnapshotOperations interface
+type SnapshotOperationsImpl struct {
+	k8sClient *k8s.KubeClient
+	crHelper  *k8s.CRHelper
+
+	metrics metrics.Statistic
+	log     *logrus.Entry
+}
+
+// NewSnapshotOperationsImpl is the constructor for SnapshotOperationsImpl struct
+// Receives an instance of base.KubeClient and logrus logger
+// Returns an instance of SnapshotOperationsImpl
+func NewSnapshotOperationsImpl(k8sClient *k8s.KubeClient, logger *logrus.Logger) *SnapshotOperationsImpl {
+	snapshotMetrics := metrics.NewMetrics(prometheus.HistogramOpts{
+		Name:    "snapshot_operations_duration",
+		Help:    "Snapshot operations methods duration",
+		Buckets: metrics.ExtendedDefBuckets,
+	}, "method")
+	if err := prometheus.Register(snapshotMetrics.Collect()); err != nil {
+		logger.WithField("component", "NewSnapshotOperationsImpl").
+			Errorf("Failed to register metric: %v", err)
+	}
+	return &SnapshotOperationsImpl{
+		k8sClient: k8sClient,
+		crHelper:  k8s.NewCRHelper(k8sClient, logger),
+		metrics:   snapshotMetrics,
+		log:       logger.WithField("component", "SnapshotOperationsImpl"),
+	}
+}
+
+// CreateSnapshot reserves capacity in LogicalVolumeGroup of the source volume and creates Snapshot CR
+// or returns existed Snapshot CR. Snapshot reserves the same amount of space as source volume has
+// Receives golang context and api.Snapshot with filled Id and VolumeId fields
+// Returns api.Snapshot which is a Spec of created Snapshot CR or error if something went wrong
+func (so *SnapshotOperationsImpl) CreateSnapshot(ctx context.Context, s api.Snapshot) (*api.Snapshot, error) {
+	defer so.metrics.EvaluateDurationForMethod("CreateSnapshot")()
+	ll := so.log.WithFields(logrus.Fields{
+		"method":     "CreateSnapshot",
+		"snapshotID": s.Id,
+		"volumeID":   s.VolumeId,
+	})
+	ll.Infof("Processing snapshot %v", s)
+
+	snapshotCR := &snapshotcrd.Snapshot{}
+	err := so.k8sClient.ReadCR(ctx, s.Id, "", snapshotCR)
+	switch {
+	case err == nil:
+		if snapshotCR.Spec.VolumeId != s.VolumeId {
+			return nil, status.Errorf(codes.AlreadyExists,
+				"snapshot %s already exists for volume %s", s.Id, snapshotCR.Spec.VolumeId)
+		}
+		if snapshotCR.Spec.CSIStatus == apiV1.Failed {
+			ll.Infof("Snapshot has %s status, retry creation", apiV1.Failed)
+			snapshotCR.Spec.CSIStatus = apiV1.Creating
+			if err = so.k8sClient.UpdateCR(ctx, snapshotCR); err != nil {
+				ll.Errorf("Unable to update snapshot CR: %v", err)
+				return nil, status.Error(codes.Internal, "unable to update snapshot")
+			}
+		}
+		return &snapshotCR.Spec, nil
+	case !k8sError.IsNotFound(err):
+		ll.Errorf("Unable to read snapshot CR: %v", err)
+		return nil, status.Error(codes.Internal, "unable to read snapshot")
+	}
+
+	volume, err := so.crHelper.GetVolumeByID(s.VolumeId)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "source volume %s is not found", s.VolumeId)
+	}
+	if !util.IsStorageClassLVG(volume.Spec.StorageClass) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"StorageClass %s doesn't support snapshots", volume.Spec.StorageClass)
+	}
+	if volume.Spec.Encrypted {
+		return nil, status.Error(codes.InvalidArgument, "snapshots of encrypted volumes aren't supported")
+	}
+	// COW snapshot requires free space in VG, but thin pool takes all of it
+	if util.IsStorageClassLVGThin(volume.Spec.StorageClass) {
+		return nil, status.Error(codes.InvalidArgument, "snapshots of thin volumes aren't supported")
+	}
+	switch volume.Spec.CSIStatus {
+	case apiV1.Created, apiV1.VolumeReady, apiV1.Published:
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition,
+			"snapshot can't be created for volume in status %s", volume.Spec.CSIStatus)
+	}
+
+	capacity, err := so.crHelper.GetACByLocation(volume.Spec.Location)
+	if err != nil {
+		ll.Errorf("Failed to get AC by location %s: %v", volume.Spec.Location, err)
+		return nil, status.Error(codes.Internal, "unable to read AC")
+	}
+	if capacity.Spec.Size < volume.Spec.Size {
+		return nil, status.Errorf(codes.ResourceExhausted,
+			"not enough capacity to create snapshot: requested - %d, available - %d", volume.Spec.Size, capacity.Spec.Size)
+	}
+	capacity.Spec.Size -= volume.Spec.Size
+	if err = so.k8sClient.UpdateCRWithAttempts(ctx, capacity, 5); err != nil {
+		ll.Errorf("Failed to update AC: %v", err)
+		return nil, status.Error(codes.Internal, "unable to reserve AC")
+	}
+
+	s.NodeId = volume.Spec.NodeId
+	s.Location = volume.Spec.Location
+	s.StorageClass = volume.Spec.StorageClass
+	s.Size = volume.Spec.Size
+	s.CSIStatus = apiV1.Creating
+	s.Health = apiV1.HealthGood
+	s.CreationTime = time.Now().Unix()
+
+	snapshotCR = so.k8sClient.ConstructSnapshotCR(s.Id, s)
+	if err = so.k8sClient.CreateCR(ctx, s.Id, snapshotCR); err != nil {
+		ll.Errorf("Unable to create snapshot CR: %v", err)
+		capacity.Spec.Size += s.Size
+		if updateErr := so.k8sClient.UpdateCRWithAttempts(ctx, capacity, 5); updateErr != nil {
+			ll.Errorf("Unable to return reserved capacity to AC %s: %v", capacity.Name, updateErr)
+		}
+		return nil, status.Error(codes.Internal, "unable to create snapshot CR")
+	}
+
+	return &s, nil
+}
+
+// DeleteSnapshot changes Snapshot CR status to Removing to trigger removal on the node
+// if Snapshot CR doesn't exist returns NotFound error and that error should be handled by caller
+// Receives golang context and a snapshot ID to delete
+// Returns error if something went wrong or Snapshot with snapshotID wasn't found
+func (so *SnapshotOperationsImpl) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	defer so.metrics.EvaluateDurationForMethod("DeleteSnapshot")()
+	ll := so.log.WithFields(logrus.Fields{
+		"method":     "DeleteSnapshot",
+		"snapshotID": snapshotID,
+	})
+	ll.Info("Processing")
+
+	snapshotCR := &snapshotcrd.Snapshot{}
+	if err := so.k8sClient.ReadCR(ctx, snapshotID, "", snapshotCR); err != nil {
+		return err
+	}
+
+	switch snapshotCR.Spec.CSIStatus {
+	case apiV1.Created, apiV1.Failed:
+	case apiV1.Removing, apiV1.Removed:
+		ll.Debugf("Snapshot has %s status", snapshotCR.Spec.CSIStatus)
+		return nil
+	default:
+		return status.Errorf(codes.FailedPrecondition,
+			"snapshot in status %s can't be deleted", snapshotCR.Spec.CSIStatus)
+	}
+
+	snapshotCR.Spec.CSIStatus = apiV1.Removing
+	return so.k8sClient.UpdateCR(ctx, snapshotCR)
+}
+
+// UpdateCRsAfterSnapshotDeletion should considered as a second step in DeleteSnapshot,
+// removes Snapshot CR and returns reserved capacity to AC of LogicalVolumeGroup
+func (so *SnapshotOperationsImpl) UpdateCRsAfterSnapshotDeletion(ctx context.Context, snapshotID string) {
+	defer so.metrics.EvaluateDurationForMethod("UpdateCRsAfterSnapshotDeletion")()
+	ll := so.log.WithFields(logrus.Fields{
+		"method":     "UpdateCRsAfterSnapshotDeletion",
+		"snapshotID": snapshotID,
+	})
+
+	snapshotCR := &snapshotcrd.Snapshot{}
+	if err := so.k8sClient.ReadCR(ctx, snapshotID, "", snapshotCR); err != nil {
+		if !k8sError.IsNotFound(err) {
+			ll.Errorf("Unable to read snapshot CR: %v. Snapshot CR will not be removed", err)
+		}
+		return
+	}
+
+	if err := so.k8sClient.DeleteCR(ctx, snapshotCR); err != nil {
+		ll.Errorf("Unable to delete snapshot CR: %v", err)
+		return
+	}
+
+	capacity, err := so.crHelper.GetACByLocation(snapshotCR.Spec.Location)
+	if err != nil {
+		ll.Errorf("Snapshot was deleted but AC with location %s wasn't updated: %v", snapshotCR.Spec.Location, err)
+		return
+	}
+	capacity.Spec.Size += snapshotCR.Spec.Size
+	if err = so.k8sClient.UpdateCRWithAttempts(ctx, capacity, 5); err != nil {
+		ll.Errorf("Unable to update AC %s size: %v", capacity.Name, err)
+	}
+}
+
+// WaitStatus checks snapshot status until it will be reached one of the statuses
+// return error if context is done or snapshot reaches failed status, return nil if reached status != failed
+func (so *SnapshotOperationsImpl) WaitStatus(ctx context.Context, snapshotID string, statuses ...string) error {
+	defer so.metrics.EvaluateDurationForMethod("WaitStatus")()
+	ll := so.log.WithFields(logrus.Fields{
+		"method":     "WaitStatus",
+		"snapshotID": snapshotID,
+	})
+
+	ll.Infof("Pulling snapshot status")
+
+	var (
+		s                   = &snapshotcrd.Snapshot{}
+		timeoutBetweenCheck = time.Second
+		err                 error
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			ll.Warnf("Context is done but snapshot still not reach one of the expected status: %v", statuses)
+			return fmt.Errorf("snapshot context is done")
+		case <-time.After(timeoutBetweenCheck):
+			if err = so.k8sClient.ReadCR(ctx, snapshotID, "", s); err != nil {
+				ll.Errorf("Unable to read snapshot CR: %v", err)
+				if k8sError.IsNotFound(err) {
+					return fmt.Errorf("snapshot isn't found")
+				}
+				continue
+			}
+			for _, st := range statuses {
+				if s.Spec.CSIStatus == st {
+					if st == apiV1.Failed {
+						return fmt.Errorf("snapshot has reached Failed status")
+					}
+					return nil
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,253 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+var (
+	testSnapshotID   = "snapshot-1"
+	testSnapshotVol  = "volume-1"
+	testSnapshotLVG  = "lvg-1"
+	testSnapshotSize = int64(1024 * 1024 * 1024)
+)
+
+// creates fake k8s client with LVG based volume and AC of its LogicalVolumeGroup
+// returns instance of SnapshotOperationsImpl based on created k8s client
+func setupSnapshotOperationsTest(t *testing.T, acSize int64) *SnapshotOperationsImpl {
+	k8sClient, err := k8s.GetFakeKubeClient(testNS, testLogger)
+	assert.Nil(t, err)
+
+	volume := k8sClient.ConstructVolumeCR(testSnapshotVol, testNS, map[string]string{}, api.Volume{
+		Id:           testSnapshotVol,
+		NodeId:       testNode1Name,
+		Location:     testSnapshotLVG,
+		StorageClass: apiV1.StorageClassHDDLVG,
+		Size:         testSnapshotSize,
+		CSIStatus:    apiV1.Published,
+	})
+	assert.Nil(t, k8sClient.CreateCR(testCtx, volume.Name, volume))
+
+	ac := k8sClient.ConstructACCR(testSnapshotLVG, api.AvailableCapacity{
+		Location:     testSnapshotLVG,
+		NodeId:       testNode1Name,
+		StorageClass: apiV1.StorageClassHDDLVG,
+		Size:         acSize,
+	})
+	assert.Nil(t, k8sClient.CreateCR(testCtx, ac.Name, ac))
+
+	return NewSnapshotOperationsImpl(k8sClient, testLogger)
+}
+
+func getSnapshotACSize(t *testing.T, so *SnapshotOperationsImpl) int64 {
+	ac := &accrd.AvailableCapacity{}
+	assert.Nil(t, so.k8sClient.ReadCR(testCtx, testSnapshotLVG, "", ac))
+	return ac.Spec.Size
+}
+
+func TestSnapshotOperationsImpl_CreateSnapshot(t *testing.T) {
+	req := api.Snapshot{Id: testSnapshotID, VolumeId: testSnapshotVol}
+
+	t.Run("Snapshot is created", func(t *testing.T) {
+		so := setupSnapshotOperationsTest(t, testSnapshotSize*2)
+
+		snapshot, err := so.CreateSnapshot(testCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, apiV1.Creating, snapshot.CSIStatus)
+		assert.Equal(t, testNode1Name, snapshot.NodeId)
+		assert.Equal(t, testSnapshotLVG, snapshot.Location)
+		assert.Equal(t, testSnapshotSize, snapshot.Size)
+		assert.Equal(t, testSnapshotSize, getSnapshotACSize(t, so))
+
+		snapshotCR := &snapshotcrd.Snapshot{}
+		assert.Nil(t, so.k8sClient.ReadCR(testCtx, testSnapshotID, "", snapshotCR))
+		assert.Equal(t, *snapshot, snapshotCR.Spec)
+
+		// second call returns the same snapshot and doesn't reserve capacity again
+		snapshot, err = so.CreateSnapshot(testCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, testSnapshotID, snapshot.Id)
+		assert.Equal(t, testSnapshotSize, getSnapshotACSize(t, so))
+	})
+
+	t.Run("Snapshot exists for another volume", func(t *testing.T) {
+		so := setupSnapshotOperationsTest(t, testSnapshotSize)
+		snapshotCR := so.k8sClient.ConstructSnapshotCR(testSnapshotID, api.Snapshot{Id: testSnapshotID, VolumeId: "another"})
+		assert.Nil(t, so.k8sClient.CreateCR(testCtx, testSnapshotID, snapshotCR))
+
+		_, err := so.CreateSnapshot(testCtx, req)
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
+
+	t.Run("Failed snapshot is retried", func(t *testing.T) {
+		so := setupSnapshotOperationsTest(t, testSnapshotSize)
+		snapshotCR := so.k8sClient.ConstructSnapshotCR(testSnapshotID,
+			api.Snapshot{Id: testSnapshotID, VolumeId: testSnapshotVol, CSIStatus: apiV1.Failed})
+		assert.Nil(t, so.k8sClient.CreateCR(testCtx, testSnapshotID, snapshotCR))
+
+		snapshot, err := so.CreateSnapshot(testCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, apiV1.Creating, snapshot.CSIStatus)
+	})
+
+	t.Run("Source volume not found", func(t *testing.T) {
+		so := setupSnapshotOperationsTest(t, testSnapshotSize)
+
+		_, err := so.CreateSnapshot(testCtx, api.Snapshot{Id: testSnapshotID, VolumeId: "not-found"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Source volume isn't LVG based", func(t *testing.T) {
+		so := setupSnapshotOperationsTest(t, testSnapshotSize)
+		volume, err := so.crHelper.GetVolumeByID(testSnapshotVol)
+		assert.Nil(t, err)
+		volume.Spec.StorageClass = apiV1.StorageClassHDD
+		assert.Nil(t, so.k8sClient.UpdateCR(testCtx, volume))
+
+		_, err = so.CreateSnapshot(testCtx, req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Source volume is encrypted", func(t *testing.T) {
+		so := setupSnapshotOperationsTest(t, testSnapshotSize)
+		volume, err := so.crHelper.GetVolumeByID(testSnapshotVol)
+		assert.Nil(t, err)
+		volume.Spec.Encrypted = true
+		assert.Nil(t, so.k8sClient.UpdateCR(testCtx, volume))
+
+		_, err = so.CreateSnapshot(testCtx, req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Source volume is thin", func(t *testing.T) {
+		so := setupSnapshotOperationsTest(t, testSnapshotSize)
+		volume, err := so.crHelper.GetVolumeByID(testSnapshotVol)
+		assert.Nil(t, err)
+		volume.Spec.StorageClass = apiV1.StorageClassHDDLVGThin
+		assert.Nil(t, so.k8sClient.UpdateCR(testCtx, volume))
+
+		_, err = so.CreateSnapshot(testCtx, req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Source volume isn't ready", func(t *testing.T) {
+		so := setupSnapshotOperationsTest(t, testSnapshotSize)
+		volume, err := so.crHelper.GetVolumeByID(testSnapshotVol)
+		assert.Nil(t, err)
+		volume.Spec.CSIStatus = apiV1.Removing
+		assert.Nil(t, so.k8sClient.UpdateCR(testCtx, volume))
+
+		_, err = so.CreateSnapshot(testCtx, req)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("Not enough capacity", func(t *testing.T) {
+		so := setupSnapshotOperationsTest(t, testSnapshotSize-1)
+
+		_, err := so.CreateSnapshot(testCtx, req)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, testSnapshotSize-1, getSnapshotACSize(t, so))
+	})
+}
+
+func TestSnapshotOperationsImpl_DeleteSnapshot(t *testing.T) {
+	so := setupSnapshotOperationsTest(t, testSnapshotSize)
+
+	err := so.DeleteSnapshot(testCtx, testSnapshotID)
+	assert.True(t, k8sError.IsNotFound(err))
+
+	snapshotCR := so.k8sClient.ConstructSnapshotCR(testSnapshotID,
+		api.Snapshot{Id: testSnapshotID, VolumeId: testSnapshotVol, CSIStatus: apiV1.Creating})
+	assert.Nil(t, so.k8sClient.CreateCR(testCtx, testSnapshotID, snapshotCR))
+
+	testCases := []struct {
+		status         string
+		expectedCode   codes.Code
+		expectedStatus string
+	}{
+		{apiV1.Creating, codes.FailedPrecondition, apiV1.Creating},
+		{apiV1.Removed, codes.OK, apiV1.Removed},
+		{apiV1.Failed, codes.OK, apiV1.Removing},
+		{apiV1.Created, codes.OK, apiV1.Removing},
+	}
+	for _, tc := range testCases {
+		assert.Nil(t, so.k8sClient.ReadCR(testCtx, testSnapshotID, "", snapshotCR))
+		snapshotCR.Spec.CSIStatus = tc.status
+		assert.Nil(t, so.k8sClient.UpdateCR(testCtx, snapshotCR))
+
+		err = so.DeleteSnapshot(testCtx, testSnapshotID)
+		assert.Equal(t, tc.expectedCode, status.Code(err), tc.status)
+		assert.Nil(t, so.k8sClient.ReadCR(testCtx, testSnapshotID, "", snapshotCR))
+		assert.Equal(t, tc.expectedStatus, snapshotCR.Spec.CSIStatus)
+	}
+}
+
+func TestSnapshotOperationsImpl_UpdateCRsAfterSnapshotDeletion(t *testing.T) {
+	so := setupSnapshotOperationsTest(t, testSnapshotSize)
+	snapshotCR := so.k8sClient.ConstructSnapshotCR(testSnapshotID, api.Snapshot{
+		Id:        testSnapshotID,
+		VolumeId:  testSnapshotVol,
+		Location:  testSnapshotLVG,
+		Size:      testSnapshotSize,
+		CSIStatus: apiV1.Removed,
+	})
+	assert.Nil(t, so.k8sClient.CreateCR(testCtx, testSnapshotID, snapshotCR))
+
+	so.UpdateCRsAfterSnapshotDeletion(testCtx, testSnapshotID)
+	err := so.k8sClient.ReadCR(testCtx, testSnapshotID, "", snapshotCR)
+	assert.True(t, k8sError.IsNotFound(err))
+	assert.Equal(t, testSnapshotSize*2, getSnapshotACSize(t, so))
+
+	// snapshot doesn't exist, AC isn't changed
+	so.UpdateCRsAfterSnapshotDeletion(testCtx, testSnapshotID)
+	assert.Equal(t, testSnapshotSize*2, getSnapshotACSize(t, so))
+}
+
+func TestSnapshotOperationsImpl_WaitStatus(t *testing.T) {
+	so := setupSnapshotOperationsTest(t, testSnapshotSize)
+
+	ctx, cancelFn := context.WithTimeout(testCtx, 2*time.Second)
+	err := so.WaitStatus(ctx, testSnapshotID, apiV1.Created)
+	cancelFn()
+	assert.NotNil(t, err)
+
+	snapshotCR := so.k8sClient.ConstructSnapshotCR(testSnapshotID,
+		api.Snapshot{Id: testSnapshotID, CSIStatus: apiV1.Created})
+	assert.Nil(t, so.k8sClient.CreateCR(testCtx, testSnapshotID, snapshotCR))
+
+	ctx, cancelFn = context.WithTimeout(testCtx, 2*time.Second)
+	defer cancelFn()
+	assert.Nil(t, so.WaitStatus(ctx, testSnapshotID, apiV1.Failed, apiV1.Created))
+
+	snapshotCR.Spec.CSIStatus = apiV1.Failed
+	assert.Nil(t, so.k8sClient.UpdateCR(testCtx, snapshotCR))
+	assert.NotNil(t, so.WaitStatus(ctx, testSnapshotID, apiV1.Failed, apiV1.Created))
+}
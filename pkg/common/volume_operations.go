@@ -35,6 +35,7 @@ import (
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
@@ -155,31 +156,39 @@ func (vo *VolumeOperationsImpl) handleVolumeCreation(ctx context.Context, log *l
 	}
 
 	var (
-		isFound           = false
-		ac                = &accrd.AvailableCapacity{}
+		acs               []*accrd.AvailableCapacity
+		requiredACs       = 1
 		volumeReservation = podReservation.Spec.ReservationRequests[volumeReservationNum]
 		claimLabels       map[string]string
 	)
+	// RAID volume consumes AC of each member drive
+	if v.RaidLevel != "" {
+		requiredACs = int(v.RaidDevices)
+	}
 	// scheduler extender reserves capacity on different nodes during filter stage since 'reserve' API is not available
 	// need to find capacity on requested node
 	for _, capacityName := range volumeReservation.Reservations {
+		// break when found
+		if len(acs) == requiredACs {
+			break
+		}
 		// read available capacity
-		err = vo.k8sClient.ReadCR(ctx, capacityName, "", ac)
+		capacity := &accrd.AvailableCapacity{}
+		err = vo.k8sClient.ReadCR(ctx, capacityName, "", capacity)
 		if err != nil {
 			log.Errorf("Failed to read capacity %s: %v", capacityName, err)
 			return nil, err
 		}
-		// break when found
-		if ac.Spec.NodeId == v.NodeId {
-			isFound = true
-			break
+		if capacity.Spec.NodeId == v.NodeId {
+			acs = append(acs, capacity)
 		}
 	}
 	// capacity must be found when reservation exists
-	if !isFound {
+	if len(acs) < requiredACs {
 		return nil, status.Error(codes.ResourceExhausted,
 			fmt.Sprintf("there is no suitable drive for volume %s", v.Id))
 	}
+	ac := acs[0]
 
 	if ac.Spec.StorageClass != v.StorageClass && util.IsStorageClassLVG(v.StorageClass) {
 		// AC needs to be converted to LogicalVolumeGroup AC, LogicalVolumeGroup doesn't exist yet
@@ -187,6 +196,7 @@ func (vo *VolumeOperationsImpl) handleVolumeCreation(ctx context.Context, log *l
 			return nil, status.Errorf(codes.Internal,
 				"unable to prepare underlying storage for storage class %s", v.StorageClass)
 		}
+		acs[0] = ac
 	}
 	log.Infof("AC %v was selected", ac)
 
@@ -196,12 +206,25 @@ func (vo *VolumeOperationsImpl) handleVolumeCreation(ctx context.Context, log *l
 		sc             = ac.Spec.StorageClass
 		allocatedBytes int64
 		locationType   string
+		raidLocations  []string
 	)
 
-	if util.IsStorageClassLVG(sc) {
+	switch {
+	case v.RaidLevel != "":
+		// RAID member is limited by the smallest drive
+		memberSize := ac.Spec.Size
+		for _, capacity := range acs {
+			raidLocations = append(raidLocations, capacity.Spec.Location)
+			if capacity.Spec.Size < memberSize {
+				memberSize = capacity.Spec.Size
+			}
+		}
+		allocatedBytes = util.GetRaidCapacity(v.RaidLevel, v.RaidDevices, memberSize)
+		locationType = apiV1.LocationTypeRAID
+	case util.IsStorageClassLVG(sc):
 		allocatedBytes = capacityplanner.AlignSizeByPE(v.Size)
 		locationType = apiV1.LocationTypeLVM
-	} else {
+	default:
 		allocatedBytes = ac.Spec.Size
 		locationType = apiV1.LocationTypeDrive
 	}
@@ -229,6 +252,20 @@ func (vo *VolumeOperationsImpl) handleVolumeCreation(ctx context.Context, log *l
 		Usage:             apiV1.VolumeUsageInUse,
 		Mode:              v.Mode,
 		Type:              v.Type,
+		ContentSourceType: v.ContentSourceType,
+		ContentSourceId:   v.ContentSourceId,
+
+		Encrypted:                 v.Encrypted,
+		EncryptionSecretName:      v.EncryptionSecretName,
+		EncryptionSecretNamespace: v.EncryptionSecretNamespace,
+		ErasePolicy:               v.ErasePolicy,
+		MkfsOptions:               v.MkfsOptions,
+
+		RaidLevel:     v.RaidLevel,
+		RaidDevices:   v.RaidDevices,
+		RaidLocations: raidLocations,
+
+		IoLimits: v.IoLimits,
 	}
 	volumeCR := vo.k8sClient.ConstructVolumeCR(v.Id, podNamespace, claimLabels, apiVolume)
 
@@ -238,10 +275,16 @@ func (vo *VolumeOperationsImpl) handleVolumeCreation(ctx context.Context, log *l
 	}
 	vo.cache.Set(v.Id, podNamespace)
 
-	// decrease AC size
-	ac.Spec.Size -= allocatedBytes
-	if err = vo.k8sClient.UpdateCRWithAttempts(ctx, ac, 5); err != nil {
-		log.Errorf("Unable to set size for AC %s to %d, error: %v", ac.Name, ac.Spec.Size, err)
+	// decrease AC size, drives of RAID volume are consumed entirely
+	for _, capacity := range acs {
+		if v.RaidLevel != "" {
+			capacity.Spec.Size = 0
+		} else {
+			capacity.Spec.Size -= allocatedBytes
+		}
+		if err = vo.k8sClient.UpdateCRWithAttempts(ctx, capacity, 5); err != nil {
+			log.Errorf("Unable to set size for AC %s to %d, error: %v", capacity.Name, capacity.Spec.Size, err)
+		}
 	}
 	// release reservation
 	if err := vo.deleteVolumeReservation(ctx, podReservation, volumeReservationNum); err != nil {
@@ -271,6 +314,10 @@ func (vo *VolumeOperationsImpl) handleVolumeInProgress(ctx context.Context, log
 		return &volumeCR.Spec, nil
 	// check timeout only for Creating
 	case apiV1.Creating:
+		// copying of data could take longer than timeout, node sets Failed status if copying fails
+		if volumeCR.Spec.ContentSourceId != "" {
+			return &volumeCR.Spec, nil
+		}
 		expiredAt := volumeCR.ObjectMeta.GetCreationTimestamp().Add(base.DefaultTimeoutForVolumeOperations)
 		if expiredAt.Before(time.Now()) {
 			log.Errorf("Timeout of %s for volume creation exceeded.", base.DefaultTimeoutForVolumeOperations)
@@ -426,6 +473,10 @@ func (vo *VolumeOperationsImpl) UpdateCRsAfterVolumeDeletion(ctx context.Context
 	}
 
 	vo.cache.Delete(volumeID)
+	if volumeCR.Spec.LocationType == apiV1.LocationTypeRAID {
+		vo.releaseRaidCapacity(ctx, ll, &volumeCR)
+		return
+	}
 	// find corresponding AC CR
 	acList := accrd.AvailableCapacityList{}
 	if err = vo.k8sClient.ReadList(ctx, &acList); err != nil {
@@ -473,6 +524,32 @@ func (vo *VolumeOperationsImpl) UpdateCRsAfterVolumeDeletion(ctx context.Context
 	}
 }
 
+// releaseRaidCapacity returns capacity of the RAID member drives to their ACs.
+// Capacity isn't returned for unhealthy drives to avoid new allocations on top of them
+func (vo *VolumeOperationsImpl) releaseRaidCapacity(ctx context.Context, log *logrus.Entry, volume *volumecrd.Volume) {
+	for _, location := range volume.Spec.RaidLocations {
+		drive := &drivecrd.Drive{}
+		if err := vo.k8sClient.ReadCR(ctx, location, "", drive); err != nil {
+			log.Errorf("Unable to read drive %s of RAID volume: %v", location, err)
+			continue
+		}
+		if drive.Spec.Health != apiV1.HealthGood {
+			log.Warnf("Drive %s has %s health, AC size isn't increased", location, drive.Spec.Health)
+			continue
+		}
+
+		acCR, err := vo.crHelper.GetACByLocation(location)
+		if err != nil {
+			log.Errorf("Unable to find available capacity for drive %s: %v", location, err)
+			continue
+		}
+		acCR.Spec.Size = drive.Spec.Size
+		if err = vo.k8sClient.UpdateCRWithAttempts(ctx, acCR, 5); err != nil {
+			log.Errorf("Unable to update AC %s size: %v", acCR.Name, err)
+		}
+	}
+}
+
 // WaitStatus check volume status until it will be reached one of the statuses
 // return error if context is done or volume reaches failed status, return nil if reached status != failed
 func (vo *VolumeOperationsImpl) WaitStatus(ctx context.Context, volumeID string, statuses ...string) error {
@@ -520,7 +597,8 @@ func (vo *VolumeOperationsImpl) WaitStatus(ctx context.Context, volumeID string,
 	}
 }
 
-// ExpandVolume updates Volume status to Resizing to trigger expansion in reconcile, if volume has already had status
+// ExpandVolume updates Volume status to Resizing to trigger expansion in reconcile (LVG based volume)
+// or in NodeExpandVolume (drive based volume), if volume has already had status
 // Resizing or Resized, function doesn't do anything. In case of statuses beside VolumeReady, Created, Published function return error
 // Receive golang context, volume CR, requiredBytes as int
 // Return volume spec, error
@@ -535,25 +613,14 @@ func (vo *VolumeOperationsImpl) ExpandVolume(ctx context.Context, volume *volume
 	case apiV1.Resizing, apiV1.Resized:
 		ll.Debug("Volume is already expanding")
 	case apiV1.VolumeReady, apiV1.Created, apiV1.Published:
-		if !util.IsStorageClassLVG(volume.Spec.StorageClass) {
-			return status.Error(codes.FailedPrecondition,
-				fmt.Sprintf("StorageClass %s doesn't support resizing", volume.Spec.StorageClass))
+		var err error
+		if util.IsStorageClassLVG(volume.Spec.StorageClass) {
+			err = vo.reserveACForExpansion(ctx, volume, requiredBytes)
+		} else {
+			err = vo.checkDriveForExpansion(ctx, volume, requiredBytes)
 		}
-		capacity, err := vo.crHelper.GetACByLocation(volume.Spec.Location)
 		if err != nil {
-			ll.Errorf("Failed to get AC by location %s", volume.Spec.Location)
-			return status.Error(codes.Internal, "Unable to read AC")
-		}
-
-		acSize := requiredBytes - volume.Spec.Size
-		if capacity.Spec.Size < acSize {
-			return status.Error(codes.OutOfRange,
-				fmt.Sprintf("Not enough capacity to expand volume: requested - %d, available - %d", requiredBytes, capacity.Spec.Size))
-		}
-		capacity.Spec.Size -= acSize
-		if err := vo.k8sClient.UpdateCRWithAttempts(ctx, capacity, 5); err != nil {
-			ll.Errorf("Failed to update AC, error: %v", err)
-			return status.Error(codes.Internal, "Unable to reserve AC")
+			return err
 		}
 
 		if volume.Annotations == nil {
@@ -574,6 +641,49 @@ func (vo *VolumeOperationsImpl) ExpandVolume(ctx context.Context, volume *volume
 	return nil
 }
 
+// reserveACForExpansion reserves capacity for expansion of LVG based volume in AC of its LogicalVolumeGroup
+func (vo *VolumeOperationsImpl) reserveACForExpansion(ctx context.Context, volume *volumecrd.Volume, requiredBytes int64) error {
+	ll := vo.log.WithFields(logrus.Fields{
+		"method":   "reserveACForExpansion",
+		"volumeID": volume.Spec.Id,
+	})
+
+	capacity, err := vo.crHelper.GetACByLocation(volume.Spec.Location)
+	if err != nil {
+		ll.Errorf("Failed to get AC by location %s", volume.Spec.Location)
+		return status.Error(codes.Internal, "Unable to read AC")
+	}
+
+	acSize := requiredBytes - volume.Spec.Size
+	if capacity.Spec.Size < acSize {
+		return status.Error(codes.OutOfRange,
+			fmt.Sprintf("Not enough capacity to expand volume: requested - %d, available - %d", requiredBytes, capacity.Spec.Size))
+	}
+	capacity.Spec.Size -= acSize
+	if err := vo.k8sClient.UpdateCRWithAttempts(ctx, capacity, 5); err != nil {
+		ll.Errorf("Failed to update AC, error: %v", err)
+		return status.Error(codes.Internal, "Unable to reserve AC")
+	}
+	return nil
+}
+
+// checkDriveForExpansion checks that drive based volume could be expanded up to requiredBytes.
+// Drive based volume occupies the whole drive and AC of the drive is empty, so nothing is reserved,
+// partition could be expanded only when drive became larger than volume
+func (vo *VolumeOperationsImpl) checkDriveForExpansion(ctx context.Context, volume *volumecrd.Volume, requiredBytes int64) error {
+	drive := &drivecrd.Drive{}
+	if err := vo.k8sClient.ReadCR(ctx, volume.Spec.Location, "", drive); err != nil {
+		vo.log.WithField("volumeID", volume.Spec.Id).Errorf("Failed to read drive %s: %v", volume.Spec.Location, err)
+		return status.Error(codes.Internal, "Unable to read drive")
+	}
+
+	if drive.Spec.Size < requiredBytes {
+		return status.Error(codes.OutOfRange,
+			fmt.Sprintf("Not enough capacity on drive to expand volume: requested - %d, drive size - %d", requiredBytes, drive.Spec.Size))
+	}
+	return nil
+}
+
 // UpdateCRsAfterVolumeExpansion update volume and AC crs after volume expansion
 // Receive golang context, volume spec
 // Return error
@@ -589,11 +699,14 @@ func (vo *VolumeOperationsImpl) UpdateCRsAfterVolumeExpansion(ctx context.Contex
 		volume    = &volumecrd.Volume{}
 	)
 	namespace, err = vo.cache.Get(volID)
-	if err != nil {
-		ll.Errorf("Failed to get namespace from cache, error: %v", err)
-		return
+	if err == nil {
+		err = vo.k8sClient.ReadCR(ctx, volID, namespace, volume)
+	} else {
+		// volume could be created by another instance of VolumeOperations, e.g. expansion is finished on the node
+		ll.Debugf("Failed to get namespace from cache, error: %v. Search volume by ID", err)
+		volume, err = vo.crHelper.GetVolumeByID(volID)
 	}
-	if err = vo.k8sClient.ReadCR(ctx, volID, namespace, volume); err != nil {
+	if err != nil {
 		ll.Errorf("Failed to read volume: %v", err)
 		return
 	}
@@ -605,6 +718,10 @@ func (vo *VolumeOperationsImpl) UpdateCRsAfterVolumeExpansion(ctx context.Contex
 			return
 		}
 		volume.Spec.Size = capacity
+		// capacity isn't reserved for expansion of drive based volume
+		if !util.IsStorageClassLVG(volume.Spec.StorageClass) {
+			break
+		}
 		ac, err := vo.crHelper.GetACByLocation(volume.Spec.Location)
 		if err != nil {
 			ll.Errorf("Failed to read AC: %v", err)
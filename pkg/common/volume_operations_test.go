@@ -37,6 +37,7 @@ import (
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
 	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base/cache"
@@ -146,15 +147,100 @@ func TestVolumeOperationsImpl_CreateVolume_HDDVolumeCreated(t *testing.T) {
 	assert.Nil(t, err)
 
 	createdVolume, err := svc.CreateVolume(ctx, api.Volume{
-		Id:           volumeID,
-		StorageClass: requiredSC,
-		NodeId:       requiredNode,
-		Size:         requiredBytes,
+		Id:                volumeID,
+		StorageClass:      requiredSC,
+		NodeId:            requiredNode,
+		Size:              requiredBytes,
+		ContentSourceType: apiV1.ContentSourceVolume,
+		ContentSourceId:   "source-volume",
 	})
 	assert.Nil(t, err)
+	testVolume.Spec.ContentSourceType = apiV1.ContentSourceVolume
+	testVolume.Spec.ContentSourceId = "source-volume"
 	assert.Equal(t, &testVolume.Spec, createdVolume)
 }
 
+func TestVolumeOperationsImpl_CreateVolume_RAIDVolumeCreated(t *testing.T) {
+	var (
+		svc        = setupVOOperationsTest(t)
+		testVolume = testVolume1.DeepCopy()
+		testPVC    = testPVC1.DeepCopy()
+		acs        = []*accrd.AvailableCapacity{testAC1.DeepCopy(), testAC1.DeepCopy()}
+	)
+	acs[1].Name = "raid-member-ac"
+	acs[1].Spec.Location = testDrive2UUID
+	acs[1].Spec.Size = testAC1.Spec.Size / 2
+
+	parameters := map[string]string{
+		util.ClaimNamespaceKey: testNS,
+		util.ClaimNameKey:      testPVC.Name,
+	}
+	volumeInfo, err := util.NewVolumeInfo(parameters)
+	assert.Nil(t, err)
+	ctx := context.WithValue(testCtx, util.VolumeInfoKey, volumeInfo)
+
+	for _, ac := range acs {
+		assert.Nil(t, svc.k8sClient.CreateCR(ctx, ac.Name, ac))
+	}
+	assert.Nil(t, svc.k8sClient.Create(testCtx, testPVC))
+	testACR := getTestACR(testVolume.Spec.Size, apiV1.StorageClassHDD, parameters[util.ClaimNameKey],
+		testVolume.Namespace, acs)
+	assert.Nil(t, svc.k8sClient.CreateCR(ctx, testACR.Name, testACR))
+
+	createdVolume, err := svc.CreateVolume(ctx, api.Volume{
+		Id:           testVolume.Spec.Id,
+		StorageClass: apiV1.StorageClassHDD,
+		NodeId:       testNode1Name,
+		Size:         acs[1].Spec.Size,
+		RaidLevel:    apiV1.RaidLevel1,
+		RaidDevices:  2,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocationTypeRAID, createdVolume.LocationType)
+	assert.Equal(t, []string{testDrive1UUID, testDrive2UUID}, createdVolume.RaidLocations)
+	assert.Equal(t, testDrive1UUID, createdVolume.Location)
+	// RAID1 is limited by the smallest drive
+	assert.Equal(t, acs[1].Spec.Size, createdVolume.Size)
+
+	for _, ac := range acs {
+		updatedAC := &accrd.AvailableCapacity{}
+		assert.Nil(t, svc.k8sClient.ReadCR(testCtx, ac.Name, "", updatedAC))
+		assert.Equal(t, int64(0), updatedAC.Spec.Size)
+	}
+}
+
+func TestVolumeOperationsImpl_CreateVolume_RAIDNotEnoughAC(t *testing.T) {
+	var (
+		svc        = setupVOOperationsTest(t)
+		testVolume = testVolume1.DeepCopy()
+		testPVC    = testPVC1.DeepCopy()
+		testAC     = testAC1.DeepCopy()
+	)
+
+	parameters := map[string]string{
+		util.ClaimNamespaceKey: testNS,
+		util.ClaimNameKey:      testPVC.Name,
+	}
+	volumeInfo, err := util.NewVolumeInfo(parameters)
+	assert.Nil(t, err)
+	ctx := context.WithValue(testCtx, util.VolumeInfoKey, volumeInfo)
+
+	assert.Nil(t, svc.k8sClient.CreateCR(ctx, testAC.Name, testAC))
+	testACR := getTestACR(testVolume.Spec.Size, apiV1.StorageClassHDD, parameters[util.ClaimNameKey],
+		testVolume.Namespace, []*accrd.AvailableCapacity{testAC})
+	assert.Nil(t, svc.k8sClient.CreateCR(ctx, testACR.Name, testACR))
+
+	_, err = svc.CreateVolume(ctx, api.Volume{
+		Id:           testVolume.Spec.Id,
+		StorageClass: apiV1.StorageClassHDD,
+		NodeId:       testNode1Name,
+		Size:         testAC.Spec.Size,
+		RaidLevel:    apiV1.RaidLevel1,
+		RaidDevices:  2,
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
 func Test_handleVolumeInProgress(t *testing.T) {
 	var (
 		svc             = setupVOOperationsTest(t)
@@ -193,6 +279,14 @@ func Test_handleVolumeInProgress(t *testing.T) {
 	volume, err = svc.handleVolumeInProgress(ctx, logger, testVolume, podName, reservationName)
 	assert.NotNil(t, err)
 	assert.Nil(t, volume)
+
+	// Timeout isn't checked while data is being copied
+	testVolume.Spec.CSIStatus = apiV1.Creating
+	testVolume.Spec.ContentSourceType = apiV1.ContentSourceSnapshot
+	testVolume.Spec.ContentSourceId = "snapshot-1"
+	volume, err = svc.handleVolumeInProgress(ctx, logger, testVolume, podName, reservationName)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.Creating, volume.CSIStatus)
 }
 
 // Volume CR was successfully created, HDDLVG SC
@@ -569,6 +663,45 @@ func TestVolumeOperationsImpl_UpdateCRsAfterVolumeDeletion(t *testing.T) {
 	assert.Equal(t, testAC4.Spec.Size+volumeOne.Spec.Size, updatedAC.Spec.Size)
 }
 
+func TestVolumeOperationsImpl_UpdateCRsAfterVolumeDeletion_RAID(t *testing.T) {
+	var (
+		svc    = setupVOOperationsTest(t)
+		volume = testVolume1.DeepCopy()
+		drives = []*drivecrd.Drive{testDriveCR4.DeepCopy(), testDriveCR4.DeepCopy()}
+		acs    = []*accrd.AvailableCapacity{testAC1.DeepCopy(), testAC1.DeepCopy()}
+	)
+	drives[0].Name, drives[0].Spec.UUID = testDrive1UUID, testDrive1UUID
+	drives[1].Name, drives[1].Spec.UUID = testDrive2UUID, testDrive2UUID
+	drives[0].Spec.Health = apiV1.HealthGood
+	drives[1].Spec.Health = apiV1.HealthBad
+	acs[1].Name = "raid-member-ac"
+	acs[1].Spec.Location = testDrive2UUID
+	for i := range drives {
+		acs[i].Spec.Size = 0
+		assert.Nil(t, svc.k8sClient.CreateCR(testCtx, drives[i].Name, drives[i]))
+		assert.Nil(t, svc.k8sClient.CreateCR(testCtx, acs[i].Name, acs[i]))
+	}
+
+	volume.Spec.LocationType = apiV1.LocationTypeRAID
+	volume.Spec.RaidLevel = apiV1.RaidLevel1
+	volume.Spec.RaidDevices = 2
+	volume.Spec.RaidLocations = []string{testDrive1UUID, testDrive2UUID}
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, volume.Name, volume))
+	svc.cache.Set(volume.Name, volume.Namespace)
+
+	svc.UpdateCRsAfterVolumeDeletion(testCtx, volume.Name)
+	err := svc.k8sClient.ReadCR(testCtx, volume.Name, volume.Namespace, &volumecrd.Volume{})
+	assert.True(t, k8sError.IsNotFound(err))
+
+	// capacity is returned for the healthy drive only
+	updatedAC := &accrd.AvailableCapacity{}
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, acs[0].Name, "", updatedAC))
+	assert.Equal(t, drives[0].Spec.Size, updatedAC.Spec.Size)
+	updatedAC = &accrd.AvailableCapacity{}
+	assert.Nil(t, svc.k8sClient.ReadCR(testCtx, acs[1].Name, "", updatedAC))
+	assert.Equal(t, int64(0), updatedAC.Spec.Size)
+}
+
 func TestVolumeOperationsImpl_ExpandVolume_DifferentStatuses(t *testing.T) {
 	var (
 		svc      *VolumeOperationsImpl
@@ -634,19 +767,27 @@ func TestVolumeOperationsImpl_ExpandVolume_Fail(t *testing.T) {
 		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
 	}
 
-	// Storage class is not lvg
+	// Storage class is not lvg and drive doesn't exist
 	volumeCR.Spec.CSIStatus = apiV1.Created
 	volumeCR.ObjectMeta.ResourceVersion = ""
 	assert.NotNil(t, svc.k8sClient.UpdateCR(testCtx, volumeCR))
-	if err := svc.ExpandVolume(testCtx, volumeCR, capacity); err != nil {
-		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
-	}
+	err := svc.ExpandVolume(testCtx, volumeCR, capacity)
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	// Storage class is not lvg and drive is smaller than required capacity
+	drive := svc.k8sClient.ConstructDriveCR(volumeCR.Spec.Location, api.Drive{
+		UUID: volumeCR.Spec.Location, Size: capacity - 1})
+	assert.Nil(t, svc.k8sClient.CreateCR(testCtx, drive.Name, drive))
+	err = svc.ExpandVolume(testCtx, volumeCR, capacity)
+	assert.NotNil(t, err)
+	assert.Equal(t, codes.OutOfRange, status.Code(err))
 
 	// Failed to get AC
 	volumeCR.ObjectMeta.ResourceVersion = ""
 	volumeCR.Spec.StorageClass = apiV1.StorageClassSystemLVG
 	assert.NotNil(t, svc.k8sClient.UpdateCR(testCtx, volumeCR))
-	err := svc.ExpandVolume(testCtx, volumeCR, capacity)
+	err = svc.ExpandVolume(testCtx, volumeCR, capacity)
 	assert.NotNil(t, err)
 	assert.Equal(t, codes.Internal, status.Code(err))
 
@@ -675,6 +816,7 @@ func TestVolumeOperationsImpl_UpdateCRsAfterVolumeExpansion(t *testing.T) {
 
 	svc.cache.Set(volumeCR.Spec.Id, volumeCR.Namespace)
 	volumeCR.Spec.CSIStatus = apiV1.Failed
+	volumeCR.Spec.StorageClass = apiV1.StorageClassSystemLVG
 	err = svc.k8sClient.CreateCR(testCtx, volumeCR.Spec.Id, volumeCR)
 	volAC := &accrd.AvailableCapacity{
 		TypeMeta:   k8smetav1.TypeMeta{Kind: "AvailableCapacity", APIVersion: apiV1.APIV1Version},
@@ -729,6 +871,27 @@ func TestVolumeOperationsImpl_UpdateCRsAfterVolumeExpansion(t *testing.T) {
 	err = svc.k8sClient.ReadCR(testCtx, volumeCR.Name, volumeCR.Namespace, volumeCR)
 	assert.Nil(t, err)
 	assert.Equal(t, apiV1.Created, volumeCR.Spec.CSIStatus)
+
+	// drive based volume has status failed, capacity wasn't reserved and AC isn't changed
+	volumeCR.Spec.CSIStatus = apiV1.Failed
+	volumeCR.Spec.StorageClass = apiV1.StorageClassHDD
+	volumeCR.Annotations = map[string]string{
+		apiV1.VolumePreviousCapacity: strconv.FormatInt(int64(util.MBYTE), 10),
+	}
+	assert.Nil(t, svc.k8sClient.UpdateCR(testCtx, volumeCR))
+	pAC, err = svc.crHelper.GetACByLocation(volumeCR.Spec.Location)
+	assert.Nil(t, err)
+	// volume namespace isn't cached
+	svc.cache = cache.NewMemCache()
+	svc.UpdateCRsAfterVolumeExpansion(testCtx, volumeCR.Spec.Id, int64(util.GBYTE)*100)
+	uVol := &volumecrd.Volume{}
+	err = svc.k8sClient.ReadCR(testCtx, volumeCR.Name, volumeCR.Namespace, uVol)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(util.MBYTE), uVol.Spec.Size)
+	assert.Empty(t, uVol.Annotations[apiV1.VolumePreviousCapacity])
+	capacity, err = svc.crHelper.GetACByLocation(volumeCR.Spec.Location)
+	assert.Nil(t, err)
+	assert.Equal(t, pAC.Spec.Size, capacity.Spec.Size)
 }
 
 func TestVolumeOperationsImpl_deleteLVGIfVolumesNotExistOrUpdate(t *testing.T) {
@@ -106,6 +106,10 @@ func (d *Controller) reconcileLVG(lvg *lvgcrd.LogicalVolumeGroup) (ctrl.Result,
 	if status == apiV1.Failed || health != apiV1.HealthGood {
 		return ctrl.Result{}, d.resetACSizeOfLVG(name)
 	}
+	// AC of thin LVG is reset when its pool becomes overfilled, restore it after pool usage is back to normal
+	if lvg.Spec.GetThin() {
+		return ctrl.Result{}, d.restoreACSizeOfThinLVG(lvg)
+	}
 	// If LVG is already presented on a machine but doesn't have AC, try to create its AC using annotation with
 	// VG free space
 	size, err := getFreeSpaceFromLVGAnnotation(lvg.Annotations)
@@ -288,6 +292,39 @@ func (d *Controller) resetACSizeOfLVG(lvgName string) error {
 	return nil
 }
 
+// restoreACSizeOfThinLVG sets size of thin LVG AC to its virtual size minus size of volumes placed on it
+func (d *Controller) restoreACSizeOfThinLVG(lvg *lvgcrd.LogicalVolumeGroup) error {
+	ac, err := d.cachedCrHelper.GetACByLocation(lvg.Name)
+	if err != nil {
+		if err == errTypes.ErrorNotFound {
+			// non re-triable error
+			d.log.Errorf("AC CR for LogicalVolumeGroup %s not found", lvg.Name)
+			return nil
+		}
+		return err
+	}
+	volumes, err := d.crHelper.GetVolumesByLocation(context.Background(), lvg.Name)
+	if err != nil {
+		return err
+	}
+
+	size := lvg.Spec.VirtualSize
+	for _, volume := range volumes {
+		size -= volume.Spec.Size
+	}
+	if size < 0 {
+		size = 0
+	}
+	if ac.Spec.Size != size {
+		ac.Spec.Size = size
+		if err := d.client.UpdateCR(context.Background(), ac); err != nil {
+			d.log.Errorf("Unable to update AC CR %s, error: %v.", ac.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
 func (d *Controller) filterUpdateEvent(old runtime.Object, new runtime.Object) bool {
 	var (
 		oldDrive *drivecrd.Drive
@@ -329,7 +366,9 @@ func filter(old api.Drive, new api.Drive) bool {
 func filterLVG(old *lvgcrd.LogicalVolumeGroup, new *lvgcrd.LogicalVolumeGroup) bool {
 	// controller perform reconcile for lvg, which have different statuses, health or annotation field.
 	// Another LVGs are skipped
+	// thin LVG is reconciled when it becomes healthy to restore its AC
 	return (new.Spec.GetHealth() != apiV1.HealthGood && old.Spec.GetHealth() != new.Spec.GetHealth()) ||
+		(new.Spec.GetThin() && old.Spec.GetHealth() != new.Spec.GetHealth()) ||
 		(new.Spec.GetStatus() == apiV1.Failed && old.Spec.GetStatus() != new.Spec.GetStatus()) ||
 		checkLVGAnnotation(old.Annotations, new.Annotations)
 }
@@ -451,6 +451,45 @@ func TestController_ReconcileLVG(t *testing.T) {
 		assert.Equal(t, apiV1.StorageClassSystemLVG, acList.Items[0].Spec.StorageClass)
 	})
 }
+func TestController_ReconcileThinLVG(t *testing.T) {
+	kubeClient, err := k8s.GetFakeKubeClient(ns, testLogger)
+	assert.Nil(t, err)
+	controller := NewCapacityController(kubeClient, kubeClient, testLogger)
+	testAC := acCR1.DeepCopy()
+	testAC.Spec.StorageClass = apiV1.StorageClassHDDLVGThin
+	assert.Nil(t, kubeClient.Create(tCtx, testAC))
+	testLVG := lvgCR1.DeepCopy()
+	testLVG.Spec.Thin = true
+	testLVG.Spec.VirtualSize = testLVG.Spec.Size * 2
+	testLVG.Spec.Health = apiV1.HealthSuspect
+	assert.Nil(t, kubeClient.Create(tCtx, testLVG))
+	volume := kubeClient.ConstructVolumeCR("volume", ns, map[string]string{}, api.Volume{
+		Id:       "volume",
+		Location: testLVG.Name,
+		Size:     int64(util.GBYTE),
+	})
+	assert.Nil(t, kubeClient.Create(tCtx, volume))
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: testLVG.Name}}
+
+	// pool is overfilled, AC is reset
+	_, err = controller.Reconcile(tCtx, req)
+	assert.Nil(t, err)
+	acList := &accrd.AvailableCapacityList{}
+	assert.Nil(t, kubeClient.ReadList(tCtx, acList))
+	assert.Equal(t, int64(0), acList.Items[0].Spec.Size)
+
+	// pool usage is back to normal, AC is restored
+	oldLVG := testLVG.DeepCopy()
+	testLVG.Spec.Health = apiV1.HealthGood
+	assert.True(t, controller.filterUpdateEvent(oldLVG, testLVG))
+	assert.Nil(t, kubeClient.UpdateCR(tCtx, testLVG))
+	_, err = controller.Reconcile(tCtx, req)
+	assert.Nil(t, err)
+	acList = &accrd.AvailableCapacityList{}
+	assert.Nil(t, kubeClient.ReadList(tCtx, acList))
+	assert.Equal(t, testLVG.Spec.VirtualSize-int64(util.GBYTE), acList.Items[0].Spec.Size)
+}
+
 func TestController_ReconcileResourcesNotFound(t *testing.T) {
 	kubeClient, err := k8s.GetFakeKubeClient(ns, testLogger)
 	assert.Nil(t, err)
@@ -0,0 +1,231 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacitycontroller
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	storagev1 "k8s.io/api/storage/v1"
+	storagev1beta1 "k8s.io/api/storage/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/node/common"
+	metricsC "github.com/dell/csi-baremetal/pkg/metrics/common"
+)
+
+const (
+	// StorageCapacityDriverLabel is a label key with driver name which is set for managed CSIStorageCapacity objects
+	StorageCapacityDriverLabel = "csi-baremetal/driver"
+	// StorageCapacityNodeLabel is a label key with node ID which is set for managed CSIStorageCapacity objects
+	StorageCapacityNodeLabel = "csi-baremetal/node"
+
+	storageCapacityNamePrefix = "csisc-"
+)
+
+// StorageCapacityRefreshInterval is the interval of CSIStorageCapacity objects recalculation for each node,
+// it allows to pick up StorageClasses which were created after the last AC change
+var StorageCapacityRefreshInterval = time.Minute * 5
+
+// StorageCapacityController maintains CSIStorageCapacity objects for each node and StorageClass of the driver.
+// Objects are recalculated when AvailableCapacity or AvailableCapacityReservation CRs of the node are changed.
+// storage.k8s.io/v1beta1 API is used since v1 isn't available in the vendored k8s.io/api
+type StorageCapacityController struct {
+	client *k8s.KubeClient
+	log    *logrus.Entry
+}
+
+// NewStorageCapacityController creates new instance of StorageCapacityController structure
+// Receives an instance of base.KubeClient and logrus logger
+// Returns an instance of StorageCapacityController
+func NewStorageCapacityController(client *k8s.KubeClient, log *logrus.Logger) *StorageCapacityController {
+	return &StorageCapacityController{
+		client: client,
+		log:    log.WithField("component", "StorageCapacityController"),
+	}
+}
+
+// SetupWithManager registers StorageCapacityController to ControllerManager
+// Reconcile requests are keyed by node ID
+func (s *StorageCapacityController) SetupWithManager(mgr ctrl.Manager) error {
+	c, err := controller.New("storage-capacity-controller", mgr, controller.Options{Reconciler: s})
+	if err != nil {
+		return err
+	}
+	if err = c.Watch(&source.Kind{Type: &accrd.AvailableCapacity{}},
+		handler.EnqueueRequestsFromMapFunc(mapACToNode)); err != nil {
+		return err
+	}
+	return c.Watch(&source.Kind{Type: &acrcrd.AvailableCapacityReservation{}},
+		handler.EnqueueRequestsFromMapFunc(mapACRToNodes))
+}
+
+// Reconcile creates, updates or removes CSIStorageCapacity objects of the node
+func (s *StorageCapacityController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	defer metricsC.ReconcileDuration.EvaluateDurationForType("csicontroller_storage_capacity_controller")()
+	nodeID := req.Name
+	ctx, cancelFn := context.WithTimeout(ctx, 60*time.Second)
+	defer cancelFn()
+
+	log := s.log.WithFields(logrus.Fields{"method": "Reconcile", "nodeID": nodeID})
+
+	scList := &storagev1.StorageClassList{}
+	if err := s.client.ReadList(ctx, scList); err != nil {
+		log.Errorf("Failed to read StorageClass list: %v", err)
+		return ctrl.Result{}, err
+	}
+	acList := &accrd.AvailableCapacityList{}
+	if err := s.client.ReadList(ctx, acList); err != nil {
+		log.Errorf("Failed to read AC list: %v", err)
+		return ctrl.Result{}, err
+	}
+	acrList := &acrcrd.AvailableCapacityReservationList{}
+	if err := s.client.ReadList(ctx, acrList); err != nil {
+		log.Errorf("Failed to read ACR list: %v", err)
+		return ctrl.Result{}, err
+	}
+	capacityList := &storagev1beta1.CSIStorageCapacityList{}
+	if err := s.client.List(ctx, capacityList, client.InNamespace(s.client.Namespace),
+		client.MatchingLabels{StorageCapacityNodeLabel: nodeID}); err != nil {
+		log.Errorf("Failed to read CSIStorageCapacity list: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	existed := make(map[string]*storagev1beta1.CSIStorageCapacity, len(capacityList.Items))
+	for i := range capacityList.Items {
+		existed[capacityList.Items[i].Name] = &capacityList.Items[i]
+	}
+
+	// node without ACs was removed or isn't discovered yet, all its objects are stale
+	nodeHasACs := false
+	for _, ac := range acList.Items {
+		if ac.Spec.NodeId == nodeID {
+			nodeHasACs = true
+			break
+		}
+	}
+
+	var lastErr error
+	for _, sc := range scList.Items {
+		if sc.Provisioner != base.PluginName || !nodeHasACs {
+			continue
+		}
+		total, maximum := capacityplanner.GetFreeCapacity(nodeID,
+			util.ConvertStorageClass(sc.Parameters[base.StorageTypeKey]), acList.Items, acrList.Items)
+		desired := s.constructStorageCapacity(sc.Name, nodeID, total, maximum)
+
+		current, ok := existed[desired.Name]
+		delete(existed, desired.Name)
+		if !ok {
+			if err := s.client.CreateCR(ctx, desired.Name, desired); err != nil {
+				log.Errorf("Failed to create CSIStorageCapacity for StorageClass %s: %v", sc.Name, err)
+				lastErr = err
+			}
+			continue
+		}
+		if current.Capacity.Cmp(*desired.Capacity) == 0 &&
+			current.MaximumVolumeSize.Cmp(*desired.MaximumVolumeSize) == 0 {
+			continue
+		}
+		current.Capacity = desired.Capacity
+		current.MaximumVolumeSize = desired.MaximumVolumeSize
+		if err := s.client.UpdateCR(ctx, current); err != nil {
+			log.Errorf("Failed to update CSIStorageCapacity %s: %v", current.Name, err)
+			lastErr = err
+		}
+	}
+
+	for _, stale := range existed {
+		if err := s.client.DeleteCR(ctx, stale); client.IgnoreNotFound(err) != nil {
+			log.Errorf("Failed to remove CSIStorageCapacity %s: %v", stale.Name, err)
+			lastErr = err
+		}
+	}
+
+	if lastErr != nil {
+		return ctrl.Result{}, lastErr
+	}
+	return ctrl.Result{RequeueAfter: StorageCapacityRefreshInterval}, nil
+}
+
+// constructStorageCapacity constructs CSIStorageCapacity object for StorageClass and node
+func (s *StorageCapacityController) constructStorageCapacity(scName, nodeID string,
+	total, maximum int64) *storagev1beta1.CSIStorageCapacity {
+	return &storagev1beta1.CSIStorageCapacity{
+		TypeMeta: metav1.TypeMeta{Kind: "CSIStorageCapacity", APIVersion: storagev1beta1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetStorageCapacityName(scName, nodeID),
+			Namespace: s.client.Namespace,
+			Labels: map[string]string{
+				StorageCapacityDriverLabel: base.PluginName,
+				StorageCapacityNodeLabel:   nodeID,
+			},
+		},
+		NodeTopology: &metav1.LabelSelector{
+			MatchLabels: map[string]string{csibmnodeconst.NodeIDTopologyLabelKey: nodeID},
+		},
+		StorageClassName:  scName,
+		Capacity:          resource.NewQuantity(total, resource.BinarySI),
+		MaximumVolumeSize: resource.NewQuantity(maximum, resource.BinarySI),
+	}
+}
+
+// GetStorageCapacityName returns name of CSIStorageCapacity object for StorageClass and node,
+// name is based on hash since both StorageClass name and node ID could be long
+func GetStorageCapacityName(scName, nodeID string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(scName + "/" + nodeID))
+	return fmt.Sprintf("%s%016x", storageCapacityNamePrefix, h.Sum64())
+}
+
+// mapACToNode returns reconcile request for the node of AvailableCapacity
+func mapACToNode(obj client.Object) []reconcile.Request {
+	ac, ok := obj.(*accrd.AvailableCapacity)
+	if !ok || ac.Spec.NodeId == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: ac.Spec.NodeId}}}
+}
+
+// mapACRToNodes returns reconcile requests for the nodes where AvailableCapacityReservation holds capacity
+func mapACRToNodes(obj client.Object) []reconcile.Request {
+	acr, ok := obj.(*acrcrd.AvailableCapacityReservation)
+	if !ok || acr.Spec.NodeRequests == nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(acr.Spec.NodeRequests.Reserved))
+	for _, nodeID := range acr.Spec.NodeRequests.Reserved {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKey{Name: nodeID}})
+	}
+	return requests
+}
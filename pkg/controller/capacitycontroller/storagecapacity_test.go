@@ -0,0 +1,114 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacitycontroller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	storagev1 "k8s.io/api/storage/v1"
+	storagev1beta1 "k8s.io/api/storage/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/node/common"
+)
+
+var (
+	testSCName = "csi-baremetal-sc-hdd"
+	testSC     = storagev1.StorageClass{
+		ObjectMeta:  v1.ObjectMeta{Name: testSCName},
+		Provisioner: base.PluginName,
+		Parameters:  map[string]string{base.StorageTypeKey: "HDD"},
+	}
+	testForeignSC = storagev1.StorageClass{
+		ObjectMeta:  v1.ObjectMeta{Name: "foreign-sc"},
+		Provisioner: "another-driver",
+	}
+)
+
+func readStorageCapacity(kubeClient *k8s.KubeClient, name string) (*storagev1beta1.CSIStorageCapacity, error) {
+	capacity := &storagev1beta1.CSIStorageCapacity{}
+	err := kubeClient.ReadCR(tCtx, name, ns, capacity)
+	return capacity, err
+}
+
+func TestStorageCapacityController_Reconcile(t *testing.T) {
+	kubeClient, err := k8s.GetFakeKubeClient(ns, testLogger)
+	assert.Nil(t, err)
+	assert.Nil(t, kubeClient.CreateCR(tCtx, testSCName, testSC.DeepCopy()))
+	assert.Nil(t, kubeClient.CreateCR(tCtx, testForeignSC.Name, testForeignSC.DeepCopy()))
+	ac := acCR.DeepCopy()
+	assert.Nil(t, kubeClient.CreateCR(tCtx, ac.Name, ac))
+
+	controller := NewStorageCapacityController(kubeClient, testLogger)
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: node1ID}}
+	capacityName := GetStorageCapacityName(testSCName, node1ID)
+
+	// create
+	res, err := controller.Reconcile(tCtx, req)
+	assert.Nil(t, err)
+	assert.Equal(t, ctrl.Result{RequeueAfter: StorageCapacityRefreshInterval}, res)
+	capacity, err := readStorageCapacity(kubeClient, capacityName)
+	assert.Nil(t, err)
+	assert.Equal(t, testSCName, capacity.StorageClassName)
+	assert.Equal(t, acSpec.Size, capacity.Capacity.Value())
+	assert.Equal(t, acSpec.Size, capacity.MaximumVolumeSize.Value())
+	assert.Equal(t, node1ID, capacity.NodeTopology.MatchLabels[csibmnodeconst.NodeIDTopologyLabelKey])
+	capacityList := &storagev1beta1.CSIStorageCapacityList{}
+	assert.Nil(t, kubeClient.ReadList(tCtx, capacityList))
+	assert.Len(t, capacityList.Items, 1)
+
+	// update
+	ac.Spec.Size /= 2
+	assert.Nil(t, kubeClient.UpdateCR(tCtx, ac))
+	_, err = controller.Reconcile(tCtx, req)
+	assert.Nil(t, err)
+	capacity, err = readStorageCapacity(kubeClient, capacityName)
+	assert.Nil(t, err)
+	assert.Equal(t, ac.Spec.Size, capacity.Capacity.Value())
+
+	// remove when node doesn't have ACs
+	assert.Nil(t, kubeClient.DeleteCR(tCtx, ac))
+	_, err = controller.Reconcile(tCtx, req)
+	assert.Nil(t, err)
+	_, err = readStorageCapacity(kubeClient, capacityName)
+	assert.NotNil(t, err)
+}
+
+func TestStorageCapacityController_mapFuncs(t *testing.T) {
+	requests := mapACToNode(acCR.DeepCopy())
+	assert.Len(t, requests, 1)
+	assert.Equal(t, node1ID, requests[0].Name)
+
+	acr := &acrcrd.AvailableCapacityReservation{
+		Spec: api.AvailableCapacityReservation{
+			NodeRequests: &api.NodeRequests{Reserved: []string{node1ID, "node2"}},
+		},
+	}
+	requests = mapACRToNodes(acr)
+	assert.Len(t, requests, 2)
+	assert.Equal(t, "node2", requests[1].Name)
+
+	assert.Nil(t, mapACRToNodes(&acrcrd.AvailableCapacityReservation{}))
+	assert.Nil(t, mapACToNode(drive1CR.DeepCopy()))
+}
@@ -20,24 +20,32 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
 	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/cache"
 	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	"github.com/dell/csi-baremetal/pkg/common"
 	"github.com/dell/csi-baremetal/pkg/controller/mountoptions"
@@ -64,6 +72,8 @@ type CSIControllerService struct {
 
 	svc common.VolumeOperations
 
+	snapshotSvc common.SnapshotOperations
+
 	// to track node health status
 	nodeServicesStateMonitor *node.ServicesStateMonitor
 
@@ -84,6 +94,7 @@ func NewControllerService(k8sClient *k8s.KubeClient, logger *logrus.Logger,
 		k8sclient:                k8sClient,
 		log:                      logger.WithField("component", "CSIControllerService"),
 		svc:                      common.NewVolumeOperationsImpl(k8sClient, logger, cache.NewMemCache(), featureConf),
+		snapshotSvc:              common.NewSnapshotOperationsImpl(k8sClient, logger),
 		nodeServicesStateMonitor: node.NewNodeServicesStateMonitor(k8sClient, logger),
 		IdentityServer:           NewIdentityServer(base.PluginName, base.PluginVersion),
 		crHelper:                 k8s.NewCRHelper(k8sClient, logger),
@@ -169,17 +180,66 @@ func (c *CSIControllerService) CreateVolume(ctx context.Context, req *csi.Create
 		ll.Infof("Preferred node was provided: %s", preferredNode)
 	}
 
+	sourceType, sourceID, sourceNode, err := c.getContentSource(ctx, req, preferredNode)
+	if err != nil {
+		ll.Errorf("Failed to create volume: %v", err)
+		return nil, err
+	}
+	// data is copied locally, so the volume is placed on the node of the source
+	if preferredNode == "" && sourceNode != "" {
+		ll.Infof("Volume is placed on node %s of the source %s %s", sourceNode, sourceType, sourceID)
+		preferredNode = sourceNode
+	}
+
 	// kubernetes specifics
 	volumeInfo, err := util.NewVolumeInfo(req.Parameters)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	encrypted := isEncryptionRequested(req.GetParameters())
+	if encrypted && req.Parameters[base.EncryptionSecretNameKey] == "" {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Parameter %s is required for encrypted volume", base.EncryptionSecretNameKey)
+	}
+
+	storageClass := util.ConvertStorageClass(req.Parameters[base.StorageTypeKey])
+	erasePolicy := req.Parameters[base.ErasePolicyKey]
+	if !util.IsErasePolicySupported(erasePolicy, storageClass) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"Erase policy %s isn't supported for storage type %s", erasePolicy, storageClass)
+	}
+
+	raidLevel, raidDevices, err := util.ParseRaidParameters(req.Parameters)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if raidLevel != "" {
+		if !util.IsRaidSupported(storageClass) {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"RAID volumes aren't supported for storage type %s", storageClass)
+		}
+		if erasePolicy != "" && erasePolicy != apiV1.ErasePolicyWipeFS {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"Erase policy %s isn't supported for RAID volumes", erasePolicy)
+		}
+		if sourceType != "" {
+			return nil, status.Error(codes.InvalidArgument, "RAID volumes can't be created from data source")
+		}
+	}
+
+	ioLimits, err := c.getIOLimits(ctx, req.Parameters, volumeInfo)
+	if err != nil {
+		ll.Errorf("Failed to create volume: %v", err)
+		return nil, err
+	}
+
 	var (
-		fsType   string
-		mode     string
-		vol      *api.Volume
-		ctxValue = context.WithValue(ctx, util.VolumeInfoKey, volumeInfo)
+		fsType      string
+		mkfsOptions string
+		mode        string
+		vol         *api.Volume
+		ctxValue    = context.WithValue(ctx, util.VolumeInfoKey, volumeInfo)
 	)
 
 	if len(req.GetVolumeCapabilities()) == 0 {
@@ -198,12 +258,23 @@ func (c *CSIControllerService) CreateVolume(ctx context.Context, req *csi.Create
 		fsType = strings.ToLower(accessType.Mount.FsType)
 		mode = apiV1.ModeFS
 
-		// check mountFlags
-		if !mountoptions.IsOptionsSupported(accessType.Mount.GetMountFlags()) {
-			err = fmt.Errorf("mountOptions are not supported: %+v", accessType.Mount.GetMountFlags())
+		// check mountFlags, options could be limited to some file systems
+		optionsFsType := fsType
+		if optionsFsType == "" {
+			optionsFsType = base.DefaultFsType
+		}
+		if !mountoptions.IsOptionsSupported(accessType.Mount.GetMountFlags(), optionsFsType) {
+			err = fmt.Errorf("mountOptions are not supported for %s: %+v", optionsFsType, accessType.Mount.GetMountFlags())
 			ll.Errorf("Failed to create volume: %v", err)
 			return nil, err
 		}
+
+		// mkfs options are applied to file system volumes only
+		mkfsOptions = req.Parameters[base.MkfsOptionsKey]
+		if err = fs.ValidateMkFSOptions(fs.FileSystem(optionsFsType), mkfsOptions); err != nil {
+			ll.Errorf("Failed to create volume: %v", err)
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 	}
 
 	// The additional raw mode, perform only if VolumeCapability_Block (the if block above skipped) and SC has specific parameter
@@ -213,12 +284,25 @@ func (c *CSIControllerService) CreateVolume(ctx context.Context, req *csi.Create
 
 	c.reqMu.Lock()
 	vol, err = c.svc.CreateVolume(ctxValue, api.Volume{
-		Id:           req.Name,
-		StorageClass: util.ConvertStorageClass(req.Parameters[base.StorageTypeKey]),
-		NodeId:       preferredNode,
-		Size:         req.GetCapacityRange().GetRequiredBytes(),
-		Mode:         mode,
-		Type:         fsType,
+		Id:                req.Name,
+		StorageClass:      storageClass,
+		NodeId:            preferredNode,
+		Size:              req.GetCapacityRange().GetRequiredBytes(),
+		Mode:              mode,
+		Type:              fsType,
+		ContentSourceType: sourceType,
+		ContentSourceId:   sourceID,
+
+		Encrypted:                 encrypted,
+		EncryptionSecretName:      req.Parameters[base.EncryptionSecretNameKey],
+		EncryptionSecretNamespace: req.Parameters[base.EncryptionSecretNamespaceKey],
+		ErasePolicy:               erasePolicy,
+		MkfsOptions:               mkfsOptions,
+
+		RaidLevel:   raidLevel,
+		RaidDevices: raidDevices,
+
+		IoLimits: ioLimits,
 	})
 	c.reqMu.Unlock()
 
@@ -227,6 +311,13 @@ func (c *CSIControllerService) CreateVolume(ctx context.Context, req *csi.Create
 		return nil, err
 	}
 
+	if vol.CSIStatus == apiV1.Creating && vol.ContentSourceId != "" {
+		// copying of data could take a long time, request will be retried by provisioner
+		ll.Infof("Volume is being populated, progress - %d%%", vol.CloneProgress)
+		return nil, status.Errorf(codes.Aborted, "Volume is being populated from %s %s, progress - %d%%",
+			vol.ContentSourceType, vol.ContentSourceId, vol.CloneProgress)
+	}
+
 	if vol.CSIStatus == apiV1.Creating {
 		ll.Infof("Waiting until volume will reach Created status. Current status - %s", vol.CSIStatus)
 		if err := c.svc.WaitStatus(ctx, vol.Id, apiV1.Failed, apiV1.Created); err != nil {
@@ -244,6 +335,7 @@ func (c *CSIControllerService) CreateVolume(ctx context.Context, req *csi.Create
 			VolumeId:           req.Name,
 			CapacityBytes:      vol.Size,
 			VolumeContext:      req.GetParameters(),
+			ContentSource:      req.GetVolumeContentSource(),
 			AccessibleTopology: topologyList,
 		},
 	}, nil
@@ -266,8 +358,22 @@ func (c *CSIControllerService) DeleteVolume(ctx context.Context, req *csi.Delete
 	}
 	ctxWithID := context.WithValue(context.Background(), base.RequestUUID, req.VolumeId)
 
+	snapshots, err := c.crHelper.GetSnapshotCRs(req.VolumeId)
+	if err != nil {
+		ll.Errorf("Unable to read snapshots: %v", err)
+		return nil, status.Error(codes.Internal, "Unable to read snapshots")
+	}
+	if len(snapshots) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "Volume has %d snapshot(s)", len(snapshots))
+	}
+
+	if err = c.checkContentSourceIsNotUsed(apiV1.ContentSourceVolume, req.VolumeId); err != nil {
+		ll.Errorf("Unable to delete volume: %v", err)
+		return nil, err
+	}
+
 	c.reqMu.Lock()
-	err := c.svc.DeleteVolume(ctxWithID, req.GetVolumeId())
+	err = c.svc.DeleteVolume(ctxWithID, req.GetVolumeId())
 	c.reqMu.Unlock()
 
 	if err != nil {
@@ -346,23 +452,156 @@ func (c *CSIControllerService) ControllerUnpublishVolume(ctx context.Context,
 	return &csi.ControllerUnpublishVolumeResponse{}, nil
 }
 
-// ValidateVolumeCapabilities is not implemented yet
-func (c *CSIControllerService) ValidateVolumeCapabilities(context.Context, *csi.ValidateVolumeCapabilitiesRequest) (*csi.ValidateVolumeCapabilitiesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// ValidateVolumeCapabilities is the implementation of CSI Spec ValidateVolumeCapabilities.
+// Volumes are local, so only single node access modes are confirmed. Block capability is confirmed
+// for volumes in raw mode and mount capability is confirmed for volumes with file system.
+// Receives golang context and CSI Spec ValidateVolumeCapabilitiesRequest
+// Returns CSI Spec ValidateVolumeCapabilitiesResponse or error if volume doesn't exist
+func (c *CSIControllerService) ValidateVolumeCapabilities(ctx context.Context,
+	req *csi.ValidateVolumeCapabilitiesRequest) (*csi.ValidateVolumeCapabilitiesResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method":   "ValidateVolumeCapabilities",
+		"volumeID": req.GetVolumeId(),
+	})
+	ll.Infof("Processing request: %v", req)
+
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume ID missing in request")
+	}
+	if len(req.GetVolumeCapabilities()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume capabilities missing in request")
+	}
+
+	volume, err := c.crHelper.GetVolumeByID(req.GetVolumeId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Volume %s is not found", req.GetVolumeId())
+	}
+
+	for _, capability := range req.GetVolumeCapabilities() {
+		if msg := validateVolumeCapability(capability, volume.Spec.Mode); msg != "" {
+			ll.Infof("Capability %v isn't supported: %s", capability, msg)
+			return &csi.ValidateVolumeCapabilitiesResponse{Message: msg}, nil
+		}
+	}
+
+	return &csi.ValidateVolumeCapabilitiesResponse{
+		Confirmed: &csi.ValidateVolumeCapabilitiesResponse_Confirmed{
+			VolumeContext:      req.GetVolumeContext(),
+			VolumeCapabilities: req.GetVolumeCapabilities(),
+			Parameters:         req.GetParameters(),
+		},
+	}, nil
 }
 
-// ListVolumes is not implemented yet
-func (c *CSIControllerService) ListVolumes(context.Context, *csi.ListVolumesRequest) (*csi.ListVolumesResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// ListVolumes is the implementation of CSI Spec ListVolumes. Volumes are taken from Volume CRs,
+// volume is considered published on its node while it has owners.
+// Receives golang context and CSI Spec ListVolumesRequest
+// Returns CSI Spec ListVolumesResponse or error if starting token is invalid or unable to read Volume CRs
+func (c *CSIControllerService) ListVolumes(ctx context.Context, req *csi.ListVolumesRequest) (*csi.ListVolumesResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method": "ListVolumes",
+	})
+	ll.Infof("Processing request: %v", req)
+
+	start := 0
+	if req.GetStartingToken() != "" {
+		var err error
+		if start, err = strconv.Atoi(req.GetStartingToken()); err != nil || start < 0 {
+			return nil, status.Errorf(codes.Aborted, "Invalid starting token %s", req.GetStartingToken())
+		}
+	}
+
+	volumeCRs, err := c.crHelper.GetVolumeCRs()
+	if err != nil {
+		ll.Errorf("Unable to read volumes: %v", err)
+		return nil, status.Error(codes.Internal, "Unable to read volumes")
+	}
+
+	entries := make([]*csi.ListVolumesResponse_Entry, 0, len(volumeCRs))
+	for i := range volumeCRs {
+		volume := &volumeCRs[i].Spec
+		if volume.CSIStatus == apiV1.Removed {
+			continue
+		}
+		entries = append(entries, &csi.ListVolumesResponse_Entry{
+			Volume: volumeToCSI(volume),
+			Status: &csi.ListVolumesResponse_VolumeStatus{
+				PublishedNodeIds: getPublishedNodeIDs(volume),
+				VolumeCondition:  getVolumeCondition(volume),
+			},
+		})
+	}
+
+	if start > len(entries) {
+		return nil, status.Errorf(codes.Aborted, "Starting token %d is out of range", start)
+	}
+	end := len(entries)
+	if req.GetMaxEntries() > 0 && start+int(req.GetMaxEntries()) < end {
+		end = start + int(req.GetMaxEntries())
+	}
+
+	resp := &csi.ListVolumesResponse{Entries: entries[start:end]}
+	if end < len(entries) {
+		resp.NextToken = strconv.Itoa(end)
+	}
+	return resp, nil
 }
 
-// GetCapacity is not implemented yet
-func (c *CSIControllerService) GetCapacity(context.Context, *csi.GetCapacityRequest) (*csi.GetCapacityResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// GetCapacity is the implementation of CSI Spec GetCapacity.
+// Capacity is calculated from AvailableCapacity CRs of the storage class which is taken from parameters,
+// capacity held by confirmed AvailableCapacityReservations is excluded. If accessible topology contains node ID
+// only that node is taken into account, otherwise capacity of all nodes is summed.
+// Receives golang context and CSI Spec GetCapacityRequest
+// Returns CSI Spec GetCapacityResponse or error if unable to read CRs
+func (c *CSIControllerService) GetCapacity(ctx context.Context, req *csi.GetCapacityRequest) (*csi.GetCapacityResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method": "GetCapacity",
+	})
+
+	var (
+		storageClass = util.ConvertStorageClass(req.GetParameters()[base.StorageTypeKey])
+		nodeID       = req.GetAccessibleTopology().GetSegments()[csibmnodeconst.NodeIDTopologyLabelKey]
+		acList       = &accrd.AvailableCapacityList{}
+		acrList      = &acrcrd.AvailableCapacityReservationList{}
+	)
+	if err := c.k8sclient.ReadList(ctx, acList); err != nil {
+		ll.Errorf("Unable to read AC list: %v", err)
+		return nil, status.Error(codes.Internal, "unable to read available capacity")
+	}
+	if err := c.k8sclient.ReadList(ctx, acrList); err != nil {
+		ll.Errorf("Unable to read ACR list: %v", err)
+		return nil, status.Error(codes.Internal, "unable to read capacity reservations")
+	}
+
+	nodes := map[string]struct{}{}
+	if nodeID != "" {
+		nodes[nodeID] = struct{}{}
+	} else {
+		for _, ac := range acList.Items {
+			nodes[ac.Spec.NodeId] = struct{}{}
+		}
+	}
+
+	var total, maximum int64
+	for node := range nodes {
+		nodeTotal, nodeMaximum := capacityplanner.GetFreeCapacity(node, storageClass, acList.Items, acrList.Items)
+		total += nodeTotal
+		if nodeMaximum > maximum {
+			maximum = nodeMaximum
+		}
+	}
+
+	ll.Debugf("Capacity for storage class %s and node %q: total - %d, maximum volume size - %d",
+		storageClass, nodeID, total, maximum)
+	return &csi.GetCapacityResponse{
+		AvailableCapacity: total,
+		MaximumVolumeSize: &wrappers.Int64Value{Value: maximum},
+	}, nil
 }
 
 // ControllerGetCapabilities is the implementation of CSI Spec ControllerGetCapabilities.
-// Provides Controller capabilities of CSI driver to k8s CREATE/DELETE Volume and PUBLISH/UNPUBLISH Volume for now.
+// Provides Controller capabilities of CSI driver to k8s CREATE/DELETE and PUBLISH/UNPUBLISH Volume, EXPAND Volume,
+// CREATE/DELETE and LIST Snapshots, CLONE Volume, GET Capacity, LIST/GET Volumes with published nodes and condition.
 // Receives golang context and CSI Spec ControllerGetCapabilitiesRequest
 // Returns CSI Spec ControllerGetCapabilitiesResponse and nil error
 func (c *CSIControllerService) ControllerGetCapabilities(context.Context, *csi.ControllerGetCapabilitiesRequest) (*csi.ControllerGetCapabilitiesResponse, error) {
@@ -385,6 +624,14 @@ func (c *CSIControllerService) ControllerGetCapabilities(context.Context, *csi.C
 		csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
 		csi.ControllerServiceCapability_RPC_PUBLISH_UNPUBLISH_VOLUME,
 		csi.ControllerServiceCapability_RPC_EXPAND_VOLUME,
+		csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT,
+		csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS,
+		csi.ControllerServiceCapability_RPC_CLONE_VOLUME,
+		csi.ControllerServiceCapability_RPC_GET_CAPACITY,
+		csi.ControllerServiceCapability_RPC_LIST_VOLUMES,
+		csi.ControllerServiceCapability_RPC_LIST_VOLUMES_PUBLISHED_NODES,
+		csi.ControllerServiceCapability_RPC_GET_VOLUME,
+		csi.ControllerServiceCapability_RPC_VOLUME_CONDITION,
 	} {
 		caps = append(caps, newCap(c))
 	}
@@ -398,24 +645,175 @@ func (c *CSIControllerService) ControllerGetCapabilities(context.Context, *csi.C
 	return resp, nil
 }
 
-// CreateSnapshot is not implemented yet
-func (c *CSIControllerService) CreateSnapshot(context.Context, *csi.CreateSnapshotRequest) (*csi.CreateSnapshotResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// CreateSnapshot is the implementation of CSI Spec CreateSnapshot. Snapshots are supported for LVG based volumes only.
+// This method creates Snapshot CR with Creating CSIStatus and waits for snapshot to be created by Reconcile loop
+// of appropriate Node.
+// Receives golang context and CSI Spec CreateSnapshotRequest
+// Returns CSI Spec CreateSnapshotResponse or error if something went wrong
+func (c *CSIControllerService) CreateSnapshot(ctx context.Context, req *csi.CreateSnapshotRequest) (*csi.CreateSnapshotResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method":     "CreateSnapshot",
+		"snapshotID": req.GetName(),
+		"volumeID":   req.GetSourceVolumeId(),
+	})
+	ll.Infof("Processing request: %+v", req)
+
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Snapshot name missing in request")
+	}
+	if req.GetSourceVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Source volume ID missing in request")
+	}
+
+	c.reqMu.Lock()
+	snapshot, err := c.snapshotSvc.CreateSnapshot(ctx, api.Snapshot{
+		Id:       req.GetName(),
+		VolumeId: req.GetSourceVolumeId(),
+	})
+	c.reqMu.Unlock()
+
+	if err != nil {
+		ll.Errorf("Failed to create snapshot: %v", err)
+		return nil, err
+	}
+
+	if snapshot.CSIStatus == apiV1.Creating {
+		ll.Infof("Waiting until snapshot will reach Created status. Current status - %s", snapshot.CSIStatus)
+		if err = c.snapshotSvc.WaitStatus(ctx, snapshot.Id, apiV1.Failed, apiV1.Created); err != nil {
+			return nil, status.Error(codes.Internal, "Unable to create snapshot")
+		}
+		snapshot.CSIStatus = apiV1.Created
+	}
+
+	return &csi.CreateSnapshotResponse{Snapshot: snapshotToCSI(snapshot)}, nil
 }
 
-// DeleteSnapshot is not implemented yet
-func (c *CSIControllerService) DeleteSnapshot(context.Context, *csi.DeleteSnapshotRequest) (*csi.DeleteSnapshotResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// DeleteSnapshot is the implementation of CSI Spec DeleteSnapshot. This method sets Snapshot CR's Spec.CSIStatus
+// to Removing and waits for snapshot to be removed by Reconcile loop of appropriate Node.
+// Receives golang context and CSI Spec DeleteSnapshotRequest
+// Returns CSI Spec DeleteSnapshotResponse or error if something went wrong
+func (c *CSIControllerService) DeleteSnapshot(ctx context.Context, req *csi.DeleteSnapshotRequest) (*csi.DeleteSnapshotResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method":     "DeleteSnapshot",
+		"snapshotID": req.GetSnapshotId(),
+	})
+	ll.Infof("Processing request: %v", req)
+
+	if req.GetSnapshotId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Snapshot ID must be provided")
+	}
+	ctxWithID := context.WithValue(context.Background(), base.RequestUUID, req.GetSnapshotId())
+
+	if err := c.checkContentSourceIsNotUsed(apiV1.ContentSourceSnapshot, req.GetSnapshotId()); err != nil {
+		ll.Errorf("Unable to delete snapshot: %v", err)
+		return nil, err
+	}
+
+	c.reqMu.Lock()
+	err := c.snapshotSvc.DeleteSnapshot(ctxWithID, req.GetSnapshotId())
+	c.reqMu.Unlock()
+
+	if err != nil {
+		if k8sError.IsNotFound(err) {
+			ll.Infof("Snapshot doesn't exist")
+			return &csi.DeleteSnapshotResponse{}, nil
+		}
+		ll.Errorf("Unable to delete snapshot: %v", err)
+		return nil, err
+	}
+
+	if err = c.snapshotSvc.WaitStatus(ctx, req.GetSnapshotId(), apiV1.Failed, apiV1.Removed); err != nil {
+		return nil, status.Error(codes.Internal, "Unable to delete snapshot")
+	}
+
+	c.reqMu.Lock()
+	c.snapshotSvc.UpdateCRsAfterSnapshotDeletion(ctxWithID, req.GetSnapshotId())
+	c.reqMu.Unlock()
+
+	ll.Debug("Snapshot was successfully deleted")
+
+	return &csi.DeleteSnapshotResponse{}, nil
 }
 
-// ListSnapshots is not implemented yet
-func (c *CSIControllerService) ListSnapshots(context.Context, *csi.ListSnapshotsRequest) (*csi.ListSnapshotsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// ListSnapshots is the implementation of CSI Spec ListSnapshots. This method reads Snapshot CRs,
+// filters them by snapshot ID or source volume ID and returns requested page of them.
+// StartingToken is an index of the first snapshot to return
+// Receives golang context and CSI Spec ListSnapshotsRequest
+// Returns CSI Spec ListSnapshotsResponse or error if something went wrong
+func (c *CSIControllerService) ListSnapshots(ctx context.Context, req *csi.ListSnapshotsRequest) (*csi.ListSnapshotsResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method": "ListSnapshots",
+	})
+	ll.Infof("Processing request: %v", req)
+
+	start := 0
+	if req.GetStartingToken() != "" {
+		var err error
+		if start, err = strconv.Atoi(req.GetStartingToken()); err != nil || start < 0 {
+			return nil, status.Errorf(codes.Aborted, "Invalid starting token %s", req.GetStartingToken())
+		}
+	}
+
+	snapshotCRs, err := c.crHelper.GetSnapshotCRs()
+	if err != nil {
+		ll.Errorf("Unable to read snapshots: %v", err)
+		return nil, status.Error(codes.Internal, "Unable to read snapshots")
+	}
+
+	entries := make([]*csi.ListSnapshotsResponse_Entry, 0)
+	for i := range snapshotCRs {
+		snapshot := &snapshotCRs[i].Spec
+		if req.GetSnapshotId() != "" && snapshot.Id != req.GetSnapshotId() {
+			continue
+		}
+		if req.GetSourceVolumeId() != "" && snapshot.VolumeId != req.GetSourceVolumeId() {
+			continue
+		}
+		entries = append(entries, &csi.ListSnapshotsResponse_Entry{Snapshot: snapshotToCSI(snapshot)})
+	}
+
+	if start > len(entries) {
+		return nil, status.Errorf(codes.Aborted, "Starting token %d is out of range", start)
+	}
+	end := len(entries)
+	if req.GetMaxEntries() > 0 && start+int(req.GetMaxEntries()) < end {
+		end = start + int(req.GetMaxEntries())
+	}
+
+	resp := &csi.ListSnapshotsResponse{Entries: entries[start:end]}
+	if end < len(entries) {
+		resp.NextToken = strconv.Itoa(end)
+	}
+	return resp, nil
 }
 
-// ControllerGetVolume is not implemented yet
-func (c *CSIControllerService) ControllerGetVolume(ctx context.Context, request *csi.ControllerGetVolumeRequest) (*csi.ControllerGetVolumeResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "not implemented yet")
+// ControllerGetVolume is the implementation of CSI Spec ControllerGetVolume.
+// Health and OperationalStatus of the Volume CR are reported as volume condition
+// Receives golang context and CSI Spec ControllerGetVolumeRequest
+// Returns CSI Spec ControllerGetVolumeResponse or error if volume doesn't exist
+func (c *CSIControllerService) ControllerGetVolume(ctx context.Context, req *csi.ControllerGetVolumeRequest) (*csi.ControllerGetVolumeResponse, error) {
+	ll := c.log.WithFields(logrus.Fields{
+		"method":   "ControllerGetVolume",
+		"volumeID": req.GetVolumeId(),
+	})
+	ll.Debugf("Processing request: %v", req)
+
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume ID missing in request")
+	}
+
+	volume, err := c.crHelper.GetVolumeByID(req.GetVolumeId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "Volume %s is not found", req.GetVolumeId())
+	}
+
+	return &csi.ControllerGetVolumeResponse{
+		Volume: volumeToCSI(&volume.Spec),
+		Status: &csi.ControllerGetVolumeResponse_VolumeStatus{
+			PublishedNodeIds: getPublishedNodeIDs(&volume.Spec),
+			VolumeCondition:  getVolumeCondition(&volume.Spec),
+		},
+	}, nil
 }
 
 // ControllerExpandVolume is the implementation of CSI Spec ControllerExpandVolume.
@@ -423,6 +821,8 @@ func (c *CSIControllerService) ControllerGetVolume(ctx context.Context, request
 // After it controller wait for volume to have previous status, in case of Failed status it tries to return AC size back
 // In case of volume size is equal or less than requiredBytes than ControllerExpandVolume does nothing
 // In case of status different from Volume_Ready, Created, Published and Resizing Controller returns error
+// Drive based volumes are expanded on the node, for them controller doesn't wait and requires NodeExpandVolume
+// Expansion of encrypted volumes and volumes with f2fs isn't supported
 // Receives golang context and CSI Spec ControllerExpandVolumeRequest
 // Returns CSI Spec ControllerExpandVolumeResponse or error if something went wrong
 func (c *CSIControllerService) ControllerExpandVolume(ctx context.Context, req *csi.ControllerExpandVolumeRequest) (*csi.ControllerExpandVolumeResponse, error) {
@@ -434,7 +834,7 @@ func (c *CSIControllerService) ControllerExpandVolume(ctx context.Context, req *
 	var (
 		volID         = req.GetVolumeId()
 		ctxWithID     = context.WithValue(context.Background(), base.RequestUUID, volID)
-		requiredBytes = capacityplanner.AlignSizeByPE(req.GetCapacityRange().GetRequiredBytes())
+		requiredBytes = req.GetCapacityRange().GetRequiredBytes()
 	)
 
 	if volID == "" {
@@ -446,6 +846,16 @@ func (c *CSIControllerService) ControllerExpandVolume(ctx context.Context, req *
 	if err != nil {
 		return nil, status.Error(codes.NotFound, "Volume doesn't exist")
 	}
+	if volume.Spec.Encrypted {
+		return nil, status.Error(codes.InvalidArgument, "Expansion of encrypted volumes isn't supported")
+	}
+	if fs.FileSystem(volume.Spec.Type) == fs.F2FS {
+		return nil, status.Error(codes.InvalidArgument, "Expansion of f2fs volumes isn't supported")
+	}
+	isLVG := util.IsStorageClassLVG(volume.Spec.StorageClass)
+	if isLVG {
+		requiredBytes = capacityplanner.AlignSizeByPE(requiredBytes)
+	}
 	if volume.Spec.Size == requiredBytes || volume.Spec.Size > requiredBytes {
 		return &csi.ControllerExpandVolumeResponse{
 			CapacityBytes:         0,
@@ -461,6 +871,14 @@ func (c *CSIControllerService) ControllerExpandVolume(ctx context.Context, req *
 		return nil, err
 	}
 
+	if !isLVG {
+		// partition and file system are grown by the node in NodeExpandVolume
+		return &csi.ControllerExpandVolumeResponse{
+			CapacityBytes:         requiredBytes,
+			NodeExpansionRequired: true,
+		}, nil
+	}
+
 	err = c.svc.WaitStatus(ctxWithID, volID, apiV1.Failed, apiV1.Resized)
 
 	c.reqMu.Lock()
@@ -477,6 +895,191 @@ func (c *CSIControllerService) ControllerExpandVolume(ctx context.Context, req *
 	}, nil
 }
 
+// snapshotToCSI converts Snapshot CR spec to CSI Spec Snapshot
+func snapshotToCSI(snapshot *api.Snapshot) *csi.Snapshot {
+	return &csi.Snapshot{
+		SizeBytes:      snapshot.Size,
+		SnapshotId:     snapshot.Id,
+		SourceVolumeId: snapshot.VolumeId,
+		CreationTime:   &timestamp.Timestamp{Seconds: snapshot.CreationTime},
+		ReadyToUse:     snapshot.CSIStatus == apiV1.Created && snapshot.Health != apiV1.HealthBad,
+	}
+}
+
+// volumeToCSI converts Volume CR spec to CSI Spec Volume
+func volumeToCSI(volume *api.Volume) *csi.Volume {
+	return &csi.Volume{
+		VolumeId:      volume.Id,
+		CapacityBytes: volume.Size,
+		AccessibleTopology: []*csi.Topology{
+			{Segments: map[string]string{csibmnodeconst.NodeIDTopologyLabelKey: volume.NodeId}},
+		},
+	}
+}
+
+// getPublishedNodeIDs returns node of the volume if it's published to any pod
+func getPublishedNodeIDs(volume *api.Volume) []string {
+	if len(volume.Owners) == 0 {
+		return nil
+	}
+	return []string{volume.NodeId}
+}
+
+// getVolumeCondition converts Health, CSIStatus, OperationalStatus and Usage of the volume to CSI Spec VolumeCondition
+// according to the volume health monitoring proposal
+func getVolumeCondition(volume *api.Volume) *csi.VolumeCondition {
+	problems := make([]string, 0)
+	if volume.Health != apiV1.HealthGood {
+		problems = append(problems, fmt.Sprintf("health is %s", volume.Health))
+	}
+	if volume.CSIStatus == apiV1.Failed {
+		problems = append(problems, fmt.Sprintf("status is %s", volume.CSIStatus))
+	}
+	if volume.OperationalStatus == apiV1.OperationalStatusMissing ||
+		volume.OperationalStatus == apiV1.OperationalStatusInoperative {
+		problems = append(problems, fmt.Sprintf("operational status is %s", volume.OperationalStatus))
+	}
+	if volume.Usage == apiV1.VolumeUsageFailed {
+		problems = append(problems, fmt.Sprintf("usage is %s", volume.Usage))
+	}
+
+	if len(problems) == 0 {
+		return &csi.VolumeCondition{Message: "Volume is healthy"}
+	}
+	return &csi.VolumeCondition{Abnormal: true, Message: "Volume " + strings.Join(problems, ", ")}
+}
+
+// validateVolumeCapability returns reason why capability isn't supported for the volume in mode
+// or empty string if capability is supported
+func validateVolumeCapability(capability *csi.VolumeCapability, mode string) string {
+	switch capability.GetAccessMode().GetMode() {
+	case csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER,
+		csi.VolumeCapability_AccessMode_SINGLE_NODE_READER_ONLY,
+		csi.VolumeCapability_AccessMode_SINGLE_NODE_SINGLE_WRITER,
+		csi.VolumeCapability_AccessMode_SINGLE_NODE_MULTI_WRITER:
+	default:
+		return fmt.Sprintf("access mode %s isn't supported", capability.GetAccessMode().GetMode())
+	}
+	isRaw := mode == apiV1.ModeRAW || mode == apiV1.ModeRAWPART
+	if capability.GetBlock() != nil && !isRaw {
+		return fmt.Sprintf("block access isn't supported for volume in %s mode", mode)
+	}
+	if capability.GetMount() != nil && isRaw {
+		return fmt.Sprintf("mount access isn't supported for volume in %s mode", mode)
+	}
+	return ""
+}
+
+// getContentSource validates content source of CreateVolumeRequest and returns its type, ID and node,
+// empty values are returned when content source isn't provided.
+// Data is copied locally on the node, so the source must be located on the preferred node
+// and the volume must be placed on the node of the source.
+// Data is copied between mapper devices for encrypted volumes, so encryption of the source must match the request
+func (c *CSIControllerService) getContentSource(ctx context.Context, req *csi.CreateVolumeRequest,
+	preferredNode string) (string, string, string, error) {
+	source := req.GetVolumeContentSource()
+	if source == nil {
+		return "", "", "", nil
+	}
+
+	var (
+		sourceType, sourceID string
+		sourceNode, sourceSC string
+		sourceSize           int64
+		sourceEncrypted      bool
+	)
+	switch {
+	case source.GetSnapshot() != nil:
+		sourceType, sourceID = apiV1.ContentSourceSnapshot, source.GetSnapshot().GetSnapshotId()
+		snapshot := &snapshotcrd.Snapshot{}
+		if err := c.k8sclient.ReadCR(ctx, sourceID, "", snapshot); err != nil {
+			if k8sError.IsNotFound(err) {
+				return "", "", "", status.Errorf(codes.NotFound, "Source snapshot %s is not found", sourceID)
+			}
+			return "", "", "", status.Error(codes.Internal, "Unable to read source snapshot")
+		}
+		if snapshot.Spec.CSIStatus != apiV1.Created || snapshot.Spec.Health == apiV1.HealthBad {
+			return "", "", "", status.Errorf(codes.FailedPrecondition, "Source snapshot %s isn't ready to use", sourceID)
+		}
+		sourceNode, sourceSC, sourceSize = snapshot.Spec.NodeId, snapshot.Spec.StorageClass, snapshot.Spec.Size
+	case source.GetVolume() != nil:
+		sourceType, sourceID = apiV1.ContentSourceVolume, source.GetVolume().GetVolumeId()
+		volume, err := c.crHelper.GetVolumeByID(sourceID)
+		if err != nil {
+			return "", "", "", status.Errorf(codes.NotFound, "Source volume %s is not found", sourceID)
+		}
+		switch volume.Spec.CSIStatus {
+		case apiV1.Created, apiV1.VolumeReady, apiV1.Published:
+		default:
+			return "", "", "", status.Errorf(codes.FailedPrecondition,
+				"Source volume %s in status %s can't be cloned", sourceID, volume.Spec.CSIStatus)
+		}
+		sourceNode, sourceSC, sourceSize = volume.Spec.NodeId, volume.Spec.StorageClass, volume.Spec.Size
+		sourceEncrypted = volume.Spec.Encrypted
+	default:
+		return "", "", "", status.Error(codes.InvalidArgument, "Unsupported volume content source")
+	}
+
+	if sourceEncrypted != isEncryptionRequested(req.GetParameters()) {
+		return "", "", "", status.Errorf(codes.InvalidArgument,
+			"Encryption of the volume doesn't match encryption of source %s %s", sourceType, sourceID)
+	}
+	if preferredNode != "" && preferredNode != sourceNode {
+		return "", "", "", status.Errorf(codes.ResourceExhausted,
+			"Source %s %s is located on node %s, but %s was requested", sourceType, sourceID, sourceNode, preferredNode)
+	}
+	// size of drive based volume is a size of the whole drive and can't be compared with requested size,
+	// destination partition size is checked during copying on the node
+	required := req.GetCapacityRange().GetRequiredBytes()
+	if util.IsStorageClassLVG(sourceSC) && required > 0 && capacityplanner.AlignSizeByPE(required) < sourceSize {
+		return "", "", "", status.Errorf(codes.OutOfRange,
+			"Requested size %d is less than size of source %s %s - %d", required, sourceType, sourceID, sourceSize)
+	}
+
+	return sourceType, sourceID, sourceNode, nil
+}
+
+// checkContentSourceIsNotUsed returns FailedPrecondition error when volume or snapshot with sourceID
+// is used for population of volume in Creating status
+func (c *CSIControllerService) checkContentSourceIsNotUsed(sourceType, sourceID string) error {
+	volumes, err := c.crHelper.GetVolumeCRs()
+	if err != nil {
+		c.log.WithField("method", "checkContentSourceIsNotUsed").Errorf("Unable to read volumes: %v", err)
+		return status.Error(codes.Internal, "Unable to read volumes")
+	}
+
+	for _, volume := range volumes {
+		if volume.Spec.CSIStatus == apiV1.Creating &&
+			volume.Spec.ContentSourceType == sourceType && volume.Spec.ContentSourceId == sourceID {
+			return status.Errorf(codes.FailedPrecondition, "%s %s is used for population of volume %s",
+				sourceType, sourceID, volume.Spec.Id)
+		}
+	}
+	return nil
+}
+
+// getIOLimits reads IO limits from StorageClass parameters and annotations of the PVC
+// Returns nil if limits aren't requested or status error if PVC can't be read or limits are invalid
+func (c *CSIControllerService) getIOLimits(ctx context.Context, params map[string]string,
+	volumeInfo *util.VolumeInfo) (*api.IOLimits, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := c.k8sclient.Get(ctx, k8sCl.ObjectKey{Name: volumeInfo.Name, Namespace: volumeInfo.Namespace}, pvc)
+	if err != nil && !k8sError.IsNotFound(err) {
+		c.log.WithField("method", "getIOLimits").Errorf("Unable to read PVC %s: %v", volumeInfo.Name, err)
+		return nil, status.Error(codes.Internal, "unable to read PVC")
+	}
+
+	limits, err := util.ParseIOLimits(params, pvc.GetAnnotations())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return limits, nil
+}
+
+func isEncryptionRequested(params map[string]string) bool {
+	return params[base.EncryptedKey] == "true"
+}
+
 func isNeedForRawPart(params map[string]string) bool {
 	if value, ok := params[RawPartModeKey]; ok && value == RawPartModeValue {
 		return true
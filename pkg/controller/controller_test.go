@@ -34,14 +34,18 @@ import (
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 	v1 "k8s.io/api/core/v1"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
 	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
 	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
 	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
 	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/cache"
 	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
@@ -49,6 +53,7 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	"github.com/dell/csi-baremetal/pkg/common"
+	"github.com/dell/csi-baremetal/pkg/controller/mountoptions"
 	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/node/common"
 	"github.com/dell/csi-baremetal/pkg/mocks"
 	"github.com/dell/csi-baremetal/pkg/testutils"
@@ -200,6 +205,49 @@ var _ = Describe("CSIControllerService CreateVolume", func() {
 			Expect(err).ToNot(BeNil())
 			Expect(err.Error()).To(ContainSubstring("Volume capabilities missing in request"))
 		})
+		It("Secret isn't set for encrypted volume", func() {
+			req := getCreateVolumeRequest("req1", 1024*1024, testNode1Name, testPVC1Name, false, false)
+			req.Parameters[base.EncryptedKey] = "true"
+
+			resp, err := controller.CreateVolume(context.Background(), req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+			Expect(err.Error()).To(ContainSubstring(base.EncryptionSecretNameKey))
+		})
+		It("Erase policy isn't supported", func() {
+			req := getCreateVolumeRequest("req1", 1024*1024, testNode1Name, testPVC1Name, false, false)
+			req.Parameters[base.StorageTypeKey] = apiV1.StorageClassHDDLVG
+			req.Parameters[base.ErasePolicyKey] = apiV1.ErasePolicyZeroFill
+			_, err := controller.CreateVolume(context.Background(), req)
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			req.Parameters[base.StorageTypeKey] = apiV1.StorageClassHDD
+			req.Parameters[base.ErasePolicyKey] = apiV1.ErasePolicyNVMeSanitize
+			_, err = controller.CreateVolume(context.Background(), req)
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			req.Parameters[base.ErasePolicyKey] = "unknown"
+			_, err = controller.CreateVolume(context.Background(), req)
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("RAID parameters are invalid", func() {
+			req := getCreateVolumeRequest("req1", 1024*1024, testNode1Name, testPVC1Name, false, false)
+			req.Parameters[base.StorageTypeKey] = apiV1.StorageClassHDD
+			req.Parameters[base.RaidLevelKey] = apiV1.RaidLevel10
+			req.Parameters[base.RaidDevicesKey] = "3"
+			_, err := controller.CreateVolume(context.Background(), req)
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			req.Parameters[base.RaidDevicesKey] = "4"
+			req.Parameters[base.StorageTypeKey] = apiV1.StorageClassHDDLVG
+			_, err = controller.CreateVolume(context.Background(), req)
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			req.Parameters[base.StorageTypeKey] = apiV1.StorageClassHDD
+			req.Parameters[base.ErasePolicyKey] = apiV1.ErasePolicyZeroFill
+			_, err = controller.CreateVolume(context.Background(), req)
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
 		It("Reservation not found", func() {
 			req := getCreateVolumeRequest("req1", 1024*1024*1024*1024, "", "testClaim", false, false)
 
@@ -301,6 +349,32 @@ var _ = Describe("CSIControllerService CreateVolume", func() {
 			Expect(vol.Spec.Mode).To(Equal(apiV1.ModeFS))
 			Expect(vol.Labels[k8s.AppLabelKey]).To(Equal(testApp))
 		})
+		It("Volume is created with IO limits from StorageClass and PVC annotations", func() {
+			err := testutils.AddAC(controller.k8sclient, testAC1.DeepCopy(), testAC2.DeepCopy())
+			Expect(err).To(BeNil())
+			pvc := testPVC1.DeepCopy()
+			pvc.Annotations = map[string]string{base.IOLimitsAnnotationPrefix + base.IOReadIOPSKey: "500"}
+			err = controller.k8sclient.Create(testCtx, pvc)
+			Expect(err).To(BeNil())
+			err = controller.k8sclient.CreateCR(testCtx, testACR1.Name, testACR1.DeepCopy())
+			Expect(err).To(BeNil())
+			var (
+				req = getCreateVolumeRequest("req1", 1024*53, testNode1Name, testPVC1Name, false, false)
+				vol = &vcrd.Volume{}
+			)
+			req.Parameters[base.IOReadIOPSKey] = "100"
+			req.Parameters[base.IOWriteBPSKey] = "1Mi"
+
+			go testutils.VolumeReconcileImitation(controller.k8sclient, "req1", testNs, apiV1.Created)
+
+			resp, err := controller.CreateVolume(context.Background(), req)
+			Expect(err).To(BeNil())
+			Expect(resp).ToNot(BeNil())
+
+			err = controller.k8sclient.ReadCR(context.Background(), "req1", testNs, vol)
+			Expect(err).To(BeNil())
+			Expect(vol.Spec.IoLimits).To(Equal(&api.IOLimits{ReadIOPS: 500, WriteBPS: 1024 * 1024}))
+		})
 		It("Volume is created successfully (Block)", func() {
 			err := testutils.AddAC(controller.k8sclient, testAC1.DeepCopy(), testAC2.DeepCopy())
 			Expect(err).To(BeNil())
@@ -404,6 +478,175 @@ var _ = Describe("CSIControllerService CreateVolume", func() {
 	})
 })
 
+var _ = Describe("CSIControllerService CreateVolume from content source", func() {
+	var (
+		controller *CSIControllerService
+		svc        *mocks.VolumeOperationsMock
+		sourceID   = "source-1"
+		snapshotID = "snapshot-1"
+		size       = int64(1024 * 1024 * 1024)
+	)
+
+	getRequest := func(source *csi.VolumeContentSource) *csi.CreateVolumeRequest {
+		req := getCreateVolumeRequest("req1", size, testNode1Name, testPVC1Name, false, false)
+		req.VolumeContentSource = source
+		return req
+	}
+	snapshotSource := &csi.VolumeContentSource{Type: &csi.VolumeContentSource_Snapshot{
+		Snapshot: &csi.VolumeContentSource_SnapshotSource{SnapshotId: snapshotID}}}
+	volumeSource := &csi.VolumeContentSource{Type: &csi.VolumeContentSource_Volume{
+		Volume: &csi.VolumeContentSource_VolumeSource{VolumeId: sourceID}}}
+
+	BeforeEach(func() {
+		controller = newSvc()
+		svc = &mocks.VolumeOperationsMock{}
+		controller.svc = svc
+
+		volumeCR := controller.k8sclient.ConstructVolumeCR(sourceID, testNs, map[string]string{}, api.Volume{
+			Id: sourceID, NodeId: testNode1Name, Size: size, StorageClass: apiV1.StorageClassHDDLVG,
+			CSIStatus: apiV1.Published})
+		Expect(controller.k8sclient.CreateCR(testCtx, sourceID, volumeCR)).To(BeNil())
+		snapshotCR := controller.k8sclient.ConstructSnapshotCR(snapshotID, api.Snapshot{
+			Id: snapshotID, VolumeId: sourceID, NodeId: testNode1Name, Size: size,
+			StorageClass: apiV1.StorageClassHDDLVG, CSIStatus: apiV1.Created, Health: apiV1.HealthGood})
+		Expect(controller.k8sclient.CreateCR(testCtx, snapshotID, snapshotCR)).To(BeNil())
+	})
+
+	Context("Fail scenarios", func() {
+		It("Source doesn't exist", func() {
+			resp, err := controller.CreateVolume(testCtx, getRequest(&csi.VolumeContentSource{
+				Type: &csi.VolumeContentSource_Snapshot{
+					Snapshot: &csi.VolumeContentSource_SnapshotSource{SnapshotId: "not-found"}}}))
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+			resp, err = controller.CreateVolume(testCtx, getRequest(&csi.VolumeContentSource{
+				Type: &csi.VolumeContentSource_Volume{
+					Volume: &csi.VolumeContentSource_VolumeSource{VolumeId: "not-found"}}}))
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+		It("Snapshot isn't ready", func() {
+			snapshotCR := &snapshotcrd.Snapshot{}
+			Expect(controller.k8sclient.ReadCR(testCtx, snapshotID, "", snapshotCR)).To(BeNil())
+			snapshotCR.Spec.Health = apiV1.HealthBad
+			Expect(controller.k8sclient.UpdateCR(testCtx, snapshotCR)).To(BeNil())
+
+			resp, err := controller.CreateVolume(testCtx, getRequest(snapshotSource))
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+		})
+		It("Source is located on another node", func() {
+			req := getRequest(volumeSource)
+			req.AccessibilityRequirements.Preferred[0].Segments[csibmnodeconst.NodeIDTopologyLabelKey] = testNode2Name
+
+			resp, err := controller.CreateVolume(testCtx, req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+		})
+		It("Requested size is less than source size", func() {
+			req := getRequest(snapshotSource)
+			req.CapacityRange.RequiredBytes = size / 2
+
+			resp, err := controller.CreateVolume(testCtx, req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.OutOfRange))
+		})
+		It("Volume is being populated", func() {
+			svc.On("CreateVolume", mock.Anything, mock.Anything).Return(&api.Volume{
+				Id: "req1", CSIStatus: apiV1.Creating, CloneProgress: 40,
+				ContentSourceType: apiV1.ContentSourceVolume, ContentSourceId: sourceID}, nil)
+
+			resp, err := controller.CreateVolume(testCtx, getRequest(volumeSource))
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.Aborted))
+			Expect(err.Error()).To(ContainSubstring("40%"))
+		})
+		It("Encryption doesn't match source", func() {
+			req := getRequest(volumeSource)
+			req.Parameters[base.EncryptedKey] = "true"
+			req.Parameters[base.EncryptionSecretNameKey] = "secret"
+
+			resp, err := controller.CreateVolume(testCtx, req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+	})
+
+	Context("Success scenarios", func() {
+		It("Volume is created from snapshot", func() {
+			svc.On("CreateVolume", mock.Anything, mock.MatchedBy(func(v api.Volume) bool {
+				return v.ContentSourceType == apiV1.ContentSourceSnapshot && v.ContentSourceId == snapshotID
+			})).Return(&api.Volume{Id: "req1", NodeId: testNode1Name, Size: size, CSIStatus: apiV1.Created,
+				ContentSourceType: apiV1.ContentSourceSnapshot, ContentSourceId: snapshotID}, nil)
+
+			resp, err := controller.CreateVolume(testCtx, getRequest(snapshotSource))
+			Expect(err).To(BeNil())
+			Expect(resp.Volume.ContentSource).To(Equal(snapshotSource))
+		})
+		It("Encrypted volume is created", func() {
+			svc.On("CreateVolume", mock.Anything, mock.MatchedBy(func(v api.Volume) bool {
+				return v.Encrypted && v.EncryptionSecretName == "secret" && v.EncryptionSecretNamespace == testNs
+			})).Return(&api.Volume{Id: "req1", NodeId: testNode1Name, Size: size, CSIStatus: apiV1.Created}, nil)
+
+			req := getRequest(nil)
+			req.Parameters[base.EncryptedKey] = "true"
+			req.Parameters[base.EncryptionSecretNameKey] = "secret"
+			req.Parameters[base.EncryptionSecretNamespaceKey] = testNs
+			resp, err := controller.CreateVolume(testCtx, req)
+			Expect(err).To(BeNil())
+			Expect(resp).ToNot(BeNil())
+		})
+		It("Volume with erase policy is created", func() {
+			svc.On("CreateVolume", mock.Anything, mock.MatchedBy(func(v api.Volume) bool {
+				return v.ErasePolicy == apiV1.ErasePolicyNVMeFormat && v.StorageClass == apiV1.StorageClassNVMe
+			})).Return(&api.Volume{Id: "req1", NodeId: testNode1Name, Size: size, CSIStatus: apiV1.Created}, nil)
+
+			req := getRequest(nil)
+			req.Parameters[base.StorageTypeKey] = apiV1.StorageClassNVMe
+			req.Parameters[base.ErasePolicyKey] = apiV1.ErasePolicyNVMeFormat
+			resp, err := controller.CreateVolume(testCtx, req)
+			Expect(err).To(BeNil())
+			Expect(resp).ToNot(BeNil())
+		})
+		It("RAID volume is created", func() {
+			svc.On("CreateVolume", mock.Anything, mock.MatchedBy(func(v api.Volume) bool {
+				return v.RaidLevel == apiV1.RaidLevel1 && v.RaidDevices == 2
+			})).Return(&api.Volume{Id: "req1", NodeId: testNode1Name, Size: size, CSIStatus: apiV1.Created}, nil)
+
+			req := getRequest(nil)
+			req.Parameters[base.StorageTypeKey] = apiV1.StorageClassSSD
+			req.Parameters[base.RaidLevelKey] = apiV1.RaidLevel1
+			req.Parameters[base.RaidDevicesKey] = "2"
+			resp, err := controller.CreateVolume(testCtx, req)
+			Expect(err).To(BeNil())
+			Expect(resp).ToNot(BeNil())
+		})
+		It("Volume is cloned", func() {
+			svc.On("CreateVolume", mock.Anything, mock.MatchedBy(func(v api.Volume) bool {
+				return v.ContentSourceType == apiV1.ContentSourceVolume && v.ContentSourceId == sourceID
+			})).Return(&api.Volume{Id: "req1", NodeId: testNode1Name, Size: size, CSIStatus: apiV1.Created,
+				ContentSourceType: apiV1.ContentSourceVolume, ContentSourceId: sourceID, CloneProgress: 100}, nil)
+
+			resp, err := controller.CreateVolume(testCtx, getRequest(volumeSource))
+			Expect(err).To(BeNil())
+			Expect(resp.Volume.ContentSource).To(Equal(volumeSource))
+		})
+		It("Volume is cloned on the source node without topology preference", func() {
+			svc.On("CreateVolume", mock.Anything, mock.MatchedBy(func(v api.Volume) bool {
+				return v.ContentSourceId == sourceID && v.NodeId == testNode1Name
+			})).Return(&api.Volume{Id: "req1", NodeId: testNode1Name, Size: size, CSIStatus: apiV1.Created,
+				ContentSourceType: apiV1.ContentSourceVolume, ContentSourceId: sourceID, CloneProgress: 100}, nil)
+
+			req := getRequest(volumeSource)
+			req.AccessibilityRequirements = nil
+			resp, err := controller.CreateVolume(testCtx, req)
+			Expect(err).To(BeNil())
+			Expect(resp.Volume.ContentSource).To(Equal(volumeSource))
+		})
+	})
+})
+
 var _ = Describe("CSIControllerService DeleteVolume", func() {
 	var (
 		controller *CSIControllerService
@@ -444,6 +687,27 @@ var _ = Describe("CSIControllerService DeleteVolume", func() {
 			Expect(resp).To(BeNil())
 			Expect(err).To(Equal(status.Error(codes.InvalidArgument, "Volume ID must be provided")))
 		})
+		It("Volume has snapshots", func() {
+			snapshotCR := controller.k8sclient.ConstructSnapshotCR("snapshot-1",
+				api.Snapshot{Id: "snapshot-1", VolumeId: uuid, CSIStatus: apiV1.Created})
+			err := controller.k8sclient.CreateCR(testCtx, snapshotCR.Name, snapshotCR)
+			Expect(err).To(BeNil())
+
+			resp, err := controller.DeleteVolume(context.Background(), &csi.DeleteVolumeRequest{VolumeId: uuid})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+		})
+		It("Volume is used as content source", func() {
+			volumeCR := controller.k8sclient.ConstructVolumeCR("clone-1", testNs, map[string]string{}, api.Volume{
+				Id: "clone-1", CSIStatus: apiV1.Creating,
+				ContentSourceType: apiV1.ContentSourceVolume, ContentSourceId: uuid})
+			err := controller.k8sclient.CreateCR(testCtx, volumeCR.Name, volumeCR)
+			Expect(err).To(BeNil())
+
+			resp, err := controller.DeleteVolume(context.Background(), &csi.DeleteVolumeRequest{VolumeId: uuid})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+		})
 		It("Node service mark volume as Failed", func() {
 			var (
 				volumeID  = "volume-id-2222"
@@ -485,6 +749,34 @@ var _ = Describe("CSIControllerService DeleteVolume", func() {
 			Expect(err).ToNot(BeNil())
 			Expect(resp).To(BeNil())
 		})
+		It("Volume is created with option of another file system", func() {
+			Expect(mountoptions.SetSupportedOptions([]mountoptions.MountOption{
+				{Name: "data=writeback", Type: mountoptions.PublishCmdOpt, FileSystems: []string{"ext4"}},
+			})).To(BeNil())
+			defer mountoptions.ResetSupportedOptions()
+
+			// request uses xfs
+			req := getCreateVolumeRequest("req1", 1024*53, testNode1Name, testPVC1Name, false, false, "data=writeback")
+			resp, err := controller.CreateVolume(context.Background(), req)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(ContainSubstring("not supported for xfs"))
+			Expect(resp).To(BeNil())
+		})
+		It("Volume is created with unsupported mkfs options", func() {
+			req := getCreateVolumeRequest("req1", 1024*53, testNode1Name, testPVC1Name, false, false)
+			// -E is ext specific option
+			req.Parameters[base.MkfsOptionsKey] = "-E lazy_itable_init=0"
+			resp, err := controller.CreateVolume(context.Background(), req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("Volume is created with invalid IO limits", func() {
+			req := getCreateVolumeRequest("req1", 1024*53, testNode1Name, testPVC1Name, false, false)
+			req.Parameters[base.IOWeightKey] = "0.5"
+			resp, err := controller.CreateVolume(context.Background(), req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
 	})
 
 	Context("Success scenarios", func() {
@@ -632,6 +924,14 @@ var _ = Describe("CSIControllerService ControllerGetCapabilities", func() {
 				csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
 				csi.ControllerServiceCapability_RPC_PUBLISH_UNPUBLISH_VOLUME,
 				csi.ControllerServiceCapability_RPC_EXPAND_VOLUME,
+				csi.ControllerServiceCapability_RPC_CREATE_DELETE_SNAPSHOT,
+				csi.ControllerServiceCapability_RPC_LIST_SNAPSHOTS,
+				csi.ControllerServiceCapability_RPC_CLONE_VOLUME,
+				csi.ControllerServiceCapability_RPC_GET_CAPACITY,
+				csi.ControllerServiceCapability_RPC_LIST_VOLUMES,
+				csi.ControllerServiceCapability_RPC_LIST_VOLUMES_PUBLISHED_NODES,
+				csi.ControllerServiceCapability_RPC_GET_VOLUME,
+				csi.ControllerServiceCapability_RPC_VOLUME_CONDITION,
 			}
 		)
 
@@ -775,6 +1075,28 @@ var _ = Describe("CSIControllerService ControllerExpandVolume", func() {
 			Expect(resp).To(BeNil())
 			Expect(err).To(Equal(status.Error(codes.NotFound, "Volume doesn't exist")))
 		})
+		It("Volume is encrypted", func() {
+			volume := &vcrd.Volume{}
+			Expect(controller.k8sclient.ReadCR(testCtx, uuid, testNs, volume)).To(BeNil())
+			volume.Spec.Encrypted = true
+			Expect(controller.k8sclient.UpdateCR(testCtx, volume)).To(BeNil())
+
+			req := &csi.ControllerExpandVolumeRequest{VolumeId: uuid, CapacityRange: &csi.CapacityRange{RequiredBytes: 2000}}
+			resp, err := controller.ControllerExpandVolume(context.Background(), req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("Volume has f2fs", func() {
+			volume := &vcrd.Volume{}
+			Expect(controller.k8sclient.ReadCR(testCtx, uuid, testNs, volume)).To(BeNil())
+			volume.Spec.Type = string(fs.F2FS)
+			Expect(controller.k8sclient.UpdateCR(testCtx, volume)).To(BeNil())
+
+			req := &csi.ControllerExpandVolumeRequest{VolumeId: uuid, CapacityRange: &csi.CapacityRange{RequiredBytes: 2000}}
+			resp, err := controller.ControllerExpandVolume(context.Background(), req)
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
 		It("Node service mark volume as Failed", func() {
 			var (
 				volumeCrd = &vcrd.Volume{}
@@ -897,47 +1219,361 @@ var _ = Describe("CSIControllerService ControllerExpandVolume", func() {
 			Expect(resp.CapacityBytes).To(Equal(capacityplanner.AlignSizeByPE(capacity)))
 
 		})
+		It("Drive based volume requires node expansion", func() {
+			var (
+				volumeCrd = &vcrd.Volume{}
+				err       error
+				capacity  = int64(1024)
+			)
+			err = controller.k8sclient.ReadCR(testCtx, uuid, testNs, volumeCrd)
+			Expect(err).To(BeNil())
+			volumeCrd.Spec.StorageClass = apiV1.StorageClassHDD
+			err = controller.k8sclient.UpdateCR(testCtx, volumeCrd)
+			Expect(err).To(BeNil())
+
+			volMock := mocks.VolumeOperationsMock{}
+			volMock.On("ExpandVolume", mock.Anything, mock.Anything, capacity).Return(nil)
+			controller.svc = &volMock
+			resp, err := controller.ControllerExpandVolume(context.Background(),
+				&csi.ControllerExpandVolumeRequest{
+					VolumeId:         uuid,
+					VolumeCapability: &csi.VolumeCapability{},
+					CapacityRange:    &csi.CapacityRange{RequiredBytes: capacity},
+				})
+			Expect(err).To(BeNil())
+			Expect(resp.CapacityBytes).To(Equal(capacity))
+			Expect(resp.NodeExpansionRequired).To(BeTrue())
+			volMock.AssertNotCalled(GinkgoT(), "WaitStatus", mock.Anything, mock.Anything, mock.Anything)
+		})
 	})
 })
 
-func TestController_UnimplementedMethods(t *testing.T) {
+var _ = Describe("CSIControllerService Snapshots", func() {
+	var (
+		controller  *CSIControllerService
+		snapshotSvc *mocks.SnapshotOperationsMock
+		snapshotID  = "snapshot-1"
+		volumeID    = "volume-1"
+	)
 
-	controller := newSvc()
-	expected := codes.Unimplemented.String()
+	BeforeEach(func() {
+		controller = newSvc()
+		snapshotSvc = &mocks.SnapshotOperationsMock{}
+		controller.snapshotSvc = snapshotSvc
+	})
+
+	Context("CreateSnapshot", func() {
+		It("Request doesn't contain name or source volume", func() {
+			resp, err := controller.CreateSnapshot(testCtx, &csi.CreateSnapshotRequest{SourceVolumeId: volumeID})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			resp, err = controller.CreateSnapshot(testCtx, &csi.CreateSnapshotRequest{Name: snapshotID})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("Snapshot operations returns error", func() {
+			snapshotSvc.On("CreateSnapshot", mock.Anything, mock.Anything).
+				Return(nil, status.Error(codes.ResourceExhausted, "error"))
+
+			resp, err := controller.CreateSnapshot(testCtx,
+				&csi.CreateSnapshotRequest{Name: snapshotID, SourceVolumeId: volumeID})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+		})
+		It("Snapshot reaches Failed status", func() {
+			snapshotSvc.On("CreateSnapshot", mock.Anything, mock.Anything).
+				Return(&api.Snapshot{Id: snapshotID, VolumeId: volumeID, CSIStatus: apiV1.Creating}, nil)
+			snapshotSvc.On("WaitStatus", mock.Anything, snapshotID, mock.Anything).Return(errors.New("failed"))
+
+			resp, err := controller.CreateSnapshot(testCtx,
+				&csi.CreateSnapshotRequest{Name: snapshotID, SourceVolumeId: volumeID})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.Internal))
+		})
+		It("Snapshot is created", func() {
+			snapshotSvc.On("CreateSnapshot", mock.Anything, api.Snapshot{Id: snapshotID, VolumeId: volumeID}).
+				Return(&api.Snapshot{Id: snapshotID, VolumeId: volumeID, Size: 1024,
+					CSIStatus: apiV1.Creating, Health: apiV1.HealthGood, CreationTime: 100}, nil)
+			snapshotSvc.On("WaitStatus", mock.Anything, snapshotID, mock.Anything).Return(nil)
+
+			resp, err := controller.CreateSnapshot(testCtx,
+				&csi.CreateSnapshotRequest{Name: snapshotID, SourceVolumeId: volumeID})
+			Expect(err).To(BeNil())
+			Expect(resp.Snapshot.SnapshotId).To(Equal(snapshotID))
+			Expect(resp.Snapshot.SourceVolumeId).To(Equal(volumeID))
+			Expect(resp.Snapshot.SizeBytes).To(Equal(int64(1024)))
+			Expect(resp.Snapshot.CreationTime.Seconds).To(Equal(int64(100)))
+			Expect(resp.Snapshot.ReadyToUse).To(BeTrue())
+		})
+	})
+
+	Context("DeleteSnapshot", func() {
+		It("Request doesn't contain snapshot ID", func() {
+			resp, err := controller.DeleteSnapshot(testCtx, &csi.DeleteSnapshotRequest{})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("Snapshot doesn't exist", func() {
+			snapshotSvc.On("DeleteSnapshot", mock.Anything, snapshotID).
+				Return(k8sError.NewNotFound(v1.Resource("snapshot"), snapshotID))
+
+			resp, err := controller.DeleteSnapshot(testCtx, &csi.DeleteSnapshotRequest{SnapshotId: snapshotID})
+			Expect(err).To(BeNil())
+			Expect(resp).ToNot(BeNil())
+		})
+		It("Snapshot is used as content source", func() {
+			volumeCR := controller.k8sclient.ConstructVolumeCR("clone-1", testNs, map[string]string{}, api.Volume{
+				Id: "clone-1", CSIStatus: apiV1.Creating,
+				ContentSourceType: apiV1.ContentSourceSnapshot, ContentSourceId: snapshotID})
+			Expect(controller.k8sclient.CreateCR(testCtx, volumeCR.Name, volumeCR)).To(BeNil())
+
+			resp, err := controller.DeleteSnapshot(testCtx, &csi.DeleteSnapshotRequest{SnapshotId: snapshotID})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+			snapshotSvc.AssertNotCalled(GinkgoT(), "DeleteSnapshot", mock.Anything, snapshotID)
+		})
+		It("Snapshot reaches Failed status", func() {
+			snapshotSvc.On("DeleteSnapshot", mock.Anything, snapshotID).Return(nil)
+			snapshotSvc.On("WaitStatus", mock.Anything, snapshotID, mock.Anything).Return(errors.New("failed"))
+
+			resp, err := controller.DeleteSnapshot(testCtx, &csi.DeleteSnapshotRequest{SnapshotId: snapshotID})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.Internal))
+			snapshotSvc.AssertNotCalled(GinkgoT(), "UpdateCRsAfterSnapshotDeletion", mock.Anything, snapshotID)
+		})
+		It("Snapshot is deleted", func() {
+			snapshotSvc.On("DeleteSnapshot", mock.Anything, snapshotID).Return(nil)
+			snapshotSvc.On("WaitStatus", mock.Anything, snapshotID, mock.Anything).Return(nil)
+			snapshotSvc.On("UpdateCRsAfterSnapshotDeletion", mock.Anything, snapshotID).Return()
+
+			resp, err := controller.DeleteSnapshot(testCtx, &csi.DeleteSnapshotRequest{SnapshotId: snapshotID})
+			Expect(err).To(BeNil())
+			Expect(resp).ToNot(BeNil())
+			snapshotSvc.AssertCalled(GinkgoT(), "UpdateCRsAfterSnapshotDeletion", mock.Anything, snapshotID)
+		})
+	})
+
+	Context("ListSnapshots", func() {
+		BeforeEach(func() {
+			for i, volID := range []string{volumeID, volumeID, "volume-2"} {
+				name := fmt.Sprintf("snapshot-%d", i)
+				snapshotCR := controller.k8sclient.ConstructSnapshotCR(name,
+					api.Snapshot{Id: name, VolumeId: volID, CSIStatus: apiV1.Created})
+				Expect(controller.k8sclient.CreateCR(testCtx, name, snapshotCR)).To(BeNil())
+			}
+		})
+		It("Invalid starting token", func() {
+			resp, err := controller.ListSnapshots(testCtx, &csi.ListSnapshotsRequest{StartingToken: "abc"})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.Aborted))
+
+			resp, err = controller.ListSnapshots(testCtx, &csi.ListSnapshotsRequest{StartingToken: "4"})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.Aborted))
+		})
+		It("List all snapshots", func() {
+			resp, err := controller.ListSnapshots(testCtx, &csi.ListSnapshotsRequest{})
+			Expect(err).To(BeNil())
+			Expect(len(resp.Entries)).To(Equal(3))
+			Expect(resp.NextToken).To(BeEmpty())
+		})
+		It("Filter by snapshot ID and source volume ID", func() {
+			resp, err := controller.ListSnapshots(testCtx, &csi.ListSnapshotsRequest{SnapshotId: "snapshot-2"})
+			Expect(err).To(BeNil())
+			Expect(len(resp.Entries)).To(Equal(1))
+			Expect(resp.Entries[0].Snapshot.SourceVolumeId).To(Equal("volume-2"))
+
+			resp, err = controller.ListSnapshots(testCtx, &csi.ListSnapshotsRequest{SourceVolumeId: volumeID})
+			Expect(err).To(BeNil())
+			Expect(len(resp.Entries)).To(Equal(2))
+		})
+		It("Paginate snapshots", func() {
+			resp, err := controller.ListSnapshots(testCtx, &csi.ListSnapshotsRequest{MaxEntries: 2})
+			Expect(err).To(BeNil())
+			Expect(len(resp.Entries)).To(Equal(2))
+			Expect(resp.NextToken).To(Equal("2"))
 
-	t.Run("ValidateVolumeCapabilities", func(t *testing.T) {
-		_, err := controller.ValidateVolumeCapabilities(testCtx, nil)
-		assert.True(t, strings.Contains(err.Error(), expected))
+			resp, err = controller.ListSnapshots(testCtx,
+				&csi.ListSnapshotsRequest{MaxEntries: 2, StartingToken: resp.NextToken})
+			Expect(err).To(BeNil())
+			Expect(len(resp.Entries)).To(Equal(1))
+			Expect(resp.NextToken).To(BeEmpty())
+		})
 	})
+})
 
-	t.Run("ListVolumes", func(t *testing.T) {
-		_, err := controller.ListVolumes(testCtx, nil)
-		assert.True(t, strings.Contains(err.Error(), expected))
+var _ = Describe("CSIControllerService volumes listing", func() {
+	var controller *CSIControllerService
+
+	BeforeEach(func() {
+		controller = newSvc()
+		volumes := []api.Volume{
+			{Id: "volume-0", NodeId: testNode1Name, Size: 1024, CSIStatus: apiV1.Published, Mode: apiV1.ModeFS,
+				Health: apiV1.HealthGood, OperationalStatus: apiV1.OperationalStatusOperative, Owners: []string{"pod"}},
+			{Id: "volume-1", NodeId: testNode2Name, Size: 2048, CSIStatus: apiV1.Created, Mode: apiV1.ModeRAW,
+				Health: apiV1.HealthBad, OperationalStatus: apiV1.OperationalStatusOperative},
+			{Id: "volume-2", NodeId: testNode2Name, Size: 2048, CSIStatus: apiV1.Created, Mode: apiV1.ModeFS,
+				Health: apiV1.HealthGood, OperationalStatus: apiV1.OperationalStatusMissing},
+			{Id: "volume-3", NodeId: testNode2Name, CSIStatus: apiV1.Removed},
+		}
+		for _, vol := range volumes {
+			volumeCR := controller.k8sclient.ConstructVolumeCR(vol.Id, testNs, map[string]string{}, vol)
+			Expect(controller.k8sclient.CreateCR(testCtx, vol.Id, volumeCR)).To(BeNil())
+		}
 	})
 
-	t.Run("CreateSnapshot", func(t *testing.T) {
-		_, err := controller.CreateSnapshot(testCtx, nil)
-		assert.True(t, strings.Contains(err.Error(), expected))
+	Context("ListVolumes", func() {
+		It("Invalid starting token", func() {
+			resp, err := controller.ListVolumes(testCtx, &csi.ListVolumesRequest{StartingToken: "abc"})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.Aborted))
+
+			resp, err = controller.ListVolumes(testCtx, &csi.ListVolumesRequest{StartingToken: "4"})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.Aborted))
+		})
+		It("List all volumes", func() {
+			resp, err := controller.ListVolumes(testCtx, &csi.ListVolumesRequest{})
+			Expect(err).To(BeNil())
+			Expect(len(resp.Entries)).To(Equal(3))
+			Expect(resp.NextToken).To(BeEmpty())
+
+			Expect(resp.Entries[0].Volume.VolumeId).To(Equal("volume-0"))
+			Expect(resp.Entries[0].Volume.CapacityBytes).To(Equal(int64(1024)))
+			Expect(resp.Entries[0].Status.PublishedNodeIds).To(Equal([]string{testNode1Name}))
+			Expect(resp.Entries[0].Status.VolumeCondition.Abnormal).To(BeFalse())
+			Expect(resp.Entries[1].Status.PublishedNodeIds).To(BeEmpty())
+			Expect(resp.Entries[1].Status.VolumeCondition.Abnormal).To(BeTrue())
+			Expect(resp.Entries[2].Status.VolumeCondition.Abnormal).To(BeTrue())
+		})
+		It("Paginate volumes", func() {
+			resp, err := controller.ListVolumes(testCtx, &csi.ListVolumesRequest{MaxEntries: 2})
+			Expect(err).To(BeNil())
+			Expect(len(resp.Entries)).To(Equal(2))
+			Expect(resp.NextToken).To(Equal("2"))
+
+			resp, err = controller.ListVolumes(testCtx,
+				&csi.ListVolumesRequest{MaxEntries: 2, StartingToken: resp.NextToken})
+			Expect(err).To(BeNil())
+			Expect(len(resp.Entries)).To(Equal(1))
+			Expect(resp.NextToken).To(BeEmpty())
+		})
 	})
 
-	t.Run("DeleteSnapshot", func(t *testing.T) {
-		_, err := controller.DeleteSnapshot(testCtx, nil)
-		assert.True(t, strings.Contains(err.Error(), expected))
+	Context("ControllerGetVolume", func() {
+		It("Request doesn't contain volume ID", func() {
+			resp, err := controller.ControllerGetVolume(testCtx, &csi.ControllerGetVolumeRequest{})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+		})
+		It("Volume doesn't exist", func() {
+			resp, err := controller.ControllerGetVolume(testCtx, &csi.ControllerGetVolumeRequest{VolumeId: "unknown"})
+			Expect(resp).To(BeNil())
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+		It("Volume with BAD health", func() {
+			resp, err := controller.ControllerGetVolume(testCtx, &csi.ControllerGetVolumeRequest{VolumeId: "volume-1"})
+			Expect(err).To(BeNil())
+			Expect(resp.Volume.VolumeId).To(Equal("volume-1"))
+			Expect(resp.Volume.AccessibleTopology[0].Segments[csibmnodeconst.NodeIDTopologyLabelKey]).To(Equal(testNode2Name))
+			Expect(resp.Status.VolumeCondition.Abnormal).To(BeTrue())
+			Expect(resp.Status.VolumeCondition.Message).To(ContainSubstring(apiV1.HealthBad))
+		})
+	})
+
+	Context("ValidateVolumeCapabilities", func() {
+		newCapability := func(mode csi.VolumeCapability_AccessMode_Mode, block bool) *csi.VolumeCapability {
+			capability := &csi.VolumeCapability{AccessMode: &csi.VolumeCapability_AccessMode{Mode: mode}}
+			if block {
+				capability.AccessType = &csi.VolumeCapability_Block{Block: &csi.VolumeCapability_BlockVolume{}}
+			} else {
+				capability.AccessType = &csi.VolumeCapability_Mount{Mount: &csi.VolumeCapability_MountVolume{}}
+			}
+			return capability
+		}
+		It("Invalid request", func() {
+			_, err := controller.ValidateVolumeCapabilities(testCtx, &csi.ValidateVolumeCapabilitiesRequest{})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			_, err = controller.ValidateVolumeCapabilities(testCtx,
+				&csi.ValidateVolumeCapabilitiesRequest{VolumeId: "volume-0"})
+			Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+			_, err = controller.ValidateVolumeCapabilities(testCtx, &csi.ValidateVolumeCapabilitiesRequest{
+				VolumeId:           "unknown",
+				VolumeCapabilities: []*csi.VolumeCapability{newCapability(csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER, false)},
+			})
+			Expect(status.Code(err)).To(Equal(codes.NotFound))
+		})
+		It("Capabilities are confirmed", func() {
+			resp, err := controller.ValidateVolumeCapabilities(testCtx, &csi.ValidateVolumeCapabilitiesRequest{
+				VolumeId:           "volume-1",
+				VolumeCapabilities: []*csi.VolumeCapability{newCapability(csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER, true)},
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Confirmed).ToNot(BeNil())
+		})
+		It("Capabilities aren't confirmed", func() {
+			resp, err := controller.ValidateVolumeCapabilities(testCtx, &csi.ValidateVolumeCapabilitiesRequest{
+				VolumeId:           "volume-0",
+				VolumeCapabilities: []*csi.VolumeCapability{newCapability(csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER, false)},
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Confirmed).To(BeNil())
+			Expect(resp.Message).ToNot(BeEmpty())
+
+			resp, err = controller.ValidateVolumeCapabilities(testCtx, &csi.ValidateVolumeCapabilitiesRequest{
+				VolumeId:           "volume-0",
+				VolumeCapabilities: []*csi.VolumeCapability{newCapability(csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER, true)},
+			})
+			Expect(err).To(BeNil())
+			Expect(resp.Confirmed).To(BeNil())
+		})
+	})
+})
+
+func TestController_GetCapacity(t *testing.T) {
+	controller := newSvc()
+	for _, obj := range []k8sCl.Object{testAC1.DeepCopy(), testAC2.DeepCopy(), testAC3.DeepCopy(), testACR1.DeepCopy()} {
+		obj.SetResourceVersion("")
+		assert.Nil(t, controller.k8sclient.CreateCR(testCtx, obj.GetName(), obj))
+	}
+	getRequest := func(storageType, nodeID string) *csi.GetCapacityRequest {
+		req := &csi.GetCapacityRequest{Parameters: map[string]string{base.StorageTypeKey: storageType}}
+		if nodeID != "" {
+			req.AccessibleTopology = &csi.Topology{
+				Segments: map[string]string{csibmnodeconst.NodeIDTopologyLabelKey: nodeID},
+			}
+		}
+		return req
+	}
+
+	t.Run("All nodes, reserved AC is excluded", func(t *testing.T) {
+		resp, err := controller.GetCapacity(testCtx, getRequest(apiV1.StorageClassHDD, ""))
+		assert.Nil(t, err)
+		assert.Equal(t, testAC2.Spec.Size, resp.AvailableCapacity)
+		assert.Equal(t, testAC2.Spec.Size, resp.MaximumVolumeSize.GetValue())
 	})
 
-	t.Run("ListSnapshots", func(t *testing.T) {
-		_, err := controller.ListSnapshots(testCtx, nil)
-		assert.True(t, strings.Contains(err.Error(), expected))
+	t.Run("Node without free capacity", func(t *testing.T) {
+		resp, err := controller.GetCapacity(testCtx, getRequest(apiV1.StorageClassHDD, testNode1Name))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), resp.AvailableCapacity)
+		assert.Equal(t, int64(0), resp.MaximumVolumeSize.GetValue())
 	})
 
-	t.Run("ControllerGetVolume", func(t *testing.T) {
-		_, err := controller.ControllerGetVolume(testCtx, nil)
-		assert.True(t, strings.Contains(err.Error(), expected))
+	t.Run("LVG storage class", func(t *testing.T) {
+		resp, err := controller.GetCapacity(testCtx, getRequest(apiV1.StorageClassHDDLVG, testNode2Name))
+		assert.Nil(t, err)
+		assert.Equal(t, testAC2.Spec.Size+testAC3.Spec.Size, resp.AvailableCapacity)
+		assert.Equal(t, testAC2.Spec.Size, resp.MaximumVolumeSize.GetValue())
 	})
 
-	t.Run("GetCapacity", func(t *testing.T) {
-		_, err := controller.GetCapacity(testCtx, nil)
-		assert.True(t, strings.Contains(err.Error(), expected))
+	t.Run("Unknown node", func(t *testing.T) {
+		resp, err := controller.GetCapacity(testCtx, getRequest(apiV1.StorageClassHDD, "unknown"))
+		assert.Nil(t, err)
+		assert.Equal(t, int64(0), resp.AvailableCapacity)
 	})
 }
 
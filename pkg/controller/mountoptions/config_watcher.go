@@ -0,0 +1,76 @@
+package mountoptions
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// DefaultConfigPath is the path where mount options ConfigMap is mounted
+	DefaultConfigPath = "/etc/mount_options/mount-options.yaml"
+
+	watchTimeout = 60 * time.Second
+)
+
+// Config is a content of mount options ConfigMap
+type Config struct {
+	Options []MountOption `yaml:"options"`
+}
+
+// ConfigWatcher is watcher to update the list of supported mount options from ConfigMap
+// ConfigMap is mounted as a file, so its changes are picked up without restart
+type ConfigWatcher struct {
+	path string
+	log  *logrus.Entry
+}
+
+// NewConfigWatcher creates new mount options Config Watcher for config file in path
+func NewConfigWatcher(path string, log *logrus.Logger) *ConfigWatcher {
+	return &ConfigWatcher{
+		path: path,
+		log:  log.WithField("component", "MountOptionsConfigWatcher"),
+	}
+}
+
+// StartWatch reads mount options config periodically and updates the list of supported options
+func (w *ConfigWatcher) StartWatch() {
+	go func() {
+		for {
+			w.reload()
+			time.Sleep(watchTimeout)
+		}
+	}()
+}
+
+// reload reads config and sets supported options. Default options are used when config doesn't exist,
+// the previous list is kept if config is invalid
+func (w *ConfigWatcher) reload() {
+	conf, err := w.readConfig()
+	switch {
+	case os.IsNotExist(err):
+		w.log.Debugf("Config %s doesn't exist, use default mount options", w.path)
+		ResetSupportedOptions()
+	case err != nil:
+		w.log.Errorf("Unable to read mount options config: %v", err)
+	default:
+		if err = SetSupportedOptions(conf.Options); err != nil {
+			w.log.Errorf("Invalid mount options config: %v", err)
+		}
+	}
+}
+
+func (w *ConfigWatcher) readConfig() (*Config, error) {
+	confFile, err := ioutil.ReadFile(w.path)
+	if err != nil {
+		return nil, err
+	}
+	conf := &Config{}
+	if err = yaml.Unmarshal(confFile, conf); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
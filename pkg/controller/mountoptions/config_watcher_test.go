@@ -0,0 +1,55 @@
+package mountoptions
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigWatcher_reload(t *testing.T) {
+	defer ResetSupportedOptions()
+
+	dir, err := ioutil.TempDir("", "mount-options")
+	assert.Nil(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	path := filepath.Join(dir, "mount-options.yaml")
+	w := NewConfigWatcher(path, logrus.New())
+
+	// config doesn't exist
+	w.reload()
+	assert.True(t, IsOptionSupported(noatimeOpt, "xfs"))
+
+	// valid config
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`
+options:
+  - name: nodiratime
+    type: publish
+  - name: inode64
+    type: publish
+    filesystems: [xfs]
+`), 0600))
+	w.reload()
+	assert.False(t, IsOptionSupported(noatimeOpt, "xfs"))
+	assert.True(t, IsOptionSupported("nodiratime", "ext4"))
+	assert.True(t, IsOptionSupported("inode64", "xfs"))
+	assert.False(t, IsOptionSupported("inode64", "ext4"))
+
+	// invalid config, previous options are kept
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`
+options:
+  - name: nodiratime
+    type: mount
+`), 0600))
+	w.reload()
+	assert.True(t, IsOptionSupported("nodiratime", "ext4"))
+
+	// config is removed
+	assert.Nil(t, os.Remove(path))
+	w.reload()
+	assert.True(t, IsOptionSupported(noatimeOpt, "xfs"))
+	assert.False(t, IsOptionSupported("nodiratime", "ext4"))
+}
@@ -9,21 +9,34 @@ package mountoptions
 		  name: sc1
 		mountOptions:
 		  - noatime
+	The list of supported options could be extended with ConfigMap, see ConfigWatcher
 */
 
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
 // MountOptionType type to clarify option purpose
 type MountOptionType string
 
 const (
 	// PublishCmdOpt are options for mount func on NodePublishRequest
 	// Example: mount -o <PublishCmdOpt> /src /dst
-	PublishCmdOpt = MountOptionType("cmdOpt")
+	// File system is mounted only on NodePublishRequest, NodeStageRequest bind mounts the volume device,
+	// so all file system options have this type
+	PublishCmdOpt = MountOptionType("publish")
 )
 
 // MountOption describes mount option
 type MountOption struct {
-	arg       string
-	mountType MountOptionType
+	// Name is an option passed from SC, option with trailing "=" accepts any value, for example "logbufs="
+	Name string `yaml:"name"`
+	// Type defines on which request option is applied, PublishCmdOpt is used if omitted
+	Type MountOptionType `yaml:"type"`
+	// FileSystems which support option, option is supported by any file system if list is empty
+	FileSystems []string `yaml:"filesystems"`
 }
 
 const (
@@ -31,28 +44,90 @@ const (
 )
 
 var (
-	// supportedMountOption contains all supported options
-	// map[optName]{optArg, optType}
-	// optName - passed from SC
-	// optArg - cmd string
-	supportedMountOption = map[string]MountOption{
-		noatimeOpt: {
-			arg:       noatimeOpt,
-			mountType: PublishCmdOpt,
+	// defaultMountOptions are supported when options aren't configured with ConfigMap
+	defaultMountOptions = []MountOption{
+		{
+			Name: noatimeOpt,
+			Type: PublishCmdOpt,
 		},
 	}
+
+	// supportedMountOption contains all supported options, it's replaced on ConfigMap change
+	supportedMountOption = defaultMountOptions
+	supportedMu          sync.RWMutex
 )
 
-// IsOptionSupported returns true if option in supportedMountOption
-func IsOptionSupported(option string) bool {
-	_, ok := supportedMountOption[option]
-	return ok
+// SetSupportedOptions validates and replaces the list of supported options
+// Returns error if some option is invalid, in that case the list isn't changed
+func SetSupportedOptions(options []MountOption) error {
+	validated := make([]MountOption, len(options))
+	for i, option := range options {
+		if option.Name == "" || option.Name == "=" {
+			return fmt.Errorf("option name must be provided")
+		}
+		if option.Type == "" {
+			option.Type = PublishCmdOpt
+		}
+		if option.Type != PublishCmdOpt {
+			return fmt.Errorf("option %s has unknown type %s", option.Name, option.Type)
+		}
+		validated[i] = option
+	}
+
+	supportedMu.Lock()
+	defer supportedMu.Unlock()
+	supportedMountOption = validated
+	return nil
+}
+
+// ResetSupportedOptions restores the default list of supported options
+func ResetSupportedOptions() {
+	supportedMu.Lock()
+	defer supportedMu.Unlock()
+	supportedMountOption = defaultMountOptions
+}
+
+// findOption returns description of the option or nil if option isn't supported
+func findOption(option string) *MountOption {
+	supportedMu.RLock()
+	defer supportedMu.RUnlock()
+	for i, supported := range supportedMountOption {
+		if strings.HasSuffix(supported.Name, "=") {
+			// option requires value
+			if strings.HasPrefix(option, supported.Name) && len(option) > len(supported.Name) {
+				return &supportedMountOption[i]
+			}
+			continue
+		}
+		if supported.Name == option {
+			return &supportedMountOption[i]
+		}
+	}
+	return nil
+}
+
+// IsOptionSupported returns true if option in supportedMountOption and supports fsType,
+// file system isn't checked when fsType is empty
+func IsOptionSupported(option, fsType string) bool {
+	supported := findOption(option)
+	if supported == nil {
+		return false
+	}
+	if fsType == "" || len(supported.FileSystems) == 0 {
+		return true
+	}
+	for _, fs := range supported.FileSystems {
+		if strings.EqualFold(fs, fsType) {
+			return true
+		}
+	}
+	return false
 }
 
-// IsOptionsSupported returns true if all options in supportedMountOption
-func IsOptionsSupported(options []string) bool {
+// IsOptionsSupported returns true if all options in supportedMountOption and support fsType
+func IsOptionsSupported(options []string, fsType string) bool {
 	for _, option := range options {
-		if !IsOptionSupported(option) {
+		if !IsOptionSupported(option, fsType) {
 			return false
 		}
 	}
@@ -63,9 +138,9 @@ func IsOptionsSupported(options []string) bool {
 // FilterWithType returns all option from list with passed type
 func FilterWithType(mountType MountOptionType, options []string) (filteredOptions []string) {
 	for _, option := range options {
-		if val, ok := supportedMountOption[option]; ok {
-			if mountType == val.mountType {
-				filteredOptions = append(filteredOptions, val.arg)
+		if val := findOption(option); val != nil {
+			if mountType == val.Type {
+				filteredOptions = append(filteredOptions, option)
 			}
 		}
 	}
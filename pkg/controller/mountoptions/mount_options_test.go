@@ -0,0 +1,54 @@
+package mountoptions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testOptions = []MountOption{
+	{Name: noatimeOpt, Type: PublishCmdOpt},
+	{Name: "nodiratime", Type: PublishCmdOpt},
+	{Name: "discard"},
+	{Name: "logbufs=", Type: PublishCmdOpt, FileSystems: []string{"xfs"}},
+	{Name: "data=writeback", Type: PublishCmdOpt, FileSystems: []string{"ext4"}},
+}
+
+func TestIsOptionsSupported(t *testing.T) {
+	defer ResetSupportedOptions()
+
+	assert.True(t, IsOptionsSupported([]string{noatimeOpt}, "xfs"))
+	assert.False(t, IsOptionsSupported([]string{noatimeOpt, "nodiratime"}, "xfs"))
+
+	assert.Nil(t, SetSupportedOptions(testOptions))
+	assert.True(t, IsOptionsSupported([]string{noatimeOpt, "nodiratime", "discard"}, "xfs"))
+	assert.True(t, IsOptionsSupported([]string{"logbufs=8"}, "xfs"))
+	assert.False(t, IsOptionsSupported([]string{"logbufs=8"}, "ext4"))
+	assert.False(t, IsOptionsSupported([]string{"logbufs="}, "xfs"))
+	assert.True(t, IsOptionsSupported([]string{"data=writeback"}, "EXT4"))
+	assert.False(t, IsOptionsSupported([]string{"data=ordered"}, "ext4"))
+	assert.True(t, IsOptionsSupported([]string{"data=writeback"}, ""))
+	assert.True(t, IsOptionsSupported(nil, "xfs"))
+}
+
+func TestSetSupportedOptions(t *testing.T) {
+	defer ResetSupportedOptions()
+
+	assert.NotNil(t, SetSupportedOptions([]MountOption{{Name: "", Type: PublishCmdOpt}}))
+	assert.NotNil(t, SetSupportedOptions([]MountOption{{Name: "discard", Type: "unknown"}}))
+	// file system is mounted on publish only, options of the bind mount on stage aren't supported
+	assert.NotNil(t, SetSupportedOptions([]MountOption{{Name: "discard", Type: "stage"}}))
+	// invalid list doesn't replace the current one
+	assert.True(t, IsOptionSupported(noatimeOpt, ""))
+	assert.False(t, IsOptionSupported("discard", ""))
+}
+
+func TestFilterWithType(t *testing.T) {
+	defer ResetSupportedOptions()
+	assert.Nil(t, SetSupportedOptions(testOptions))
+
+	options := []string{noatimeOpt, "discard", "logbufs=8", "unknown"}
+	// option without type is applied on publish
+	assert.Equal(t, []string{noatimeOpt, "discard", "logbufs=8"}, FilterWithType(PublishCmdOpt, options))
+	assert.Empty(t, FilterWithType(MountOptionType("stage"), options))
+}
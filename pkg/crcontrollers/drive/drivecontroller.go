@@ -160,10 +160,10 @@ func (c *Controller) handleDriveUpdate(ctx context.Context, log *logrus.Entry, d
 		if err != nil {
 			return ignore, err
 		}
+		// member drive of RAID volume is released by node service once it isn't active member of the array
 		allFound := true
 		for _, vol := range volumes {
-			status, found := drive.Annotations[fmt.Sprintf(
-				"%s/%s", apiV1.DriveAnnotationVolumeStatusPrefix, vol.Name)]
+			status, found := drive.Annotations[getVolumeStatusAnnotationKey(vol.Name)]
 			if !found || status != apiV1.VolumeUsageReleased {
 				allFound = false
 				break
@@ -195,6 +195,10 @@ func (c *Controller) handleDriveUpdate(ctx context.Context, log *logrus.Entry, d
 			return ignore, err
 		}
 		for _, vol := range volumes {
+			// RAID array keeps working without released member drive
+			if isReleasedRaidMember(drive, vol) {
+				continue
+			}
 			value, found := getDriveAnnotationRemoval(vol.Annotations)
 			if !found || value != apiV1.DriveAnnotationRemovalReady {
 				// need to update volume annotations
@@ -227,6 +231,17 @@ func (c *Controller) handleDriveUpdate(ctx context.Context, log *logrus.Entry, d
 	return ignore, nil
 }
 
+// getVolumeStatusAnnotationKey returns key of the drive annotation with status of the volume
+func getVolumeStatusAnnotationKey(volumeName string) string {
+	return fmt.Sprintf("%s/%s", apiV1.DriveAnnotationVolumeStatusPrefix, volumeName)
+}
+
+// isReleasedRaidMember checks whether drive is member of RAID volume which was released from the array
+func isReleasedRaidMember(drive *drivecrd.Drive, volume *volumecrd.Volume) bool {
+	return volume.Spec.LocationType == apiV1.LocationTypeRAID &&
+		drive.Annotations[getVolumeStatusAnnotationKey(volume.Name)] == apiV1.VolumeUsageReleased
+}
+
 // For support deprecated Replacement annotation
 func getDriveAnnotationRemoval(annotations map[string]string) (string, bool) {
 	status, found := annotations[apiV1.DriveAnnotationRemoval]
@@ -245,6 +260,10 @@ func (c *Controller) handleDriveStatus(ctx context.Context, drive *drivecrd.Driv
 	switch drive.Spec.Status {
 	case apiV1.DriveStatusOffline:
 		for _, volume := range volumes {
+			// RAID volume state depends on the whole array, it is checked by node service
+			if volume.Spec.LocationType == apiV1.LocationTypeRAID {
+				continue
+			}
 			if err := c.crHelper.UpdateVolumeOpStatus(ctx, volume, apiV1.OperationalStatusMissing); err != nil {
 				return err
 			}
@@ -262,9 +281,9 @@ func (c *Controller) handleDriveStatus(ctx context.Context, drive *drivecrd.Driv
 	return nil
 }
 
-func (c *Controller) checkAllVolsRemoved(volumes []*volumecrd.Volume) bool {
+func (c *Controller) checkAllVolsRemoved(drive *drivecrd.Drive, volumes []*volumecrd.Volume) bool {
 	for _, vol := range volumes {
-		if vol.Spec.CSIStatus != apiV1.Removed {
+		if vol.Spec.CSIStatus != apiV1.Removed && !isReleasedRaidMember(drive, vol) {
 			return false
 		}
 	}
@@ -311,7 +330,7 @@ func (c *Controller) handleDriveUsageRemoving(ctx context.Context, log *logrus.E
 	if err != nil {
 		return ignore, err
 	}
-	if !c.checkAllVolsRemoved(volumes) {
+	if !c.checkAllVolsRemoved(drive, volumes) {
 		return ignore, nil
 	}
 	drive.Spec.Usage = apiV1.DriveUsageRemoved
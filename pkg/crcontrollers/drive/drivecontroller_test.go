@@ -0,0 +1,141 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/events"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+)
+
+var (
+	testCtx    = context.Background()
+	testLogger = logrus.New()
+	testNs     = "default"
+	nodeID     = "node-1"
+
+	member1UUID = "uuid-drive1"
+	member2UUID = "uuid-drive2"
+	raidVolume  = "pvc-raid"
+)
+
+func setupTestController(t *testing.T) *Controller {
+	kubeClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	scheme, err := k8s.PrepareScheme()
+	assert.Nil(t, err)
+	recorder, err := events.New("test", nodeID, fake.NewSimpleClientset().CoreV1().Events(testNs), scheme, testLogger)
+	assert.Nil(t, err)
+	return NewController(kubeClient, nodeID, mocks.NewMockDriveMgrClient(nil), recorder, testLogger)
+}
+
+// createRaidVolume creates RAID volume which members are member1UUID and member2UUID drives
+func createRaidVolume(t *testing.T, c *Controller) *volumecrd.Volume {
+	volume := c.client.ConstructVolumeCR(raidVolume, testNs, map[string]string{}, api.Volume{
+		Id:            raidVolume,
+		NodeId:        nodeID,
+		Location:      member1UUID,
+		LocationType:  apiV1.LocationTypeRAID,
+		RaidLocations: []string{member1UUID, member2UUID},
+		CSIStatus:     apiV1.Published,
+		Health:        apiV1.HealthSuspect,
+		Usage:         apiV1.VolumeUsageInUse,
+	})
+	volume.Annotations = map[string]string{}
+	assert.Nil(t, c.client.CreateCR(testCtx, volume.Name, volume))
+	return volume
+}
+
+func createMemberDrive(t *testing.T, c *Controller, uuid string) *drivecrd.Drive {
+	drive := c.client.ConstructDriveCR(uuid, api.Drive{
+		UUID:         uuid,
+		SerialNumber: "sn-" + uuid,
+		NodeId:       nodeID,
+		Type:         apiV1.DriveTypeHDD,
+		Status:       apiV1.DriveStatusOnline,
+		Health:       apiV1.HealthBad,
+		Usage:        apiV1.DriveUsageInUse,
+	})
+	assert.Nil(t, c.client.CreateCR(testCtx, drive.Name, drive))
+	return drive
+}
+
+func TestController_handleDriveUpdateRaidMember(t *testing.T) {
+	for _, member := range []string{member1UUID, member2UUID} {
+		member := member
+		t.Run(member, func(t *testing.T) {
+			c := setupTestController(t)
+			volume := createRaidVolume(t, c)
+			drive := createMemberDrive(t, c, member)
+
+			status, err := c.handleDriveUpdate(testCtx, c.log, drive)
+			assert.Nil(t, err)
+			assert.Equal(t, update, status)
+			assert.Equal(t, apiV1.DriveUsageReleasing, drive.Spec.Usage)
+
+			// drive is still active member of the array
+			status, err = c.handleDriveUpdate(testCtx, c.log, drive)
+			assert.Nil(t, err)
+			assert.Equal(t, ignore, status)
+			assert.Equal(t, apiV1.DriveUsageReleasing, drive.Spec.Usage)
+
+			// node service released drive from the array
+			drive.Annotations = map[string]string{getVolumeStatusAnnotationKey(raidVolume): apiV1.VolumeUsageReleased}
+			status, err = c.handleDriveUpdate(testCtx, c.log, drive)
+			assert.Nil(t, err)
+			assert.Equal(t, update, status)
+			assert.Equal(t, apiV1.DriveUsageReleased, drive.Spec.Usage)
+
+			// RAID volume isn't annotated for removal and doesn't block removal of the drive
+			drive.Annotations[apiV1.DriveAnnotationRemoval] = apiV1.DriveAnnotationRemovalReady
+			status, err = c.handleDriveUpdate(testCtx, c.log, drive)
+			assert.Nil(t, err)
+			assert.Equal(t, update, status)
+			assert.Equal(t, apiV1.DriveUsageRemoving, drive.Spec.Usage)
+			assert.Nil(t, c.client.ReadCR(testCtx, volume.Name, testNs, volume))
+			assert.Empty(t, volume.Annotations[apiV1.DriveAnnotationRemoval])
+
+			status, err = c.handleDriveUpdate(testCtx, c.log, drive)
+			assert.Nil(t, err)
+			assert.Equal(t, update, status)
+			assert.NotEqual(t, apiV1.DriveUsageRemoving, drive.Spec.Usage)
+		})
+	}
+}
+
+func TestController_handleDriveStatusRaidMember(t *testing.T) {
+	c := setupTestController(t)
+	volume := createRaidVolume(t, c)
+	drive := createMemberDrive(t, c, member2UUID)
+
+	// RAID volume state depends on the whole array
+	drive.Spec.Status = apiV1.DriveStatusOffline
+	assert.Nil(t, c.handleDriveStatus(testCtx, drive))
+	assert.Nil(t, c.client.ReadCR(testCtx, volume.Name, testNs, volume))
+	assert.NotEqual(t, apiV1.OperationalStatusMissing, volume.Spec.OperationalStatus)
+}
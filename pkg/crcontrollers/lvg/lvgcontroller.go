@@ -154,6 +154,11 @@ func (c *Controller) handlerLVGCreation(lvg *lvgcrd.LogicalVolumeGroup) (ctrl.Re
 	if locations, err = c.createSystemLVG(lvg); err != nil {
 		ll.Errorf("Unable to create system LogicalVolumeGroup: %v", err)
 		newStatus = apiV1.Failed
+	} else if lvg.Spec.Thin {
+		if err = c.lvmOps.ThinPoolCreate(lvm.ThinPoolName, lvg.Name); err != nil {
+			ll.Errorf("Unable to create thin pool: %v", err)
+			newStatus = apiV1.Failed
+		}
 	}
 	lvg.Spec.Status = newStatus
 	lvg.Spec.Locations = locations
@@ -198,7 +203,7 @@ func (c *Controller) handleLVGRemoving(lvg *lvgcrd.LogicalVolumeGroup) (ctrl.Res
 	drivesUUIDs := c.k8sClient.GetSystemDriveUUIDs()
 	if !util.ContainsString(drivesUUIDs, lvg.Spec.Locations[0]) {
 		// cleanup LVM artifacts
-		if err := c.removeLVGArtifacts(lvg.Name); err != nil {
+		if err := c.removeLVGArtifacts(lvg); err != nil {
 			ll.Errorf("Unable to cleanup LVM artifacts: %v", err)
 			return ctrl.Result{}, err
 		}
@@ -285,14 +290,28 @@ func (c *Controller) createSystemLVG(lvg *lvgcrd.LogicalVolumeGroup) (locations
 }
 
 // removeLVGArtifacts removes LogicalVolumeGroup and PVs that doesn't correspond to particular LogicalVolumeGroup
-// when LogicalVolumeGroup is removed all PVs that were in that LogicalVolumeGroup becomes orphans
-func (c *Controller) removeLVGArtifacts(lvgName string) error {
+// when LogicalVolumeGroup is removed all PVs that were in that LogicalVolumeGroup becomes orphans.
+// Thin pool of LogicalVolumeGroup is removed first if there are no thin LVs in it
+func (c *Controller) removeLVGArtifacts(lvg *lvgcrd.LogicalVolumeGroup) error {
+	lvgName := lvg.Name
 	ll := c.log.WithFields(logrus.Fields{
 		"method":  "removeLVGArtifacts",
 		"lvgName": lvgName,
 	})
 	ll.Info("Processing ...")
 
+	if lvg.Spec.Thin {
+		lvs, err := c.lvmOps.GetLVsInVG(lvgName)
+		if err != nil {
+			return fmt.Errorf("unable to list LVs in LogicalVolumeGroup %s: %v", lvgName, err)
+		}
+		if len(lvs) == 1 && lvs[0] == lvm.ThinPoolName {
+			if err = c.lvmOps.LVRemove(fmt.Sprintf("/dev/%s/%s", lvgName, lvm.ThinPoolName)); err != nil {
+				return fmt.Errorf("unable to remove thin pool of LogicalVolumeGroup %s: %v", lvgName, err)
+			}
+		}
+	}
+
 	if c.lvmOps.IsVGContainsLVs(lvgName) {
 		ll.Errorf("There are LVs in LogicalVolumeGroup. Unable to remove it.")
 		return fmt.Errorf("there are LVs in LogicalVolumeGroup %s", lvgName)
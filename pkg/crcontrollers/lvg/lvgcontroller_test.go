@@ -202,6 +202,39 @@ func TestReconcile_SuccessCreatingLVG(t *testing.T) {
 	assert.Contains(t, currLVG.ObjectMeta.Finalizers, lvgFinalizer)
 }
 
+func TestReconcile_CreatingThinLVG(t *testing.T) {
+	for _, poolErr := range []error{nil, errors.New("error")} {
+		var (
+			lvmOps  = &mocklu.MockWrapLVM{}
+			listBlk = &mocklu.MockWrapLsblk{}
+			fLVG    = lvgCR1.DeepCopy()
+			lvg     = &lvgcrd.LogicalVolumeGroup{}
+		)
+
+		fLVG.Finalizers = []string{lvgFinalizer}
+		fLVG.Spec.Thin = true
+		c := setup(t, node1ID, fLVG)
+		c.lvmOps = lvmOps
+		c.listBlk = listBlk
+
+		listBlk.On("SearchDrivePath", mock.Anything).Return("", nil)
+		lvmOps.On("PVCreate", mock.Anything).Return(nil)
+		lvmOps.On("VGCreate", mock.Anything, mock.Anything).Return(nil)
+		lvmOps.On("ThinPoolCreate", lvm.ThinPoolName, fLVG.Name).Return(poolErr).Times(1)
+
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: ns, Name: fLVG.Name}}
+		_, err := c.Reconcile(tCtx, req)
+		assert.Nil(t, err)
+		assert.Nil(t, c.k8sClient.ReadCR(tCtx, req.Name, "", lvg))
+		if poolErr == nil {
+			assert.Equal(t, apiV1.Created, lvg.Spec.Status)
+		} else {
+			assert.Equal(t, apiV1.Failed, lvg.Spec.Status)
+		}
+		lvmOps.AssertExpectations(t)
+	}
+}
+
 func TestReconcile_LVGHealthBad(t *testing.T) {
 	var fLVG = lvgCR1.DeepCopy()
 	fLVG.Spec.Status = apiV1.Created
@@ -363,13 +396,13 @@ func Test_removeLVGArtifacts_Success(t *testing.T) {
 	e.OnCommand(fmt.Sprintf(lvm.LVsInVGCmdTmpl, lvgCR1.Name)).Return("", "", nil)
 	e.OnCommand(fmt.Sprintf(lvm.VGRemoveCmdTmpl, vg)).Return("", "", nil)
 	e.OnCommand(fmt.Sprintf(lvm.PVsInVGCmdTmpl, lvm.EmptyName)).Return("", "", nil).Times(1)
-	err = c.removeLVGArtifacts(vg)
+	err = c.removeLVGArtifacts(lvgCR1.DeepCopy())
 	assert.Nil(t, err)
 
 	// expect that RemoveOrphanPVs failed and ignore it
 	e.OnCommand(fmt.Sprintf(lvm.PVsInVGCmdTmpl, lvm.EmptyName)).
 		Return("", "", errors.New("error")).Times(1)
-	err = c.removeLVGArtifacts(vg)
+	err = c.removeLVGArtifacts(lvgCR1.DeepCopy())
 	assert.Nil(t, err)
 }
 
@@ -385,16 +418,42 @@ func Test_removeLVGArtifacts_Fail(t *testing.T) {
 
 	// expect that VG contains LV
 	e.OnCommand(fmt.Sprintf(lvm.LVsInVGCmdTmpl, vg)).Return("some-lv1", "", nil).Times(1)
-	err = c.removeLVGArtifacts(vg)
+	err = c.removeLVGArtifacts(lvgCR1.DeepCopy())
 	assert.Equal(t, fmt.Errorf("there are LVs in LogicalVolumeGroup %s", vg), err)
 
 	// expect that VGRemove failed
 	e.OnCommand(fmt.Sprintf(lvm.LVsInVGCmdTmpl, vg)).Return("", "", nil).Times(1)
 	e.OnCommand(fmt.Sprintf(lvm.VGRemoveCmdTmpl, vg)).Return("", "", errors.New("error"))
-	err = c.removeLVGArtifacts(vg)
+	err = c.removeLVGArtifacts(lvgCR1.DeepCopy())
 	assert.Contains(t, err.Error(), "unable to remove LogicalVolumeGroup")
 }
 
+func Test_removeLVGArtifacts_Thin(t *testing.T) {
+	var (
+		c        = setup(t, node1ID)
+		lvmOps   = &mocklu.MockWrapLVM{}
+		lvg      = lvgCR1.DeepCopy()
+		poolPath = fmt.Sprintf("/dev/%s/%s", lvg.Name, lvm.ThinPoolName)
+	)
+	lvg.Spec.Thin = true
+	c.lvmOps = lvmOps
+
+	// thin pool is removed before VG
+	lvmOps.On("GetLVsInVG", lvg.Name).Return([]string{lvm.ThinPoolName}, nil).Times(1)
+	lvmOps.On("LVRemove", poolPath).Return(nil).Times(1)
+	lvmOps.On("IsVGContainsLVs", lvg.Name).Return(false).Times(1)
+	lvmOps.On("VGRemove", lvg.Name).Return(nil).Times(1)
+	lvmOps.On("RemoveOrphanPVs").Return(nil).Times(1)
+	assert.Nil(t, c.removeLVGArtifacts(lvg))
+	lvmOps.AssertExpectations(t)
+
+	// thin pool isn't removed if there are thin LVs in it
+	lvmOps.On("GetLVsInVG", lvg.Name).Return([]string{lvm.ThinPoolName, "some-lv1"}, nil).Times(1)
+	lvmOps.On("IsVGContainsLVs", lvg.Name).Return(true).Times(1)
+	err := c.removeLVGArtifacts(lvg)
+	assert.Equal(t, fmt.Errorf("there are LVs in LogicalVolumeGroup %s", lvg.Name), err)
+}
+
 func Test_increaseACSize(t *testing.T) {
 	c := setup(t, node1ID)
 
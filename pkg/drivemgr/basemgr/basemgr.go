@@ -17,7 +17,14 @@ limitations under the License.
 package basemgr
 
 import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
@@ -26,11 +33,40 @@ import (
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/ipmi"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsscsi"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/nvmecli"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/ses"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/smartctl"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/uevent"
+	"github.com/dell/csi-baremetal/pkg/drivemgr"
+	"github.com/dell/csi-baremetal/pkg/drivemgr/healthpolicy"
 )
 
+// smartStatusFailedReason explains BAD health of the drive
+const smartStatusFailedReason = "SMART overall-health self-assessment test failed"
+
+// criticalWarningReasonTmpl explains not GOOD health of the NVMe drive
+const criticalWarningReasonTmpl = "NVMe critical warning 0x%x"
+
+const (
+	// nvmeDevicePrefix is the prefix of NVMe namespaces, other disks are inspected with lsscsi
+	nvmeDevicePrefix = "/dev/nvme"
+	// ueventsDebouncePeriod merges udev events which come during enclosure reset or rescan
+	ueventsDebouncePeriod = 2 * time.Second
+	// ueventsMaxDelay limits delay of the events handling during continuous events
+	ueventsMaxDelay = 10 * time.Second
+	// maxTargetedRediscovery is the max number of the devices which are rediscovered one by one,
+	// all drives are rediscovered if more devices are changed at once
+	maxTargetedRediscovery = 4
+	// byIDDir contains persistent links of the disks created by udev
+	byIDDir = "/dev/disk/by-id"
+)
+
+// partitionLinkRegexp matches by-id links of the partitions, e.g. wwn-0x5000c500a0b1c2d3-part1
+var partitionLinkRegexp = regexp.MustCompile(`-part\d+$`)
+
 // BaseManager is a drive manager based on Linux system utils
 type BaseManager struct {
 	exec     command.CmdExecutor
@@ -38,10 +74,24 @@ type BaseManager struct {
 	lsscsi   lsscsi.WrapLsscsi
 	smartctl smartctl.WrapSmartctl
 	nvme     nvmecli.WrapNvmecli
+	ses      ses.WrapSES
+	ipmi     ipmi.WrapIpmi
+	lsblk    lsblk.WrapLsblk
+	// directory with persistent links of the disks
+	byIDDir string
+	// predicts drive failure from SMART attributes, nil disables prediction
+	healthPolicy *healthpolicy.Engine
+	// notifies drive watchers about udev events of the disks
+	notifier *drivemgr.Notifier
+
+	// drives discovered by GetDrivesList, devices from udev events are rediscovered in place
+	drives []*api.Drive
+	mu     sync.Mutex
 }
 
 // GetDrivesList gets api.Drive slice using Linux system utils
-func (mgr BaseManager) GetDrivesList() ([]*api.Drive, error) {
+// Enclosure, Slot and Bay of the drives are filled if drives are inserted into SCSI enclosure
+func (mgr *BaseManager) GetDrivesList() ([]*api.Drive, error) {
 	ll := mgr.log.WithField("method", "GetDrivesList")
 	var (
 		devices    []*api.Drive
@@ -55,18 +105,320 @@ func (mgr BaseManager) GetDrivesList() ([]*api.Drive, error) {
 		ll.Errorf("Failed to initialize devices, Error: %v", err)
 	}
 	devices = append(devices, nvmDevices...)
+
+	for _, d := range devices {
+		mgr.fillSlot(d)
+	}
+	mgr.fillIdentifiers("", devices...)
+	mgr.mu.Lock()
+	mgr.drives = cloneDrives(devices)
+	mgr.mu.Unlock()
 	return devices, nil
 }
 
-// Locate implements Locate method of DriveManager interface
+// GetCachedDrivesList implements drivemgr.DriveCache, returns drives discovered last time
+// with devices from udev events rediscovered, all drives are discovered if there weren't discovery yet
+func (mgr *BaseManager) GetCachedDrivesList() ([]*api.Drive, error) {
+	mgr.mu.Lock()
+	drives := mgr.drives
+	mgr.mu.Unlock()
+	if drives == nil {
+		return mgr.GetDrivesList()
+	}
+	return cloneDrives(drives), nil
+}
+
+// GetDrivesHealth implements drivemgr.DriveHealthChecker, refreshes health and SMART telemetry of the cached drives
+// without listing of the devices. Drive is kept as is if its SMART information isn't available or device
+// has another serial number, such changes are handled by udev events and rediscovery
+func (mgr *BaseManager) GetDrivesHealth() ([]*api.Drive, error) {
+	drives, err := mgr.GetCachedDrivesList()
+	if err != nil {
+		return nil, err
+	}
+	for _, drive := range drives {
+		mgr.refreshHealth(drive)
+	}
+
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	for _, cached := range mgr.drives {
+		for _, drive := range drives {
+			if cached.SerialNumber == drive.SerialNumber && cached.Path == drive.Path {
+				copyHealth(cached, drive)
+			}
+		}
+	}
+	return drives, nil
+}
+
+// refreshHealth reads SMART information of the drive and updates its health
+func (mgr *BaseManager) refreshHealth(drive *api.Drive) {
+	ll := mgr.log.WithField("method", "refreshHealth")
+	var current *api.Drive
+	if drive.Type == apiV1.DriveTypeNVMe {
+		device, err := mgr.nvme.GetNVMDevice(drive.Path)
+		if err != nil || device == nil {
+			ll.Warnf("Failed to get NVMe Device %s, health isn't refreshed: %v", drive.Path, err)
+			return
+		}
+		current = mgr.nvmeDrive(device)
+	} else {
+		current = mgr.scsiDrive(&lsscsi.SCSIDevice{Path: drive.Path, Vendor: drive.VID, Model: drive.PID,
+			Firmware: drive.Firmware, Size: drive.Size})
+	}
+	if current == nil || current.SerialNumber != drive.SerialNumber {
+		ll.Warnf("Device %s isn't drive %s anymore, health isn't refreshed", drive.Path, drive.SerialNumber)
+		return
+	}
+	copyHealth(drive, current)
+}
+
+// copyHealth copies health and SMART telemetry of the drive
+func copyHealth(dst, src *api.Drive) {
+	dst.Health = src.Health
+	dst.HealthReason = src.HealthReason
+	dst.Temperature = src.Temperature
+	dst.PowerOnHours = src.PowerOnHours
+	dst.Endurance = src.Endurance
+}
+
+// fillSlot fills Enclosure, Slot and Bay of the drive inserted into SCSI enclosure
+func (mgr *BaseManager) fillSlot(drive *api.Drive) {
+	slot, err := mgr.ses.GetSlot(drive.Path)
+	if err != nil {
+		mgr.log.WithField("method", "fillSlot").
+			Warnf("Failed to find enclosure slot of Device %s, Error: %v", drive.Path, err)
+		return
+	}
+	if slot != nil {
+		drive.Enclosure, drive.Slot, drive.Bay = slot.Enclosure, slot.Slot, slot.Bay
+	}
+}
+
+// fillIdentifiers fills WWN and by-id link of the drives, which identify drives when kernel names of the devices
+// are changed, e.g. after reboot. Only provided device is inspected by lsblk, all devices if device is empty
+func (mgr *BaseManager) fillIdentifiers(device string, drives ...*api.Drive) {
+	if len(drives) == 0 {
+		return
+	}
+	ll := mgr.log.WithField("method", "fillIdentifiers")
+	wwns := make(map[string]string, len(drives))
+	blockDevices, err := mgr.lsblk.GetBlockDevices(device)
+	if err != nil {
+		ll.Warnf("Failed to get WWN of the devices, Error: %v", err)
+	}
+	for _, bd := range blockDevices {
+		wwns[bd.Name] = bd.WWN
+	}
+	links, err := byIDLinks(mgr.byIDDir)
+	if err != nil {
+		ll.Warnf("Failed to read persistent links of the devices, Error: %v", err)
+	}
+	for _, d := range drives {
+		d.WWN, d.ByIdPath = wwns[d.Path], links[d.Path]
+	}
+}
+
+// byIDLinks returns by-id links of the disks mapped by current kernel names of the devices,
+// links based on WWN or EUI are preferred to the links based on model and serial number
+func byIDLinks(dir string) (map[string]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	links := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if partitionLinkRegexp.MatchString(entry.Name()) {
+			continue
+		}
+		link := filepath.Join(dir, entry.Name())
+		device, err := filepath.EvalSymlinks(link)
+		if err != nil {
+			continue
+		}
+		if current, ok := links[device]; !ok || (!isWWNLink(current) && isWWNLink(link)) {
+			links[device] = link
+		}
+	}
+	return links, nil
+}
+
+// isWWNLink checks whether by-id link is based on WWN of SCSI/SATA drive or EUI of NVMe drive
+func isWWNLink(link string) bool {
+	name := filepath.Base(link)
+	return strings.HasPrefix(name, "wwn-") || strings.HasPrefix(name, "nvme-eui.")
+}
+
+// Locate implements Locate method of DriveManager interface,
+// changes locate LED of the enclosure slot with the drive or uses ledctl
 func (mgr *BaseManager) Locate(serialNumber string, action int32) (int32, error) {
-	return -1, status.Error(codes.Unimplemented, "method Locate not implemented in BaseManager")
+	path, err := mgr.getDevicePath(serialNumber)
+	if err != nil {
+		return -1, err
+	}
+	switch action {
+	case apiV1.LocateStart:
+		if err = mgr.ses.Locate(path, true); err != nil {
+			return -1, err
+		}
+		return apiV1.LocateStatusOn, nil
+	case apiV1.LocateStop:
+		if err = mgr.ses.Locate(path, false); err != nil {
+			return -1, err
+		}
+		return apiV1.LocateStatusOff, nil
+	case apiV1.LocateStatus:
+		return mgr.ses.LocateStatus(path)
+	}
+	return -1, status.Error(codes.InvalidArgument, "Wrong arguments for Locate methods")
 }
 
-// LocateNode implements LocateNode method of DriveManager interface
+// LocateNode implements LocateNode method of DriveManager interface, changes chassis identify LED with ipmitool
 func (mgr *BaseManager) LocateNode(action int32) error {
-	// not implemented
-	return status.Error(codes.Unimplemented, "method Locate not implemented in BaseManager")
+	switch action {
+	case apiV1.LocateStart:
+		return mgr.ipmi.ChassisIdentify(true)
+	case apiV1.LocateStop:
+		return mgr.ipmi.ChassisIdentify(false)
+	}
+	return status.Error(codes.InvalidArgument, "Wrong arguments for LocateNode method")
+}
+
+// WatchDrives implements drivemgr.DriveWatcher, notifications are sent on add, remove and change events of the disks
+func (mgr *BaseManager) WatchDrives() (<-chan struct{}, func()) {
+	return mgr.notifier.Subscribe()
+}
+
+// HandleUevents rediscovers disks from add, remove and change udev events and notifies drive watchers,
+// bursts of the events are debounced. Blocks until events channel is closed
+func (mgr *BaseManager) HandleUevents(events <-chan *uevent.Event) {
+	ll := mgr.log.WithField("method", "HandleUevents")
+	for batch := range uevent.Debounce(events, ueventsDebouncePeriod, ueventsMaxDelay) {
+		if mgr.rediscover(batch) {
+			mgr.notifier.Notify()
+		}
+	}
+	ll.Info("Events channel is closed")
+}
+
+// rediscover updates cached drives with devices from add, remove and change events of the disks,
+// returns false if there are no such events
+func (mgr *BaseManager) rediscover(events []*uevent.Event) bool {
+	ll := mgr.log.WithField("method", "rediscover")
+	disks := make([]*uevent.Event, 0, len(events))
+	for _, event := range events {
+		if !event.IsDisk() || event.DevName == "" {
+			continue
+		}
+		switch event.Action {
+		case uevent.ActionAdd, uevent.ActionRemove, uevent.ActionChange:
+			ll.Infof("Disk %s is changed, action %s", event.DevName, event.Action)
+			disks = append(disks, event)
+		}
+	}
+	if len(disks) == 0 {
+		return false
+	}
+	if len(disks) > maxTargetedRediscovery {
+		ll.Infof("%d disks are changed, rediscover all drives", len(disks))
+		if _, err := mgr.GetDrivesList(); err != nil {
+			ll.Errorf("Failed to discover drives: %v", err)
+		}
+		return true
+	}
+
+	for _, event := range disks {
+		var (
+			drive *api.Drive
+			err   error
+		)
+		if event.Action != uevent.ActionRemove {
+			if drive, err = mgr.discoverDevice(event.DevName); err != nil {
+				// device is kept as is, it is updated on the next discovery
+				ll.Errorf("Failed to discover Device %s: %v", event.DevName, err)
+				continue
+			}
+		}
+		mgr.updateCachedDrive(event.DevName, drive)
+	}
+	return true
+}
+
+// discoverDevice discovers the drive with provided path, returns nil if device isn't a valid drive
+func (mgr *BaseManager) discoverDevice(path string) (*api.Drive, error) {
+	var drive *api.Drive
+	if strings.HasPrefix(path, nvmeDevicePrefix) {
+		device, err := mgr.nvme.GetNVMDevice(path)
+		if err != nil {
+			return nil, err
+		}
+		if device != nil {
+			drive = mgr.nvmeDrive(device)
+		}
+	} else {
+		device, err := mgr.lsscsi.GetSCSIDevice(path)
+		if err != nil {
+			return nil, err
+		}
+		if device != nil {
+			drive = mgr.scsiDrive(device)
+		}
+	}
+	if drive != nil {
+		mgr.fillSlot(drive)
+		mgr.fillIdentifiers(path, drive)
+	}
+	return drive, nil
+}
+
+// updateCachedDrive replaces cached drive with provided path, drive with the same serial number is replaced too
+// because its path is changed. Drive is removed from the cache if provided drive is nil
+func (mgr *BaseManager) updateCachedDrive(path string, drive *api.Drive) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	drives := make([]*api.Drive, 0, len(mgr.drives)+1)
+	replaced := false
+	for _, d := range mgr.drives {
+		if d.Path != path && (drive == nil || d.SerialNumber != drive.SerialNumber) {
+			drives = append(drives, d)
+			continue
+		}
+		if drive != nil && !replaced {
+			drives = append(drives, drive)
+			replaced = true
+		}
+	}
+	if drive != nil && !replaced {
+		drives = append(drives, drive)
+	}
+	mgr.drives = drives
+}
+
+// getDevicePath returns path of the drive with provided serial number, drives are rediscovered if it isn't known
+func (mgr *BaseManager) getDevicePath(serialNumber string) (string, error) {
+	if path := mgr.findDevicePath(serialNumber); path != "" {
+		return path, nil
+	}
+	if _, err := mgr.GetDrivesList(); err != nil {
+		return "", err
+	}
+	if path := mgr.findDevicePath(serialNumber); path != "" {
+		return path, nil
+	}
+	return "", status.Errorf(codes.NotFound, "drive %s isn't found", serialNumber)
+}
+
+// findDevicePath returns path of the cached drive with provided serial number or empty string
+func (mgr *BaseManager) findDevicePath(serialNumber string) string {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	for _, d := range mgr.drives {
+		if d.SerialNumber == serialNumber {
+			return d.Path
+		}
+	}
+	return ""
 }
 
 // New is a constructor BaseManager
@@ -77,55 +429,77 @@ func New(exec command.CmdExecutor, logger *logrus.Logger) *BaseManager {
 		lsscsi:   lsscsi.NewLSSCSI(exec, logger),
 		smartctl: smartctl.NewSMARTCTL(exec),
 		nvme:     nvmecli.NewNVMECLI(exec, logger),
+		ses:      ses.NewSES(exec, logger),
+		ipmi:     ipmi.NewIPMI(exec),
+		lsblk:    lsblk.NewLSBLK(logger),
+		byIDDir:  byIDDir,
+		notifier: drivemgr.NewNotifier(),
+	}
+}
+
+// SetHealthPolicy replaces SMART health policy of BaseManager, nil policy disables failure prediction
+func (mgr *BaseManager) SetHealthPolicy(policy *healthpolicy.Policy) {
+	if policy == nil {
+		mgr.healthPolicy = nil
+		return
 	}
+	mgr.healthPolicy = healthpolicy.NewEngine(policy)
 }
 
 // GetSCSIDevices get []*api.Drive using lsscsi system util
 func (mgr *BaseManager) GetSCSIDevices() ([]*api.Drive, error) {
 	ll := mgr.log.WithField("method", "GetSCSIDevices")
-	allDevices := make([]*api.Drive, 0)
 	scsiDevices, err := mgr.lsscsi.GetSCSIDevices()
 	if err != nil {
-		ll.Errorf("Failed to get SCSI allDevices, Error: %v", err)
+		ll.Errorf("Failed to get SCSI devices, Error: %v", err)
 		return nil, err
 	}
-	for _, device := range scsiDevices {
-		allDevices = append(allDevices, &api.Drive{
-			Path:     device.Path,
-			Firmware: device.Firmware,
-			VID:      device.Vendor,
-			PID:      device.Model,
-			Size:     device.Size,
-		})
-	}
 	devices := make([]*api.Drive, 0)
-	for i, device := range allDevices {
-		smartInfo, err := mgr.smartctl.GetDriveInfoByPath(device.Path)
-		if err != nil {
-			// We don't fail whole drivemgr because of error with just one device, we don't add it in allDevices slice
-			ll.Errorf("Failed to get SMART information for Device %v, Error: %v", allDevices[i], err)
-		} else {
-			allDevices[i].SerialNumber = smartInfo.SerialNumber
-			if allDevices[i].SerialNumber != "" && allDevices[i].VID != "" && allDevices[i].PID != "" {
-				if smartInfo.Rotation > 0 {
-					allDevices[i].Type = apiV1.DriveTypeHDD
-				} else {
-					allDevices[i].Type = apiV1.DriveTypeSSD
-				}
-				if smartInfo.SmartStatus["passed"] {
-					allDevices[i].Health = apiV1.HealthGood
-				} else {
-					allDevices[i].Health = apiV1.HealthBad
-				}
-				devices = append(devices, allDevices[i])
-			} else {
-				ll.Errorf("Device has empty VID, PID or SN field: %v", allDevices[i])
-			}
+	for _, device := range scsiDevices {
+		if drive := mgr.scsiDrive(device); drive != nil {
+			devices = append(devices, drive)
 		}
 	}
 	return devices, nil
 }
 
+// scsiDrive converts SCSI device to api.Drive, serial number, type and health are filled from SMART information
+// Returns nil if SMART information isn't available or drive has empty VID, PID or SN
+func (mgr *BaseManager) scsiDrive(device *lsscsi.SCSIDevice) *api.Drive {
+	ll := mgr.log.WithField("method", "scsiDrive")
+	drive := &api.Drive{
+		Path:     device.Path,
+		Firmware: device.Firmware,
+		VID:      device.Vendor,
+		PID:      device.Model,
+		Size:     device.Size,
+	}
+	smartInfo, err := mgr.smartctl.GetDriveInfoByPath(drive.Path)
+	if err != nil {
+		// We don't fail whole drivemgr because of error with just one device, we don't add it in devices slice
+		ll.Errorf("Failed to get SMART information for Device %v, Error: %v", drive, err)
+		return nil
+	}
+	drive.SerialNumber = smartInfo.SerialNumber
+	if drive.SerialNumber == "" || drive.VID == "" || drive.PID == "" {
+		ll.Errorf("Device has empty VID, PID or SN field: %v", drive)
+		return nil
+	}
+	if smartInfo.Rotation > 0 {
+		drive.Type = apiV1.DriveTypeHDD
+	} else {
+		drive.Type = apiV1.DriveTypeSSD
+	}
+	if smartInfo.SmartStatus["passed"] {
+		drive.Health = apiV1.HealthGood
+	} else {
+		drive.Health = apiV1.HealthBad
+		drive.HealthReason = smartStatusFailedReason
+	}
+	mgr.applySMARTData(drive)
+	return drive
+}
+
 // GetNVMDevices get []*api.Drive using nvme_cli system util
 func (mgr *BaseManager) GetNVMDevices() ([]*api.Drive, error) {
 	ll := mgr.log.WithField("method", "GetNVMDevices")
@@ -135,21 +509,91 @@ func (mgr *BaseManager) GetNVMDevices() ([]*api.Drive, error) {
 		ll.Errorf("Failed to get NVMe devices, Error: %v", err)
 		return nil, err
 	}
-	for _, device := range nvmeDevices {
-		if device.Vendor != 0 && device.ModelNumber != "" && device.SerialNumber != "" {
-			devices = append(devices, &api.Drive{
-				Health:       device.Health,
-				PID:          device.ModelNumber,
-				VID:          strconv.Itoa(device.Vendor),
-				SerialNumber: device.SerialNumber,
-				Type:         apiV1.DriveTypeNVMe,
-				Size:         device.PhysicalSize,
-				Firmware:     device.Firmware,
-				Path:         device.DevicePath,
-			})
-		} else {
-			ll.Errorf("Device has empty VID, PID or SN field: %v", device)
+	for i := range nvmeDevices {
+		if drive := mgr.nvmeDrive(&nvmeDevices[i]); drive != nil {
+			devices = append(devices, drive)
 		}
 	}
 	return devices, nil
 }
+
+// nvmeDrive converts NVMe device to api.Drive, returns nil if device has empty VID, PID or SN
+func (mgr *BaseManager) nvmeDrive(device *nvmecli.NVMDevice) *api.Drive {
+	if device.Vendor == 0 || device.ModelNumber == "" || device.SerialNumber == "" {
+		mgr.log.WithField("method", "nvmeDrive").Errorf("Device has empty VID, PID or SN field: %v", *device)
+		return nil
+	}
+	drive := &api.Drive{
+		Health:       device.Health,
+		PID:          device.ModelNumber,
+		VID:          strconv.Itoa(device.Vendor),
+		SerialNumber: device.SerialNumber,
+		Type:         apiV1.DriveTypeNVMe,
+		Size:         device.PhysicalSize,
+		Firmware:     device.Firmware,
+		Path:         device.DevicePath,
+	}
+	if device.SMARTLog != nil {
+		drive.Endurance = device.SMARTLog.Endurance()
+		drive.PowerOnHours = int64(device.SMARTLog.PowerOnHours)
+		if device.SMARTLog.Temperature > 0 {
+			drive.Temperature = device.SMARTLog.TemperatureCelsius()
+		}
+		if drive.Health != apiV1.HealthGood {
+			drive.HealthReason = fmt.Sprintf(criticalWarningReasonTmpl, device.SMARTLog.CriticalWarning)
+		}
+		if mgr.healthPolicy != nil {
+			mgr.markSuspect(drive, mgr.healthPolicy.EvaluateNVMe(drive.SerialNumber, device.SMARTLog))
+		}
+	}
+	return drive
+}
+
+// applySMARTData fills drive telemetry from SMART attributes and marks GOOD drive as SUSPECT
+// if they violate health policy, violated rules are saved in HealthReason field
+func (mgr *BaseManager) applySMARTData(drive *api.Drive) {
+	ll := mgr.log.WithField("method", "applySMARTData")
+
+	data, err := mgr.smartctl.GetSMARTDataByPath(drive.Path)
+	if err != nil {
+		// health reported by SMART status is kept
+		ll.Warnf("Failed to get SMART attributes for Device %s, Error: %v", drive.Path, err)
+		return
+	}
+	drive.Temperature = data.Temperature.Current
+	drive.PowerOnHours = data.PowerOnTime.Hours
+	if mgr.healthPolicy != nil {
+		mgr.markSuspect(drive, mgr.healthPolicy.Evaluate(drive.SerialNumber, data))
+	}
+}
+
+// markSuspect marks GOOD drive as SUSPECT if health policy is violated,
+// violations are appended to HealthReason of the drive with not GOOD health
+func (mgr *BaseManager) markSuspect(drive *api.Drive, violations []string) {
+	if len(violations) == 0 {
+		return
+	}
+	ll := mgr.log.WithField("method", "markSuspect")
+	reason := healthpolicy.Explain(violations)
+	if drive.Health == apiV1.HealthGood {
+		ll.Warnf("Drive %s violates SMART health policy: %s", drive.SerialNumber, reason)
+		drive.Health = apiV1.HealthSuspect
+		drive.HealthReason = reason
+		return
+	}
+	if drive.HealthReason == "" {
+		drive.HealthReason = reason
+		return
+	}
+	drive.HealthReason += "; " + reason
+}
+
+// cloneDrives returns deep copy of the drives, drives returned by BaseManager are changed by the callers
+func cloneDrives(drives []*api.Drive) []*api.Drive {
+	clones := make([]*api.Drive, 0, len(drives))
+	for _, d := range drives {
+		clone := *d
+		clones = append(clones, &clone)
+	}
+	return clones
+}
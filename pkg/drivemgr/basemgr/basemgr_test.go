@@ -18,6 +18,9 @@ package basemgr
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/sirupsen/logrus"
@@ -25,15 +28,30 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsscsi"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/nvmecli"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/ses"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/smartctl"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/uevent"
+	"github.com/dell/csi-baremetal/pkg/drivemgr/healthpolicy"
 	"github.com/dell/csi-baremetal/pkg/mocks"
 	"github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
 )
 
 var logger = logrus.New()
 
+func testHealthPolicy() *healthpolicy.Policy {
+	threshold := func(counter string, value int64) healthpolicy.Rule {
+		return healthpolicy.Rule{Counter: counter, Threshold: &value}
+	}
+	return &healthpolicy.Policy{Rules: []healthpolicy.Rule{
+		threshold(smartctl.PendingSectors, 0),
+		threshold(nvmecli.MediaErrors, 0),
+		threshold(nvmecli.PercentageUsed, 90),
+	}}
+}
+
 func TestLoopBackManager_GetNVMDevicesSuccess(t *testing.T) {
 	var (
 		mockexec = &mocks.GoMockExecutor{}
@@ -68,6 +86,65 @@ func TestLoopBackManager_GetNVMDevicesSuccess(t *testing.T) {
 	assert.Equal(t, "2311", devices[0].VID)
 }
 
+func TestLoopBackManager_GetNVMDevicesWear(t *testing.T) {
+	var (
+		mockexec = &mocks.GoMockExecutor{}
+		manager  = New(mockexec, logger)
+		mockNvme = &linuxutils.MockWrapNvmecli{}
+	)
+	nvmeDevices := []nvmecli.NVMDevice{
+		{
+			DevicePath: "path1", ModelNumber: "testModel", SerialNumber: "SN1", Vendor: 2311,
+			Health: apiV1.HealthGood, SMARTLog: &nvmecli.SMARTLog{PercentageUsed: 12, Temperature: 310, PowerOnHours: 1000},
+		},
+		{
+			DevicePath: "path2", ModelNumber: "testModel", SerialNumber: "SN2", Vendor: 2311,
+			Health: apiV1.HealthGood, SMARTLog: &nvmecli.SMARTLog{PercentageUsed: 95, MediaErrors: 2},
+		},
+		{
+			DevicePath: "path3", ModelNumber: "testModel", SerialNumber: "SN3", Vendor: 2311,
+			Health: apiV1.HealthBad, SMARTLog: &nvmecli.SMARTLog{CriticalWarning: 4, PercentageUsed: 120},
+		},
+		{
+			DevicePath: "path4", ModelNumber: "testModel", SerialNumber: "SN4", Vendor: 2311,
+			Health: apiV1.HealthUnknown,
+		},
+	}
+	mockNvme.On("GetNVMDevices", mock.Anything).Return(nvmeDevices, nil)
+	manager.nvme = mockNvme
+	manager.SetHealthPolicy(testHealthPolicy())
+
+	devices, err := manager.GetNVMDevices()
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(devices))
+
+	assert.Equal(t, apiV1.HealthGood, devices[0].Health)
+	assert.Equal(t, int64(88), devices[0].Endurance)
+	assert.Equal(t, int64(37), devices[0].Temperature)
+	assert.Equal(t, int64(1000), devices[0].PowerOnHours)
+	assert.Empty(t, devices[0].HealthReason)
+
+	assert.Equal(t, apiV1.HealthSuspect, devices[1].Health)
+	assert.Equal(t, int64(5), devices[1].Endurance)
+	assert.Equal(t, int64(0), devices[1].Temperature)
+	assert.Equal(t, "media_errors is 2, threshold 0; percentage_used is 95, threshold 90", devices[1].HealthReason)
+
+	assert.Equal(t, apiV1.HealthBad, devices[2].Health)
+	assert.Equal(t, int64(0), devices[2].Endurance)
+	assert.Equal(t, "NVMe critical warning 0x4; percentage_used is 120, threshold 90", devices[2].HealthReason)
+
+	assert.Equal(t, apiV1.HealthUnknown, devices[3].Health)
+	assert.Equal(t, int64(0), devices[3].Endurance)
+	assert.Empty(t, devices[3].HealthReason)
+
+	// prediction is disabled, endurance is still reported
+	manager.SetHealthPolicy(nil)
+	devices, err = manager.GetNVMDevices()
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.HealthGood, devices[1].Health)
+	assert.Equal(t, int64(5), devices[1].Endurance)
+}
+
 func TestLoopBackManager_GetNVMDevicesEmptyVidPidSn(t *testing.T) {
 	var (
 		mockexec = &mocks.GoMockExecutor{}
@@ -137,6 +214,8 @@ func TestLoopBackManager_GetSCSIDevices(t *testing.T) {
 
 	mockSmartctl.On("GetDriveInfoByPath", "testPath").
 		Return(smart, nil)
+	mockSmartctl.On("GetSMARTDataByPath", "testPath").
+		Return(&smartctl.DeviceSMARTData{}, nil)
 
 	manager.lsscsi = mockLsscsi
 	manager.smartctl = mockSmartctl
@@ -160,9 +239,73 @@ func TestLoopBackManager_GetSCSIDevices(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 1, len(devices))
 	assert.Equal(t, apiV1.HealthBad, devices[0].Health)
+	assert.Equal(t, smartStatusFailedReason, devices[0].HealthReason)
 	assert.Equal(t, apiV1.DriveTypeHDD, devices[0].Type)
 }
 
+func TestLoopBackManager_GetSCSIDevicesHealthPolicy(t *testing.T) {
+	var (
+		mockexec     = &mocks.GoMockExecutor{}
+		manager      = New(mockexec, logger)
+		mockLsscsi   = &linuxutils.MockWrapLsscsi{}
+		mockSmartctl = &linuxutils.MockWrapSmartctl{}
+		smart        = &smartctl.DeviceSMARTInfo{
+			SerialNumber: "testSN",
+			SmartStatus:  map[string]bool{"passed": true},
+			Rotation:     7200,
+		}
+		smartData = &smartctl.DeviceSMARTData{}
+	)
+	smartData.ATAAttributes.Table = []smartctl.ATAAttribute{{ID: 197, Name: "Current_Pending_Sector"}}
+	smartData.ATAAttributes.Table[0].Raw.Value = 8
+	smartData.Temperature.Current = 35
+	smartData.PowerOnTime.Hours = 20000
+
+	mockLsscsi.On("GetSCSIDevices", mock.Anything).
+		Return([]*lsscsi.SCSIDevice{{Path: "testPath", Vendor: "testVendor", Model: "testModel"}}, nil)
+	mockSmartctl.On("GetDriveInfoByPath", "testPath").
+		Return(smart, nil)
+	mockSmartctl.On("GetSMARTDataByPath", "testPath").
+		Return(smartData, nil).Twice()
+
+	manager.lsscsi = mockLsscsi
+	manager.smartctl = mockSmartctl
+
+	// failure prediction is disabled by default
+	devices, err := manager.GetSCSIDevices()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(devices))
+	assert.Equal(t, apiV1.HealthGood, devices[0].Health)
+	assert.Empty(t, devices[0].HealthReason)
+
+	manager.SetHealthPolicy(testHealthPolicy())
+	devices, err = manager.GetSCSIDevices()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(devices))
+	assert.Equal(t, apiV1.HealthSuspect, devices[0].Health)
+	assert.Equal(t, "pending_sectors is 8, threshold 0", devices[0].HealthReason)
+	assert.Equal(t, int64(35), devices[0].Temperature)
+	assert.Equal(t, int64(20000), devices[0].PowerOnHours)
+
+	// SMART attributes aren't available, health is reported by SMART status
+	mockSmartctl.On("GetSMARTDataByPath", "testPath").
+		Return(&smartctl.DeviceSMARTData{}, fmt.Errorf("error")).Once()
+	devices, err = manager.GetSCSIDevices()
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.HealthGood, devices[0].Health)
+	assert.Empty(t, devices[0].HealthReason)
+
+	// prediction is disabled, telemetry is still reported
+	mockSmartctl.On("GetSMARTDataByPath", "testPath").
+		Return(smartData, nil).Once()
+	manager.SetHealthPolicy(nil)
+	devices, err = manager.GetSCSIDevices()
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.HealthGood, devices[0].Health)
+	assert.Equal(t, int64(35), devices[0].Temperature)
+	mockSmartctl.AssertNumberOfCalls(t, "GetSMARTDataByPath", 4)
+}
+
 func TestLoopBackManager_GetSCSIDevicesEmptyVidPidSn(t *testing.T) {
 	var (
 		mockexec     = &mocks.GoMockExecutor{}
@@ -301,8 +444,267 @@ func TestLoopBackManager_GetDrivesListSuccess(t *testing.T) {
 		Return([]*lsscsi.SCSIDevice{}, nil)
 	manager.lsscsi = mockLsscsi
 	manager.nvme = mockNvme
+	manager.ses = &linuxutils.MockWrapSES{}
 
 	_, err := manager.GetDrivesList()
 
 	assert.Nil(t, err)
 }
+
+func TestBaseManager_GetDrivesListIdentifiers(t *testing.T) {
+	var (
+		manager    = New(&mocks.GoMockExecutor{}, logger)
+		mockLsscsi = &linuxutils.MockWrapLsscsi{}
+		mockNvme   = &linuxutils.MockWrapNvmecli{}
+		mockLsblk  = &linuxutils.MockWrapLsblk{}
+		mockSES    = &linuxutils.MockWrapSES{}
+		dir        = t.TempDir()
+		byIDDir    = filepath.Join(dir, "by-id")
+		sdc        = filepath.Join(dir, "sdc")
+		nvme0n1    = filepath.Join(dir, "nvme0n1")
+	)
+	assert.Nil(t, os.Mkdir(byIDDir, 0700))
+	for _, device := range []string{sdc, sdc + "1", nvme0n1} {
+		assert.Nil(t, ioutil.WriteFile(device, nil, 0600))
+	}
+	for link, device := range map[string]string{
+		"ata-MODEL_SN1":                 sdc,
+		"scsi-35000c500a0b1c2d3":        sdc,
+		"wwn-0x5000c500a0b1c2d3":        sdc,
+		"wwn-0x5000c500a0b1c2d3-part1":  sdc + "1",
+		"nvme-MODEL_SN2":                nvme0n1,
+		"nvme-eui.0025388b71b1e3f4":     nvme0n1,
+		"nvme-eui.0025388b71b1e3f4-ns1": nvme0n1,
+	} {
+		assert.Nil(t, os.Symlink(device, filepath.Join(byIDDir, link)))
+	}
+	manager.lsscsi, manager.nvme, manager.lsblk, manager.ses = mockLsscsi, mockNvme, mockLsblk, mockSES
+	manager.byIDDir = byIDDir
+
+	mockLsscsi.On("GetSCSIDevices").
+		Return([]*lsscsi.SCSIDevice{{Path: sdc, Vendor: "vendor", Model: "model"}}, nil)
+	mockNvme.On("GetNVMDevices").Return([]nvmecli.NVMDevice{
+		{DevicePath: nvme0n1, ModelNumber: "model", SerialNumber: "SN2", Vendor: 2311}}, nil)
+	mockSmartctl := &linuxutils.MockWrapSmartctl{}
+	mockSmartctl.On("GetDriveInfoByPath", sdc).
+		Return(&smartctl.DeviceSMARTInfo{SerialNumber: "SN1", SmartStatus: map[string]bool{"passed": true}}, nil)
+	mockSmartctl.On("GetSMARTDataByPath", sdc).Return(&smartctl.DeviceSMARTData{}, nil)
+	manager.smartctl = mockSmartctl
+	mockSES.On("GetSlot", mock.Anything).Return(nil, nil)
+	mockLsblk.On("GetBlockDevices", "").Return([]lsblk.BlockDevice{
+		{Name: sdc, WWN: "0x5000c500a0b1c2d3"}, {Name: nvme0n1, WWN: "eui.0025388b71b1e3f4"}}, nil)
+
+	drives, err := manager.GetDrivesList()
+	assert.Nil(t, err)
+	assert.Len(t, drives, 2)
+	assert.Equal(t, "0x5000c500a0b1c2d3", drives[0].WWN)
+	assert.Equal(t, filepath.Join(byIDDir, "wwn-0x5000c500a0b1c2d3"), drives[0].ByIdPath)
+	assert.Equal(t, "eui.0025388b71b1e3f4", drives[1].WWN)
+	assert.Equal(t, filepath.Join(byIDDir, "nvme-eui.0025388b71b1e3f4"), drives[1].ByIdPath)
+
+	// drives are discovered without identifiers if lsblk fails and links don't exist
+	mockLsblk.ExpectedCalls = nil
+	mockLsblk.On("GetBlockDevices", "").Return(nil, fmt.Errorf("error"))
+	manager.byIDDir = filepath.Join(dir, "not-exist")
+	drives, err = manager.GetDrivesList()
+	assert.Nil(t, err)
+	assert.Len(t, drives, 2)
+	assert.Empty(t, drives[0].WWN)
+	assert.Empty(t, drives[0].ByIdPath)
+}
+
+func TestBaseManager_Locate(t *testing.T) {
+	var (
+		mockexec = &mocks.GoMockExecutor{}
+		manager  = New(mockexec, logger)
+		mockNvme = &linuxutils.MockWrapNvmecli{}
+		mockSES  = &linuxutils.MockWrapSES{}
+		mockIpmi = &linuxutils.MockWrapIpmi{}
+	)
+	mockNvme.On("GetNVMDevices", mock.Anything).Return([]nvmecli.NVMDevice{
+		{DevicePath: "/dev/nvme0n1", ModelNumber: "testModel", SerialNumber: "nvmeSN", Vendor: 2311},
+		{DevicePath: "/dev/nvme1n1", ModelNumber: "testModel", SerialNumber: "nvmeSN2", Vendor: 2311},
+	}, nil)
+	manager.lsscsi = &linuxutils.MockWrapLsscsi{}
+	manager.lsscsi.(*linuxutils.MockWrapLsscsi).On("GetSCSIDevices").Return([]*lsscsi.SCSIDevice{}, nil)
+	manager.nvme = mockNvme
+	manager.ses = mockSES
+	manager.ipmi = mockIpmi
+	manager.lsblk = linuxutils.GetMockWrapLsblk("")
+	manager.byIDDir = t.TempDir()
+
+	mockSES.On("GetSlot", "/dev/nvme0n1").
+		Return(&ses.Slot{Enclosure: "0x500056b36789abff", Slot: "3", Bay: "Slot 03"}, nil)
+	mockSES.On("GetSlot", "/dev/nvme1n1").Return(nil, fmt.Errorf("error"))
+
+	// Enclosure, Slot and Bay are filled during discovery
+	drives, err := manager.GetDrivesList()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(drives))
+	assert.Equal(t, "0x500056b36789abff", drives[0].Enclosure)
+	assert.Equal(t, "3", drives[0].Slot)
+	assert.Equal(t, "Slot 03", drives[0].Bay)
+	assert.Empty(t, drives[1].Slot)
+
+	mockSES.On("Locate", "/dev/nvme0n1", true).Return(nil).Once()
+	ledStatus, err := manager.Locate("nvmeSN", apiV1.LocateStart)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOn, ledStatus)
+
+	mockSES.On("LocateStatus", "/dev/nvme0n1").Return(apiV1.LocateStatusOn, nil).Once()
+	ledStatus, err = manager.Locate("nvmeSN", apiV1.LocateStatus)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOn, ledStatus)
+
+	mockSES.On("Locate", "/dev/nvme0n1", false).Return(fmt.Errorf("error")).Once()
+	_, err = manager.Locate("nvmeSN", apiV1.LocateStop)
+	assert.NotNil(t, err)
+
+	_, err = manager.Locate("nvmeSN", 10)
+	assert.NotNil(t, err)
+	_, err = manager.Locate("unknownSN", apiV1.LocateStart)
+	assert.NotNil(t, err)
+	// drives were rediscovered to find unknown serial number
+	mockNvme.AssertNumberOfCalls(t, "GetNVMDevices", 2)
+
+	mockIpmi.On("ChassisIdentify", true).Return(nil).Once()
+	assert.Nil(t, manager.LocateNode(apiV1.LocateStart))
+	mockIpmi.On("ChassisIdentify", false).Return(fmt.Errorf("error")).Once()
+	assert.NotNil(t, manager.LocateNode(apiV1.LocateStop))
+	assert.NotNil(t, manager.LocateNode(apiV1.LocateStatus))
+}
+
+func TestBaseManager_HandleUevents(t *testing.T) {
+	var (
+		manager      = New(&mocks.GoMockExecutor{}, logger)
+		mockLsscsi   = &linuxutils.MockWrapLsscsi{}
+		mockSmartctl = &linuxutils.MockWrapSmartctl{}
+		mockNvme     = &linuxutils.MockWrapNvmecli{}
+		mockSES      = &linuxutils.MockWrapSES{}
+		sda          = &lsscsi.SCSIDevice{Path: "/dev/sda", Vendor: "vendor", Model: "model"}
+		sdb          = &lsscsi.SCSIDevice{Path: "/dev/sdb", Vendor: "vendor", Model: "model"}
+	)
+	manager.lsscsi, manager.smartctl, manager.nvme, manager.ses = mockLsscsi, mockSmartctl, mockNvme, mockSES
+	manager.lsblk, manager.byIDDir = linuxutils.GetMockWrapLsblk(""), t.TempDir()
+	mockLsscsi.On("GetSCSIDevices").Return([]*lsscsi.SCSIDevice{sda}, nil)
+	mockLsscsi.On("GetSCSIDevice", "/dev/sdb").Return(sdb, nil)
+	mockNvme.On("GetNVMDevices").Return([]nvmecli.NVMDevice{}, nil)
+	mockNvme.On("GetNVMDevice", "/dev/nvme0n1").Return(&nvmecli.NVMDevice{
+		DevicePath: "/dev/nvme0n1", ModelNumber: "model", SerialNumber: "nvme0", Vendor: 2311,
+		Health: apiV1.HealthGood}, nil)
+	for path, sn := range map[string]string{"/dev/sda": "sda", "/dev/sdb": "sdb"} {
+		mockSmartctl.On("GetDriveInfoByPath", path).
+			Return(&smartctl.DeviceSMARTInfo{SerialNumber: sn, SmartStatus: map[string]bool{"passed": true}}, nil)
+		mockSmartctl.On("GetSMARTDataByPath", path).Return(&smartctl.DeviceSMARTData{}, nil)
+	}
+	mockSES.On("GetSlot", mock.Anything).Return(nil, nil)
+
+	notifications, cancel := manager.WatchDrives()
+	defer cancel()
+	handle := func(events ...*uevent.Event) {
+		ch := make(chan *uevent.Event, len(events))
+		for _, e := range events {
+			ch <- e
+		}
+		close(ch)
+		manager.HandleUevents(ch)
+	}
+	disk := func(action, devName string) *uevent.Event {
+		return &uevent.Event{Action: action, DevPath: "/block" + devName, DevName: devName,
+			Subsystem: uevent.SubsystemBlock, DevType: uevent.DevTypeDisk}
+	}
+
+	// drives are discovered without udev events
+	drives, err := manager.GetCachedDrivesList()
+	assert.Nil(t, err)
+	assert.Len(t, drives, 1)
+
+	// events of the partitions and other actions are ignored
+	partition := disk(uevent.ActionAdd, "/dev/sda1")
+	partition.DevType = "partition"
+	handle(partition, disk("bind", "/dev/sda"))
+	assert.Len(t, notifications, 0)
+
+	// only changed devices are rediscovered
+	handle(disk(uevent.ActionRemove, "/dev/sda"), disk(uevent.ActionAdd, "/dev/sdb"),
+		disk(uevent.ActionAdd, "/dev/nvme0n1"))
+	assert.Len(t, notifications, 1)
+	<-notifications
+	drives, err = manager.GetCachedDrivesList()
+	assert.Nil(t, err)
+	assert.Len(t, drives, 2)
+	assert.Equal(t, "sdb", drives[0].SerialNumber)
+	assert.Equal(t, "nvme0", drives[1].SerialNumber)
+	mockLsscsi.AssertNumberOfCalls(t, "GetSCSIDevices", 1)
+
+	// cached drives aren't changed by the callers
+	drives[0].NodeId = "node"
+	drives, _ = manager.GetCachedDrivesList()
+	assert.Empty(t, drives[0].NodeId)
+
+	// all drives are rediscovered if many disks are changed at once
+	events := make([]*uevent.Event, 0)
+	for _, dev := range []string{"/dev/sdc", "/dev/sdd", "/dev/sde", "/dev/sdf", "/dev/sdg"} {
+		events = append(events, disk(uevent.ActionAdd, dev))
+	}
+	handle(events...)
+	assert.Len(t, notifications, 1)
+	mockLsscsi.AssertNumberOfCalls(t, "GetSCSIDevices", 2)
+	drives, _ = manager.GetCachedDrivesList()
+	assert.Len(t, drives, 1)
+	assert.Equal(t, "sda", drives[0].SerialNumber)
+}
+
+func TestBaseManager_GetDrivesHealth(t *testing.T) {
+	var (
+		manager      = New(&mocks.GoMockExecutor{}, logger)
+		mockLsscsi   = &linuxutils.MockWrapLsscsi{}
+		mockSmartctl = &linuxutils.MockWrapSmartctl{}
+		mockNvme     = &linuxutils.MockWrapNvmecli{}
+		mockSES      = &linuxutils.MockWrapSES{}
+		smartInfo    = &smartctl.DeviceSMARTInfo{SerialNumber: "sda", SmartStatus: map[string]bool{"passed": true}}
+		nvmeDevice   = nvmecli.NVMDevice{DevicePath: "/dev/nvme0n1", ModelNumber: "model", SerialNumber: "nvme0",
+			Vendor: 2311, Health: apiV1.HealthGood}
+	)
+	manager.lsscsi, manager.smartctl, manager.nvme, manager.ses = mockLsscsi, mockSmartctl, mockNvme, mockSES
+	manager.lsblk, manager.byIDDir = linuxutils.GetMockWrapLsblk(""), t.TempDir()
+	mockLsscsi.On("GetSCSIDevices").
+		Return([]*lsscsi.SCSIDevice{{Path: "/dev/sda", Vendor: "vendor", Model: "model"}}, nil)
+	mockNvme.On("GetNVMDevices").Return([]nvmecli.NVMDevice{nvmeDevice}, nil)
+	mockSmartctl.On("GetDriveInfoByPath", "/dev/sda").Return(smartInfo, nil)
+	mockSmartctl.On("GetSMARTDataByPath", "/dev/sda").Return(&smartctl.DeviceSMARTData{}, nil)
+	mockSES.On("GetSlot", mock.Anything).Return(nil, nil)
+
+	drives, err := manager.GetDrivesList()
+	assert.Nil(t, err)
+	assert.Len(t, drives, 2)
+
+	// health is refreshed from SMART without listing of the devices
+	smartInfo.SmartStatus["passed"] = false
+	badNVMe := nvmeDevice
+	badNVMe.Health = apiV1.HealthBad
+	mockNvme.On("GetNVMDevice", "/dev/nvme0n1").Return(&badNVMe, nil).Once()
+	drives, err = manager.GetDrivesHealth()
+	assert.Nil(t, err)
+	assert.Len(t, drives, 2)
+	for _, drive := range drives {
+		assert.Equal(t, apiV1.HealthBad, drive.Health)
+	}
+	assert.Equal(t, smartStatusFailedReason, drives[0].HealthReason)
+	mockLsscsi.AssertNumberOfCalls(t, "GetSCSIDevices", 1)
+	mockNvme.AssertNumberOfCalls(t, "GetNVMDevices", 1)
+
+	// cache is updated
+	drives, _ = manager.GetCachedDrivesList()
+	assert.Equal(t, apiV1.HealthBad, drives[0].Health)
+	assert.Equal(t, apiV1.HealthBad, drives[1].Health)
+
+	// device is replaced by another drive or isn't available, health isn't changed
+	smartInfo.SmartStatus["passed"] = true
+	smartInfo.SerialNumber = "sdx"
+	mockNvme.On("GetNVMDevice", "/dev/nvme0n1").Return(nil, fmt.Errorf("error")).Once()
+	drives, err = manager.GetDrivesHealth()
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.HealthBad, drives[0].Health)
+	assert.Equal(t, apiV1.HealthBad, drives[1].Health)
+}
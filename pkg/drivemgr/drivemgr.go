@@ -29,3 +29,25 @@ type DriveManager interface {
 	// LocateNode manipulates of node's led state, which should be synced with drive's led
 	LocateNode(action int32) error
 }
+
+// DriveWatcher is implemented by DriveManager which is able to detect changes of the drives without polling,
+// e.g. from udev events
+type DriveWatcher interface {
+	// WatchDrives returns a channel which receives a notification each time the drives might be changed
+	// and a function which cancels the subscription
+	WatchDrives() (<-chan struct{}, func())
+}
+
+// DriveCache is implemented by DriveWatcher which keeps drives up to date between discoveries,
+// e.g. rediscovers only devices which are changed
+type DriveCache interface {
+	// GetCachedDrivesList returns drives discovered last time with changes which watchers are notified about
+	GetCachedDrivesList() ([]*api.Drive, error)
+}
+
+// DriveHealthChecker is implemented by DriveCache which is able to refresh health of the cached drives
+// from SMART without discovery of the devices, which is cheaper than GetDrivesList
+type DriveHealthChecker interface {
+	// GetDrivesHealth returns cached drives with health, health reason and SMART telemetry refreshed
+	GetDrivesHealth() ([]*api.Drive, error)
+}
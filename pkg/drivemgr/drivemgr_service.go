@@ -18,6 +18,7 @@ package drivemgr
 
 import (
 	"context"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
@@ -32,38 +33,164 @@ import (
 type DriveServiceServerImpl struct {
 	mgr DriveManager
 	log *logrus.Entry
+	// interval of drives rediscovery in WatchDrives, catches changes which DriveWatcher doesn't notify about
+	resyncInterval time.Duration
+	// interval of drives health refreshing in WatchDrives, used if DriveManager implements DriveHealthChecker
+	healthCheckInterval time.Duration
 }
 
+const (
+	// DefaultResyncInterval is the default interval of drives rediscovery in WatchDrives
+	DefaultResyncInterval = 10 * time.Minute
+	// DefaultHealthCheckInterval is the default interval of drives health refreshing in WatchDrives
+	DefaultHealthCheckInterval = time.Minute
+)
+
 // NewDriveServer is the constructor for DriveServiceServerImpl struct
 // Receives logrus logger and implementation of DriveManager as parameters
 // Returns an instance of DriveServiceServerImpl
 func NewDriveServer(logger *logrus.Logger, manager DriveManager) DriveServiceServerImpl {
 	driveService := DriveServiceServerImpl{
-		log: logger.WithField("component", "DriveServiceServerImpl"),
-		mgr: manager,
+		log:                 logger.WithField("component", "DriveServiceServerImpl"),
+		mgr:                 manager,
+		resyncInterval:      DefaultResyncInterval,
+		healthCheckInterval: DefaultHealthCheckInterval,
 	}
 	return driveService
 }
 
+// SetResyncInterval sets interval of drives rediscovery in WatchDrives, non-positive interval is ignored
+func (svc *DriveServiceServerImpl) SetResyncInterval(interval time.Duration) {
+	if interval > 0 {
+		svc.resyncInterval = interval
+	}
+}
+
+// SetHealthCheckInterval sets interval of drives health refreshing in WatchDrives, non-positive interval is ignored
+func (svc *DriveServiceServerImpl) SetHealthCheckInterval(interval time.Duration) {
+	if interval > 0 {
+		svc.healthCheckInterval = interval
+	}
+}
+
 // GetDrivesList invokes DriveManager's GetDrivesList() and sends the response over gRPC
 // Receives go context and DrivesRequest which contains node id
 // Returns DrivesResponse with slice of api.Drives structs
 func (svc *DriveServiceServerImpl) GetDrivesList(ctx context.Context, req *api.DrivesRequest) (*api.DrivesResponse, error) {
-	drives, err := svc.mgr.GetDrivesList()
+	drives, err := svc.getDrives(req.NodeId, svc.mgr.GetDrivesList)
 	if err != nil {
 		svc.log.Errorf("DriveManager failed with error: %s", err.Error())
 		return nil, status.Error(codes.Internal, err.Error())
 	}
-	// All drives are ONLINE by default
+	return &api.DrivesResponse{
+		Disks: drives,
+	}, nil
+}
+
+// WatchDrives sends ADDED, REMOVED and CHANGED events of the drives over gRPC stream until the client cancels it
+// Drives are rediscovered when DriveWatcher notifies about changes and each resyncInterval,
+// cached drives are used on notifications if DriveManager implements DriveCache.
+// Health of the drives is refreshed each healthCheckInterval if DriveManager implements DriveHealthChecker.
+// Events are computed relative to the drives discovered when the stream is opened
+// Returns Unimplemented error if DriveManager doesn't implement DriveWatcher
+func (svc *DriveServiceServerImpl) WatchDrives(req *api.DrivesRequest, stream api.DriveService_WatchDrivesServer) error {
+	ll := svc.log.WithField("method", "WatchDrives")
+	watcher, ok := svc.mgr.(DriveWatcher)
+	if !ok {
+		return status.Error(codes.Unimplemented, "drive manager doesn't support watching of the drives")
+	}
+	notifications, cancel := watcher.WatchDrives()
+	defer cancel()
+
+	previous, err := svc.getDrives(req.NodeId, svc.mgr.GetDrivesList)
+	if err != nil {
+		ll.Errorf("DriveManager failed with error: %v", err)
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	cachedList := svc.mgr.GetDrivesList
+	if cache, ok := svc.mgr.(DriveCache); ok {
+		cachedList = cache.GetCachedDrivesList
+	}
+	var healthCheck <-chan time.Time
+	healthChecker, ok := svc.mgr.(DriveHealthChecker)
+	if ok {
+		healthTicker := time.NewTicker(svc.healthCheckInterval)
+		defer healthTicker.Stop()
+		healthCheck = healthTicker.C
+	}
+	ticker := time.NewTicker(svc.resyncInterval)
+	defer ticker.Stop()
+	for {
+		var list func() ([]*api.Drive, error)
+		select {
+		case <-stream.Context().Done():
+			ll.Debugf("Stream is closed: %v", stream.Context().Err())
+			return nil
+		case <-notifications:
+			list = cachedList
+		case <-healthCheck:
+			list = healthChecker.GetDrivesHealth
+		case <-ticker.C:
+			list = svc.mgr.GetDrivesList
+		}
+		current, err := svc.getDrives(req.NodeId, list)
+		if err != nil {
+			// keep the stream, drives will be rediscovered on the next notification
+			ll.Errorf("DriveManager failed with error: %v", err)
+			continue
+		}
+		for _, event := range diffDrives(previous, current) {
+			ll.Infof("Drive %s is %s", event.Drive.SerialNumber, event.Type)
+			if err = stream.Send(event); err != nil {
+				return err
+			}
+		}
+		previous = current
+	}
+}
+
+// getDrives returns drives of DriveManager received with list function, all drives are ONLINE by default
+func (svc *DriveServiceServerImpl) getDrives(nodeID string, list func() ([]*api.Drive, error)) ([]*api.Drive, error) {
+	drives, err := list()
+	if err != nil {
+		return nil, err
+	}
 	for _, drive := range drives {
-		drive.NodeId = req.NodeId
+		drive.NodeId = nodeID
 		if drive.Status == "" {
 			drive.Status = apiV1.DriveStatusOnline
 		}
 	}
-	return &api.DrivesResponse{
-		Disks: drives,
-	}, nil
+	return drives, nil
+}
+
+// diffDrives returns events which transform previous drives to current ones,
+// drive is CHANGED if its health, status or path is changed
+func diffDrives(previous, current []*api.Drive) []*api.DriveEvent {
+	previousDrives := make(map[string]*api.Drive, len(previous))
+	for _, drive := range previous {
+		previousDrives[drive.SerialNumber] = drive
+	}
+	events := make([]*api.DriveEvent, 0)
+	for _, drive := range current {
+		prev, ok := previousDrives[drive.SerialNumber]
+		delete(previousDrives, drive.SerialNumber)
+		switch {
+		case !ok:
+			events = append(events, &api.DriveEvent{Type: apiV1.DriveEventAdded, Drive: drive})
+		case prev.Health != drive.Health || prev.HealthReason != drive.HealthReason ||
+			prev.Status != drive.Status || prev.Path != drive.Path:
+			events = append(events, &api.DriveEvent{Type: apiV1.DriveEventChanged, Drive: drive})
+		}
+	}
+	// keep order of the previous drives
+	for _, drive := range previous {
+		if _, ok := previousDrives[drive.SerialNumber]; ok {
+			events = append(events, &api.DriveEvent{Type: apiV1.DriveEventRemoved, Drive: drive})
+		}
+	}
+	return events
 }
 
 // Locate invokes DriveManager's Locate method for manipulation drive's LED state
@@ -0,0 +1,263 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drivemgr
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+var testLogger = logrus.New()
+
+// watchedManager is DriveManager which implements DriveWatcher
+type watchedManager struct {
+	drives   []*api.Drive
+	notifier *Notifier
+	// count of GetDrivesList calls
+	calls int
+	mu    sync.Mutex
+}
+
+func (m *watchedManager) GetDrivesList() ([]*api.Drive, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls++
+	drives := make([]*api.Drive, 0, len(m.drives))
+	for _, d := range m.drives {
+		drive := *d
+		drives = append(drives, &drive)
+	}
+	return drives, nil
+}
+
+func (m *watchedManager) Locate(serialNumber string, action int32) (int32, error) {
+	return apiV1.LocateStatusOff, nil
+}
+
+func (m *watchedManager) LocateNode(action int32) error {
+	return nil
+}
+
+func (m *watchedManager) WatchDrives() (<-chan struct{}, func()) {
+	return m.notifier.Subscribe()
+}
+
+func (m *watchedManager) setDrives(drives ...*api.Drive) {
+	m.mu.Lock()
+	m.drives = drives
+	m.mu.Unlock()
+	m.notifier.Notify()
+}
+
+// cachedManager is watchedManager which implements DriveCache
+type cachedManager struct {
+	*watchedManager
+	cached []*api.Drive
+}
+
+func (m *cachedManager) GetCachedDrivesList() ([]*api.Drive, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cached, nil
+}
+
+// healthCheckedManager is cachedManager which implements DriveHealthChecker
+type healthCheckedManager struct {
+	*cachedManager
+}
+
+func (m *healthCheckedManager) GetDrivesHealth() ([]*api.Drive, error) {
+	return m.GetCachedDrivesList()
+}
+
+// watchDrivesStream is DriveService_WatchDrivesServer which sends events to channel
+type watchDrivesStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *api.DriveEvent
+}
+
+func (s *watchDrivesStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchDrivesStream) Send(event *api.DriveEvent) error {
+	s.events <- event
+	return nil
+}
+
+// waitForSubscription waits till WatchDrives subscribes to notifications and discovers initial drives
+func waitForSubscription(t *testing.T, mgr *watchedManager) {
+	assert.Eventually(t, func() bool {
+		mgr.mu.Lock()
+		defer mgr.mu.Unlock()
+		return mgr.calls > 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestDriveServiceServerImpl_WatchDrives(t *testing.T) {
+	hdd1 := &api.Drive{SerialNumber: "hdd1", Health: apiV1.HealthGood, Path: "/dev/sda"}
+	hdd2 := &api.Drive{SerialNumber: "hdd2", Health: apiV1.HealthGood, Path: "/dev/sdb"}
+	mgr := &watchedManager{drives: []*api.Drive{hdd1}, notifier: NewNotifier()}
+	svc := NewDriveServer(testLogger, mgr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &watchDrivesStream{ctx: ctx, events: make(chan *api.DriveEvent)}
+	done := make(chan error)
+	go func() {
+		done <- svc.WatchDrives(&api.DrivesRequest{NodeId: "node"}, stream)
+	}()
+
+	waitForSubscription(t, mgr)
+	mgr.setDrives(hdd1, hdd2)
+	event := <-stream.events
+	assert.Equal(t, apiV1.DriveEventAdded, event.Type)
+	assert.Equal(t, "hdd2", event.Drive.SerialNumber)
+	assert.Equal(t, "node", event.Drive.NodeId)
+	assert.Equal(t, apiV1.DriveStatusOnline, event.Drive.Status)
+
+	mgr.setDrives(hdd2, &api.Drive{SerialNumber: "hdd1", Health: apiV1.HealthBad, Path: "/dev/sda"})
+	event = <-stream.events
+	assert.Equal(t, apiV1.DriveEventChanged, event.Type)
+	assert.Equal(t, apiV1.HealthBad, event.Drive.Health)
+
+	mgr.setDrives(hdd2)
+	event = <-stream.events
+	assert.Equal(t, apiV1.DriveEventRemoved, event.Type)
+	assert.Equal(t, "hdd1", event.Drive.SerialNumber)
+
+	cancel()
+	assert.Nil(t, <-done)
+	assert.Empty(t, mgr.notifier.subscribers)
+}
+
+func TestDriveServiceServerImpl_WatchDrivesUnimplemented(t *testing.T) {
+	svc := NewDriveServer(testLogger, struct{ DriveManager }{})
+	err := svc.WatchDrives(&api.DrivesRequest{}, &watchDrivesStream{ctx: context.Background()})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestDriveServiceServerImpl_WatchDrivesResync(t *testing.T) {
+	mgr := &watchedManager{notifier: NewNotifier()}
+	svc := NewDriveServer(testLogger, mgr)
+	svc.SetResyncInterval(10 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &watchDrivesStream{ctx: ctx, events: make(chan *api.DriveEvent)}
+	go func() {
+		_ = svc.WatchDrives(&api.DrivesRequest{}, stream)
+	}()
+
+	// drives are changed without notification
+	waitForSubscription(t, mgr)
+	mgr.mu.Lock()
+	mgr.drives = []*api.Drive{{SerialNumber: "hdd1"}}
+	mgr.mu.Unlock()
+	event := <-stream.events
+	assert.Equal(t, apiV1.DriveEventAdded, event.Type)
+}
+
+func TestDriveServiceServerImpl_WatchDrivesCached(t *testing.T) {
+	mgr := &cachedManager{watchedManager: &watchedManager{notifier: NewNotifier()}}
+	svc := NewDriveServer(testLogger, mgr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &watchDrivesStream{ctx: ctx, events: make(chan *api.DriveEvent)}
+	go func() {
+		_ = svc.WatchDrives(&api.DrivesRequest{}, stream)
+	}()
+
+	// notified changes are taken from the cache, drives aren't discovered
+	waitForSubscription(t, mgr.watchedManager)
+	mgr.mu.Lock()
+	mgr.cached = []*api.Drive{{SerialNumber: "hdd1"}}
+	mgr.mu.Unlock()
+	mgr.notifier.Notify()
+	event := <-stream.events
+	assert.Equal(t, apiV1.DriveEventAdded, event.Type)
+	assert.Equal(t, "hdd1", event.Drive.SerialNumber)
+	mgr.mu.Lock()
+	assert.Equal(t, 1, mgr.calls)
+	mgr.mu.Unlock()
+}
+
+func TestNotifier(t *testing.T) {
+	n := NewNotifier()
+	ch1, cancel1 := n.Subscribe()
+	ch2, cancel2 := n.Subscribe()
+	defer cancel2()
+
+	// notifications are merged and don't block
+	n.Notify()
+	n.Notify()
+	assert.Len(t, ch1, 1)
+	assert.Len(t, ch2, 1)
+	<-ch1
+	<-ch2
+
+	cancel1()
+	n.Notify()
+	assert.Len(t, ch1, 0)
+	assert.Len(t, ch2, 1)
+}
+
+func TestDriveServiceServerImpl_WatchDrivesHealthCheck(t *testing.T) {
+	mgr := &healthCheckedManager{
+		cachedManager: &cachedManager{
+			watchedManager: &watchedManager{notifier: NewNotifier(),
+				drives: []*api.Drive{{SerialNumber: "hdd1", Health: apiV1.HealthGood}}},
+			cached: []*api.Drive{{SerialNumber: "hdd1", Health: apiV1.HealthGood}},
+		},
+	}
+	svc := NewDriveServer(testLogger, mgr)
+	svc.SetHealthCheckInterval(10 * time.Millisecond)
+	// ignored
+	svc.SetResyncInterval(0)
+	assert.Equal(t, DefaultResyncInterval, svc.resyncInterval)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &watchDrivesStream{ctx: ctx, events: make(chan *api.DriveEvent)}
+	go func() {
+		_ = svc.WatchDrives(&api.DrivesRequest{}, stream)
+	}()
+
+	// health is changed without notification and is refreshed without discovery
+	waitForSubscription(t, mgr.watchedManager)
+	mgr.mu.Lock()
+	mgr.cached = []*api.Drive{{SerialNumber: "hdd1", Health: apiV1.HealthSuspect}}
+	mgr.mu.Unlock()
+	event := <-stream.events
+	assert.Equal(t, apiV1.DriveEventChanged, event.Type)
+	assert.Equal(t, apiV1.HealthSuspect, event.Drive.Health)
+	mgr.mu.Lock()
+	assert.Equal(t, 1, mgr.calls)
+	mgr.mu.Unlock()
+}
@@ -0,0 +1,213 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package healthpolicy contains policy which predicts drive failure from SMART counters
+// before overall SMART status of the drive becomes failed
+package healthpolicy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/nvmecli"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/smartctl"
+)
+
+// knownCounters contains counters of ATA/SCSI and NVMe drives which could be used in rules
+var knownCounters = append(append([]string{}, smartctl.Counters...), nvmecli.Counters...)
+
+// Policy contains rules which violation marks drive as SUSPECT
+type Policy struct {
+	// mark drive when normalized value of any ATA attribute reaches its threshold
+	FailingAttributes bool   `yaml:"failing_attributes"`
+	Rules             []Rule `yaml:"rules"`
+}
+
+// Rule checks value and trend of the single SMART counter, not set check is skipped
+type Rule struct {
+	// one of smartctl.Counters or nvmecli.Counters
+	Counter string `yaml:"counter"`
+	// rule is violated when counter value is greater than threshold
+	Threshold *int64 `yaml:"threshold"`
+	// rule is violated when counter increases more than MaxIncrease during Window
+	MaxIncrease *int64        `yaml:"max_increase"`
+	Window      time.Duration `yaml:"window"`
+}
+
+// LoadPolicy reads policy from YAML file, nil policy is returned if path is empty, so failure prediction is disabled
+func LoadPolicy(path string) (*Policy, error) {
+	if path == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	policy := &Policy{}
+	if err = yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("unable to parse health policy %s: %v", path, err)
+	}
+	if err = policy.Validate(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// Validate checks that rules use known counters and have correct values
+func (p *Policy) Validate() error {
+	for i, r := range p.Rules {
+		known := false
+		for _, c := range knownCounters {
+			if c == r.Counter {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("rule %d: unknown counter %q, supported: %v", i, r.Counter, knownCounters)
+		}
+		if r.Threshold == nil && r.MaxIncrease == nil {
+			return fmt.Errorf("rule %d: threshold or max_increase must be set", i)
+		}
+		if r.Threshold != nil && *r.Threshold < 0 {
+			return fmt.Errorf("rule %d: threshold must not be negative", i)
+		}
+		if r.MaxIncrease != nil && (*r.MaxIncrease < 0 || r.Window <= 0) {
+			return fmt.Errorf("rule %d: max_increase must not be negative and window must be positive", i)
+		}
+	}
+	return nil
+}
+
+type sample struct {
+	value int64
+	time  time.Time
+}
+
+// Engine evaluates Policy for drives, keeps history of counters to detect trend violations.
+// History isn't persisted, so trends are calculated from the drive manager start
+type Engine struct {
+	policy *Policy
+	// serial number -> counter -> changes of the counter sorted by time
+	history   map[string]map[string][]sample
+	maxWindow time.Duration
+	now       func() time.Time
+	mu        sync.Mutex
+}
+
+// NewEngine is a constructor for Engine
+func NewEngine(policy *Policy) *Engine {
+	e := &Engine{
+		policy:  policy,
+		history: map[string]map[string][]sample{},
+		now:     time.Now,
+	}
+	for _, r := range policy.Rules {
+		if r.Window > e.maxWindow {
+			e.maxWindow = r.Window
+		}
+	}
+	return e
+}
+
+// Evaluate checks SMART data of the drive with provided serial number against the policy
+// Returns sorted descriptions of violated rules, empty slice means drive is healthy
+func (e *Engine) Evaluate(serialNumber string, data *smartctl.DeviceSMARTData) []string {
+	var violations []string
+	if e.policy.FailingAttributes {
+		for _, attr := range data.FailingAttributes() {
+			violations = append(violations, fmt.Sprintf("attribute %s reached failure threshold", attr))
+		}
+	}
+	return e.evaluate(serialNumber, data.Counters(), violations)
+}
+
+// EvaluateNVMe checks SMART log of the NVMe drive with provided serial number against the policy
+// Returns sorted descriptions of violated rules, empty slice means drive is healthy
+func (e *Engine) EvaluateNVMe(serialNumber string, log *nvmecli.SMARTLog) []string {
+	return e.evaluate(serialNumber, log.Counters(), nil)
+}
+
+// evaluate checks counters against the rules and appends violations to the provided ones
+func (e *Engine) evaluate(serialNumber string, counters map[string]int64, violations []string) []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.now()
+	history := e.updateHistory(serialNumber, counters, now)
+	for _, r := range e.policy.Rules {
+		value, ok := counters[r.Counter]
+		if !ok {
+			continue
+		}
+		if r.Threshold != nil && value > *r.Threshold {
+			violations = append(violations, fmt.Sprintf("%s is %d, threshold %d", r.Counter, value, *r.Threshold))
+		}
+		if r.MaxIncrease != nil {
+			if increase := value - baseline(history[r.Counter], now.Add(-r.Window)); increase > *r.MaxIncrease {
+				violations = append(violations, fmt.Sprintf("%s increased by %d in %s, max increase %d",
+					r.Counter, increase, r.Window, *r.MaxIncrease))
+			}
+		}
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+// updateHistory saves changed counters to the history of the drive,
+// the last sample older than the largest window is kept as a baseline for trends
+func (e *Engine) updateHistory(serialNumber string, counters map[string]int64, now time.Time) map[string][]sample {
+	history, ok := e.history[serialNumber]
+	if !ok {
+		history = map[string][]sample{}
+		e.history[serialNumber] = history
+	}
+	for counter, value := range counters {
+		samples := history[counter]
+		if len(samples) == 0 || samples[len(samples)-1].value != value {
+			samples = append(samples, sample{value: value, time: now})
+		}
+		first := 0
+		for first < len(samples)-1 && now.Sub(samples[first+1].time) >= e.maxWindow {
+			first++
+		}
+		history[counter] = samples[first:]
+	}
+	return history
+}
+
+// baseline returns counter value at the provided time, the oldest known value is returned if history is shorter
+func baseline(samples []sample, at time.Time) int64 {
+	value := samples[0].value
+	for _, s := range samples[1:] {
+		if s.time.After(at) {
+			break
+		}
+		value = s.value
+	}
+	return value
+}
+
+// Explain joins violations into the single message
+func Explain(violations []string) string {
+	return strings.Join(violations, "; ")
+}
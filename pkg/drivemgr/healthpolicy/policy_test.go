@@ -0,0 +1,156 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthpolicy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/nvmecli"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/smartctl"
+)
+
+const testSN = "hdd1"
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func testPolicy() *Policy {
+	day := 24 * time.Hour
+	return &Policy{
+		FailingAttributes: true,
+		Rules: []Rule{
+			{Counter: smartctl.ReallocatedSectors, Threshold: int64Ptr(50), MaxIncrease: int64Ptr(10), Window: day},
+			{Counter: smartctl.PendingSectors, Threshold: int64Ptr(0)},
+			{Counter: nvmecli.PercentageUsed, Threshold: int64Ptr(90)},
+			{Counter: nvmecli.MediaErrors, Threshold: int64Ptr(0)},
+		},
+	}
+}
+
+func ataData(reallocated, pending int64) *smartctl.DeviceSMARTData {
+	data := &smartctl.DeviceSMARTData{}
+	data.ATAAttributes.Table = make([]smartctl.ATAAttribute, 2)
+	data.ATAAttributes.Table[0].ID, data.ATAAttributes.Table[0].Name = 5, "Reallocated_Sector_Ct"
+	data.ATAAttributes.Table[0].Raw.Value = reallocated
+	data.ATAAttributes.Table[1].ID, data.ATAAttributes.Table[1].Name = 197, "Current_Pending_Sector"
+	data.ATAAttributes.Table[1].Raw.Value = pending
+	return data
+}
+
+func TestEngine_Evaluate(t *testing.T) {
+	t.Run("healthy", func(t *testing.T) {
+		e := NewEngine(testPolicy())
+		assert.Empty(t, e.Evaluate(testSN, ataData(1, 0)))
+		assert.Empty(t, e.Evaluate(testSN, &smartctl.DeviceSMARTData{}))
+	})
+	t.Run("threshold", func(t *testing.T) {
+		e := NewEngine(testPolicy())
+		assert.Equal(t, []string{
+			"pending_sectors is 2, threshold 0",
+			"reallocated_sectors is 51, threshold 50",
+		}, e.Evaluate(testSN, ataData(51, 2)))
+	})
+	t.Run("failing attribute", func(t *testing.T) {
+		e := NewEngine(testPolicy())
+		data := ataData(0, 0)
+		data.ATAAttributes.Table[0].Value, data.ATAAttributes.Table[0].Thresh = 5, 10
+		assert.Equal(t, []string{"attribute Reallocated_Sector_Ct reached failure threshold"}, e.Evaluate(testSN, data))
+
+		e = NewEngine(&Policy{})
+		assert.Empty(t, e.Evaluate(testSN, data))
+	})
+	t.Run("trend", func(t *testing.T) {
+		var (
+			e   = NewEngine(testPolicy())
+			now = time.Now()
+		)
+		e.now = func() time.Time { return now }
+
+		assert.Empty(t, e.Evaluate(testSN, ataData(5, 0)))
+		now = now.Add(12 * time.Hour)
+		assert.Empty(t, e.Evaluate(testSN, ataData(10, 0)))
+		// another drive doesn't affect history
+		assert.Empty(t, e.Evaluate("hdd2", ataData(30, 0)))
+		now = now.Add(6 * time.Hour)
+		assert.Equal(t, []string{"reallocated_sectors increased by 11 in 24h0m0s, max increase 10"},
+			e.Evaluate(testSN, ataData(16, 0)))
+		// increase is calculated from value which counter had 24 hours ago
+		now = now.Add(7 * time.Hour)
+		assert.NotEmpty(t, e.Evaluate(testSN, ataData(16, 0)))
+		now = now.Add(12 * time.Hour)
+		assert.Empty(t, e.Evaluate(testSN, ataData(16, 0)))
+		now = now.Add(48 * time.Hour)
+		assert.Empty(t, e.Evaluate(testSN, ataData(16, 0)))
+		assert.Len(t, e.history[testSN][smartctl.ReallocatedSectors], 1)
+	})
+}
+
+func TestEngine_EvaluateNVMe(t *testing.T) {
+	e := NewEngine(testPolicy())
+	assert.Empty(t, e.EvaluateNVMe(testSN, &nvmecli.SMARTLog{PercentageUsed: 90, UnsafeShutdowns: 10}))
+	assert.Equal(t, []string{
+		"media_errors is 1, threshold 0",
+		"percentage_used is 91, threshold 90",
+	}, e.EvaluateNVMe(testSN, &nvmecli.SMARTLog{PercentageUsed: 91, MediaErrors: 1}))
+
+	// temperature is converted to Celsius
+	e = NewEngine(&Policy{Rules: []Rule{{Counter: nvmecli.Temperature, Threshold: int64Ptr(70)}}})
+	assert.Empty(t, e.EvaluateNVMe(testSN, &nvmecli.SMARTLog{Temperature: 343}))
+	assert.Equal(t, []string{"temperature is 71, threshold 70"},
+		e.EvaluateNVMe(testSN, &nvmecli.SMARTLog{Temperature: 344}))
+}
+
+func TestLoadPolicy(t *testing.T) {
+	// policy is disabled if file isn't provided
+	policy, err := LoadPolicy("")
+	assert.Nil(t, err)
+	assert.Nil(t, policy)
+
+	_, err = LoadPolicy("/not/existing/policy.yaml")
+	assert.NotNil(t, err)
+
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte(`
+failing_attributes: false
+rules:
+  - counter: crc_errors
+    max_increase: 100
+    window: 1h
+`), 0644))
+	policy, err = LoadPolicy(path)
+	assert.Nil(t, err)
+	assert.False(t, policy.FailingAttributes)
+	assert.Equal(t, []Rule{{Counter: smartctl.CRCErrors, MaxIncrease: int64Ptr(100), Window: time.Hour}}, policy.Rules)
+
+	for _, invalid := range []string{
+		"rules:\n  - counter: unknown\n    threshold: 1\n",
+		"rules:\n  - counter: crc_errors\n",
+		"rules:\n  - counter: crc_errors\n    threshold: -1\n",
+		"rules:\n  - counter: crc_errors\n    max_increase: 1\n",
+		"unknown_field: true\n",
+	} {
+		assert.Nil(t, os.WriteFile(path, []byte(invalid), 0644))
+		_, err = LoadPolicy(path)
+		assert.NotNil(t, err, invalid)
+	}
+}
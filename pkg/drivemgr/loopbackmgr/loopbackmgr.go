@@ -36,6 +36,7 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/drivemgr"
 )
 
 const (
@@ -76,6 +77,8 @@ type LoopBackManager struct {
 	nodeName string
 	devices  []*LoopBackDevice
 	config   *Config
+	// notifies drive watchers when devices are updated from config
+	notifier *drivemgr.Notifier
 	sync.Mutex
 }
 
@@ -123,6 +126,7 @@ func NewLoopBackManager(exec command.CmdExecutor, nodeID, nodeName string, logge
 		nodeID:   nodeID,
 		nodeName: nodeName,
 		devices:  make([]*LoopBackDevice, 0),
+		notifier: drivemgr.NewNotifier(),
 	}
 
 	mgr.attemptToRecoverDevices(imagesFolder)
@@ -577,7 +581,12 @@ func (mgr *LoopBackManager) CleanupLoopDevices() {
 	}
 }
 
-// UpdateOnConfigChange triggers update configuration and init of devices.
+// WatchDrives implements drivemgr.DriveWatcher, notifications are sent when devices are updated from config
+func (mgr *LoopBackManager) WatchDrives() (<-chan struct{}, func()) {
+	return mgr.notifier.Subscribe()
+}
+
+// UpdateOnConfigChange triggers update configuration and init of devices, drive watchers are notified after that
 func (mgr *LoopBackManager) UpdateOnConfigChange(watcher *fsnotify.Watcher) {
 	ll := mgr.log.WithField("method", "UpdateOnConfigChange")
 	err := watcher.Add(configPath)
@@ -613,5 +622,6 @@ func (mgr *LoopBackManager) UpdateOnConfigChange(watcher *fsnotify.Watcher) {
 		ll.Debugf("triggering devices update on %s event", event.Op)
 		mgr.updateDevicesFromConfig()
 		mgr.Init()
+		mgr.notifier.Notify()
 	}
 }
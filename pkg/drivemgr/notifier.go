@@ -0,0 +1,56 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drivemgr
+
+import "sync"
+
+// Notifier broadcasts notifications about changes of the drives to the subscribers of DriveWatcher
+type Notifier struct {
+	subscribers map[chan struct{}]struct{}
+	mu          sync.Mutex
+}
+
+// NewNotifier is the constructor for Notifier
+func NewNotifier() *Notifier {
+	return &Notifier{subscribers: make(map[chan struct{}]struct{})}
+}
+
+// Subscribe returns a channel which receives notifications and a function which cancels the subscription,
+// notifications which come while the previous one isn't received are merged into one
+func (n *Notifier) Subscribe() (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+	n.mu.Lock()
+	n.subscribers[ch] = struct{}{}
+	n.mu.Unlock()
+	return ch, func() {
+		n.mu.Lock()
+		delete(n.subscribers, ch)
+		n.mu.Unlock()
+	}
+}
+
+// Notify sends notification to all subscribers, it never blocks
+func (n *Notifier) Notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for ch := range n.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
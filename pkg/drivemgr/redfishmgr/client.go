@@ -0,0 +1,193 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redfishmgr
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	sessionsURL     = "/redfish/v1/SessionService/Sessions"
+	authTokenHeader = "X-Auth-Token"
+)
+
+// client performs requests to Redfish service using session authentication,
+// session is created on the first request and recreated when it expires
+type client struct {
+	http     *http.Client
+	endpoint string
+	user     string
+	password string
+
+	// token and URL of the current session
+	token   string
+	session string
+	mu      sync.Mutex
+}
+
+func newClient(conf Config) (*client, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if conf.CAFile != "" {
+		ca, err := ioutil.ReadFile(conf.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("CA file %s doesn't contain PEM certificates", conf.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return &client{
+		http: &http.Client{
+			Timeout:   conf.Timeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+		endpoint: strings.TrimSuffix(conf.Endpoint, "/"),
+		user:     conf.User,
+		password: conf.Password,
+	}, nil
+}
+
+// get reads resource with provided path, e.g. /redfish/v1/Systems, to out
+func (c *client) get(path string, out interface{}) error {
+	return c.do(http.MethodGet, path, nil, out)
+}
+
+// patch updates properties of the resource with provided path
+func (c *client) patch(path string, properties interface{}) error {
+	return c.do(http.MethodPatch, path, properties, nil)
+}
+
+// do performs authenticated request, request is repeated once with the new session if the current one expired
+func (c *client) do(method, path string, body, out interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token == "" {
+		if err := c.login(); err != nil {
+			return err
+		}
+	}
+	response, err := c.request(method, path, body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode == http.StatusUnauthorized {
+		closeBody(response)
+		if err = c.login(); err != nil {
+			return err
+		}
+		if response, err = c.request(method, path, body); err != nil {
+			return err
+		}
+	}
+	defer closeBody(response)
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s %s failed with status %s", method, path, response.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	if err = json.NewDecoder(response.Body).Decode(out); err != nil {
+		return fmt.Errorf("unable to decode %s: %v", path, err)
+	}
+	return nil
+}
+
+// login creates new session, must be called under lock
+func (c *client) login() error {
+	c.token = ""
+	response, err := c.request(http.MethodPost, sessionsURL,
+		map[string]string{"UserName": c.user, "Password": c.password})
+	if err != nil {
+		return err
+	}
+	defer closeBody(response)
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unable to create Redfish session: %s", response.Status)
+	}
+	token := response.Header.Get(authTokenHeader)
+	if token == "" {
+		return fmt.Errorf("redfish service didn't return session token")
+	}
+	c.token = token
+	c.session = response.Header.Get("Location")
+	return nil
+}
+
+// logout deletes the current session, sessions count is limited on most of BMCs
+func (c *client) logout() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token == "" || c.session == "" {
+		return nil
+	}
+	response, err := c.request(http.MethodDelete, c.session, nil)
+	c.token, c.session = "", ""
+	if err != nil {
+		return err
+	}
+	closeBody(response)
+	return nil
+}
+
+func (c *client) request(method, path string, body interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	// session location could be absolute URL
+	url := path
+	if strings.HasPrefix(path, "/") {
+		url = c.endpoint + path
+	}
+	request, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Accept", "application/json")
+	if body != nil {
+		request.Header.Add("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		request.Header.Add(authTokenHeader, c.token)
+	}
+	return c.http.Do(request)
+}
+
+func closeBody(response *http.Response) {
+	// drain body to reuse connection
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+	_ = response.Body.Close()
+}
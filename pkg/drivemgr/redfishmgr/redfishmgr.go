@@ -0,0 +1,389 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package redfishmgr provides the vendor neutral Redfish based implementation of DriveManager interface
+package redfishmgr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+const (
+	systemsURL = "/redfish/v1/Systems"
+
+	// values of IndicatorLED property, deprecated in favor of LocationIndicatorActive since Redfish 2020.3
+	ledLit      = "Lit"
+	ledBlinking = "Blinking"
+	ledOff      = "Off"
+
+	// DefaultTimeout is a default timeout of requests to Redfish service
+	DefaultTimeout = 10 * time.Second
+)
+
+// Config contains parameters of connection to Redfish service
+type Config struct {
+	// URL of BMC, e.g. https://10.0.0.1
+	Endpoint string
+	User     string
+	Password string
+	// path to PEM encoded CA certificates of BMC, system CAs are used if it is empty
+	CAFile  string
+	Timeout time.Duration
+}
+
+// link is a reference to Redfish resource
+type link struct {
+	ID string `json:"@odata.id"`
+}
+
+// collection is a Redfish resource collection
+type collection struct {
+	Members []link `json:"Members"`
+}
+
+// resourceStatus is a Status property of Redfish resource
+type resourceStatus struct {
+	Health string `json:"Health"`
+	State  string `json:"State"`
+}
+
+// locationIndicator contains properties of the resource identify LED, LocationIndicatorActive is nil
+// if the resource supports only deprecated IndicatorLED property
+type locationIndicator struct {
+	IndicatorLED            string `json:"IndicatorLED"`
+	LocationIndicatorActive *bool  `json:"LocationIndicatorActive"`
+}
+
+// system contains properties of ComputerSystem resource
+type system struct {
+	locationIndicator
+	Storage link `json:"Storage"`
+}
+
+// storage contains properties of Storage resource
+type storage struct {
+	Drives []link `json:"Drives"`
+}
+
+// redfishDrive contains properties of Drive resource
+type redfishDrive struct {
+	locationIndicator
+	ID                            string         `json:"Id"`
+	SerialNumber                  string         `json:"SerialNumber"`
+	Manufacturer                  string         `json:"Manufacturer"`
+	Model                         string         `json:"Model"`
+	Revision                      string         `json:"Revision"`
+	CapacityBytes                 int64          `json:"CapacityBytes"`
+	MediaType                     string         `json:"MediaType"`
+	Protocol                      string         `json:"Protocol"`
+	Status                        resourceStatus `json:"Status"`
+	PredictedMediaLifeLeftPercent *float64       `json:"PredictedMediaLifeLeftPercent"`
+	PhysicalLocation              struct {
+		PartLocation struct {
+			ServiceLabel         string `json:"ServiceLabel"`
+			LocationOrdinalValue *int   `json:"LocationOrdinalValue"`
+		} `json:"PartLocation"`
+	} `json:"PhysicalLocation"`
+	// deprecated location property, is used by older BMCs
+	Location []struct {
+		Info string `json:"Info"`
+	} `json:"Location"`
+}
+
+// RedfishManager is the struct that implements DriveManager interface using Redfish service of BMC,
+// it walks through Systems, Storage and Drives resources and doesn't depend on BMC vendor
+type RedfishManager struct {
+	log    *logrus.Entry
+	client *client
+
+	// serial number -> URL of the drive resource, is updated by GetDrivesList
+	driveURLs map[string]string
+	mu        sync.Mutex
+}
+
+// NewRedfishManager is the constructor of RedfishManager struct
+// Receives logrus logger and parameters of connection to Redfish service
+// Returns an instance of RedfishManager or error if CA file can't be read
+func NewRedfishManager(log *logrus.Logger, conf Config) (*RedfishManager, error) {
+	if conf.Timeout == 0 {
+		conf.Timeout = DefaultTimeout
+	}
+	c, err := newClient(conf)
+	if err != nil {
+		return nil, err
+	}
+	return &RedfishManager{
+		log:       log.WithField("component", "RedfishManager"),
+		client:    c,
+		driveURLs: map[string]string{},
+	}, nil
+}
+
+// Close deletes Redfish session
+func (mgr *RedfishManager) Close() error {
+	return mgr.client.logout()
+}
+
+// GetDrivesList returns slice of *api.Drive created from drives of all systems reported by Redfish service
+// Returns slice of *api.Drives struct or error if something went wrong
+func (mgr *RedfishManager) GetDrivesList() ([]*api.Drive, error) {
+	ll := mgr.log.WithField("method", "GetDrivesList")
+
+	driveURLs, err := mgr.getDriveURLs()
+	if err != nil {
+		return nil, err
+	}
+	drives := make([]*api.Drive, 0, len(driveURLs))
+	urlBySerial := make(map[string]string, len(driveURLs))
+	for _, url := range driveURLs {
+		d := &redfishDrive{}
+		if err = mgr.client.get(url, d); err != nil {
+			ll.Errorf("Failed to get drive %s: %v", url, err)
+			continue
+		}
+		if d.Status.State == "Absent" {
+			continue
+		}
+		if d.SerialNumber == "" {
+			ll.Errorf("Drive %s has empty serial number", url)
+			continue
+		}
+		urlBySerial[d.SerialNumber] = url
+		drives = append(drives, convertDrive(d))
+	}
+
+	mgr.mu.Lock()
+	mgr.driveURLs = urlBySerial
+	mgr.mu.Unlock()
+	return drives, nil
+}
+
+// Locate implements Locate method of DriveManager interface, changes identify LED of the drive
+func (mgr *RedfishManager) Locate(serialNumber string, action int32) (int32, error) {
+	url, err := mgr.getDriveURL(serialNumber)
+	if err != nil {
+		return -1, err
+	}
+	return mgr.locate(url, action)
+}
+
+// LocateNode implements LocateNode method of DriveManager interface, changes identify LED of all systems
+func (mgr *RedfishManager) LocateNode(action int32) error {
+	systems := &collection{}
+	if err := mgr.client.get(systemsURL, systems); err != nil {
+		return err
+	}
+	for _, s := range systems.Members {
+		if _, err := mgr.locate(s.ID, action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getDriveURLs returns URLs of the drives of all systems
+func (mgr *RedfishManager) getDriveURLs() ([]string, error) {
+	systems := &collection{}
+	if err := mgr.client.get(systemsURL, systems); err != nil {
+		return nil, err
+	}
+	var driveURLs []string
+	for _, systemLink := range systems.Members {
+		s := &system{}
+		if err := mgr.client.get(systemLink.ID, s); err != nil {
+			return nil, err
+		}
+		// Storage resource isn't implemented by some BMCs
+		if s.Storage.ID == "" {
+			mgr.log.Warnf("System %s doesn't have Storage resource", systemLink.ID)
+			continue
+		}
+		storages := &collection{}
+		if err := mgr.client.get(s.Storage.ID, storages); err != nil {
+			return nil, err
+		}
+		for _, storageLink := range storages.Members {
+			st := &storage{}
+			if err := mgr.client.get(storageLink.ID, st); err != nil {
+				return nil, err
+			}
+			for _, d := range st.Drives {
+				driveURLs = append(driveURLs, d.ID)
+			}
+		}
+	}
+	if len(driveURLs) == 0 {
+		return nil, errors.New("unable to find drives in Redfish service")
+	}
+	return driveURLs, nil
+}
+
+// getDriveURL returns URL of the drive with provided serial number, drives are rediscovered if it isn't known
+func (mgr *RedfishManager) getDriveURL(serialNumber string) (string, error) {
+	mgr.mu.Lock()
+	url, ok := mgr.driveURLs[serialNumber]
+	mgr.mu.Unlock()
+	if ok {
+		return url, nil
+	}
+	if _, err := mgr.GetDrivesList(); err != nil {
+		return "", err
+	}
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	if url, ok = mgr.driveURLs[serialNumber]; !ok {
+		return "", status.Errorf(codes.NotFound, "drive %s isn't found in Redfish service", serialNumber)
+	}
+	return url, nil
+}
+
+// locate changes or reads identify LED of the drive or system resource with provided URL
+// Returns current LED status
+func (mgr *RedfishManager) locate(url string, action int32) (int32, error) {
+	indicator := &locationIndicator{}
+	if err := mgr.client.get(url, indicator); err != nil {
+		return -1, err
+	}
+	switch action {
+	case apiV1.LocateStart, apiV1.LocateStop:
+		on := action == apiV1.LocateStart
+		if err := mgr.client.patch(url, indicatorPatch(indicator, on)); err != nil {
+			return -1, err
+		}
+		if on {
+			return apiV1.LocateStatusOn, nil
+		}
+		return apiV1.LocateStatusOff, nil
+	case apiV1.LocateStatus:
+		return indicatorStatus(indicator), nil
+	}
+	return -1, status.Error(codes.InvalidArgument, "Wrong arguments for Locate methods")
+}
+
+// indicatorPatch returns properties to turn on or off identify LED,
+// LocationIndicatorActive is used if resource supports it
+func indicatorPatch(indicator *locationIndicator, on bool) map[string]interface{} {
+	if indicator.LocationIndicatorActive != nil {
+		return map[string]interface{}{"LocationIndicatorActive": on}
+	}
+	if on {
+		return map[string]interface{}{"IndicatorLED": ledBlinking}
+	}
+	return map[string]interface{}{"IndicatorLED": ledOff}
+}
+
+// indicatorStatus converts LED properties to locate status
+func indicatorStatus(indicator *locationIndicator) int32 {
+	if indicator.LocationIndicatorActive != nil {
+		if *indicator.LocationIndicatorActive {
+			return apiV1.LocateStatusOn
+		}
+		return apiV1.LocateStatusOff
+	}
+	switch indicator.IndicatorLED {
+	case ledLit, ledBlinking:
+		return apiV1.LocateStatusOn
+	case ledOff:
+		return apiV1.LocateStatusOff
+	default:
+		return apiV1.LocateStatusNotAvailable
+	}
+}
+
+// convertDrive converts Redfish drive to api.Drive
+func convertDrive(d *redfishDrive) *api.Drive {
+	drive := &api.Drive{
+		VID:          d.Manufacturer,
+		PID:          d.Model,
+		SerialNumber: d.SerialNumber,
+		Firmware:     d.Revision,
+		Size:         d.CapacityBytes,
+		Type:         convertDriveType(d.MediaType, d.Protocol),
+		Health:       convertDriveHealth(d.Status.Health),
+		Status:       convertDriveState(d.Status.State),
+		Slot:         driveSlot(d),
+	}
+	if drive.Health == apiV1.HealthSuspect || drive.Health == apiV1.HealthBad {
+		drive.HealthReason = fmt.Sprintf("BMC reports drive health %s", d.Status.Health)
+	}
+	if d.PredictedMediaLifeLeftPercent != nil {
+		drive.Endurance = int64(*d.PredictedMediaLifeLeftPercent)
+	}
+	return drive
+}
+
+// convertDriveHealth converts Redfish health to apiV1 Health string
+// Receives Redfish Status.Health value
+// Returns string variable (GOOD, SUSPECT, BAD, UNKNOWN)
+func convertDriveHealth(health string) string {
+	switch health {
+	case "OK":
+		return apiV1.HealthGood
+	case "Warning":
+		return apiV1.HealthSuspect
+	case "Critical":
+		return apiV1.HealthBad
+	default:
+		return apiV1.HealthUnknown
+	}
+}
+
+// convertDriveState converts Redfish state to apiV1 drive status
+func convertDriveState(state string) string {
+	switch state {
+	case "", "Enabled", "StandbySpare", "Updating", "Starting":
+		return apiV1.DriveStatusOnline
+	default:
+		return apiV1.DriveStatusOffline
+	}
+}
+
+// convertDriveType converts Redfish media type and protocol to drive type string var
+// Returns string variable of drive type (HDD, SSD, NVMe)
+func convertDriveType(mediaType, protocol string) string {
+	if protocol == "NVMe" {
+		return apiV1.DriveTypeNVMe
+	}
+	if mediaType == "SSD" {
+		return apiV1.DriveTypeSSD
+	}
+	return apiV1.DriveTypeHDD
+}
+
+// driveSlot returns service label or ordinal number of the drive slot, deprecated Location is used by older BMCs
+func driveSlot(d *redfishDrive) string {
+	part := d.PhysicalLocation.PartLocation
+	switch {
+	case part.ServiceLabel != "":
+		return part.ServiceLabel
+	case part.LocationOrdinalValue != nil:
+		return strconv.Itoa(*part.LocationOrdinalValue)
+	case len(d.Location) > 0:
+		return d.Location[0].Info
+	}
+	return ""
+}
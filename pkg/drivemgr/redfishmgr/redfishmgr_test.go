@@ -0,0 +1,220 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redfishmgr
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/mocks/redfish"
+)
+
+const (
+	testUser     = "user"
+	testPassword = "password"
+)
+
+var logger = logrus.New()
+
+type testBMC struct {
+	*redfish.Server
+	system string
+	hdd    string
+	nvme   string
+}
+
+// prepareBMC starts Redfish service with 2 storage controllers and 3 drives, one of them is absent
+func prepareBMC(t *testing.T) *testBMC {
+	bmc := &testBMC{Server: redfish.NewServer(testUser, testPassword)}
+	bmc.system = bmc.AddSystem("1")
+	raid := bmc.AddStorage(bmc.system, "RAID.Integrated.1-1")
+	bmc.hdd = bmc.AddDrive(raid, "Disk.Bay.0", map[string]interface{}{
+		"SerialNumber":  "hdd-sn",
+		"Manufacturer":  "SEAGATE",
+		"Model":         "ST4000NM0023",
+		"Revision":      "GS0F",
+		"CapacityBytes": 4000787030016,
+		"MediaType":     "HDD",
+		"Protocol":      "SAS",
+		"Status":        map[string]interface{}{"Health": "Warning", "State": "Enabled"},
+		"IndicatorLED":  "Off",
+		"Location":      []interface{}{map[string]interface{}{"Info": "0", "InfoFormat": "Slot Number"}},
+	})
+	bmc.AddDrive(raid, "Disk.Bay.1", map[string]interface{}{
+		"Status": map[string]interface{}{"State": "Absent"},
+	})
+	nvme := bmc.AddStorage(bmc.system, "CPU.1")
+	bmc.nvme = bmc.AddDrive(nvme, "Disk.Bay.2", map[string]interface{}{
+		"SerialNumber":                  "nvme-sn",
+		"Manufacturer":                  "Intel",
+		"Model":                         "P4510",
+		"CapacityBytes":                 2000398934016,
+		"MediaType":                     "SSD",
+		"Protocol":                      "NVMe",
+		"Status":                        map[string]interface{}{"Health": "OK", "State": "Enabled"},
+		"PredictedMediaLifeLeftPercent": 87.5,
+		"LocationIndicatorActive":       false,
+		"PhysicalLocation": map[string]interface{}{
+			"PartLocation": map[string]interface{}{"ServiceLabel": "Slot 2", "LocationOrdinalValue": 2},
+		},
+	})
+	t.Cleanup(bmc.Close)
+	return bmc
+}
+
+func prepareManager(t *testing.T, bmc *testBMC) *RedfishManager {
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	assert.Nil(t, bmc.WriteCA(caFile))
+	mgr, err := NewRedfishManager(logger, Config{
+		Endpoint: bmc.URL, User: testUser, Password: testPassword, CAFile: caFile,
+	})
+	assert.Nil(t, err)
+	return mgr
+}
+
+func TestNewRedfishManager(t *testing.T) {
+	_, err := NewRedfishManager(logger, Config{CAFile: "/not/existing"})
+	assert.NotNil(t, err)
+
+	bmc := prepareBMC(t)
+	// BMC certificate isn't trusted
+	mgr, err := NewRedfishManager(logger, Config{Endpoint: bmc.URL, User: testUser, Password: testPassword})
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultTimeout, mgr.client.http.Timeout)
+	_, err = mgr.GetDrivesList()
+	assert.NotNil(t, err)
+}
+
+func TestRedfishManager_GetDrivesList(t *testing.T) {
+	bmc := prepareBMC(t)
+	mgr := prepareManager(t, bmc)
+
+	drives, err := mgr.GetDrivesList()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(drives))
+
+	hdd := drives[0]
+	assert.Equal(t, "hdd-sn", hdd.SerialNumber)
+	assert.Equal(t, "SEAGATE", hdd.VID)
+	assert.Equal(t, "ST4000NM0023", hdd.PID)
+	assert.Equal(t, "GS0F", hdd.Firmware)
+	assert.Equal(t, int64(4000787030016), hdd.Size)
+	assert.Equal(t, apiV1.DriveTypeHDD, hdd.Type)
+	assert.Equal(t, apiV1.HealthSuspect, hdd.Health)
+	assert.Equal(t, "BMC reports drive health Warning", hdd.HealthReason)
+	assert.Equal(t, apiV1.DriveStatusOnline, hdd.Status)
+	assert.Equal(t, "0", hdd.Slot)
+	assert.Equal(t, int64(0), hdd.Endurance)
+
+	nvme := drives[1]
+	assert.Equal(t, "nvme-sn", nvme.SerialNumber)
+	assert.Equal(t, apiV1.DriveTypeNVMe, nvme.Type)
+	assert.Equal(t, apiV1.HealthGood, nvme.Health)
+	assert.Empty(t, nvme.HealthReason)
+	assert.Equal(t, "Slot 2", nvme.Slot)
+	assert.Equal(t, int64(87), nvme.Endurance)
+
+	// session is reused
+	_, err = mgr.GetDrivesList()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, bmc.Requests(http.MethodPost, "/redfish/v1/SessionService/Sessions"))
+
+	// session is recreated when it expires
+	bmc.ExpireSessions()
+	_, err = mgr.GetDrivesList()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, bmc.Requests(http.MethodPost, "/redfish/v1/SessionService/Sessions"))
+
+	assert.Nil(t, mgr.Close())
+	assert.Equal(t, 0, bmc.Sessions())
+}
+
+func TestRedfishManager_GetDrivesListFail(t *testing.T) {
+	bmc := prepareBMC(t)
+	mgr := prepareManager(t, bmc)
+	mgr.client.password = "wrong"
+	_, err := mgr.GetDrivesList()
+	assert.NotNil(t, err)
+
+	// system without drives
+	bmc = &testBMC{Server: redfish.NewServer(testUser, testPassword)}
+	defer bmc.Close()
+	bmc.AddSystem("1")
+	mgr = prepareManager(t, bmc)
+	_, err = mgr.GetDrivesList()
+	assert.NotNil(t, err)
+}
+
+func TestRedfishManager_Locate(t *testing.T) {
+	bmc := prepareBMC(t)
+	mgr := prepareManager(t, bmc)
+
+	// IndicatorLED
+	ledStatus, err := mgr.Locate("hdd-sn", apiV1.LocateStart)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOn, ledStatus)
+	assert.Equal(t, "Blinking", bmc.Property(bmc.hdd, "IndicatorLED"))
+	ledStatus, err = mgr.Locate("hdd-sn", apiV1.LocateStatus)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOn, ledStatus)
+	ledStatus, err = mgr.Locate("hdd-sn", apiV1.LocateStop)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOff, ledStatus)
+	assert.Equal(t, "Off", bmc.Property(bmc.hdd, "IndicatorLED"))
+
+	// LocationIndicatorActive
+	ledStatus, err = mgr.Locate("nvme-sn", apiV1.LocateStart)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOn, ledStatus)
+	assert.Equal(t, true, bmc.Property(bmc.nvme, "LocationIndicatorActive"))
+	ledStatus, err = mgr.Locate("nvme-sn", apiV1.LocateStatus)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.LocateStatusOn, ledStatus)
+
+	// drives are discovered only once
+	assert.Equal(t, 1, bmc.Requests(http.MethodGet, "/redfish/v1/Systems"))
+
+	_, err = mgr.Locate("nvme-sn", 10)
+	assert.NotNil(t, err)
+	_, err = mgr.Locate("unknown-sn", apiV1.LocateStart)
+	assert.NotNil(t, err)
+}
+
+func TestRedfishManager_LocateNode(t *testing.T) {
+	bmc := prepareBMC(t)
+	mgr := prepareManager(t, bmc)
+
+	assert.Nil(t, mgr.LocateNode(apiV1.LocateStart))
+	assert.Equal(t, "Blinking", bmc.Property(bmc.system, "IndicatorLED"))
+	assert.Nil(t, mgr.LocateNode(apiV1.LocateStop))
+	assert.Equal(t, "Off", bmc.Property(bmc.system, "IndicatorLED"))
+	assert.NotNil(t, mgr.LocateNode(10))
+}
+
+func Test_convertDrive(t *testing.T) {
+	assert.Equal(t, apiV1.HealthBad, convertDriveHealth("Critical"))
+	assert.Equal(t, apiV1.HealthUnknown, convertDriveHealth(""))
+	assert.Equal(t, apiV1.DriveStatusOffline, convertDriveState("UnavailableOffline"))
+	assert.Equal(t, apiV1.DriveTypeSSD, convertDriveType("SSD", "SATA"))
+	assert.Equal(t, apiV1.LocateStatusNotAvailable, indicatorStatus(&locationIndicator{}))
+	assert.Equal(t, "", driveSlot(&redfishDrive{}))
+}
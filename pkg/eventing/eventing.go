@@ -172,6 +172,11 @@ var (
 		severity:    WarningType,
 		symptomCode: NoneSymptomCode,
 	}
+	DrivePathChanged = &EventDescription{
+		reason:      "DrivePathChanged",
+		severity:    WarningType,
+		symptomCode: NoneSymptomCode,
+	}
 
 	VolumeGroupScanFailed = &EventDescription{
 		reason:      "VolumeGroupScanFailed",
@@ -194,6 +199,38 @@ var (
 		symptomCode: NoneSymptomCode,
 	}
 
+	ThinPoolUsageHigh = &EventDescription{
+		reason:      "ThinPoolUsageHigh",
+		severity:    WarningType,
+		symptomCode: NoneSymptomCode,
+	}
+	ThinPoolFull = &EventDescription{
+		reason:      "ThinPoolFull",
+		severity:    ErrorType,
+		symptomCode: NoneSymptomCode,
+	}
+	ThinPoolUsageNormal = &EventDescription{
+		reason:      "ThinPoolUsageNormal",
+		severity:    NormalType,
+		symptomCode: NoneSymptomCode,
+	}
+
+	BlockTuningSetFailed = &EventDescription{
+		reason:      "BlockTuningSetFailed",
+		severity:    ErrorType,
+		symptomCode: NoneSymptomCode,
+	}
+	BlockTuningRestoreFailed = &EventDescription{
+		reason:      "BlockTuningRestoreFailed",
+		severity:    WarningType,
+		symptomCode: NoneSymptomCode,
+	}
+	BlockTuningConfigUpdateFailed = &EventDescription{
+		reason:      "BlockTuningConfigUpdateFailed",
+		severity:    WarningType,
+		symptomCode: NoneSymptomCode,
+	}
+	// WBT events are sent instead of block tuning events for the legacy WBT profile
 	WBTValueSetFailed = &EventDescription{
 		reason:      "WBTValueSetFailed",
 		severity:    ErrorType,
@@ -209,4 +246,36 @@ var (
 		severity:    WarningType,
 		symptomCode: NoneSymptomCode,
 	}
+
+	EraseStarted = &EventDescription{
+		reason:      "EraseStarted",
+		severity:    NormalType,
+		symptomCode: NoneSymptomCode,
+	}
+	EraseProgress = &EventDescription{
+		reason:      "EraseProgress",
+		severity:    NormalType,
+		symptomCode: NoneSymptomCode,
+	}
+	EraseCompleted = &EventDescription{
+		reason:      "EraseCompleted",
+		severity:    NormalType,
+		symptomCode: NoneSymptomCode,
+	}
+	EraseFailed = &EventDescription{
+		reason:      "EraseFailed",
+		severity:    ErrorType,
+		symptomCode: NoneSymptomCode,
+	}
+
+	IOLimitsSetFailed = &EventDescription{
+		reason:      "IOLimitsSetFailed",
+		severity:    ErrorType,
+		symptomCode: NoneSymptomCode,
+	}
+	IOLimitsRemoveFailed = &EventDescription{
+		reason:      "IOLimitsRemoveFailed",
+		severity:    WarningType,
+		symptomCode: NoneSymptomCode,
+	}
 )
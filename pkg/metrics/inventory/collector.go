@@ -0,0 +1,241 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inventory contains prometheus collector which exports gauges derived from csi-baremetal custom resources
+package inventory
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
+	accrd "github.com/dell/csi-baremetal/api/v1/availablecapacitycrd"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+// collectTimeout is a timeout for reading custom resources during single scrape
+const collectTimeout = 30 * time.Second
+
+var (
+	driveInfo = prometheus.NewDesc("drive_info",
+		"A metric with a constant '1' value labeled by drive properties",
+		[]string{"node", "serial_number", "type", "health", "status", "usage", "vendor", "model", "slot"}, nil)
+	driveSize = prometheus.NewDesc("drive_size_bytes",
+		"Size of the drive",
+		[]string{"node", "serial_number"}, nil)
+	volumeCount = prometheus.NewDesc("volume_count",
+		"Number of volumes",
+		[]string{"node", "storage_class", "health", "status", "usage"}, nil)
+	volumeSize = prometheus.NewDesc("volume_size_bytes",
+		"Total size of volumes",
+		[]string{"node", "storage_class", "health", "status", "usage"}, nil)
+	availableCapacity = prometheus.NewDesc("available_capacity_bytes",
+		"Capacity which could be used for new volumes, includes reserved capacity",
+		[]string{"node", "storage_class"}, nil)
+	reservedCapacity = prometheus.NewDesc("reserved_capacity_bytes",
+		"Capacity which is reserved for volumes of pods being scheduled",
+		[]string{"node", "storage_class"}, nil)
+	reservationCount = prometheus.NewDesc("capacity_reservation_count",
+		"Number of available capacity reservations",
+		[]string{"status"}, nil)
+	lvgCount = prometheus.NewDesc("lvg_count",
+		"Number of logical volume groups",
+		[]string{"node", "health", "status"}, nil)
+	lvgSize = prometheus.NewDesc("lvg_size_bytes",
+		"Total size of logical volume groups",
+		[]string{"node", "health", "status"}, nil)
+)
+
+// Collector is a prometheus.Collector which reads Drive, Volume, AvailableCapacity,
+// AvailableCapacityReservation and LogicalVolumeGroup CRs on each scrape
+type Collector struct {
+	reader k8s.CRReader
+	log    *logrus.Entry
+}
+
+// NewCollector is a constructor for Collector
+func NewCollector(reader k8s.CRReader, logger *logrus.Logger) *Collector {
+	return &Collector{
+		reader: reader,
+		log:    logger.WithField("component", "InventoryCollector"),
+	}
+}
+
+// Describe sends descriptors of all inventory metrics
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{driveInfo, driveSize, volumeCount, volumeSize,
+		availableCapacity, reservedCapacity, reservationCount, lvgCount, lvgSize} {
+		ch <- d
+	}
+}
+
+// Collect reads custom resources and sends inventory metrics,
+// metrics of the resource are skipped if its list can't be read
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancelFn()
+
+	drives := &drivecrd.DriveList{}
+	if c.readList(ctx, drives) {
+		c.collectDrives(ch, drives.Items)
+	}
+	volumes := &volumecrd.VolumeList{}
+	if c.readList(ctx, volumes) {
+		c.collectVolumes(ch, volumes.Items)
+	}
+	acs := &accrd.AvailableCapacityList{}
+	if c.readList(ctx, acs) {
+		c.collectCapacity(ch, acs.Items)
+		acrs := &acrcrd.AvailableCapacityReservationList{}
+		if c.readList(ctx, acrs) {
+			c.collectReservations(ch, acrs.Items, acs.Items)
+		}
+	}
+	lvgs := &lvgcrd.LogicalVolumeGroupList{}
+	if c.readList(ctx, lvgs) {
+		c.collectLVGs(ch, lvgs.Items)
+	}
+}
+
+func (c *Collector) readList(ctx context.Context, list client.ObjectList) bool {
+	if err := c.reader.ReadList(ctx, list); err != nil {
+		c.log.Errorf("Failed to read %T: %v", list, err)
+		return false
+	}
+	return true
+}
+
+func (c *Collector) collectDrives(ch chan<- prometheus.Metric, drives []drivecrd.Drive) {
+	for _, d := range drives {
+		s := d.Spec
+		ch <- prometheus.MustNewConstMetric(driveInfo, prometheus.GaugeValue, 1,
+			s.NodeId, s.SerialNumber, s.Type, s.Health, s.Status, s.Usage, s.VID, s.PID, s.Slot)
+		ch <- prometheus.MustNewConstMetric(driveSize, prometheus.GaugeValue, float64(s.Size),
+			s.NodeId, s.SerialNumber)
+	}
+}
+
+func (c *Collector) collectVolumes(ch chan<- prometheus.Metric, volumes []volumecrd.Volume) {
+	counts := newAggregation()
+	for _, v := range volumes {
+		s := v.Spec
+		counts.add(float64(s.Size), s.NodeId, s.StorageClass, s.Health, s.CSIStatus, s.Usage)
+	}
+	counts.send(ch, volumeCount, volumeSize)
+}
+
+func (c *Collector) collectCapacity(ch chan<- prometheus.Metric, acs []accrd.AvailableCapacity) {
+	sizes := newAggregation()
+	for _, ac := range acs {
+		sizes.add(float64(ac.Spec.Size), ac.Spec.NodeId, ac.Spec.StorageClass)
+	}
+	sizes.send(ch, nil, availableCapacity)
+}
+
+// collectReservations sends number of ACRs by status and size of capacity requests reserved by confirmed ACRs,
+// AC is labeled by node and storage class of the reservation, LVG AC could be shared by several requests
+func (c *Collector) collectReservations(ch chan<- prometheus.Metric,
+	acrs []acrcrd.AvailableCapacityReservation, acs []accrd.AvailableCapacity) {
+	acByName := make(map[string]*accrd.AvailableCapacity, len(acs))
+	for i := range acs {
+		acByName[acs[i].Name] = &acs[i]
+	}
+
+	statuses := newAggregation()
+	sizes := newAggregation()
+	for _, acr := range acrs {
+		statuses.add(0, acr.Spec.Status)
+		if acr.Spec.Status != apiV1.ReservationConfirmed {
+			continue
+		}
+		for _, request := range acr.Spec.ReservationRequests {
+			if request.CapacityRequest == nil {
+				continue
+			}
+			// request reserves one AC on each node requested by ACR
+			for _, name := range request.Reservations {
+				// AC could be removed when volume is created
+				if ac, ok := acByName[name]; ok {
+					sizes.add(float64(request.CapacityRequest.Size), ac.Spec.NodeId, ac.Spec.StorageClass)
+				}
+			}
+		}
+	}
+	statuses.send(ch, reservationCount, nil)
+	sizes.send(ch, nil, reservedCapacity)
+}
+
+func (c *Collector) collectLVGs(ch chan<- prometheus.Metric, lvgs []lvgcrd.LogicalVolumeGroup) {
+	counts := newAggregation()
+	for _, lvg := range lvgs {
+		s := lvg.Spec
+		counts.add(float64(s.Size), s.Node, s.Health, s.Status)
+	}
+	counts.send(ch, lvgCount, lvgSize)
+}
+
+type aggregated struct {
+	labels []string
+	count  float64
+	sum    float64
+}
+
+// aggregation counts objects and sums their sizes by label values
+type aggregation struct {
+	keys   []string
+	values map[string]*aggregated
+}
+
+func newAggregation() *aggregation {
+	return &aggregation{values: map[string]*aggregated{}}
+}
+
+func (a *aggregation) add(size float64, labels ...string) {
+	key := ""
+	for _, l := range labels {
+		// spec fields don't contain zero byte, so the key is unique
+		key += l + "\x00"
+	}
+	value, ok := a.values[key]
+	if !ok {
+		value = &aggregated{labels: labels}
+		a.values[key] = value
+		a.keys = append(a.keys, key)
+	}
+	value.count++
+	value.sum += size
+}
+
+// send sends count and sum gauges for each label values, nil descriptor is skipped
+func (a *aggregation) send(ch chan<- prometheus.Metric, count, sum *prometheus.Desc) {
+	for _, key := range a.keys {
+		value := a.values[key]
+		if count != nil {
+			ch <- prometheus.MustNewConstMetric(count, prometheus.GaugeValue, value.count, value.labels...)
+		}
+		if sum != nil {
+			ch <- prometheus.MustNewConstMetric(sum, prometheus.GaugeValue, value.sum, value.labels...)
+		}
+	}
+}
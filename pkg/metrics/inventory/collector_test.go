@@ -0,0 +1,147 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+)
+
+var testLogger = logrus.New()
+
+type failingReader struct{}
+
+func (failingReader) ReadCR(context.Context, string, string, k8sCl.Object) error {
+	return fmt.Errorf("error")
+}
+
+func (failingReader) ReadList(context.Context, k8sCl.ObjectList) error {
+	return fmt.Errorf("error")
+}
+
+func TestCollector_Collect(t *testing.T) {
+	kubeClient, err := k8s.GetFakeKubeClient("default", testLogger)
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	for i, d := range []api.Drive{
+		{UUID: "d1", NodeId: "node1", SerialNumber: "sn1", Type: apiV1.DriveTypeHDD, Health: apiV1.HealthGood,
+			Status: apiV1.DriveStatusOnline, Usage: apiV1.DriveUsageInUse, VID: "vendor", PID: "model", Slot: "1", Size: 100},
+		{UUID: "d2", NodeId: "node1", SerialNumber: "sn2", Type: apiV1.DriveTypeHDD, Health: apiV1.HealthBad,
+			Status: apiV1.DriveStatusOnline, Usage: apiV1.DriveUsageFailed, VID: "vendor", PID: "model", Slot: "2", Size: 200},
+	} {
+		assert.Nil(t, kubeClient.CreateCR(ctx, fmt.Sprintf("drive%d", i), kubeClient.ConstructDriveCR(d.UUID, d)))
+	}
+	for _, v := range []api.Volume{
+		{Id: "v1", NodeId: "node1", StorageClass: apiV1.StorageClassHDD, Health: apiV1.HealthGood,
+			CSIStatus: apiV1.Published, Usage: apiV1.VolumeUsageInUse, Size: 10},
+		{Id: "v2", NodeId: "node1", StorageClass: apiV1.StorageClassHDD, Health: apiV1.HealthGood,
+			CSIStatus: apiV1.Published, Usage: apiV1.VolumeUsageInUse, Size: 20},
+	} {
+		assert.Nil(t, kubeClient.CreateCR(ctx, v.Id, kubeClient.ConstructVolumeCR(v.Id, "default", nil, v)))
+	}
+	for _, ac := range []api.AvailableCapacity{
+		{Location: "d1", NodeId: "node1", StorageClass: apiV1.StorageClassHDD, Size: 90},
+		{Location: "d3", NodeId: "node2", StorageClass: apiV1.StorageClassSSD, Size: 50},
+	} {
+		assert.Nil(t, kubeClient.CreateCR(ctx, "ac-"+ac.Location, kubeClient.ConstructACCR("ac-"+ac.Location, ac)))
+	}
+	for name, acr := range map[string]api.AvailableCapacityReservation{
+		"acr1": {Namespace: "default", Status: apiV1.ReservationConfirmed,
+			ReservationRequests: []*api.ReservationRequest{{
+				CapacityRequest: &api.CapacityRequest{Name: "pvc1", StorageClass: apiV1.StorageClassSSD, Size: 40},
+				Reservations:    []string{"ac-d3", "ac-removed"}}}},
+		"acr2": {Namespace: "default", Status: apiV1.ReservationRequested},
+	} {
+		assert.Nil(t, kubeClient.CreateCR(ctx, name, kubeClient.ConstructACRCR(name, acr)))
+	}
+	lvg := api.LogicalVolumeGroup{Name: "lvg1", Node: "node2", Size: 300, Health: apiV1.HealthGood, Status: apiV1.Created}
+	assert.Nil(t, kubeClient.CreateCR(ctx, lvg.Name, kubeClient.ConstructLVGCR(lvg.Name, lvg)))
+
+	expected := `
+# HELP available_capacity_bytes Capacity which could be used for new volumes, includes reserved capacity
+# TYPE available_capacity_bytes gauge
+available_capacity_bytes{node="node1",storage_class="HDD"} 90
+available_capacity_bytes{node="node2",storage_class="SSD"} 50
+# HELP capacity_reservation_count Number of available capacity reservations
+# TYPE capacity_reservation_count gauge
+capacity_reservation_count{status="REQUESTED"} 1
+capacity_reservation_count{status="RESERVED"} 1
+# HELP drive_info A metric with a constant '1' value labeled by drive properties
+# TYPE drive_info gauge
+drive_info{health="BAD",model="model",node="node1",serial_number="sn2",slot="2",status="ONLINE",type="HDD",usage="FAILED",vendor="vendor"} 1
+drive_info{health="GOOD",model="model",node="node1",serial_number="sn1",slot="1",status="ONLINE",type="HDD",usage="IN_USE",vendor="vendor"} 1
+# HELP lvg_size_bytes Total size of logical volume groups
+# TYPE lvg_size_bytes gauge
+lvg_size_bytes{health="GOOD",node="node2",status="CREATED"} 300
+# HELP reserved_capacity_bytes Capacity which is reserved for volumes of pods being scheduled
+# TYPE reserved_capacity_bytes gauge
+reserved_capacity_bytes{node="node2",storage_class="SSD"} 40
+# HELP volume_count Number of volumes
+# TYPE volume_count gauge
+volume_count{health="GOOD",node="node1",status="PUBLISHED",storage_class="HDD",usage="IN_USE"} 2
+# HELP volume_size_bytes Total size of volumes
+# TYPE volume_size_bytes gauge
+volume_size_bytes{health="GOOD",node="node1",status="PUBLISHED",storage_class="HDD",usage="IN_USE"} 30
+`
+	collector := NewCollector(kubeClient, testLogger)
+	assert.Nil(t, testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"available_capacity_bytes", "capacity_reservation_count", "drive_info", "lvg_size_bytes",
+		"reserved_capacity_bytes", "volume_count", "volume_size_bytes"))
+	// drives: 4, volumes: 2, ACs: 2, ACRs: 2 + 1, LVGs: 2
+	assert.Equal(t, 13, testutil.CollectAndCount(collector))
+}
+
+func TestCollector_CollectReservationsLVG(t *testing.T) {
+	kubeClient, err := k8s.GetFakeKubeClient("default", testLogger)
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	ac := api.AvailableCapacity{Location: "lvg1", NodeId: "node1", StorageClass: apiV1.StorageClassHDDLVG, Size: 300}
+	assert.Nil(t, kubeClient.CreateCR(ctx, "ac-lvg1", kubeClient.ConstructACCR("ac-lvg1", ac)))
+	// LVG AC is reserved by several ACRs, each of them reserves only requested size
+	for name, size := range map[string]int64{"acr1": 10, "acr2": 20} {
+		acr := api.AvailableCapacityReservation{Namespace: "default", Status: apiV1.ReservationConfirmed,
+			ReservationRequests: []*api.ReservationRequest{{
+				CapacityRequest: &api.CapacityRequest{Name: name, StorageClass: apiV1.StorageClassHDDLVG, Size: size},
+				Reservations:    []string{"ac-lvg1"}}}}
+		assert.Nil(t, kubeClient.CreateCR(ctx, name, kubeClient.ConstructACRCR(name, acr)))
+	}
+
+	expected := `
+# HELP reserved_capacity_bytes Capacity which is reserved for volumes of pods being scheduled
+# TYPE reserved_capacity_bytes gauge
+reserved_capacity_bytes{node="node1",storage_class="HDDLVG"} 30
+`
+	collector := NewCollector(kubeClient, testLogger)
+	assert.Nil(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "reserved_capacity_bytes"))
+}
+
+func TestCollector_CollectFail(t *testing.T) {
+	assert.Equal(t, 0, testutil.CollectAndCount(NewCollector(failingReader{}, testLogger)))
+}
@@ -30,6 +30,10 @@ import (
 // MockDriveMgrClient is the implementation of DriveManager interface to imitate success state
 type MockDriveMgrClient struct {
 	drives []*api.Drive
+	// events of WatchDrives stream, stream is unimplemented if nil
+	events <-chan *api.DriveEvent
+	// error of WatchDrives stream when events channel is closed
+	eventsErr error
 }
 
 // MockDriveMgrClientFail is the implementation of DriveManager interface to imitate failure state
@@ -52,6 +56,11 @@ func (m *MockDriveMgrClientFail) LocateNode(ctx context.Context, in *api.NodeLoc
 	return nil, errors.New("locate node failed")
 }
 
+// WatchDrives is the simulation of failure during DriveManager's WatchDrives
+func (m *MockDriveMgrClientFail) WatchDrives(ctx context.Context, in *api.DrivesRequest, opts ...grpc.CallOption) (api.DriveService_WatchDrivesClient, error) {
+	return nil, errors.New("watch drives failed")
+}
+
 // NewMockDriveMgrClient returns new instance of MockDriveMgrClient
 // Receives slice of api.Drive which would be used in imitation of GetDrivesList
 func NewMockDriveMgrClient(drives []*api.Drive) *MockDriveMgrClient {
@@ -70,6 +79,17 @@ func (m *MockDriveMgrClient) AddDrives(drives ...*api.Drive) {
 	m.drives = append(m.drives, drives...)
 }
 
+// SetDriveEvents sets channel of the events which WatchDrives stream receives,
+// closing of the channel simulates failure of the stream
+func (m *MockDriveMgrClient) SetDriveEvents(events <-chan *api.DriveEvent) {
+	m.events = events
+}
+
+// SetDriveEventsError sets error which WatchDrives stream returns when events channel is closed, Unavailable by default
+func (m *MockDriveMgrClient) SetDriveEventsError(err error) {
+	m.eventsErr = err
+}
+
 // GetDrivesList returns provided to MockDriveMgrClient drives to imitate working of DriveManager
 func (m *MockDriveMgrClient) GetDrivesList(ctx context.Context, in *api.DrivesRequest, opts ...grpc.CallOption) (*api.DrivesResponse, error) {
 	return &api.DrivesResponse{
@@ -86,3 +106,36 @@ func (m *MockDriveMgrClient) Locate(ctx context.Context, in *api.DriveLocateRequ
 func (m *MockDriveMgrClient) LocateNode(ctx context.Context, in *api.NodeLocateRequest, opts ...grpc.CallOption) (*api.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method LocateNode not implemented in MockDriveMgrClient")
 }
+
+// WatchDrives returns stream with events provided by SetDriveEvents
+func (m *MockDriveMgrClient) WatchDrives(ctx context.Context, in *api.DrivesRequest, opts ...grpc.CallOption) (api.DriveService_WatchDrivesClient, error) {
+	if m.events == nil {
+		return nil, status.Error(codes.Unimplemented, "method WatchDrives not implemented in MockDriveMgrClient")
+	}
+	err := m.eventsErr
+	if err == nil {
+		err = status.Error(codes.Unavailable, "stream is closed")
+	}
+	return &MockWatchDrivesClient{ctx: ctx, events: m.events, err: err}, nil
+}
+
+// MockWatchDrivesClient is the implementation of DriveService_WatchDrivesClient which receives events from channel
+type MockWatchDrivesClient struct {
+	grpc.ClientStream
+	ctx    context.Context
+	events <-chan *api.DriveEvent
+	err    error
+}
+
+// Recv returns next event, err is returned when events channel is closed
+func (m *MockWatchDrivesClient) Recv() (*api.DriveEvent, error) {
+	select {
+	case <-m.ctx.Done():
+		return nil, status.Error(codes.Canceled, m.ctx.Err().Error())
+	case event, ok := <-m.events:
+		if !ok {
+			return nil, m.err
+		}
+		return event, nil
+	}
+}
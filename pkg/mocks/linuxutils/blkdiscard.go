@@ -1,5 +1,5 @@
 /*
-Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
 
 Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
@@ -20,19 +20,14 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-// MockWrapWbt is a mock implementation of WrapWbt
-type MockWrapWbt struct {
+// MockWrapBlkdiscard is a mock implementation of WrapBlkdiscard interface from blkdiscard package
+type MockWrapBlkdiscard struct {
 	mock.Mock
 }
 
-// SetValue is a mock implementations
-func (m *MockWrapWbt) SetValue(device string, value uint32) error {
-	args := m.Mock.Called(device, value)
-	return args.Error(0)
-}
-
-// RestoreDefault is a mock implementations
-func (m *MockWrapWbt) RestoreDefault(device string) error {
+// Discard is a mock implementation
+func (m *MockWrapBlkdiscard) Discard(device string) error {
 	args := m.Mock.Called(device)
+
 	return args.Error(0)
 }
@@ -0,0 +1,43 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linuxutils
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/dell/csi-baremetal/pkg/node/blocktuning/common"
+)
+
+// MockWrapBlockTuning is a mock implementation of WrapBlockTuning
+type MockWrapBlockTuning struct {
+	mock.Mock
+}
+
+// Apply is a mock implementations
+func (m *MockWrapBlockTuning) Apply(device string, settings []common.Setting) (map[string]string, error) {
+	args := m.Mock.Called(device, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+// Restore is a mock implementations
+func (m *MockWrapBlockTuning) Restore(device string, values map[string]string) error {
+	args := m.Mock.Called(device, values)
+	return args.Error(0)
+}
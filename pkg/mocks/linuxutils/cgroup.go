@@ -0,0 +1,52 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linuxutils
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+)
+
+// MockWrapCgroup is a mock implementation of WrapCgroup
+type MockWrapCgroup struct {
+	mock.Mock
+}
+
+// GetPodCgroupPath is a mock implementations
+func (m *MockWrapCgroup) GetPodCgroupPath(podUID string) (string, error) {
+	args := m.Mock.Called(podUID)
+	return args.String(0), args.Error(1)
+}
+
+// GetDeviceNumber is a mock implementations
+func (m *MockWrapCgroup) GetDeviceNumber(device string) (string, error) {
+	args := m.Mock.Called(device)
+	return args.String(0), args.Error(1)
+}
+
+// SetIOLimits is a mock implementations
+func (m *MockWrapCgroup) SetIOLimits(cgroupPath, devNumber string, limits *api.IOLimits) (*api.IOLimits, error) {
+	args := m.Mock.Called(cgroupPath, devNumber, limits)
+	return args.Get(0).(*api.IOLimits), args.Error(1)
+}
+
+// RemoveIOLimits is a mock implementations
+func (m *MockWrapCgroup) RemoveIOLimits(cgroupPath, devNumber string) error {
+	args := m.Mock.Called(cgroupPath, devNumber)
+	return args.Error(0)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linuxutils
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWrapCryptsetup is a mock implementation of WrapCryptsetup interface from cryptsetup package
+type MockWrapCryptsetup struct {
+	mock.Mock
+}
+
+// IsLuks is a mock implementation
+func (m *MockWrapCryptsetup) IsLuks(device string) (bool, error) {
+	args := m.Mock.Called(device)
+
+	return args.Bool(0), args.Error(1)
+}
+
+// LuksFormat is a mock implementation
+func (m *MockWrapCryptsetup) LuksFormat(device, passphrase string) error {
+	args := m.Mock.Called(device, passphrase)
+
+	return args.Error(0)
+}
+
+// LuksOpen is a mock implementation
+func (m *MockWrapCryptsetup) LuksOpen(device, name, passphrase string) error {
+	args := m.Mock.Called(device, name, passphrase)
+
+	return args.Error(0)
+}
+
+// LuksClose is a mock implementation
+func (m *MockWrapCryptsetup) LuksClose(name string) error {
+	args := m.Mock.Called(name)
+
+	return args.Error(0)
+}
+
+// LuksErase is a mock implementation
+func (m *MockWrapCryptsetup) LuksErase(device string) error {
+	args := m.Mock.Called(device)
+
+	return args.Error(0)
+}
+
+// IsOpened is a mock implementation
+func (m *MockWrapCryptsetup) IsOpened(name string) (bool, error) {
+	args := m.Mock.Called(name)
+
+	return args.Bool(0), args.Error(1)
+}
@@ -41,6 +41,16 @@ func (m *MockWrapFS) GetFSSpace(src string) (int64, error) {
 	return args.Get(0).(int64), args.Error(1)
 }
 
+// GetFSStats is a mock implementations
+func (m *MockWrapFS) GetFSStats(path string) (*fs.FSStats, error) {
+	args := m.Mock.Called(path)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*fs.FSStats), args.Error(1)
+}
+
 // MkDir is a mock implementations
 func (m *MockWrapFS) MkDir(src string) error {
 	args := m.Mock.Called(src)
@@ -63,8 +73,15 @@ func (m *MockWrapFS) RmDir(src string) error {
 }
 
 // CreateFS is a mock implementations
-func (m *MockWrapFS) CreateFS(fsType fs.FileSystem, device string) error {
-	args := m.Mock.Called(fsType, device)
+func (m *MockWrapFS) CreateFS(fsType fs.FileSystem, device, options string) error {
+	args := m.Mock.Called(fsType, device, options)
+
+	return args.Error(0)
+}
+
+// GrowFS is a mock implementations
+func (m *MockWrapFS) GrowFS(fsType fs.FileSystem, device, mountPoint string) error {
+	args := m.Mock.Called(fsType, device, mountPoint)
 
 	return args.Error(0)
 }
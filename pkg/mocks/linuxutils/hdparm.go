@@ -0,0 +1,33 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linuxutils
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWrapHdparm is a mock implementation of WrapHdparm interface from hdparm package
+type MockWrapHdparm struct {
+	mock.Mock
+}
+
+// SecurityErase is a mock implementation
+func (m *MockWrapHdparm) SecurityErase(device string) error {
+	args := m.Mock.Called(device)
+
+	return args.Error(0)
+}
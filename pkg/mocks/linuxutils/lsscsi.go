@@ -33,3 +33,12 @@ func (m *MockWrapLsscsi) GetSCSIDevices() ([]*lsscsi.SCSIDevice, error) {
 
 	return args.Get(0).([]*lsscsi.SCSIDevice), args.Error(1)
 }
+
+// GetSCSIDevice is a mock implementations
+func (m *MockWrapLsscsi) GetSCSIDevice(path string) (*lsscsi.SCSIDevice, error) {
+	args := m.Mock.Called(path)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*lsscsi.SCSIDevice), args.Error(1)
+}
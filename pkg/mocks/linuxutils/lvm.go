@@ -137,3 +137,45 @@ func (m *MockWrapLVM) GetVGNameByPVName(pvName string) (string, error) {
 
 	return args.String(0), args.Error(1)
 }
+
+// LVSnapshotCreate is a mock implementations
+func (m *MockWrapLVM) LVSnapshotCreate(name, size, fullLVName string) error {
+	args := m.Mock.Called(name, size, fullLVName)
+
+	return args.Error(0)
+}
+
+// LVSnapshotRemove is a mock implementations
+func (m *MockWrapLVM) LVSnapshotRemove(fullSnapshotName string) error {
+	args := m.Mock.Called(fullSnapshotName)
+
+	return args.Error(0)
+}
+
+// GetLVSnapshotUsage is a mock implementations
+func (m *MockWrapLVM) GetLVSnapshotUsage(fullSnapshotName string) (float64, error) {
+	args := m.Mock.Called(fullSnapshotName)
+
+	return args.Get(0).(float64), args.Error(1)
+}
+
+// ThinPoolCreate is a mock implementations
+func (m *MockWrapLVM) ThinPoolCreate(name, vgName string) error {
+	args := m.Mock.Called(name, vgName)
+
+	return args.Error(0)
+}
+
+// ThinLVCreate is a mock implementations
+func (m *MockWrapLVM) ThinLVCreate(name, size, vgName, poolName string) error {
+	args := m.Mock.Called(name, size, vgName, poolName)
+
+	return args.Error(0)
+}
+
+// GetThinPoolUsage is a mock implementations
+func (m *MockWrapLVM) GetThinPoolUsage(fullPoolName string) (float64, float64, error) {
+	args := m.Mock.Called(fullPoolName)
+
+	return args.Get(0).(float64), args.Get(1).(float64), args.Error(2)
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linuxutils
+
+import (
+	"github.com/stretchr/testify/mock"
+
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/mdadm"
+)
+
+// MockWrapMdadm is a mock implementation of WrapMdadm interface from mdadm package
+type MockWrapMdadm struct {
+	mock.Mock
+}
+
+// Create is a mock implementation
+func (m *MockWrapMdadm) Create(device, level string, members []string) error {
+	args := m.Mock.Called(device, level, members)
+
+	return args.Error(0)
+}
+
+// Assemble is a mock implementation
+func (m *MockWrapMdadm) Assemble(device string, members []string) error {
+	args := m.Mock.Called(device, members)
+
+	return args.Error(0)
+}
+
+// Stop is a mock implementation
+func (m *MockWrapMdadm) Stop(device string) error {
+	args := m.Mock.Called(device)
+
+	return args.Error(0)
+}
+
+// ZeroSuperblock is a mock implementation
+func (m *MockWrapMdadm) ZeroSuperblock(member string) error {
+	args := m.Mock.Called(member)
+
+	return args.Error(0)
+}
+
+// GetArrayState is a mock implementation
+func (m *MockWrapMdadm) GetArrayState(device string) (*mdadm.ArrayState, error) {
+	args := m.Mock.Called(device)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*mdadm.ArrayState), args.Error(1)
+}
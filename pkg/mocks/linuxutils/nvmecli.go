@@ -33,3 +33,33 @@ func (m *MockWrapNvmecli) GetNVMDevices() ([]nvmecli.NVMDevice, error) {
 
 	return args.Get(0).([]nvmecli.NVMDevice), args.Error(1)
 }
+
+// GetNVMDevice is a mock implementations
+func (m *MockWrapNvmecli) GetNVMDevice(path string) (*nvmecli.NVMDevice, error) {
+	args := m.Mock.Called(path)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*nvmecli.NVMDevice), args.Error(1)
+}
+
+// Format is a mock implementations
+func (m *MockWrapNvmecli) Format(device string) error {
+	args := m.Mock.Called(device)
+
+	return args.Error(0)
+}
+
+// Sanitize is a mock implementations
+func (m *MockWrapNvmecli) Sanitize(device string) error {
+	args := m.Mock.Called(device)
+
+	return args.Error(0)
+}
+
+// GetSanitizeStatus is a mock implementations
+func (m *MockWrapNvmecli) GetSanitizeStatus(device string) (*nvmecli.SanitizeStatus, error) {
+	args := m.Mock.Called(device)
+
+	return args.Get(0).(*nvmecli.SanitizeStatus), args.Error(1)
+}
@@ -74,6 +74,13 @@ func (m *MockWrapPartition) DeletePartition(device, partNum string) (err error)
 	return args.Error(0)
 }
 
+// GrowPartition is a mock implementations
+func (m *MockWrapPartition) GrowPartition(device, partNum string) error {
+	args := m.Mock.Called(device, partNum)
+
+	return args.Error(0)
+}
+
 // GetPartitionUUID is a mock implementations
 func (m *MockWrapPartition) GetPartitionUUID(device, partNum string) (string, error) {
 	args := m.Mock.Called(device, partNum)
@@ -33,3 +33,10 @@ func (m *MockWrapSmartctl) GetDriveInfoByPath(path string) (*smartctl.DeviceSMAR
 
 	return args.Get(0).(*smartctl.DeviceSMARTInfo), args.Error(1)
 }
+
+// GetSMARTDataByPath is a mock implementations
+func (m *MockWrapSmartctl) GetSMARTDataByPath(path string) (*smartctl.DeviceSMARTData, error) {
+	args := m.Mock.Called(path)
+
+	return args.Get(0).(*smartctl.DeviceSMARTData), args.Error(1)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioners
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/dell/csi-baremetal/pkg/node/provisioners/utilwrappers"
+)
+
+// MockEraseOps is a mock implementation of EraseOperations interface
+type MockEraseOps struct {
+	mock.Mock
+}
+
+// Erase is a mock implementation, reports 100 percent of progress on success
+func (m *MockEraseOps) Erase(ctx context.Context, policy, device string, progress utilwrappers.EraseProgressFunc) error {
+	args := m.Mock.Called(policy, device)
+
+	err := args.Error(0)
+	if err == nil && progress != nil {
+		progress(100)
+	}
+	return err
+}
@@ -0,0 +1,262 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package redfish contains in-process Redfish service which emulates BMC for tests of Redfish based drive manager
+package redfish
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+)
+
+const (
+	serviceRoot     = "/redfish/v1"
+	systemsURL      = serviceRoot + "/Systems"
+	sessionsURL     = serviceRoot + "/SessionService/Sessions"
+	authTokenHeader = "X-Auth-Token"
+)
+
+// Server is a TLS Redfish service with session authentication,
+// it serves resources as JSON objects and merges properties of PATCH requests into them
+type Server struct {
+	*httptest.Server
+	user     string
+	password string
+
+	// path -> properties of the resource
+	resources map[string]map[string]interface{}
+	// token -> path of the session resource
+	sessions  map[string]string
+	nextToken int
+	// number of requests by method and path, e.g. "PATCH /redfish/v1/Systems/1"
+	requests map[string]int
+	mu       sync.Mutex
+}
+
+// NewServer starts Redfish service which accepts provided credentials, service must be closed by caller
+func NewServer(user, password string) *Server {
+	s := &Server{
+		user:      user,
+		password:  password,
+		resources: map[string]map[string]interface{}{},
+		sessions:  map[string]string{},
+		requests:  map[string]int{},
+	}
+	s.resources[serviceRoot] = map[string]interface{}{
+		"Systems": link(systemsURL),
+	}
+	s.resources[systemsURL] = members()
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// AddSystem adds ComputerSystem resource with storage collection
+// Returns path of the system
+func (s *Server) AddSystem(id string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	systemURL := path.Join(systemsURL, id)
+	storageURL := path.Join(systemURL, "Storage")
+	s.resources[systemURL] = map[string]interface{}{
+		"Id":           id,
+		"IndicatorLED": "Off",
+		"Storage":      link(storageURL),
+	}
+	s.resources[storageURL] = members()
+	s.addMember(systemsURL, systemURL)
+	return systemURL
+}
+
+// AddStorage adds Storage resource to the system with provided path
+// Returns path of the storage
+func (s *Server) AddStorage(systemURL, id string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	storageURL := path.Join(systemURL, "Storage", id)
+	s.resources[storageURL] = map[string]interface{}{
+		"Id":     id,
+		"Drives": []interface{}{},
+	}
+	s.addMember(path.Join(systemURL, "Storage"), storageURL)
+	return storageURL
+}
+
+// AddDrive adds Drive resource with provided properties to the storage with provided path
+// Returns path of the drive
+func (s *Server) AddDrive(storageURL, id string, properties map[string]interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	driveURL := path.Join(storageURL, "Drives", id)
+	resource := map[string]interface{}{"Id": id}
+	for k, v := range properties {
+		resource[k] = v
+	}
+	s.resources[driveURL] = resource
+	st := s.resources[storageURL]
+	st["Drives"] = append(st["Drives"].([]interface{}), link(driveURL))
+	return driveURL
+}
+
+// Property returns value of the resource property
+func (s *Server) Property(resourceURL, name string) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.resources[resourceURL][name]
+}
+
+// SetProperty sets value of the resource property
+func (s *Server) SetProperty(resourceURL, name string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resources[resourceURL][name] = value
+}
+
+// ExpireSessions removes all sessions, next requests with old tokens fail with 401
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = map[string]string{}
+}
+
+// Sessions returns number of active sessions
+func (s *Server) Sessions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.sessions)
+}
+
+// Requests returns number of requests with provided method and path
+func (s *Server) Requests(method, resourceURL string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[method+" "+resourceURL]
+}
+
+// WriteCA writes PEM encoded certificate of the server to the file
+func (s *Server) WriteCA(file string) error {
+	return ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}), 0600)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resourceURL := strings.TrimSuffix(r.URL.Path, "/")
+	s.requests[r.Method+" "+resourceURL]++
+
+	if r.Method == http.MethodPost && resourceURL == sessionsURL {
+		s.login(w, r)
+		return
+	}
+	token := r.Header.Get(authTokenHeader)
+	if _, ok := s.sessions[token]; !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resource, ok := s.resources[resourceURL]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(withID(resourceURL, resource))
+	case http.MethodPatch:
+		resource, ok := s.resources[resourceURL]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		properties := map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&properties); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		for k, v := range properties {
+			if _, ok := resource[k]; !ok {
+				// Redfish services reject unknown properties
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			resource[k] = v
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if s.sessions[token] != resourceURL {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.sessions, token)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) login(w http.ResponseWriter, r *http.Request) {
+	credentials := struct {
+		UserName string
+		Password string
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if credentials.UserName != s.user || credentials.Password != s.password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	s.nextToken++
+	token := fmt.Sprintf("token-%d", s.nextToken)
+	s.sessions[token] = path.Join(sessionsURL, fmt.Sprint(s.nextToken))
+	w.Header().Set(authTokenHeader, token)
+	w.Header().Set("Location", s.sessions[token])
+	w.WriteHeader(http.StatusCreated)
+}
+
+// addMember adds link to the collection, must be called under lock
+func (s *Server) addMember(collectionURL, memberURL string) {
+	c := s.resources[collectionURL]
+	c["Members"] = append(c["Members"].([]interface{}), link(memberURL))
+}
+
+func link(resourceURL string) map[string]interface{} {
+	return map[string]interface{}{"@odata.id": resourceURL}
+}
+
+func members() map[string]interface{} {
+	return map[string]interface{}{"Members": []interface{}{}}
+}
+
+func withID(resourceURL string, resource map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{"@odata.id": resourceURL}
+	for k, v := range resource {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+)
+
+// SnapshotOperationsMock is the mock implementation of SnapshotOperations interface for test purposes.
+// All of the mock methods based on stretchr/testify/mock.
+type SnapshotOperationsMock struct {
+	mock.Mock
+}
+
+// CreateSnapshot is the mock implementation of CreateSnapshot method from SnapshotOperations made for simulating
+// creating of Snapshot CR on a cluster.
+// Returns a fake api.Snapshot instance
+func (so *SnapshotOperationsMock) CreateSnapshot(ctx context.Context, s api.Snapshot) (*api.Snapshot, error) {
+	args := so.Mock.Called(ctx, s)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*api.Snapshot), args.Error(1)
+}
+
+// DeleteSnapshot is the mock implementation of DeleteSnapshot method from SnapshotOperations made for simulating
+// deletion of Snapshot CR on a cluster.
+// Returns error if user simulates error in tests or nil
+func (so *SnapshotOperationsMock) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	args := so.Mock.Called(ctx, snapshotID)
+
+	return args.Error(0)
+}
+
+// UpdateCRsAfterSnapshotDeletion is the mock implementation of UpdateCRsAfterSnapshotDeletion
+func (so *SnapshotOperationsMock) UpdateCRsAfterSnapshotDeletion(ctx context.Context, snapshotID string) {
+	so.Mock.Called(ctx, snapshotID)
+}
+
+// WaitStatus is the mock implementation of WaitStatus. Simulates waiting of Snapshot to be reached one of provided
+// statuses
+func (so *SnapshotOperationsMock) WaitStatus(ctx context.Context, snapshotID string, statuses ...string) error {
+	args := so.Mock.Called(ctx, snapshotID, statuses)
+
+	return args.Error(0)
+}
@@ -0,0 +1,193 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Names of the block device queue attributes which could be changed by tuning profile,
+// order of the slice is the order of applying, scheduler goes first because its change resets nr_requests
+const (
+	Scheduler    = "scheduler"
+	NrRequests   = "nr_requests"
+	ReadAheadKB  = "read_ahead_kb"
+	RqAffinity   = "rq_affinity"
+	WbtLatUsec   = "wbt_lat_usec"
+	MaxSectorsKB = "max_sectors_kb"
+
+	// DefaultWbtLatUsec restores kernel default WBT value
+	DefaultWbtLatUsec = "-1"
+	// LegacyWbtProfileName is the name of the profile created from WBT ConfigMap
+	LegacyWbtProfileName = "wbt"
+)
+
+// Attributes is the ordered list of supported queue attributes
+var Attributes = []string{Scheduler, NrRequests, ReadAheadKB, RqAffinity, WbtLatUsec, MaxSectorsKB}
+
+var schedulerRegexp = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// Config is a part of block tuning ConfigMap, contains the list of tuning profiles
+type Config struct {
+	Profiles []Profile `yaml:"profiles"`
+}
+
+// Profile contains block device queue settings and selectors of the volumes which settings are applied for,
+// empty selector matches any value
+type Profile struct {
+	Name string `yaml:"name"`
+	// names of Kubernetes StorageClasses
+	StorageClasses []string `yaml:"storage_classes"`
+	// types of the drives: HDD, SSD, NVME
+	DriveTypes []string `yaml:"drive_types"`
+	// volume modes: FS, RAW, RAW_PART
+	Modes    []string `yaml:"modes"`
+	Settings Settings `yaml:"settings"`
+}
+
+// Settings contains values of block device queue attributes, not set attribute isn't changed
+type Settings struct {
+	Scheduler    string `yaml:"scheduler"`
+	NrRequests   *int64 `yaml:"nr_requests"`
+	ReadAheadKB  *int64 `yaml:"read_ahead_kb"`
+	RqAffinity   *int64 `yaml:"rq_affinity"`
+	WbtLatUsec   *int64 `yaml:"wbt_lat_usec"`
+	MaxSectorsKB *int64 `yaml:"max_sectors_kb"`
+}
+
+// Setting is a value of the single queue attribute
+type Setting struct {
+	Attribute string
+	Value     string
+}
+
+// Values returns settings which are set in order of applying
+func (s *Settings) Values() []Setting {
+	values := map[string]string{}
+	if s.Scheduler != "" {
+		values[Scheduler] = s.Scheduler
+	}
+	for attr, value := range map[string]*int64{
+		NrRequests:   s.NrRequests,
+		ReadAheadKB:  s.ReadAheadKB,
+		RqAffinity:   s.RqAffinity,
+		WbtLatUsec:   s.WbtLatUsec,
+		MaxSectorsKB: s.MaxSectorsKB,
+	} {
+		if value != nil {
+			values[attr] = strconv.FormatInt(*value, 10)
+		}
+	}
+
+	result := make([]Setting, 0, len(values))
+	for _, attr := range Attributes {
+		if value, ok := values[attr]; ok {
+			result = append(result, Setting{Attribute: attr, Value: value})
+		}
+	}
+	return result
+}
+
+// Validate checks that profiles have unique names and settings have valid values
+func (c *Config) Validate() error {
+	names := map[string]bool{}
+	for _, p := range c.Profiles {
+		if p.Name == "" {
+			return fmt.Errorf("profile name must be set")
+		}
+		if names[p.Name] {
+			return fmt.Errorf("profile %s is duplicated", p.Name)
+		}
+		names[p.Name] = true
+		if err := p.Settings.validate(); err != nil {
+			return fmt.Errorf("profile %s is invalid: %v", p.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *Settings) validate() error {
+	if s.Scheduler != "" && !schedulerRegexp.MatchString(s.Scheduler) {
+		return fmt.Errorf("%s %s is invalid", Scheduler, s.Scheduler)
+	}
+	for attr, value := range map[string]*int64{
+		NrRequests:   s.NrRequests,
+		ReadAheadKB:  s.ReadAheadKB,
+		MaxSectorsKB: s.MaxSectorsKB,
+	} {
+		if value != nil && *value <= 0 {
+			return fmt.Errorf("%s must be positive, got %d", attr, *value)
+		}
+	}
+	if s.RqAffinity != nil && (*s.RqAffinity < 0 || *s.RqAffinity > 2) {
+		return fmt.Errorf("%s must be in range [0, 2], got %d", RqAffinity, *s.RqAffinity)
+	}
+	if s.WbtLatUsec != nil && *s.WbtLatUsec < -1 {
+		return fmt.Errorf("%s must not be less than -1, got %d", WbtLatUsec, *s.WbtLatUsec)
+	}
+	if len(s.Values()) == 0 {
+		return fmt.Errorf("settings are empty")
+	}
+	return nil
+}
+
+// SelectProfile returns the first profile which selectors match provided volume parameters or nil
+func (c *Config) SelectProfile(storageClass, driveType, mode string) *Profile {
+	for i := range c.Profiles {
+		p := &c.Profiles[i]
+		if matches(p.StorageClasses, storageClass) && matches(p.DriveTypes, driveType) && matches(p.Modes, mode) {
+			return p
+		}
+	}
+	return nil
+}
+
+func matches(selector []string, value string) bool {
+	if len(selector) == 0 {
+		return true
+	}
+	for _, s := range selector {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
+
+// WbtConfig is a part of WBT ConfigMap
+// contains changing value and options to select acceptable volume
+// Deprecated: WBT ConfigMap is converted to the profile with LegacyWbtProfileName, use Config instead
+type WbtConfig struct {
+	Enable        bool          `yaml:"enable"`
+	Value         uint32        `yaml:"wbt_lat_usec_value"`
+	VolumeOptions VolumeOptions `yaml:"acceptable_volume_options"`
+}
+
+// VolumeOptions contains options to select acceptable volume
+type VolumeOptions struct {
+	Modes          []string `yaml:"modes"`
+	StorageClasses []string `yaml:"storage_classes"`
+}
+
+// AcceptableKernelsConfig is a part of WBT ConfigMap
+// contains the list of kernel versions from nodes,
+// which should be able to set custom WBT value
+type AcceptableKernelsConfig struct {
+	EnableForAll   bool     `yaml:"enable_for_all"`
+	KernelVersions []string `yaml:"node_kernel_versions"`
+}
+
+// ToProfile converts WBT config to the tuning profile, returns nil if WBT changing is disabled.
+// WBT config requires both modes and storage classes to be listed, so empty options don't match any volume
+func (w *WbtConfig) ToProfile() *Profile {
+	if !w.Enable || len(w.VolumeOptions.Modes) == 0 || len(w.VolumeOptions.StorageClasses) == 0 {
+		return nil
+	}
+	value := int64(w.Value)
+	return &Profile{
+		Name:           LegacyWbtProfileName,
+		StorageClasses: w.VolumeOptions.StorageClasses,
+		Modes:          w.VolumeOptions.Modes,
+		Settings:       Settings{WbtLatUsec: &value},
+	}
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestSettings_Values(t *testing.T) {
+	s := Settings{
+		MaxSectorsKB: int64Ptr(512),
+		WbtLatUsec:   int64Ptr(-1),
+		Scheduler:    "none",
+		NrRequests:   int64Ptr(256),
+	}
+	assert.Equal(t, []Setting{
+		{Attribute: Scheduler, Value: "none"},
+		{Attribute: NrRequests, Value: "256"},
+		{Attribute: WbtLatUsec, Value: "-1"},
+		{Attribute: MaxSectorsKB, Value: "512"},
+	}, s.Values())
+	assert.Empty(t, (&Settings{}).Values())
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := Profile{Name: "valid", Settings: Settings{Scheduler: "mq-deadline", RqAffinity: int64Ptr(2)}}
+	assert.Nil(t, (&Config{}).Validate())
+	assert.Nil(t, (&Config{Profiles: []Profile{valid}}).Validate())
+
+	for name, conf := range map[string]*Config{
+		"no name":     {Profiles: []Profile{{Settings: valid.Settings}}},
+		"duplicated":  {Profiles: []Profile{valid, valid}},
+		"empty":       {Profiles: []Profile{{Name: "empty"}}},
+		"scheduler":   {Profiles: []Profile{{Name: "p", Settings: Settings{Scheduler: "none; reboot"}}}},
+		"nr_requests": {Profiles: []Profile{{Name: "p", Settings: Settings{NrRequests: int64Ptr(0)}}}},
+		"rq_affinity": {Profiles: []Profile{{Name: "p", Settings: Settings{RqAffinity: int64Ptr(3)}}}},
+		"wbt":         {Profiles: []Profile{{Name: "p", Settings: Settings{WbtLatUsec: int64Ptr(-2)}}}},
+	} {
+		assert.NotNil(t, conf.Validate(), name)
+	}
+}
+
+func TestConfig_SelectProfile(t *testing.T) {
+	conf := &Config{Profiles: []Profile{
+		{Name: "nvme", DriveTypes: []string{"NVME"}},
+		{Name: "hdd-fs", StorageClasses: []string{"sc-hdd"}, DriveTypes: []string{"HDD"}, Modes: []string{"FS"}},
+		{Name: "default"},
+	}}
+
+	assert.Equal(t, "nvme", conf.SelectProfile("sc-nvme", "NVME", "RAW").Name)
+	assert.Equal(t, "hdd-fs", conf.SelectProfile("sc-hdd", "HDD", "FS").Name)
+	assert.Equal(t, "default", conf.SelectProfile("sc-hdd", "HDD", "RAW").Name)
+	assert.Nil(t, (&Config{Profiles: conf.Profiles[:2]}).SelectProfile("sc-ssd", "SSD", "FS"))
+}
+
+func TestWbtConfig_ToProfile(t *testing.T) {
+	conf := &WbtConfig{
+		Enable: true,
+		Value:  0,
+		VolumeOptions: VolumeOptions{
+			Modes:          []string{"FS"},
+			StorageClasses: []string{"sc-hdd"},
+		},
+	}
+	profile := conf.ToProfile()
+	assert.NotNil(t, profile)
+	assert.Equal(t, LegacyWbtProfileName, profile.Name)
+	assert.Equal(t, []Setting{{Attribute: WbtLatUsec, Value: "0"}}, profile.Settings.Values())
+
+	conf.Enable = false
+	assert.Nil(t, conf.ToProfile())
+	conf.Enable = true
+	conf.VolumeOptions.StorageClasses = nil
+	assert.Nil(t, conf.ToProfile())
+}
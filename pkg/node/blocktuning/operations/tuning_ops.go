@@ -0,0 +1,124 @@
+/*
+Copyright © 2020 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dell/csi-baremetal/pkg/node/blocktuning/common"
+)
+
+const (
+	// DefaultSysfsRoot is the root of sysfs on the node
+	DefaultSysfsRoot = "/sys"
+	// queueDirTmpl is a path to the queue attributes of the block device relative to sysfs root
+	queueDirTmpl = "block/%s/queue"
+)
+
+// WrapBlockTuning is an interface that encapsulates operations with block device queue attributes
+type WrapBlockTuning interface {
+	Apply(device string, settings []common.Setting) (map[string]string, error)
+	Restore(device string, values map[string]string) error
+}
+
+// BlockTuning is WrapBlockTuning implementation which works with sysfs files
+type BlockTuning struct {
+	sysfsRoot string
+}
+
+// NewBlockTuning returns BlockTuning instance, sysfsRoot is a mount point of sysfs
+func NewBlockTuning(sysfsRoot string) *BlockTuning {
+	return &BlockTuning{sysfsRoot: sysfsRoot}
+}
+
+// Apply writes settings to the queue attributes of the given device (e.g. sda),
+// attributes which already have requested value are skipped
+// Returns previous values of the changed attributes, they are returned even if applying fails
+// to be able to restore partially applied settings
+func (b *BlockTuning) Apply(device string, settings []common.Setting) (map[string]string, error) {
+	previous := map[string]string{}
+	for _, s := range settings {
+		current, err := b.read(device, s.Attribute)
+		if err != nil {
+			return previous, err
+		}
+		if current == s.Value {
+			continue
+		}
+		if err = b.write(device, s.Attribute, s.Value); err != nil {
+			return previous, err
+		}
+		previous[s.Attribute] = current
+	}
+	return previous, nil
+}
+
+// Restore writes values of the queue attributes of the given device in order of common.Attributes
+func (b *BlockTuning) Restore(device string, values map[string]string) error {
+	var errs []string
+	for _, attr := range common.Attributes {
+		value, ok := values[attr]
+		if !ok {
+			continue
+		}
+		if err := b.write(device, attr, value); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("unable to restore queue attributes: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// read returns current value of the queue attribute,
+// active scheduler is returned for scheduler attribute, e.g. "mq-deadline" for "[mq-deadline] kyber none"
+func (b *BlockTuning) read(device, attr string) (string, error) {
+	data, err := os.ReadFile(b.attrPath(device, attr))
+	if err != nil {
+		return "", fmt.Errorf("unable to read %s of %s: %v", attr, device, err)
+	}
+	value := strings.TrimSpace(string(data))
+	if attr == common.Scheduler {
+		for _, s := range strings.Fields(value) {
+			if strings.HasPrefix(s, "[") && strings.HasSuffix(s, "]") {
+				return strings.Trim(s, "[]"), nil
+			}
+		}
+	}
+	return value, nil
+}
+
+func (b *BlockTuning) write(device, attr, value string) error {
+	// the same flags as shell redirection uses, attribute isn't created if it doesn't exist
+	f, err := os.OpenFile(b.attrPath(device, attr), os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return fmt.Errorf("unable to open %s of %s: %v", attr, device, err)
+	}
+	defer f.Close()
+	if _, err = f.WriteString(value); err != nil {
+		return fmt.Errorf("unable to set %s of %s to %s: %v", attr, device, value, err)
+	}
+	return nil
+}
+
+func (b *BlockTuning) attrPath(device, attr string) string {
+	return filepath.Join(b.sysfsRoot, fmt.Sprintf(queueDirTmpl, device), attr)
+}
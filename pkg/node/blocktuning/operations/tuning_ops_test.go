@@ -0,0 +1,88 @@
+package operations
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dell/csi-baremetal/pkg/node/blocktuning/common"
+)
+
+const testDevice = "sdb"
+
+func prepareSysfs(t *testing.T, values map[string]string) string {
+	root := t.TempDir()
+	queueDir := filepath.Join(root, fmt.Sprintf(queueDirTmpl, testDevice))
+	assert.Nil(t, os.MkdirAll(queueDir, 0755))
+	for attr, value := range values {
+		assert.Nil(t, os.WriteFile(filepath.Join(queueDir, attr), []byte(value+"\n"), 0644))
+	}
+	return root
+}
+
+func readAttr(t *testing.T, root, attr string) string {
+	data, err := os.ReadFile(filepath.Join(root, fmt.Sprintf(queueDirTmpl, testDevice), attr))
+	assert.Nil(t, err)
+	return string(data)
+}
+
+func TestBlockTuning_Apply(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		root := prepareSysfs(t, map[string]string{
+			common.Scheduler:   "[mq-deadline] kyber none",
+			common.ReadAheadKB: "128",
+			common.WbtLatUsec:  "75000",
+		})
+
+		previous, err := NewBlockTuning(root).Apply(testDevice, []common.Setting{
+			{Attribute: common.Scheduler, Value: "none"},
+			{Attribute: common.ReadAheadKB, Value: "128"},
+			{Attribute: common.WbtLatUsec, Value: "0"},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]string{common.Scheduler: "mq-deadline", common.WbtLatUsec: "75000"}, previous)
+		assert.Equal(t, "none", readAttr(t, root, common.Scheduler))
+		assert.Equal(t, "0", readAttr(t, root, common.WbtLatUsec))
+		assert.Equal(t, "128\n", readAttr(t, root, common.ReadAheadKB))
+	})
+	t.Run("attribute doesn't exist", func(t *testing.T) {
+		root := prepareSysfs(t, map[string]string{common.NrRequests: "64"})
+
+		previous, err := NewBlockTuning(root).Apply(testDevice, []common.Setting{
+			{Attribute: common.NrRequests, Value: "256"},
+			{Attribute: common.MaxSectorsKB, Value: "512"},
+		})
+		assert.NotNil(t, err)
+		assert.Equal(t, map[string]string{common.NrRequests: "64"}, previous)
+	})
+}
+
+func TestBlockTuning_Restore(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		root := prepareSysfs(t, map[string]string{
+			common.Scheduler:  "[none] mq-deadline",
+			common.NrRequests: "256",
+		})
+
+		err := NewBlockTuning(root).Restore(testDevice, map[string]string{
+			common.Scheduler:  "mq-deadline",
+			common.NrRequests: "64",
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "mq-deadline", readAttr(t, root, common.Scheduler))
+		assert.Equal(t, "64", readAttr(t, root, common.NrRequests))
+	})
+	t.Run("fail", func(t *testing.T) {
+		root := prepareSysfs(t, map[string]string{common.NrRequests: "256"})
+
+		err := NewBlockTuning(root).Restore(testDevice, map[string]string{
+			common.NrRequests: "64",
+			common.WbtLatUsec: common.DefaultWbtLatUsec,
+		})
+		assert.NotNil(t, err)
+		assert.Equal(t, "64", readAttr(t, root, common.NrRequests))
+	})
+}
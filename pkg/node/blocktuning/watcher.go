@@ -0,0 +1,164 @@
+package blocktuning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	k8sClient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dell/csi-baremetal/pkg/eventing"
+	"github.com/dell/csi-baremetal/pkg/events"
+	"github.com/dell/csi-baremetal/pkg/node"
+	"github.com/dell/csi-baremetal/pkg/node/blocktuning/common"
+)
+
+const (
+	profilesPath = "/etc/node_config/block-tuning.yaml"
+	// legacy WBT configuration, converted to the profile with common.LegacyWbtProfileName
+	wbtConfPath    = "/etc/node_config/wbt-config.yaml"
+	wbtKernelsPath = "/etc/node_config/wbt-acceptable_kernels.yaml"
+
+	watchTimeout = 60 * time.Second
+
+	podNameEnv      = "POD_NAME"
+	podNamespaceEnv = "NAMESPACE"
+)
+
+// errWbtConfig is returned when legacy WBT configuration can't be read
+var errWbtConfig = errors.New("unable to read WBT ConfigMap")
+
+// ConfWatcher is watcher to update block tuning profiles in VolumeManager
+type ConfWatcher struct {
+	client            k8sClient.Client
+	eventsRecorder    *events.Recorder
+	log               *logrus.Entry
+	nodeKernelVersion string
+
+	profilesPath   string
+	wbtConfPath    string
+	wbtKernelsPath string
+}
+
+// NewConfWatcher create new block tuning Config Watcher with node kernel version
+func NewConfWatcher(client k8sClient.Client, eventsRecorder *events.Recorder, log *logrus.Entry, nodeKernelVersion string) *ConfWatcher {
+	return &ConfWatcher{
+		client:            client,
+		eventsRecorder:    eventsRecorder,
+		log:               log,
+		nodeKernelVersion: nodeKernelVersion,
+		profilesPath:      profilesPath,
+		wbtConfPath:       wbtConfPath,
+		wbtKernelsPath:    wbtKernelsPath,
+	}
+}
+
+// StartWatch tries to read block tuning profiles from ConfigMap
+// Set conf in VolumeManager if success, profiles are disabled otherwise
+func (w *ConfWatcher) StartWatch(cns *node.CSINodeService) {
+	go func() {
+		for {
+			conf, err := w.readConfig()
+			if err != nil {
+				w.log.Errorf("unable to read block tuning config: %+v", err)
+				event := eventing.BlockTuningConfigUpdateFailed
+				if errors.Is(err, errWbtConfig) {
+					event = eventing.WBTConfigMapUpdateFailed
+				}
+				w.sendErrorConfigmapEvent(event)
+				cns.SetBlockTuningConfig(&common.Config{})
+			} else {
+				cns.SetBlockTuningConfig(conf)
+			}
+			time.Sleep(watchTimeout)
+		}
+	}()
+}
+
+// readConfig reads profiles and appends legacy WBT profile to them, both files are optional
+func (w *ConfWatcher) readConfig() (*common.Config, error) {
+	conf := &common.Config{}
+	data, err := ioutil.ReadFile(w.profilesPath)
+	switch {
+	case err == nil:
+		if err = yaml.Unmarshal(data, conf); err != nil {
+			return nil, err
+		}
+	case !os.IsNotExist(err):
+		return nil, err
+	}
+
+	wbtProfile, err := w.readWbtProfile()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errWbtConfig, err)
+	}
+	if wbtProfile != nil {
+		conf.Profiles = append(conf.Profiles, *wbtProfile)
+	}
+
+	if err = conf.Validate(); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// readWbtProfile reads WBT ConfigMap, returns nil if it doesn't exist or node kernel isn't acceptable
+func (w *ConfWatcher) readWbtProfile() (*common.Profile, error) {
+	confFile, err := ioutil.ReadFile(w.wbtConfPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	conf := &common.WbtConfig{}
+	if err = yaml.Unmarshal(confFile, conf); err != nil {
+		return nil, err
+	}
+
+	kernels := &common.AcceptableKernelsConfig{}
+	kernelsFile, err := ioutil.ReadFile(w.wbtKernelsPath)
+	if err != nil {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(kernelsFile, kernels); err != nil {
+		return nil, err
+	}
+
+	if !kernels.EnableForAll {
+		isKernelInList := false
+		for _, kernelVersion := range kernels.KernelVersions {
+			if kernelVersion == w.nodeKernelVersion {
+				isKernelInList = true
+				break
+			}
+		}
+		if !isKernelInList {
+			return nil, nil
+		}
+	}
+
+	return conf.ToProfile(), nil
+}
+
+func (w *ConfWatcher) sendErrorConfigmapEvent(event *eventing.EventDescription) {
+	podName := os.Getenv(podNameEnv)
+	podNamespace := os.Getenv(podNamespaceEnv)
+
+	ctx := context.Background()
+
+	pod := &corev1.Pod{}
+	if err := w.client.Get(ctx, k8sClient.ObjectKey{Name: podName, Namespace: podNamespace}, pod); err != nil {
+		w.log.Errorf("Failed to get Pod %s in Namespace %s: %+v", podName, podNamespace, err)
+		return
+	}
+
+	w.eventsRecorder.Eventf(pod, event,
+		"Failed to get info from Node ConfigMap")
+}
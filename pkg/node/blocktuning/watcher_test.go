@@ -0,0 +1,51 @@
+package blocktuning
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareWatcher(t *testing.T, profiles, wbtConf string) *ConfWatcher {
+	dir := t.TempDir()
+	w := &ConfWatcher{
+		profilesPath:   filepath.Join(dir, "block-tuning.yaml"),
+		wbtConfPath:    filepath.Join(dir, "wbt-config.yaml"),
+		wbtKernelsPath: filepath.Join(dir, "wbt-acceptable_kernels.yaml"),
+	}
+	if profiles != "" {
+		assert.Nil(t, ioutil.WriteFile(w.profilesPath, []byte(profiles), 0600))
+	}
+	if wbtConf != "" {
+		assert.Nil(t, ioutil.WriteFile(w.wbtConfPath, []byte(wbtConf), 0600))
+	}
+	assert.Nil(t, ioutil.WriteFile(w.wbtKernelsPath, []byte("enable_for_all: true\n"), 0600))
+	return w
+}
+
+func TestConfWatcher_readConfig(t *testing.T) {
+	wbtConf := "enable: true\nwbt_lat_usec_value: 0\nacceptable_volume_options:\n  modes: [FS]\n  storage_classes: [csi-baremetal-sc-hdd]\n"
+
+	t.Run("Profiles and WBT ConfigMap", func(t *testing.T) {
+		w := prepareWatcher(t, "profiles:\n  - name: nvme\n    settings:\n      scheduler: none\n", wbtConf)
+		conf, err := w.readConfig()
+		assert.Nil(t, err)
+		assert.Len(t, conf.Profiles, 2)
+	})
+
+	t.Run("Invalid profiles", func(t *testing.T) {
+		w := prepareWatcher(t, "profiles: [", wbtConf)
+		_, err := w.readConfig()
+		assert.NotNil(t, err)
+		assert.False(t, errors.Is(err, errWbtConfig))
+	})
+
+	t.Run("Invalid WBT ConfigMap", func(t *testing.T) {
+		w := prepareWatcher(t, "", "enable: [")
+		_, err := w.readConfig()
+		assert.True(t, errors.Is(err, errWbtConfig))
+	})
+}
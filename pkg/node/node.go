@@ -37,18 +37,22 @@ import (
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
 	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/cache"
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	baseerr "github.com/dell/csi-baremetal/pkg/base/error"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	"github.com/dell/csi-baremetal/pkg/common"
 	"github.com/dell/csi-baremetal/pkg/controller"
 	"github.com/dell/csi-baremetal/pkg/controller/mountoptions"
 	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/node/common"
 	"github.com/dell/csi-baremetal/pkg/eventing"
+	tuningconf "github.com/dell/csi-baremetal/pkg/node/blocktuning/common"
+	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
 )
 
 const (
@@ -56,9 +60,6 @@ const (
 
 	fakeAttachVolumeAnnotation = "fake-attach"
 	fakeAttachVolumeKey        = "yes"
-
-	wbtChangedVolumeAnnotation = "wbt-changed"
-	wbtChangedVolumeKey        = "yes"
 )
 
 // CSINodeService is the implementation of NodeServer interface from GO CSI specification.
@@ -245,17 +246,20 @@ func (s *CSINodeService) NodeStageVolume(ctx context.Context, req *csi.NodeStage
 			"Fake-attach cleared for volume with ID %s", volumeID)
 	}
 
-	if newStatus == apiV1.VolumeReady && s.VolumeManager.checkWbtChangingEnable(ctx, volumeCR) {
-		if err := s.VolumeManager.setWbtValue(volumeCR); err != nil {
-			ll.Errorf("Unable to set custom WBT value for volume %s: %v", volumeCR.Name, err)
-			s.VolumeManager.recorder.Eventf(volumeCR, eventing.WBTValueSetFailed,
-				"Unable to set custom WBT value for volume %s", volumeCR.Name)
-		} else {
-			volumeCR.Annotations[wbtChangedVolumeAnnotation] = wbtChangedVolumeKey
+	tuned := volumeCR.Spec.BlockTuning != nil
+	if newStatus == apiV1.VolumeReady && !tuned && !isFakeAttachNeed {
+		if profile, err := s.VolumeManager.applyBlockTuning(ctx, volumeCR); err != nil {
+			ll.Errorf("Unable to apply block tuning profile %s for volume %s: %v", profile, volumeCR.Name, err)
+			event := eventing.BlockTuningSetFailed
+			if profile == tuningconf.LegacyWbtProfileName {
+				event = eventing.WBTValueSetFailed
+			}
+			s.VolumeManager.recorder.Eventf(volumeCR, event,
+				"Unable to apply block tuning for volume %s", volumeCR.Name)
 		}
 	}
 
-	if currStatus != apiV1.VolumeReady {
+	if currStatus != apiV1.VolumeReady || (!tuned && volumeCR.Spec.BlockTuning != nil) {
 		volumeCR.Spec.CSIStatus = newStatus
 		if err := s.k8sClient.UpdateCR(ctx, volumeCR); err != nil {
 			ll.Errorf("Unable to set volume status to %s: %v", newStatus, err)
@@ -335,13 +339,14 @@ func (s *CSINodeService) NodeUnstageVolume(ctx context.Context, req *csi.NodeUns
 		}
 	}
 
-	if val, ok := volumeCR.Annotations[wbtChangedVolumeAnnotation]; ok && val == wbtChangedVolumeKey {
-		delete(volumeCR.Annotations, wbtChangedVolumeAnnotation)
-		if err := s.VolumeManager.restoreWbtValue(volumeCR); err != nil {
-			ll.Errorf("Unable to restore WBT value for volume %s: %v", volumeCR.Name, err)
-			s.VolumeManager.recorder.Eventf(volumeCR, eventing.WBTValueSetFailed,
-				"Unable to restore WBT value for volume %s", volumeCR.Name)
-		}
+	restoreFailedEvent := eventing.BlockTuningRestoreFailed
+	if isLegacyWbtTuning(volumeCR) {
+		restoreFailedEvent = eventing.WBTValueRestoreFailed
+	}
+	if err := s.VolumeManager.restoreBlockTuning(ctx, volumeCR); err != nil {
+		ll.Errorf("Unable to restore block tuning for volume %s: %v", volumeCR.Name, err)
+		s.VolumeManager.recorder.Eventf(volumeCR, restoreFailedEvent,
+			"Unable to restore block tuning for volume %s", volumeCR.Name)
 	}
 
 	ctxWithID := context.WithValue(context.Background(), base.RequestUUID, req.GetVolumeId())
@@ -463,6 +468,15 @@ func (s *CSINodeService) NodePublishVolume(ctx context.Context, req *csi.NodePub
 		}
 	}
 
+	if newStatus == apiV1.Published && !util.IsIOLimitsEmpty(volumeCR.Spec.IoLimits) &&
+		volumeCR.Annotations[fakeAttachVolumeAnnotation] != fakeAttachVolumeKey {
+		if err := s.VolumeManager.setIOLimits(volumeCR, req.GetVolumeContext()[util.PodUIDKey]); err != nil {
+			ll.Errorf("Unable to set IO limits for volume %s: %v", volumeCR.Name, err)
+			s.VolumeManager.recorder.Eventf(volumeCR, eventing.IOLimitsSetFailed,
+				"Unable to set IO limits for volume %s: %v", volumeCR.Name, err)
+		}
+	}
+
 	var podName string
 	podName, ok := req.VolumeContext[util.PodNameKey]
 	if !ok {
@@ -605,6 +619,11 @@ func (s *CSINodeService) NodeUnpublishVolume(ctx context.Context, req *csi.NodeU
 	}
 
 	volumeCR.Spec.Owners = nil
+	if err := s.VolumeManager.removeIOLimits(volumeCR); err != nil {
+		ll.Errorf("Unable to remove IO limits for volume %s: %v", volumeCR.Name, err)
+		s.VolumeManager.recorder.Eventf(volumeCR, eventing.IOLimitsRemoveFailed,
+			"Unable to remove IO limits for volume %s: %v", volumeCR.Name, err)
+	}
 	// k8s doesn't call DeleteVolume for inline volumes, so we perform DeleteVolume operation in Unpublish request
 	if volumeCR.Spec.Ephemeral {
 		s.reqMu.Lock()
@@ -639,18 +658,194 @@ func (s *CSINodeService) NodeUnpublishVolume(ctx context.Context, req *csi.NodeU
 	return &csi.NodeUnpublishVolumeResponse{}, nil
 }
 
-// NodeGetVolumeStats returns empty response
-func (s *CSINodeService) NodeGetVolumeStats(_ context.Context, _ *csi.NodeGetVolumeStatsRequest) (*csi.NodeGetVolumeStatsResponse, error) {
-	return &csi.NodeGetVolumeStatsResponse{}, nil
+// NodeGetVolumeStats is the implementation of CSI Spec NodeGetVolumeStats.
+// For volumes in FS mode this method returns capacity and inodes usage of the file system mounted to VolumePath,
+// for RAW and RAW_PART volumes it returns size of the underlying block device.
+// Volume condition is calculated based on Health and OperationalStatus of the volume CR.
+// Receives golang context and CSI Spec NodeGetVolumeStatsRequest
+// Returns CSI Spec NodeGetVolumeStatsResponse or error if something went wrong
+func (s *CSINodeService) NodeGetVolumeStats(ctx context.Context, req *csi.NodeGetVolumeStatsRequest) (*csi.NodeGetVolumeStatsResponse, error) {
+	ll := s.log.WithFields(logrus.Fields{
+		"method":   "NodeGetVolumeStats",
+		"volumeID": req.GetVolumeId(),
+	})
+
+	// Check arguments
+	if len(req.GetVolumeId()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume ID missing in request")
+	}
+	if len(req.GetVolumePath()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume Path missing in request")
+	}
+
+	volumeCR, err := s.crHelper.GetVolumeByID(req.GetVolumeId())
+	if err != nil {
+		message := fmt.Sprintf("Unable to find volume with ID %s", req.GetVolumeId())
+		ll.Error(message)
+		return nil, status.Error(codes.NotFound, message)
+	}
+
+	resp := &csi.NodeGetVolumeStatsResponse{
+		VolumeCondition: common.GetVolumeCondition(&volumeCR.Spec),
+	}
+
+	// fake-attached volume is represented by tmpfs on node, there is no real usage to report
+	if volumeCR.Annotations[fakeAttachVolumeAnnotation] == fakeAttachVolumeKey {
+		resp.VolumeCondition = &csi.VolumeCondition{
+			Abnormal: true,
+			Message:  fmt.Sprintf("volume %s is fake-attached", volumeCR.Name),
+		}
+		return resp, nil
+	}
+
+	if volumeCR.Spec.Mode == apiV1.ModeRAW || volumeCR.Spec.Mode == apiV1.ModeRAWPART {
+		device, err := s.getProvisionerForVolume(&volumeCR.Spec).GetVolumePath(&volumeCR.Spec)
+		if err != nil {
+			ll.Errorf("Unable to determine device for volume: %v", err)
+			return nil, status.Error(codes.Internal, "unable to determine device for volume")
+		}
+		bdevs, err := s.listBlk.GetBlockDevices(device)
+		if err != nil || len(bdevs) == 0 {
+			ll.Errorf("Unable to get size of device %s: %v", device, err)
+			return nil, status.Error(codes.Internal, fmt.Sprintf("unable to get size of device %s", device))
+		}
+		resp.Usage = []*csi.VolumeUsage{
+			{
+				Total: bdevs[0].Size.Int64,
+				Unit:  csi.VolumeUsage_BYTES,
+			},
+		}
+		return resp, nil
+	}
+
+	stats, err := s.fsOps.GetFSStats(req.GetVolumePath())
+	if err != nil {
+		ll.Errorf("Unable to get file system stats for %s: %v", req.GetVolumePath(), err)
+		return nil, status.Error(codes.Internal, fmt.Sprintf("unable to get file system stats for %s", req.GetVolumePath()))
+	}
+	resp.Usage = []*csi.VolumeUsage{
+		{
+			Available: stats.AvailableBytes,
+			Total:     stats.TotalBytes,
+			Used:      stats.UsedBytes,
+			Unit:      csi.VolumeUsage_BYTES,
+		},
+		{
+			Available: stats.AvailableInodes,
+			Total:     stats.TotalInodes,
+			Used:      stats.UsedInodes,
+			Unit:      csi.VolumeUsage_INODES,
+		},
+	}
+
+	ll.Debugf("Volume usage: %v", resp.Usage)
+	return resp, nil
+}
+
+// NodeExpandVolume is the implementation of CSI Spec NodeExpandVolume.
+// LVG based volumes are expanded by VolumeManager with lvextend, so for them this method only returns volume size.
+// For drive based volumes in Resizing status it grows partition up to the end of the drive and then
+// grows file system online, after that it sets volume CR status to Resized or Failed and updates related CRs.
+// Receives golang context and CSI Spec NodeExpandVolumeRequest
+// Returns CSI Spec NodeExpandVolumeResponse or error if something went wrong
+func (s *CSINodeService) NodeExpandVolume(ctx context.Context, req *csi.NodeExpandVolumeRequest) (*csi.NodeExpandVolumeResponse, error) {
+	ll := s.log.WithFields(logrus.Fields{
+		"method":   "NodeExpandVolume",
+		"volumeID": req.GetVolumeId(),
+	})
+	ll.Infof("Processing request: %v", req)
+
+	// Check arguments
+	if len(req.GetVolumeId()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume ID missing in request")
+	}
+	if len(req.GetVolumePath()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Volume Path missing in request")
+	}
+
+	s.volMu.LockKey(req.GetVolumeId())
+	defer func() {
+		if err := s.volMu.UnlockKey(req.GetVolumeId()); err != nil {
+			ll.Warnf("Unlocking volume with error %s", err)
+		}
+	}()
+
+	volumeCR, err := s.crHelper.GetVolumeByID(req.GetVolumeId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "Unable to find volume")
+	}
+
+	if util.IsStorageClassLVG(volumeCR.Spec.StorageClass) {
+		ll.Debugf("Volume is LVG based, it was expanded by VolumeManager")
+		return &csi.NodeExpandVolumeResponse{CapacityBytes: volumeCR.Spec.Size}, nil
+	}
+
+	if volumeCR.Spec.CSIStatus != apiV1.Resizing {
+		if volumeCR.Spec.Size < req.GetCapacityRange().GetRequiredBytes() {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"volume in status %s can't be expanded", volumeCR.Spec.CSIStatus)
+		}
+		ll.Debugf("Volume is already expanded")
+		return &csi.NodeExpandVolumeResponse{CapacityBytes: volumeCR.Spec.Size}, nil
+	}
+
+	ctxWithID := context.WithValue(ctx, base.RequestUUID, req.GetVolumeId())
+	expandErr := s.expandDriveVolume(volumeCR, req.GetVolumePath())
+	if expandErr != nil {
+		ll.Errorf("Unable to expand volume: %v", expandErr)
+		volumeCR.Spec.CSIStatus = apiV1.Failed
+	} else {
+		volumeCR.Spec.CSIStatus = apiV1.Resized
+	}
+	if err = s.k8sClient.UpdateCRWithAttempts(ctxWithID, volumeCR, 5); err != nil {
+		ll.Errorf("Unable to set volume CR status to %s: %v", volumeCR.Spec.CSIStatus, err)
+		return nil, status.Error(codes.Internal, "unable to update volume")
+	}
+
+	s.reqMu.Lock()
+	s.svc.UpdateCRsAfterVolumeExpansion(ctxWithID, req.GetVolumeId(), volumeCR.Spec.Size)
+	s.reqMu.Unlock()
+
+	if expandErr != nil {
+		return nil, status.Error(codes.Internal, "unable to expand volume")
+	}
+
+	ll.Infof("Volume was expanded to %d bytes", volumeCR.Spec.Size)
+	return &csi.NodeExpandVolumeResponse{CapacityBytes: volumeCR.Spec.Size}, nil
 }
 
-// NodeExpandVolume returns empty response
-func (s *CSINodeService) NodeExpandVolume(_ context.Context, _ *csi.NodeExpandVolumeRequest) (*csi.NodeExpandVolumeResponse, error) {
-	return &csi.NodeExpandVolumeResponse{}, nil
+// expandDriveVolume grows partition of drive based volume and then grows file system mounted to volumePath
+// RAW volume occupies the whole drive, so there is nothing to grow for it
+func (s *CSINodeService) expandDriveVolume(volume *volumecrd.Volume, volumePath string) error {
+	if volume.Spec.Mode == apiV1.ModeRAW {
+		return nil
+	}
+
+	drive, err := s.crHelper.GetDriveCRByVolume(volume)
+	if err != nil {
+		return fmt.Errorf("unable to read drive %s: %v", volume.Spec.Location, err)
+	}
+	device, err := s.listBlk.SearchDrivePath(&drive.Spec)
+	if err != nil {
+		return fmt.Errorf("unable to find device for drive %s: %v", drive.Name, err)
+	}
+	if err = s.partOps.GrowPartition(device, p.DefaultPartitionNumber); err != nil {
+		return err
+	}
+
+	if volume.Spec.Mode != apiV1.ModeFS {
+		return nil
+	}
+	partPath, err := s.getProvisionerForVolume(&volume.Spec).GetVolumePath(&volume.Spec)
+	if err != nil {
+		return fmt.Errorf("unable to determine partition of volume: %v", err)
+	}
+	return s.fsOps.GrowFS(fs.FileSystem(volume.Spec.Type), partPath, volumePath)
 }
 
 // NodeGetCapabilities is the implementation of CSI Spec NodeGetCapabilities.
-// Provides Node capabilities of CSI driver to k8s. STAGE/UNSTAGE Volume for now.
+// Provides Node capabilities of CSI driver to k8s: STAGE/UNSTAGE Volume, GET_VOLUME_STATS, VOLUME_CONDITION
+// and EXPAND_VOLUME.
 // Receives golang context and CSI Spec NodeGetCapabilitiesRequest
 // Returns CSI Spec NodeGetCapabilitiesResponse and nil error
 func (s *CSINodeService) NodeGetCapabilities(_ context.Context, _ *csi.NodeGetCapabilitiesRequest) (*csi.NodeGetCapabilitiesResponse, error) {
@@ -661,6 +856,27 @@ func (s *CSINodeService) NodeGetCapabilities(_ context.Context, _ *csi.NodeGetCa
 					Type: csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME,
 				},
 			},
+		},
+		{
+			Type: &csi.NodeServiceCapability_Rpc{
+				Rpc: &csi.NodeServiceCapability_RPC{
+					Type: csi.NodeServiceCapability_RPC_GET_VOLUME_STATS,
+				},
+			},
+		},
+		{
+			Type: &csi.NodeServiceCapability_Rpc{
+				Rpc: &csi.NodeServiceCapability_RPC{
+					Type: csi.NodeServiceCapability_RPC_VOLUME_CONDITION,
+				},
+			},
+		},
+		{
+			Type: &csi.NodeServiceCapability_Rpc{
+				Rpc: &csi.NodeServiceCapability_RPC{
+					Type: csi.NodeServiceCapability_RPC_EXPAND_VOLUME,
+				},
+			},
 		}},
 	}, nil
 }
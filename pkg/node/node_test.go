@@ -40,22 +40,25 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/featureconfig"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
 	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/controller/mountoptions"
 	csibmnodeconst "github.com/dell/csi-baremetal/pkg/crcontrollers/node/common"
 	"github.com/dell/csi-baremetal/pkg/mocks"
 	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
 	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
+	tuningcommon "github.com/dell/csi-baremetal/pkg/node/blocktuning/common"
 	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
-	wbtcommon "github.com/dell/csi-baremetal/pkg/node/wbt/common"
 	"github.com/dell/csi-baremetal/pkg/testutils"
 )
 
 var (
-	node   *CSINodeService
-	prov   *mockProv.MockProvisioner
-	fsOps  *mockProv.MockFsOpts
-	volOps *mocks.VolumeOperationsMock
-	wbtOps *mocklu.MockWrapWbt
+	node      *CSINodeService
+	prov      *mockProv.MockProvisioner
+	fsOps     *mockProv.MockFsOpts
+	volOps    *mocks.VolumeOperationsMock
+	tuningOps *mocklu.MockWrapBlockTuning
 )
 
 func setVariables() {
@@ -63,14 +66,14 @@ func setVariables() {
 	prov = &mockProv.MockProvisioner{}
 	fsOps = &mockProv.MockFsOpts{}
 	volOps = &mocks.VolumeOperationsMock{}
-	wbtOps = &mocklu.MockWrapWbt{}
+	tuningOps = &mocklu.MockWrapBlockTuning{}
 	node.provisioners = map[p.VolumeType]p.Provisioner{
 		p.DriveBasedVolumeType: prov,
 		p.LVMBasedVolumeType:   prov,
 	}
 	node.fsOps = fsOps
 	node.svc = volOps
-	node.wbtOps = wbtOps
+	node.tuningOps = tuningOps
 }
 
 func TestCSINodeService(t *testing.T) {
@@ -89,7 +92,7 @@ var _ = Describe("CSINodeService NodePublish()", func() {
 			req.VolumeContext[util.PodNameKey] = testPodName
 
 			fsOps.On("PrepareAndPerformMount",
-				path.Join(req.GetStagingTargetPath(), stagingFileName), req.GetTargetPath(), false, true).
+				path.Join(req.GetStagingTargetPath(), stagingFileName), req.GetTargetPath(), false, true, mock.Anything).
 				Return(nil)
 
 			resp, err := node.NodePublishVolume(testCtx, req)
@@ -113,6 +116,102 @@ var _ = Describe("CSINodeService NodePublish()", func() {
 			Expect(err).To(BeNil())
 			Expect(len(volumeCR.Spec.Owners)).To(Equal(1))
 		})
+		It("Should pass file system mount options to the file system mount", func() {
+			defer mountoptions.ResetSupportedOptions()
+			Expect(mountoptions.SetSupportedOptions([]mountoptions.MountOption{
+				{Name: "discard"},
+				{Name: "logbufs=", FileSystems: []string{"xfs"}},
+			})).To(BeNil())
+			volumeCap := csi.VolumeCapability{
+				AccessType: &csi.VolumeCapability_Mount{
+					Mount: &csi.VolumeCapability_MountVolume{FsType: "xfs", MountFlags: []string{"discard", "logbufs=8"}},
+				},
+			}
+
+			// volume device is bind mounted on stage, file system options don't have effect there
+			stageReq := getNodeStageRequest(testVolume2.Id, volumeCap)
+			partitionPath := "/partition/path/for/volume1"
+			prov.On("GetVolumePath", &testVolume2).Return(partitionPath, nil)
+			fsOps.On("PrepareAndPerformMount",
+				partitionPath, path.Join(stageReq.GetStagingTargetPath(), stagingFileName), true, false, []string(nil)).
+				Return(nil)
+			stageResp, err := node.NodeStageVolume(testCtx, stageReq)
+			Expect(stageResp).NotTo(BeNil())
+			Expect(err).To(BeNil())
+
+			// file system is mounted on publish
+			req := getNodePublishRequest(testVolume2.Id, targetPath, volumeCap)
+			fsOps.On("PrepareAndPerformMount",
+				path.Join(req.GetStagingTargetPath(), stagingFileName), req.GetTargetPath(), false, true,
+				[]string{"discard", "logbufs=8"}).
+				Return(nil)
+			resp, err := node.NodePublishVolume(testCtx, req)
+			Expect(resp).NotTo(BeNil())
+			Expect(err).To(BeNil())
+			fsOps.AssertExpectations(GinkgoT())
+		})
+		It("Should publish volume and apply IO limits to the pod cgroup", func() {
+			var (
+				cgroupOps     = &mocklu.MockWrapCgroup{}
+				limits        = &api.IOLimits{ReadIOPS: 100, Weight: 50}
+				podUID        = "pod-uid"
+				podCgroup     = "/sys/fs/cgroup/kubepods/podpod-uid"
+				partitionPath = "/partition/path/for/volume1"
+				volumeCR      = &vcrd.Volume{}
+			)
+			node.cgroupOps = cgroupOps
+			Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", volumeCR)).To(BeNil())
+			volumeCR.Spec.IoLimits = limits
+			Expect(node.k8sClient.UpdateCR(testCtx, volumeCR)).To(BeNil())
+
+			req := getNodePublishRequest(testV1ID, targetPath, *testVolumeCap)
+			req.VolumeContext[util.PodUIDKey] = podUID
+			fsOps.On("PrepareAndPerformMount", mock.Anything, mock.Anything, false, true, mock.Anything).Return(nil)
+			prov.On("GetVolumePath", mock.Anything).Return(partitionPath, nil)
+			cgroupOps.On("GetPodCgroupPath", podUID).Return(podCgroup, nil)
+			cgroupOps.On("GetDeviceNumber", partitionPath).Return("8:17", nil)
+			cgroupOps.On("SetIOLimits", podCgroup, "8:17", limits).Return(limits, nil)
+
+			resp, err := node.NodePublishVolume(testCtx, req)
+			Expect(resp).NotTo(BeNil())
+			Expect(err).To(BeNil())
+
+			volumeCR = &vcrd.Volume{}
+			Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", volumeCR)).To(BeNil())
+			Expect(volumeCR.Spec.AppliedIoLimits).To(Equal(limits))
+			Expect(volumeCR.Annotations[ioLimitsCgroupAnnotation]).To(Equal(podCgroup))
+
+			// limits are removed on unpublish
+			fsOps.On("UnmountWithCheck", targetPath).Return(nil)
+			cgroupOps.On("RemoveIOLimits", podCgroup, "8:17").Return(nil).Once()
+			_, err = node.NodeUnpublishVolume(testCtx, getNodeUnpublishRequest(testV1ID, targetPath))
+			Expect(err).To(BeNil())
+
+			volumeCR = &vcrd.Volume{}
+			Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", volumeCR)).To(BeNil())
+			Expect(volumeCR.Spec.AppliedIoLimits).To(BeNil())
+			Expect(volumeCR.Annotations).NotTo(HaveKey(ioLimitsCgroupAnnotation))
+			cgroupOps.AssertExpectations(GinkgoT())
+		})
+		It("Should publish volume when IO limits can't be applied", func() {
+			volumeCR := &vcrd.Volume{}
+			Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", volumeCR)).To(BeNil())
+			volumeCR.Spec.IoLimits = &api.IOLimits{WriteBPS: 1024}
+			Expect(node.k8sClient.UpdateCR(testCtx, volumeCR)).To(BeNil())
+
+			// pod UID isn't provided
+			req := getNodePublishRequest(testV1ID, targetPath, *testVolumeCap)
+			fsOps.On("PrepareAndPerformMount", mock.Anything, mock.Anything, false, true, mock.Anything).Return(nil)
+
+			resp, err := node.NodePublishVolume(testCtx, req)
+			Expect(resp).NotTo(BeNil())
+			Expect(err).To(BeNil())
+
+			volumeCR = &vcrd.Volume{}
+			Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", volumeCR)).To(BeNil())
+			Expect(volumeCR.Spec.CSIStatus).To(Equal(apiV1.Published))
+			Expect(volumeCR.Spec.AppliedIoLimits).To(BeNil())
+		})
 	})
 
 	Context("NodePublish() failure", func() {
@@ -186,7 +285,7 @@ var _ = Describe("CSINodeService NodePublish()", func() {
 			req := getNodePublishRequest(testV1ID, targetPath, *testVolumeCap)
 
 			fsOps.On("PrepareAndPerformMount",
-				path.Join(req.GetStagingTargetPath(), stagingFileName), req.GetTargetPath(), false, true).
+				path.Join(req.GetStagingTargetPath(), stagingFileName), req.GetTargetPath(), false, true, mock.Anything).
 				Return(errors.New("error mount"))
 
 			resp, err := node.NodePublishVolume(testCtx, req)
@@ -209,7 +308,7 @@ var _ = Describe("CSINodeService NodeStage()", func() {
 			partitionPath := "/partition/path/for/volume1"
 			prov.On("GetVolumePath", &testVolume2).Return(partitionPath, nil)
 			fsOps.On("PrepareAndPerformMount",
-				partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false).
+				partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false, mock.Anything).
 				Return(nil)
 
 			resp, err := node.NodeStageVolume(testCtx, req)
@@ -230,7 +329,7 @@ var _ = Describe("CSINodeService NodeStage()", func() {
 			partitionPath := "/partition/path/for/volume1"
 			prov.On("GetVolumePath", &vol1.Spec).Return(partitionPath, nil)
 			fsOps.On("PrepareAndPerformMount",
-				partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false).
+				partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false, mock.Anything).
 				Return(nil)
 
 			resp, err := node.NodeStageVolume(testCtx, req)
@@ -296,7 +395,7 @@ var _ = Describe("CSINodeService NodeStage()", func() {
 			partitionPath := "/partition/path/for/volume1"
 			prov.On("GetVolumePath", &testVolume2).Return(partitionPath, nil)
 			fsOps.On("PrepareAndPerformMount",
-				partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false).
+				partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false, mock.Anything).
 				Return(errors.New("PrepareAndPerformMount error"))
 
 			resp, err := node.NodeStageVolume(testCtx, req)
@@ -313,7 +412,7 @@ var _ = Describe("CSINodeService NodeStage()", func() {
 			partitionPath := "/partition/path/for/volume1"
 			prov.On("GetVolumePath", &vol1.Spec).Return(partitionPath, nil)
 			fsOps.On("PrepareAndPerformMount",
-				partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false).
+				partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false, mock.Anything).
 				Return(errors.New("mount error"))
 
 			resp, err := node.NodeStageVolume(testCtx, req)
@@ -565,20 +664,230 @@ var _ = Describe("CSINodeService NodeGetInfo()", func() {
 })
 
 var _ = Describe("CSINodeService NodeGetCapabilities()", func() {
-	It("Should return STAGE_UNSTAGE_VOLUME capabilities", func() {
+	It("Should return STAGE_UNSTAGE_VOLUME, GET_VOLUME_STATS, VOLUME_CONDITION and EXPAND_VOLUME capabilities", func() {
 		node := newNodeService()
 
 		resp, err := node.NodeGetCapabilities(testCtx, &csi.NodeGetCapabilitiesRequest{})
 		Expect(err).To(BeNil())
 		Expect(resp).ToNot(BeNil())
 		capabilities := resp.GetCapabilities()
-		expectedCapability := &csi.NodeServiceCapability_Rpc{
-			Rpc: &csi.NodeServiceCapability_RPC{
-				Type: csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME,
-			},
+		Expect(len(capabilities)).To(Equal(4))
+		for i, capType := range []csi.NodeServiceCapability_RPC_Type{
+			csi.NodeServiceCapability_RPC_STAGE_UNSTAGE_VOLUME,
+			csi.NodeServiceCapability_RPC_GET_VOLUME_STATS,
+			csi.NodeServiceCapability_RPC_VOLUME_CONDITION,
+			csi.NodeServiceCapability_RPC_EXPAND_VOLUME,
+		} {
+			Expect(capabilities[i].Type).To(Equal(&csi.NodeServiceCapability_Rpc{
+				Rpc: &csi.NodeServiceCapability_RPC{Type: capType},
+			}))
 		}
-		Expect(len(capabilities)).To(Equal(1))
-		Expect(capabilities[0].Type).To(Equal(expectedCapability))
+	})
+})
+
+var _ = Describe("CSINodeService NodeGetVolumeStats()", func() {
+	BeforeEach(func() {
+		setVariables()
+	})
+
+	It("Should return capacity and inodes usage for volume in FS mode", func() {
+		req := &csi.NodeGetVolumeStatsRequest{VolumeId: testV1ID, VolumePath: targetPath}
+		fsOps.On("GetFSStats", targetPath).Return(&fs.FSStats{
+			TotalBytes:      100,
+			UsedBytes:       40,
+			AvailableBytes:  60,
+			TotalInodes:     10,
+			UsedInodes:      1,
+			AvailableInodes: 9,
+		}, nil)
+
+		resp, err := node.NodeGetVolumeStats(testCtx, req)
+		Expect(err).To(BeNil())
+		Expect(resp.GetUsage()).To(Equal([]*csi.VolumeUsage{
+			{Available: 60, Total: 100, Used: 40, Unit: csi.VolumeUsage_BYTES},
+			{Available: 9, Total: 10, Used: 1, Unit: csi.VolumeUsage_INODES},
+		}))
+		Expect(resp.GetVolumeCondition().GetAbnormal()).To(BeFalse())
+	})
+
+	It("Should return device size for volume in RAW mode", func() {
+		var (
+			req    = &csi.NodeGetVolumeStatsRequest{VolumeId: testV1ID, VolumePath: targetPath}
+			device = "/dev/sda"
+			vol    = &vcrd.Volume{}
+		)
+		Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", vol)).To(BeNil())
+		vol.Spec.Mode = apiV1.ModeRAW
+		Expect(node.k8sClient.UpdateCR(testCtx, vol)).To(BeNil())
+
+		listBlk := &mocklu.MockWrapLsblk{}
+		listBlk.On("GetBlockDevices", device).
+			Return([]lsblk.BlockDevice{{Name: device, Size: lsblk.CustomInt64{Int64: 1024}}}, nil)
+		node.listBlk = listBlk
+		prov.On("GetVolumePath", &vol.Spec).Return(device, nil)
+
+		resp, err := node.NodeGetVolumeStats(testCtx, req)
+		Expect(err).To(BeNil())
+		Expect(resp.GetUsage()).To(Equal([]*csi.VolumeUsage{{Total: 1024, Unit: csi.VolumeUsage_BYTES}}))
+	})
+
+	It("Should report abnormal condition for volume with BAD health", func() {
+		var (
+			req = &csi.NodeGetVolumeStatsRequest{VolumeId: testV1ID, VolumePath: targetPath}
+			vol = &vcrd.Volume{}
+		)
+		Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", vol)).To(BeNil())
+		vol.Spec.Health = apiV1.HealthBad
+		Expect(node.k8sClient.UpdateCR(testCtx, vol)).To(BeNil())
+		fsOps.On("GetFSStats", targetPath).Return(&fs.FSStats{}, nil)
+
+		resp, err := node.NodeGetVolumeStats(testCtx, req)
+		Expect(err).To(BeNil())
+		Expect(resp.GetVolumeCondition().GetAbnormal()).To(BeTrue())
+	})
+
+	It("Should fail with missing arguments", func() {
+		_, err := node.NodeGetVolumeStats(testCtx, &csi.NodeGetVolumeStatsRequest{VolumePath: targetPath})
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+		_, err = node.NodeGetVolumeStats(testCtx, &csi.NodeGetVolumeStatsRequest{VolumeId: testV1ID})
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+
+	It("Should fail, because of volume CR isn't exist", func() {
+		req := &csi.NodeGetVolumeStatsRequest{VolumeId: "unknown-volume", VolumePath: targetPath}
+
+		_, err := node.NodeGetVolumeStats(testCtx, req)
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+
+	It("Should fail, because of GetFSStats failed", func() {
+		req := &csi.NodeGetVolumeStatsRequest{VolumeId: testV1ID, VolumePath: targetPath}
+		fsOps.On("GetFSStats", targetPath).Return(nil, errors.New("df error"))
+
+		_, err := node.NodeGetVolumeStats(testCtx, req)
+		Expect(status.Code(err)).To(Equal(codes.Internal))
+	})
+})
+
+var _ = Describe("CSINodeService NodeExpandVolume()", func() {
+	var (
+		device   = "/dev/sda"
+		partPath = "/dev/sda1"
+		capacity = int64(1024 * 1024 * 1024)
+		partOps  *mocklu.MockWrapPartition
+		listBlk  *mocklu.MockWrapLsblk
+		vol      *vcrd.Volume
+	)
+
+	BeforeEach(func() {
+		setVariables()
+		partOps = &mocklu.MockWrapPartition{}
+		listBlk = &mocklu.MockWrapLsblk{}
+		node.partOps = partOps
+		node.listBlk = listBlk
+
+		vol = &vcrd.Volume{}
+		Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", vol)).To(BeNil())
+		vol.Spec.CSIStatus = apiV1.Resizing
+		vol.Spec.Mode = apiV1.ModeFS
+		vol.Spec.Type = string(fs.XFS)
+		vol.Spec.Size = capacity
+		Expect(node.k8sClient.UpdateCR(testCtx, vol)).To(BeNil())
+
+		listBlk.On("SearchDrivePath", mock.Anything).Return(device, nil)
+		prov.On("GetVolumePath", mock.Anything).Return(partPath, nil)
+		volOps.On("UpdateCRsAfterVolumeExpansion", mock.Anything, testV1ID, capacity)
+	})
+
+	It("Should grow partition and file system", func() {
+		req := &csi.NodeExpandVolumeRequest{VolumeId: testV1ID, VolumePath: targetPath}
+		partOps.On("GrowPartition", device, p.DefaultPartitionNumber).Return(nil).Once()
+		fsOps.On("GrowFS", fs.XFS, partPath, targetPath).Return(nil).Once()
+
+		resp, err := node.NodeExpandVolume(testCtx, req)
+		Expect(err).To(BeNil())
+		Expect(resp.GetCapacityBytes()).To(Equal(capacity))
+		Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", vol)).To(BeNil())
+		Expect(vol.Spec.CSIStatus).To(Equal(apiV1.Resized))
+		partOps.AssertExpectations(GinkgoT())
+		fsOps.AssertExpectations(GinkgoT())
+		volOps.AssertExpectations(GinkgoT())
+	})
+
+	It("Should grow only partition for volume in RAW_PART mode", func() {
+		req := &csi.NodeExpandVolumeRequest{VolumeId: testV1ID, VolumePath: targetPath}
+		vol.Spec.Mode = apiV1.ModeRAWPART
+		Expect(node.k8sClient.UpdateCR(testCtx, vol)).To(BeNil())
+		partOps.On("GrowPartition", device, p.DefaultPartitionNumber).Return(nil).Once()
+
+		_, err := node.NodeExpandVolume(testCtx, req)
+		Expect(err).To(BeNil())
+		fsOps.AssertNotCalled(GinkgoT(), "GrowFS", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	It("Should skip LVG based volume", func() {
+		req := &csi.NodeExpandVolumeRequest{VolumeId: testV1ID, VolumePath: targetPath}
+		vol.Spec.StorageClass = apiV1.StorageClassHDDLVG
+		Expect(node.k8sClient.UpdateCR(testCtx, vol)).To(BeNil())
+
+		resp, err := node.NodeExpandVolume(testCtx, req)
+		Expect(err).To(BeNil())
+		Expect(resp.GetCapacityBytes()).To(Equal(capacity))
+		partOps.AssertNotCalled(GinkgoT(), "GrowPartition", mock.Anything, mock.Anything)
+	})
+
+	It("Should return size of already expanded volume", func() {
+		req := &csi.NodeExpandVolumeRequest{VolumeId: testV1ID, VolumePath: targetPath,
+			CapacityRange: &csi.CapacityRange{RequiredBytes: capacity}}
+		vol.Spec.CSIStatus = apiV1.Published
+		Expect(node.k8sClient.UpdateCR(testCtx, vol)).To(BeNil())
+
+		resp, err := node.NodeExpandVolume(testCtx, req)
+		Expect(err).To(BeNil())
+		Expect(resp.GetCapacityBytes()).To(Equal(capacity))
+
+		req.CapacityRange.RequiredBytes = capacity + 1
+		_, err = node.NodeExpandVolume(testCtx, req)
+		Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+	})
+
+	It("Should fail with missing arguments", func() {
+		_, err := node.NodeExpandVolume(testCtx, &csi.NodeExpandVolumeRequest{VolumePath: targetPath})
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+		_, err = node.NodeExpandVolume(testCtx, &csi.NodeExpandVolumeRequest{VolumeId: testV1ID})
+		Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	})
+
+	It("Should fail, because of volume CR isn't exist", func() {
+		req := &csi.NodeExpandVolumeRequest{VolumeId: "unknown-volume", VolumePath: targetPath}
+
+		_, err := node.NodeExpandVolume(testCtx, req)
+		Expect(status.Code(err)).To(Equal(codes.NotFound))
+	})
+
+	It("Should set Failed status, because of GrowFS failed", func() {
+		req := &csi.NodeExpandVolumeRequest{VolumeId: testV1ID, VolumePath: targetPath}
+		partOps.On("GrowPartition", device, p.DefaultPartitionNumber).Return(nil).Once()
+		fsOps.On("GrowFS", fs.XFS, partPath, targetPath).Return(errors.New("xfs_growfs error")).Once()
+
+		_, err := node.NodeExpandVolume(testCtx, req)
+		Expect(status.Code(err)).To(Equal(codes.Internal))
+		Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", vol)).To(BeNil())
+		Expect(vol.Spec.CSIStatus).To(Equal(apiV1.Failed))
+		volOps.AssertExpectations(GinkgoT())
+	})
+
+	It("Should set Failed status, because of GrowPartition failed", func() {
+		req := &csi.NodeExpandVolumeRequest{VolumeId: testV1ID, VolumePath: targetPath}
+		partOps.On("GrowPartition", device, p.DefaultPartitionNumber).Return(errors.New("growpart error")).Once()
+
+		_, err := node.NodeExpandVolume(testCtx, req)
+		Expect(status.Code(err)).To(Equal(codes.Internal))
+		Expect(node.k8sClient.ReadCR(testCtx, testV1ID, "", vol)).To(BeNil())
+		Expect(vol.Spec.CSIStatus).To(Equal(apiV1.Failed))
+		fsOps.AssertNotCalled(GinkgoT(), "GrowFS", mock.Anything, mock.Anything, mock.Anything)
 	})
 })
 
@@ -637,7 +946,7 @@ var _ = Describe("CSINodeService InlineVolumes", func() {
 
 			volOps.On("CreateVolume", mock.Anything, mock.Anything).Return(&createdVolCR.Spec, nil)
 			prov.On("GetVolumePath", createdVolCR.Spec).Return(srcPath, nil)
-			fsOps.On("PrepareAndPerformMount", srcPath, req.GetTargetPath(), false, true).Return(nil)
+			fsOps.On("PrepareAndPerformMount", srcPath, req.GetTargetPath(), false, true, mock.Anything).Return(nil)
 
 			resp, err := node.NodePublishVolume(testCtx, req)
 			Expect(resp).NotTo(BeNil())
@@ -755,7 +1064,7 @@ var _ = Describe("CSINodeService Fake-Attach", func() {
 		partitionPath := "/partition/path/for/volume1"
 		prov.On("GetVolumePath", &vol1.Spec).Return(partitionPath, nil)
 		fsOps.On("PrepareAndPerformMount",
-			partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false).
+			partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false, mock.Anything).
 			Return(errors.New("mount error"))
 
 		resp, err := node.NodeStageVolume(testCtx, req)
@@ -822,7 +1131,7 @@ var _ = Describe("CSINodeService Fake-Attach", func() {
 		partitionPath := "/partition/path/for/volume1"
 		prov.On("GetVolumePath", &vol1.Spec).Return(partitionPath, nil)
 		fsOps.On("PrepareAndPerformMount",
-			partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false).
+			partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false, mock.Anything).
 			Return(nil)
 
 		resp, err := node.NodeStageVolume(testCtx, req)
@@ -858,51 +1167,86 @@ var _ = Describe("CSINodeService Fake-Attach", func() {
 	})
 })
 
-var _ = Describe("CSINodeService Wbt Configuration", func() {
+var _ = Describe("CSINodeService Block Tuning", func() {
+	var (
+		volumeSC  = "csi-baremetal-sc-hdd"
+		device    = "sda" //disk1.Spec.Path = "/dev/sda"
+		readAhead = int64(4096)
+		conf      = &tuningcommon.Config{
+			Profiles: []tuningcommon.Profile{{
+				Name:           "hdd",
+				StorageClasses: []string{volumeSC},
+				Settings: tuningcommon.Settings{
+					Scheduler:   "mq-deadline",
+					ReadAheadKB: &readAhead,
+				},
+			}},
+		}
+		settings = []tuningcommon.Setting{
+			{Attribute: tuningcommon.Scheduler, Value: "mq-deadline"},
+			{Attribute: tuningcommon.ReadAheadKB, Value: "4096"},
+		}
+	)
+
 	BeforeEach(func() {
 		setVariables()
+		node.listBlk = mocklu.GetMockWrapLsblk("/dev/" + device)
 	})
 	Context("NodeStage() ", func() {
+		BeforeEach(func() {
+			pv := &corev1.PersistentVolume{}
+			pv.Name = testVolume2.Id
+			pv.Spec.StorageClassName = volumeSC
+			err := node.k8sClient.Create(testCtx, pv)
+			Expect(err).To(BeNil())
+			node.SetBlockTuningConfig(conf)
+		})
 		It("success", func() {
 			// testVolume2 has Create status
 			req := getNodeStageRequest(testVolume2.Id, *testVolumeCap)
 			partitionPath := "/partition/path/for/volume1"
 			prov.On("GetVolumePath", &testVolume2).Return(partitionPath, nil)
 			fsOps.On("PrepareAndPerformMount",
-				partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false).
+				partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false, mock.Anything).
 				Return(nil)
+			tuningOps.On("Apply", device, settings).Return(map[string]string{tuningcommon.Scheduler: "none"}, nil)
 
-			var (
-				volumeMode = ""
-				volumeSC   = "csi-baremetal-sc-hdd"
-				wbtConf    = &wbtcommon.WbtConfig{
-					Enable: true,
-					VolumeOptions: wbtcommon.VolumeOptions{
-						Modes:          []string{volumeMode},
-						StorageClasses: []string{volumeSC},
-					},
-				}
-				wbtValue uint32 = 0
-				device          = "sda" //testDrive.Spec.Path = "/dev/sda"
-			)
-			node.SetWbtConfig(wbtConf)
-			wbtOps.On("SetValue", device, wbtValue).Return(nil)
-
-			pv := &corev1.PersistentVolume{}
-			pv.Name = testVolume2.Id
-			pv.Spec.StorageClassName = volumeSC
-			err := node.k8sClient.Create(testCtx, pv)
+			resp, err := node.NodeStageVolume(testCtx, req)
+			Expect(resp).NotTo(BeNil())
+			Expect(err).To(BeNil())
+			// check volume CR status and applied tuning
+			volumeCR := &vcrd.Volume{}
+			err = node.k8sClient.ReadCR(testCtx, testVolume2.Id, "", volumeCR)
 			Expect(err).To(BeNil())
+			Expect(volumeCR.Spec.CSIStatus).To(Equal(apiV1.VolumeReady))
+			Expect(volumeCR.Spec.BlockTuning).NotTo(BeNil())
+			Expect(volumeCR.Spec.BlockTuning.Profile).To(Equal("hdd"))
+			Expect(volumeCR.Spec.BlockTuning.Device).To(Equal(device))
+			Expect(volumeCR.Spec.BlockTuning.Applied).To(Equal(map[string]string{
+				tuningcommon.Scheduler: "mq-deadline", tuningcommon.ReadAheadKB: "4096"}))
+			Expect(volumeCR.Spec.BlockTuning.Previous).To(Equal(map[string]string{tuningcommon.Scheduler: "none"}))
+		})
+		It("partially applied", func() {
+			// testVolume2 has Create status
+			req := getNodeStageRequest(testVolume2.Id, *testVolumeCap)
+			partitionPath := "/partition/path/for/volume1"
+			prov.On("GetVolumePath", &testVolume2).Return(partitionPath, nil)
+			fsOps.On("PrepareAndPerformMount",
+				partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false, mock.Anything).
+				Return(nil)
+			tuningOps.On("Apply", device, settings).
+				Return(map[string]string{tuningcommon.Scheduler: "none"}, fmt.Errorf("some err"))
 
 			resp, err := node.NodeStageVolume(testCtx, req)
 			Expect(resp).NotTo(BeNil())
 			Expect(err).To(BeNil())
-			// check volume CR status
+			// check volume CR status, changed attributes must be saved to be restored
 			volumeCR := &vcrd.Volume{}
 			err = node.k8sClient.ReadCR(testCtx, testVolume2.Id, "", volumeCR)
 			Expect(err).To(BeNil())
 			Expect(volumeCR.Spec.CSIStatus).To(Equal(apiV1.VolumeReady))
-			Expect(volumeCR.Annotations[wbtChangedVolumeAnnotation]).To(Equal(wbtChangedVolumeKey))
+			Expect(volumeCR.Spec.BlockTuning).NotTo(BeNil())
+			Expect(volumeCR.Spec.BlockTuning.Applied).To(Equal(map[string]string{tuningcommon.Scheduler: "mq-deadline"}))
 		})
 		It("failed", func() {
 			// testVolume2 has Create status
@@ -910,31 +1254,9 @@ var _ = Describe("CSINodeService Wbt Configuration", func() {
 			partitionPath := "/partition/path/for/volume1"
 			prov.On("GetVolumePath", &testVolume2).Return(partitionPath, nil)
 			fsOps.On("PrepareAndPerformMount",
-				partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false).
+				partitionPath, path.Join(req.GetStagingTargetPath(), stagingFileName), true, false, mock.Anything).
 				Return(nil)
-
-			var (
-				volumeMode = ""
-				volumeSC   = "csi-baremetal-sc-hdd"
-				wbtConf    = &wbtcommon.WbtConfig{
-					Enable: true,
-					VolumeOptions: wbtcommon.VolumeOptions{
-						Modes:          []string{volumeMode},
-						StorageClasses: []string{volumeSC},
-					},
-				}
-				wbtValue uint32 = 0
-				device          = "sda" //testDrive.Spec.Path = "/dev/sda"
-				someErr         = fmt.Errorf("some err")
-			)
-			node.SetWbtConfig(wbtConf)
-			wbtOps.On("SetValue", device, wbtValue).Return(someErr)
-
-			pv := &corev1.PersistentVolume{}
-			pv.Name = testVolume2.Id
-			pv.Spec.StorageClassName = volumeSC
-			err := node.k8sClient.Create(testCtx, pv)
-			Expect(err).To(BeNil())
+			tuningOps.On("Apply", device, settings).Return(map[string]string{}, fmt.Errorf("some err"))
 
 			resp, err := node.NodeStageVolume(testCtx, req)
 			Expect(resp).NotTo(BeNil())
@@ -944,8 +1266,7 @@ var _ = Describe("CSINodeService Wbt Configuration", func() {
 			err = node.k8sClient.ReadCR(testCtx, testVolume2.Id, "", volumeCR)
 			Expect(err).To(BeNil())
 			Expect(volumeCR.Spec.CSIStatus).To(Equal(apiV1.VolumeReady))
-			_, ok := volumeCR.Annotations[wbtChangedVolumeAnnotation]
-			Expect(ok).To(BeFalse())
+			Expect(volumeCR.Spec.BlockTuning).To(BeNil())
 		})
 	})
 
@@ -956,30 +1277,27 @@ var _ = Describe("CSINodeService Wbt Configuration", func() {
 			fsOps.On("UnmountWithCheck", targetPath).Return(nil)
 			fsOps.On("RmDir", targetPath).Return(nil)
 
+			previous := map[string]string{tuningcommon.Scheduler: "none"}
 			vol1 := &vcrd.Volume{}
 			err := node.k8sClient.ReadCR(testCtx, testVolume1.Id, testNs, vol1)
 			Expect(err).To(BeNil())
-			vol1.Annotations = map[string]string{wbtChangedVolumeAnnotation: wbtChangedVolumeKey}
+			vol1.Spec.BlockTuning = &api.BlockTuning{Profile: "hdd", Device: device, Previous: previous}
 			err = node.k8sClient.UpdateCR(testCtx, vol1)
 			Expect(err).To(BeNil())
-
-			var (
-				device = "sda" //testDrive.Spec.Path = "/dev/sda"
-			)
-			wbtOps.On("RestoreDefault", device).Return(nil)
+			tuningOps.On("Restore", device, previous).Return(nil)
 
 			resp, err := node.NodeUnstageVolume(testCtx, req)
 			Expect(resp).NotTo(BeNil())
 			Expect(err).To(BeNil())
-			// check owners and CSI status
+			// check tuning and CSI status
 			volumeCR := &vcrd.Volume{}
 			err = node.k8sClient.ReadCR(testCtx, testV1ID, "", volumeCR)
 			Expect(err).To(BeNil())
 			Expect(volumeCR.Spec.CSIStatus).To(Equal(apiV1.Created))
-			_, ok := volumeCR.Annotations[wbtChangedVolumeAnnotation]
-			Expect(ok).To(Equal(false))
+			Expect(volumeCR.Spec.BlockTuning).To(BeNil())
+			tuningOps.AssertCalled(GinkgoT(), "Restore", device, previous)
 		})
-		It("failed", func() {
+		It("legacy WBT annotation", func() {
 			req := getNodeUnstageRequest(testV1ID, stagePath)
 			targetPath := path.Join(req.GetStagingTargetPath(), stagingFileName)
 			fsOps.On("UnmountWithCheck", targetPath).Return(nil)
@@ -991,12 +1309,8 @@ var _ = Describe("CSINodeService Wbt Configuration", func() {
 			vol1.Annotations = map[string]string{wbtChangedVolumeAnnotation: wbtChangedVolumeKey}
 			err = node.k8sClient.UpdateCR(testCtx, vol1)
 			Expect(err).To(BeNil())
-
-			var (
-				device  = "sda" //testDrive.Spec.Path = "/dev/sda"
-				someErr = fmt.Errorf("some err")
-			)
-			wbtOps.On("RestoreDefault", device).Return(someErr)
+			tuningOps.On("Restore", device,
+				map[string]string{tuningcommon.WbtLatUsec: tuningcommon.DefaultWbtLatUsec}).Return(fmt.Errorf("some err"))
 
 			resp, err := node.NodeUnstageVolume(testCtx, req)
 			Expect(resp).NotTo(BeNil())
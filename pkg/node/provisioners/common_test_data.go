@@ -116,4 +116,16 @@ var (
 		StorageClass: apiV1.StorageClassHDD,
 		Mode:         apiV1.ModeRAWPART,
 	}
+
+	testSecretName       = "luks-secret"
+	testPassphrase       = "passphrase"
+	testVolume2Encrypted = api.Volume{ // points on testDriveCR
+		Id:                   testV2ID,
+		NodeId:               testNodeID,
+		Location:             testDriveCR.Name,
+		StorageClass:         apiV1.StorageClassHDD,
+		Type:                 "xfs",
+		Encrypted:            true,
+		EncryptionSecretName: testSecretName,
+	}
 )
@@ -51,6 +51,8 @@ type DriveProvisioner struct {
 	fsOps uw.FSOperations
 	// partOps uses for operations with partitions
 	partOps uw.PartitionOperations
+	// encryptor uses for operations with LUKS encrypted volumes
+	encryptor *volumeEncryptor
 
 	k8sClient *k8s.KubeClient
 	crHelper  *k8s.CRHelper
@@ -67,6 +69,7 @@ func NewDriveProvisioner(
 		listBlk:   lsblk.NewLSBLK(log),
 		fsOps:     uw.NewFSOperationsImpl(e, log),
 		partOps:   uw.NewPartitionOperationsImpl(e, log),
+		encryptor: newVolumeEncryptor(e, k, log),
 		k8sClient: k,
 		crHelper:  k8s.NewCRHelper(k, log),
 		log:       log.WithField("component", "DriveProvisioner"),
@@ -74,6 +77,7 @@ func NewDriveProvisioner(
 }
 
 // PrepareVolume create partition and FS based on vol attributes.
+// Partition (or the whole drive for raw volume) of encrypted volume is formatted with LUKS and FS is created on the mapper device.
 // After that partition is ready for mount operations
 func (d *DriveProvisioner) PrepareVolume(vol *api.Volume) error {
 	ll := d.log.WithFields(logrus.Fields{
@@ -100,7 +104,8 @@ func (d *DriveProvisioner) PrepareVolume(vol *api.Volume) error {
 	}
 
 	if vol.Mode == apiV1.ModeRAW {
-		return nil
+		_, err = d.encryptor.setup(vol, device)
+		return err
 	}
 
 	partUUID, _ := util.GetVolumeUUID(vol.Id)
@@ -121,14 +126,20 @@ func (d *DriveProvisioner) PrepareVolume(vol *api.Volume) error {
 	}
 	ll.Infof("Partition was created successfully %+v", partPtr)
 
+	devicePath, err := d.encryptor.setup(vol, partPtr.GetFullPath())
+	if err != nil {
+		return err
+	}
+
 	if vol.Mode == apiV1.ModeRAWPART {
 		return nil
 	}
 
-	return d.fsOps.CreateFSIfNotExist(fs.FileSystem(vol.Type), partPtr.GetFullPath())
+	return d.fsOps.CreateFSIfNotExist(fs.FileSystem(vol.Type), devicePath, vol.MkfsOptions)
 }
 
 // ReleaseVolume remove FS and partition based on vol attributes.
+// Mapping of encrypted volume is closed and LUKS header is erased before that.
 // After that partition is completely removed
 func (d *DriveProvisioner) ReleaseVolume(vol *api.Volume, drive *api.Drive) error {
 	ll := d.log.WithFields(logrus.Fields{
@@ -144,6 +155,12 @@ func (d *DriveProvisioner) ReleaseVolume(vol *api.Volume, drive *api.Drive) erro
 	}
 	ll.Debugf("Got device %s", device)
 
+	if vol.Mode == apiV1.ModeRAW {
+		if err = d.encryptor.teardown(vol, device); err != nil {
+			return err
+		}
+	}
+
 	var (
 		partUUID, _ = util.GetVolumeUUID(vol.Id)
 		part        = uw.Partition{
@@ -168,11 +185,17 @@ func (d *DriveProvisioner) ReleaseVolume(vol *api.Volume, drive *api.Drive) erro
 			fmt.Errorf("unable to find partition name for volume %s", vol.Id), ll)
 	}
 
-	// wipe FS on partition
-	if err = d.fsOps.WipeFS(part.GetFullPath()); err != nil {
+	if err = d.encryptor.teardown(vol, part.GetFullPath()); err != nil {
 		return err
 	}
 
+	// wipe FS on partition, erase policy "none" keeps data untouched
+	if vol.ErasePolicy != apiV1.ErasePolicyNone {
+		if err = d.fsOps.WipeFS(part.GetFullPath()); err != nil {
+			return err
+		}
+	}
+
 	err = d.partOps.ReleasePartition(part)
 	if err != nil {
 		return fmt.Errorf("unable to release partition: %v", err)
@@ -195,10 +218,20 @@ func (d *DriveProvisioner) wipeDevice(device string, err error, ll *logrus.Entry
 	return err
 }
 
-// GetVolumePath constructs full partition path - /dev/DEVICE_NAME+PARTITION_NAME
+// GetVolumePath constructs full partition path - /dev/DEVICE_NAME+PARTITION_NAME,
+// for encrypted volume opens the mapping if it isn't active and returns path of the mapper device
 func (d *DriveProvisioner) GetVolumePath(vol *api.Volume) (string, error) {
+	devicePath, err := d.getDevicePath(vol)
+	if err != nil {
+		return "", err
+	}
+	return d.encryptor.open(vol, devicePath)
+}
+
+// getDevicePath constructs full partition path - /dev/DEVICE_NAME+PARTITION_NAME or returns drive path for raw volume
+func (d *DriveProvisioner) getDevicePath(vol *api.Volume) (string, error) {
 	ll := d.log.WithFields(logrus.Fields{
-		"method":   "GetVolumePath",
+		"method":   "getDevicePath",
 		"volumeID": vol.Id,
 	})
 
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
 	"github.com/dell/csi-baremetal/api/v1/drivecrd"
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
@@ -87,7 +88,7 @@ func TestDriveProvisioner_PrepareVolume_Success(t *testing.T) {
 
 	mockLsblk.On("SearchDrivePath", &testDriveCR.Spec).Return(device, nil)
 	mockPH.On("PreparePartition", part).Return(&expectedPart, nil)
-	mockFS.On("CreateFSIfNotExist", fs.FileSystem(testVolume2.Type), expectedPart.GetFullPath()).
+	mockFS.On("CreateFSIfNotExist", fs.FileSystem(testVolume2.Type), expectedPart.GetFullPath(), "").
 		Return(nil)
 
 	err = dp.PrepareVolume(&testVolume2)
@@ -186,13 +187,79 @@ func TestDriveProvisioner_PrepareVolume_Fail(t *testing.T) {
 	// CreateFS failed
 	mockPH.On("PreparePartition", mock.Anything).
 		Return(&uw.Partition{}, nil).Once()
-	mockFS.On("CreateFSIfNotExist", fs.FileSystem(testVolume2.Type), mock.Anything).Return(errTest)
+	mockFS.On("CreateFSIfNotExist", fs.FileSystem(testVolume2.Type), mock.Anything, mock.Anything).Return(errTest)
 
 	err = dp.PrepareVolume(&testVolume2)
 	assert.Error(t, err)
 	assert.Equal(t, errTest, err)
 }
 
+func TestDriveProvisioner_PrepareVolume_Encrypted_Success(t *testing.T) {
+	var (
+		dp, mockLsblk, mockPH, mockFS = setupTestDriveProvisioner()
+		cryptOps                      = &mocklu.MockWrapCryptsetup{}
+		device                        = "/some/device"
+		expectedPart                  = uw.Partition{Device: device, Num: DefaultPartitionNumber, Name: "p1"}
+	)
+	dp.encryptor.cryptOps = cryptOps
+	assert.Nil(t, dp.k8sClient.CreateCR(testCtx, testDriveCR.Name, testDriveCR.DeepCopy()))
+	createTestSecret(t, dp.k8sClient)
+
+	mockLsblk.On("SearchDrivePath", &testDriveCR.Spec).Return(device, nil)
+	mockPH.On("PreparePartition", mock.Anything).Return(&expectedPart, nil)
+	cryptOps.On("IsLuks", expectedPart.GetFullPath()).Return(false, nil).Times(1)
+	cryptOps.On("LuksFormat", expectedPart.GetFullPath(), testPassphrase).Return(nil).Times(1)
+	cryptOps.On("LuksOpen", expectedPart.GetFullPath(), getMapperName(testV2ID), testPassphrase).Return(nil).Times(1)
+	// file system is created on the mapper device
+	mockFS.On("CreateFSIfNotExist", fs.FileSystem(testVolume2Encrypted.Type), "/dev/mapper/"+getMapperName(testV2ID), "").
+		Return(nil).Times(1)
+
+	assert.Nil(t, dp.PrepareVolume(&testVolume2Encrypted))
+	cryptOps.AssertExpectations(t)
+	mockFS.AssertExpectations(t)
+}
+
+func TestDriveProvisioner_ReleaseVolume_Encrypted_Success(t *testing.T) {
+	var (
+		dp, mockLsblk, mockPH, mockFS = setupTestDriveProvisioner()
+		cryptOps                      = &mocklu.MockWrapCryptsetup{}
+		deviceFile                    = "/dev/sda"
+		partName                      = "p1"
+	)
+	dp.encryptor.cryptOps = cryptOps
+
+	mockLsblk.On("SearchDrivePath", &testDriveCR.Spec).Return(deviceFile, nil)
+	mockPH.On("SearchPartName", deviceFile, testVolume2Encrypted.Id).Return(partName, nil).Once()
+	cryptOps.On("IsOpened", getMapperName(testV2ID)).Return(true, nil).Times(1)
+	cryptOps.On("LuksClose", getMapperName(testV2ID)).Return(nil).Times(1)
+	cryptOps.On("IsLuks", deviceFile+partName).Return(true, nil).Times(1)
+	cryptOps.On("LuksErase", deviceFile+partName).Return(nil).Times(1)
+	mockFS.On("WipeFS", deviceFile+partName).Return(nil).Once()
+	mockPH.On("ReleasePartition", mock.Anything).Return(nil)
+	mockFS.On("WipeFS", deviceFile).Return(nil).Once()
+
+	assert.Nil(t, dp.ReleaseVolume(&testVolume2Encrypted, &testDriveCR.Spec))
+	cryptOps.AssertExpectations(t)
+}
+
+func TestDriveProvisioner_ReleaseVolume_ErasePolicyNone(t *testing.T) {
+	var (
+		dp, mockLsblk, mockPH, mockFS = setupTestDriveProvisioner()
+		deviceFile                    = "/dev/sda"
+		partName                      = "p1"
+		vol                           = testVolume2
+	)
+	vol.ErasePolicy = apiV1.ErasePolicyNone
+
+	mockLsblk.On("SearchDrivePath", &testDriveCR.Spec).Return(deviceFile, nil)
+	mockPH.On("SearchPartName", deviceFile, vol.Id).Return(partName, nil).Once()
+	mockPH.On("ReleasePartition", mock.Anything).Return(nil)
+	mockFS.On("WipeFS", deviceFile).Return(nil).Once()
+
+	assert.Nil(t, dp.ReleaseVolume(&vol, &testDriveCR.Spec))
+	mockFS.AssertNotCalled(t, "WipeFS", deviceFile+partName)
+}
+
 func TestDriveProvisioner_ReleaseVolume_Success(t *testing.T) {
 	var (
 		dp, mockLsblk, mockPH, mockFS = setupTestDriveProvisioner()
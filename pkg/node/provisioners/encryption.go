@@ -0,0 +1,182 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioners
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/cryptsetup"
+)
+
+// mapperNamePrefix is a prefix of dm-crypt mapping name of the encrypted volume
+const mapperNamePrefix = "luks-"
+
+// volumeEncryptor sets up and tears down LUKS encryption on the devices of encrypted volumes,
+// for not encrypted volumes all methods do nothing
+type volumeEncryptor struct {
+	cryptOps  cryptsetup.WrapCryptsetup
+	k8sClient *k8s.KubeClient
+	log       *logrus.Entry
+}
+
+// newVolumeEncryptor is a constructor for volumeEncryptor
+func newVolumeEncryptor(e command.CmdExecutor, k *k8s.KubeClient, log *logrus.Logger) *volumeEncryptor {
+	return &volumeEncryptor{
+		cryptOps:  cryptsetup.NewCryptsetup(e, log),
+		k8sClient: k,
+		log:       log.WithField("component", "volumeEncryptor"),
+	}
+}
+
+// getMapperName returns name of the dm-crypt mapping for volume with volumeID
+func getMapperName(volumeID string) string {
+	return mapperNamePrefix + volumeID
+}
+
+// setup formats device with LUKS if it isn't formatted yet and opens it if the mapping isn't active,
+// so it could be retried when the volume preparation is repeated
+// Returns path of the mapper device for encrypted volume or device itself for not encrypted one
+func (v *volumeEncryptor) setup(vol *api.Volume, device string) (string, error) {
+	if !vol.Encrypted {
+		return device, nil
+	}
+	ll := v.log.WithFields(logrus.Fields{
+		"method":   "setup",
+		"volumeID": vol.Id,
+	})
+
+	passphrase, err := v.getPassphrase(vol)
+	if err != nil {
+		return "", err
+	}
+
+	isLuks, err := v.cryptOps.IsLuks(device)
+	if err != nil {
+		return "", err
+	}
+	if !isLuks {
+		ll.Infof("Formatting %s with LUKS", device)
+		if err = v.cryptOps.LuksFormat(device, passphrase); err != nil {
+			return "", fmt.Errorf("unable to format %s with LUKS: %v", device, err)
+		}
+		return v.activate(vol, device, passphrase)
+	}
+
+	opened, err := v.cryptOps.IsOpened(getMapperName(vol.Id))
+	if err != nil {
+		return "", err
+	}
+	if opened {
+		ll.Infof("Device %s has been already opened", device)
+		return cryptsetup.MapperPath(getMapperName(vol.Id)), nil
+	}
+	return v.activate(vol, device, passphrase)
+}
+
+// open opens the mapping of encrypted volume if it isn't active, for example after node reboot
+// Returns path of the mapper device for encrypted volume or device itself for not encrypted one
+func (v *volumeEncryptor) open(vol *api.Volume, device string) (string, error) {
+	if !vol.Encrypted {
+		return device, nil
+	}
+
+	opened, err := v.cryptOps.IsOpened(getMapperName(vol.Id))
+	if err != nil {
+		return "", err
+	}
+	if opened {
+		return cryptsetup.MapperPath(getMapperName(vol.Id)), nil
+	}
+
+	passphrase, err := v.getPassphrase(vol)
+	if err != nil {
+		return "", err
+	}
+	return v.activate(vol, device, passphrase)
+}
+
+// teardown closes the mapping of encrypted volume and erases all key slots of LUKS header on the device,
+// data on the device can't be decrypted after that
+func (v *volumeEncryptor) teardown(vol *api.Volume, device string) error {
+	if !vol.Encrypted {
+		return nil
+	}
+	ll := v.log.WithFields(logrus.Fields{
+		"method":   "teardown",
+		"volumeID": vol.Id,
+	})
+
+	name := getMapperName(vol.Id)
+	opened, err := v.cryptOps.IsOpened(name)
+	if err != nil {
+		return err
+	}
+	if opened {
+		ll.Infof("Closing mapping %s", name)
+		if err = v.cryptOps.LuksClose(name); err != nil {
+			return fmt.Errorf("unable to close mapping %s: %v", name, err)
+		}
+	}
+
+	isLuks, err := v.cryptOps.IsLuks(device)
+	if err != nil {
+		return err
+	}
+	if isLuks {
+		ll.Infof("Erasing LUKS header on %s", device)
+		if err = v.cryptOps.LuksErase(device); err != nil {
+			return fmt.Errorf("unable to erase LUKS header on %s: %v", device, err)
+		}
+	}
+	return nil
+}
+
+// activate opens LUKS device and returns path of the mapper device
+func (v *volumeEncryptor) activate(vol *api.Volume, device, passphrase string) (string, error) {
+	name := getMapperName(vol.Id)
+	v.log.WithField("volumeID", vol.Id).Infof("Opening %s as %s", device, name)
+	if err := v.cryptOps.LuksOpen(device, name, passphrase); err != nil {
+		return "", fmt.Errorf("unable to open LUKS device %s: %v", device, err)
+	}
+	return cryptsetup.MapperPath(name), nil
+}
+
+// getPassphrase reads passphrase of the encrypted volume from the Secret referenced by the volume,
+// Secret from the namespace of the driver is used when namespace isn't set
+func (v *volumeEncryptor) getPassphrase(vol *api.Volume) (string, error) {
+	var (
+		ctxWithID = context.WithValue(context.Background(), base.RequestUUID, vol.Id)
+		secret    = &corev1.Secret{}
+	)
+
+	if err := v.k8sClient.ReadCR(ctxWithID, vol.EncryptionSecretName, vol.EncryptionSecretNamespace, secret); err != nil {
+		return "", fmt.Errorf("unable to read secret %s: %v", vol.EncryptionSecretName, err)
+	}
+	passphrase := secret.Data[base.EncryptionPassphraseKey]
+	if len(passphrase) == 0 {
+		return "", fmt.Errorf("secret %s doesn't contain %s", vol.EncryptionSecretName, base.EncryptionPassphraseKey)
+	}
+	return string(passphrase), nil
+}
@@ -0,0 +1,234 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8smetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+)
+
+var (
+	testLuksDevice = "/dev/sda1"
+	testMapperName = getMapperName(testVolume2Encrypted.Id)
+	testMapperPath = "/dev/mapper/" + testMapperName
+)
+
+// setupTestVolumeEncryptor creates volumeEncryptor with mocked cryptsetup and Secret with passphrase if createSecret is true
+func setupTestVolumeEncryptor(t *testing.T, createSecret bool) (*volumeEncryptor, *mocklu.MockWrapCryptsetup) {
+	fakeK8s, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+
+	if createSecret {
+		createTestSecret(t, fakeK8s)
+	}
+
+	cryptOps := &mocklu.MockWrapCryptsetup{}
+	encryptor := newVolumeEncryptor(&command.Executor{}, fakeK8s, testLogger)
+	encryptor.cryptOps = cryptOps
+	return encryptor, cryptOps
+}
+
+// createTestSecret creates Secret with passphrase for encrypted test volumes
+func createTestSecret(t *testing.T, k *k8s.KubeClient) {
+	secret := &corev1.Secret{
+		ObjectMeta: k8smetav1.ObjectMeta{Name: testSecretName, Namespace: testNs},
+		Data:       map[string][]byte{base.EncryptionPassphraseKey: []byte(testPassphrase)},
+	}
+	assert.Nil(t, k.CreateCR(testCtx, testSecretName, secret))
+}
+
+func TestVolumeEncryptor_setup(t *testing.T) {
+	t.Run("Not encrypted volume", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, false)
+
+		path, err := encryptor.setup(&testVolume2, testLuksDevice)
+		assert.Nil(t, err)
+		assert.Equal(t, testLuksDevice, path)
+		cryptOps.AssertExpectations(t)
+	})
+
+	t.Run("Device is formatted and opened", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, true)
+		cryptOps.On("IsLuks", testLuksDevice).Return(false, nil).Times(1)
+		cryptOps.On("LuksFormat", testLuksDevice, testPassphrase).Return(nil).Times(1)
+		cryptOps.On("LuksOpen", testLuksDevice, testMapperName, testPassphrase).Return(nil).Times(1)
+
+		path, err := encryptor.setup(&testVolume2Encrypted, testLuksDevice)
+		assert.Nil(t, err)
+		assert.Equal(t, testMapperPath, path)
+		cryptOps.AssertExpectations(t)
+	})
+
+	t.Run("Device has been already formatted", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, true)
+		cryptOps.On("IsLuks", testLuksDevice).Return(true, nil).Times(1)
+		cryptOps.On("IsOpened", testMapperName).Return(false, nil).Times(1)
+		cryptOps.On("LuksOpen", testLuksDevice, testMapperName, testPassphrase).Return(nil).Times(1)
+
+		path, err := encryptor.setup(&testVolume2Encrypted, testLuksDevice)
+		assert.Nil(t, err)
+		assert.Equal(t, testMapperPath, path)
+		cryptOps.AssertExpectations(t)
+	})
+
+	t.Run("Device has been already formatted and opened", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, true)
+		cryptOps.On("IsLuks", testLuksDevice).Return(true, nil).Times(1)
+		cryptOps.On("IsOpened", testMapperName).Return(true, nil).Times(1)
+
+		path, err := encryptor.setup(&testVolume2Encrypted, testLuksDevice)
+		assert.Nil(t, err)
+		assert.Equal(t, testMapperPath, path)
+		cryptOps.AssertExpectations(t)
+		cryptOps.AssertNotCalled(t, "LuksOpen", testLuksDevice, testMapperName, testPassphrase)
+	})
+
+	t.Run("Status of already formatted device failed", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, true)
+		cryptOps.On("IsLuks", testLuksDevice).Return(true, nil).Times(1)
+		cryptOps.On("IsOpened", testMapperName).Return(false, errTest).Times(1)
+
+		_, err := encryptor.setup(&testVolume2Encrypted, testLuksDevice)
+		assert.Equal(t, errTest, err)
+	})
+
+	t.Run("Secret doesn't exist", func(t *testing.T) {
+		encryptor, _ := setupTestVolumeEncryptor(t, false)
+
+		_, err := encryptor.setup(&testVolume2Encrypted, testLuksDevice)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "unable to read secret")
+	})
+
+	t.Run("Secret doesn't contain passphrase", func(t *testing.T) {
+		encryptor, _ := setupTestVolumeEncryptor(t, false)
+		secret := &corev1.Secret{ObjectMeta: k8smetav1.ObjectMeta{Name: testSecretName, Namespace: testNs}}
+		assert.Nil(t, encryptor.k8sClient.CreateCR(testCtx, testSecretName, secret))
+
+		_, err := encryptor.setup(&testVolume2Encrypted, testLuksDevice)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), base.EncryptionPassphraseKey)
+	})
+
+	t.Run("LuksFormat failed", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, true)
+		cryptOps.On("IsLuks", testLuksDevice).Return(false, nil).Times(1)
+		cryptOps.On("LuksFormat", testLuksDevice, testPassphrase).Return(errTest).Times(1)
+
+		_, err := encryptor.setup(&testVolume2Encrypted, testLuksDevice)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "unable to format")
+	})
+}
+
+func TestVolumeEncryptor_open(t *testing.T) {
+	t.Run("Mapping is active", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, false)
+		cryptOps.On("IsOpened", testMapperName).Return(true, nil).Times(1)
+
+		path, err := encryptor.open(&testVolume2Encrypted, testLuksDevice)
+		assert.Nil(t, err)
+		assert.Equal(t, testMapperPath, path)
+		cryptOps.AssertExpectations(t)
+	})
+
+	t.Run("Mapping is opened", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, true)
+		cryptOps.On("IsOpened", testMapperName).Return(false, nil).Times(1)
+		cryptOps.On("LuksOpen", testLuksDevice, testMapperName, testPassphrase).Return(nil).Times(1)
+
+		path, err := encryptor.open(&testVolume2Encrypted, testLuksDevice)
+		assert.Nil(t, err)
+		assert.Equal(t, testMapperPath, path)
+		cryptOps.AssertExpectations(t)
+	})
+
+	t.Run("LuksOpen failed", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, true)
+		cryptOps.On("IsOpened", testMapperName).Return(false, nil).Times(1)
+		cryptOps.On("LuksOpen", testLuksDevice, testMapperName, testPassphrase).Return(errTest).Times(1)
+
+		_, err := encryptor.open(&testVolume2Encrypted, testLuksDevice)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Status failed", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, true)
+		cryptOps.On("IsOpened", testMapperName).Return(false, errTest).Times(1)
+
+		_, err := encryptor.open(&testVolume2Encrypted, testLuksDevice)
+		assert.Equal(t, errTest, err)
+	})
+}
+
+func TestVolumeEncryptor_teardown(t *testing.T) {
+	t.Run("Not encrypted volume", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, false)
+
+		assert.Nil(t, encryptor.teardown(&testVolume2, testLuksDevice))
+		cryptOps.AssertExpectations(t)
+	})
+
+	t.Run("Mapping is closed and header is erased", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, false)
+		cryptOps.On("IsOpened", testMapperName).Return(true, nil).Times(1)
+		cryptOps.On("LuksClose", testMapperName).Return(nil).Times(1)
+		cryptOps.On("IsLuks", testLuksDevice).Return(true, nil).Times(1)
+		cryptOps.On("LuksErase", testLuksDevice).Return(nil).Times(1)
+
+		assert.Nil(t, encryptor.teardown(&testVolume2Encrypted, testLuksDevice))
+		cryptOps.AssertExpectations(t)
+	})
+
+	t.Run("Header has been already wiped", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, false)
+		cryptOps.On("IsOpened", testMapperName).Return(false, nil).Times(1)
+		cryptOps.On("IsLuks", testLuksDevice).Return(false, nil).Times(1)
+
+		assert.Nil(t, encryptor.teardown(&testVolume2Encrypted, testLuksDevice))
+		cryptOps.AssertExpectations(t)
+	})
+
+	t.Run("LuksClose failed", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, false)
+		cryptOps.On("IsOpened", testMapperName).Return(true, nil).Times(1)
+		cryptOps.On("LuksClose", testMapperName).Return(errTest).Times(1)
+
+		err := encryptor.teardown(&testVolume2Encrypted, testLuksDevice)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "unable to close mapping")
+	})
+
+	t.Run("LuksErase failed", func(t *testing.T) {
+		encryptor, cryptOps := setupTestVolumeEncryptor(t, false)
+		cryptOps.On("IsOpened", testMapperName).Return(false, nil).Times(1)
+		cryptOps.On("IsLuks", testLuksDevice).Return(true, nil).Times(1)
+		cryptOps.On("LuksErase", testLuksDevice).Return(errTest).Times(1)
+
+		err := encryptor.teardown(&testVolume2Encrypted, testLuksDevice)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "unable to erase LUKS header")
+	})
+}
@@ -35,24 +35,27 @@ import (
 // LVMProvisioner is a implementation of Provisioner interface
 // Work with volumes based on Volume Groups
 type LVMProvisioner struct {
-	lvmOps   lvm.WrapLVM
-	fsOps    uw.FSOperations
-	crHelper *k8s.CRHelper
-	log      *logrus.Entry
+	lvmOps    lvm.WrapLVM
+	fsOps     uw.FSOperations
+	encryptor *volumeEncryptor
+	crHelper  *k8s.CRHelper
+	log       *logrus.Entry
 }
 
 // NewLVMProvisioner is a constructor for LVMProvisioner
 func NewLVMProvisioner(e command.CmdExecutor, k *k8s.KubeClient, log *logrus.Logger) *LVMProvisioner {
 	return &LVMProvisioner{
-		lvmOps:   lvm.NewLVM(e, log),
-		fsOps:    uw.NewFSOperationsImpl(e, log),
-		crHelper: k8s.NewCRHelper(k, log),
-		log:      log.WithField("component", "LVMProvisioner"),
+		lvmOps:    lvm.NewLVM(e, log),
+		fsOps:     uw.NewFSOperationsImpl(e, log),
+		encryptor: newVolumeEncryptor(e, k, log),
+		crHelper:  k8s.NewCRHelper(k, log),
+		log:       log.WithField("component", "LVMProvisioner"),
 	}
 }
 
-// PrepareVolume search volume group based on vol attributes, creates Logical Volume
-// and create file system on it. After that Logical Volume is ready for mount operations
+// PrepareVolume search volume group based on vol attributes, creates Logical Volume (thin one for thin LVG SC)
+// and create file system on it. Logical Volume of encrypted volume is formatted with LUKS and
+// file system is created on the mapper device. After that Logical Volume is ready for mount operations
 func (l *LVMProvisioner) PrepareVolume(vol *api.Volume) error {
 	ll := l.log.WithFields(logrus.Fields{
 		"method":   "PrepareVolume",
@@ -76,21 +79,31 @@ func (l *LVMProvisioner) PrepareVolume(vol *api.Volume) error {
 	}
 
 	// create lv with name /dev/VG_NAME/vol.Id
-	ll.Infof("Creating LV %s sizeof %s in VG %s", vol.Id, sizeStr, vgName)
-	if err = l.lvmOps.LVCreate(vol.Id, sizeStr, vgName); err != nil {
+	if util.IsStorageClassLVGThin(vol.StorageClass) {
+		ll.Infof("Creating thin LV %s sizeof %s in pool %s of VG %s", vol.Id, sizeStr, lvm.ThinPoolName, vgName)
+		err = l.lvmOps.ThinLVCreate(vol.Id, sizeStr, vgName, lvm.ThinPoolName)
+	} else {
+		ll.Infof("Creating LV %s sizeof %s in VG %s", vol.Id, sizeStr, vgName)
+		err = l.lvmOps.LVCreate(vol.Id, sizeStr, vgName)
+	}
+	if err != nil {
 		return fmt.Errorf("unable to create LV: %v", err)
 	}
 
-	deviceFile := fmt.Sprintf("/dev/%s/%s", vgName, vol.Id)
+	deviceFile, err := l.encryptor.setup(vol, fmt.Sprintf("/dev/%s/%s", vgName, vol.Id))
+	if err != nil {
+		return err
+	}
 	ll.Debugf("Creating FS on %s", deviceFile)
 	if vol.Mode == apiV1.ModeRAW || vol.Mode == apiV1.ModeRAWPART {
 		return nil
 	}
-	return l.fsOps.CreateFSIfNotExist(fs.FileSystem(vol.Type), deviceFile)
+	return l.fsOps.CreateFSIfNotExist(fs.FileSystem(vol.Type), deviceFile, vol.MkfsOptions)
 }
 
 // ReleaseVolume search volume group based on vol attributes, remove Logical Volume
-// and wipe file system on it. After that Logical Volume that had consumed by vol is completely removed
+// and wipe file system on it. Mapping of encrypted volume is closed and LUKS header is erased before that.
+// After that Logical Volume that had consumed by vol is completely removed
 func (l *LVMProvisioner) ReleaseVolume(vol *api.Volume, _ *api.Drive) error {
 	ll := logrus.WithFields(logrus.Fields{
 		"method":   "ReleaseVolume",
@@ -98,11 +111,15 @@ func (l *LVMProvisioner) ReleaseVolume(vol *api.Volume, _ *api.Drive) error {
 	})
 	ll.Infof("Processing for volume %v", vol)
 
-	deviceFile, err := l.GetVolumePath(vol)
+	deviceFile, err := l.getLVPath(vol)
 	if err != nil {
 		return fmt.Errorf("unable to determine full path of the volume: %v", err)
 	}
 
+	if err = l.encryptor.teardown(vol, deviceFile); err != nil {
+		return err
+	}
+
 	if err := l.fsOps.WipeFS(deviceFile); err != nil {
 		// check whether such LV (deviceFile) exist or not
 		vgName, sErr := l.getVGName(vol)
@@ -126,10 +143,20 @@ func (l *LVMProvisioner) ReleaseVolume(vol *api.Volume, _ *api.Drive) error {
 }
 
 // GetVolumePath search Volume Group name by vol attributes and construct
-// full path to the volume using template: /dev/VG_NAME/LV_NAME
+// full path to the volume using template: /dev/VG_NAME/LV_NAME,
+// for encrypted volume opens the mapping if it isn't active and returns path of the mapper device
 func (l *LVMProvisioner) GetVolumePath(vol *api.Volume) (string, error) {
+	lvPath, err := l.getLVPath(vol)
+	if err != nil {
+		return "", err
+	}
+	return l.encryptor.open(vol, lvPath)
+}
+
+// getLVPath constructs full path to the Logical Volume using template: /dev/VG_NAME/LV_NAME
+func (l *LVMProvisioner) getLVPath(vol *api.Volume) (string, error) {
 	ll := l.log.WithFields(logrus.Fields{
-		"method":   "GetVolumePath",
+		"method":   "getLVPath",
 		"volumeID": vol.Id,
 	})
 	ll.Debugf("Processing for %v", vol)
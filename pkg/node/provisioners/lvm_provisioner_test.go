@@ -28,6 +28,7 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lvm"
 	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
 	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
 )
@@ -59,13 +60,31 @@ func TestLVMProvisioner_PrepareVolume_Success(t *testing.T) {
 		Return(nil).Times(1)
 
 	devFile := fmt.Sprintf("/dev/%s/%s", testVolume1.Location, testVolume1.Id)
-	fsOps.On("CreateFSIfNotExist", fs.FileSystem(testVolume1.Type), devFile).
+	fsOps.On("CreateFSIfNotExist", fs.FileSystem(testVolume1.Type), devFile, "").
 		Return(nil).Times(1)
 
 	err := lp.PrepareVolume(&testVolume1)
 	assert.Nil(t, err)
 }
 
+func TestLVMProvisioner_PrepareVolume_Thin_Success(t *testing.T) {
+	setupTestLVMProvisioner()
+
+	vol := testVolume1
+	vol.StorageClass = apiV1.StorageClassHDDLVGThin
+	vol.MkfsOptions = "-b 4096"
+	lvmOps.On("ThinLVCreate", vol.Id, mock.Anything, vol.Location, lvm.ThinPoolName).
+		Return(nil).Times(1)
+
+	devFile := fmt.Sprintf("/dev/%s/%s", vol.Location, vol.Id)
+	fsOps.On("CreateFSIfNotExist", fs.FileSystem(vol.Type), devFile, vol.MkfsOptions).
+		Return(nil).Times(1)
+
+	err := lp.PrepareVolume(&vol)
+	assert.Nil(t, err)
+	lvmOps.AssertExpectations(t)
+}
+
 func TestLVMProvisioner_PrepareVolume_Block_Success(t *testing.T) {
 	setupTestLVMProvisioner()
 
@@ -112,7 +131,7 @@ func TestLVMProvisioner_PrepareVolume_Fail(t *testing.T) {
 		Return(nil).Times(1)
 
 	devFile := fmt.Sprintf("/dev/%s/%s", testVolume1.Location, testVolume1.Id)
-	fsOps.On("CreateFSIfNotExist", fs.FileSystem(testVolume1.Type), devFile).
+	fsOps.On("CreateFSIfNotExist", fs.FileSystem(testVolume1.Type), devFile, "").
 		Return(errTest).Times(1)
 
 	err = lp.PrepareVolume(&testVolume1)
@@ -142,6 +161,25 @@ func TestLVMProvisioner_ReleaseVolume_Success(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestLVMProvisioner_ReleaseVolume_Encrypted_Success(t *testing.T) {
+	setupTestLVMProvisioner()
+	cryptOps := &mocklu.MockWrapCryptsetup{}
+	lp.encryptor.cryptOps = cryptOps
+
+	vol := testVolume1
+	vol.Encrypted = true
+	// LUKS header and LV are removed using LV path, not the mapper device
+	devFile := fmt.Sprintf("/dev/%s/%s", vol.Location, vol.Id)
+	cryptOps.On("IsOpened", getMapperName(vol.Id)).Return(false, nil).Times(1)
+	cryptOps.On("IsLuks", devFile).Return(true, nil).Times(1)
+	cryptOps.On("LuksErase", devFile).Return(nil).Times(1)
+	fsOps.On("WipeFS", devFile).Return(nil).Times(1)
+	lvmOps.On("LVRemove", devFile).Return(nil).Times(1)
+
+	assert.Nil(t, lp.ReleaseVolume(&vol, &api.Drive{}))
+	cryptOps.AssertExpectations(t)
+}
+
 func TestLVMProvisioner_ReleaseVolume_Fail(t *testing.T) {
 	setupTestLVMProvisioner()
 
@@ -194,6 +232,20 @@ func TestLVMProvisioner_GetVolumePath_Success(t *testing.T) {
 	assert.Equal(t, expectedPath, currentPath)
 }
 
+func TestLVMProvisioner_GetVolumePath_Encrypted(t *testing.T) {
+	setupTestLVMProvisioner()
+	cryptOps := &mocklu.MockWrapCryptsetup{}
+	lp.encryptor.cryptOps = cryptOps
+
+	vol := testVolume1
+	vol.Encrypted = true
+	cryptOps.On("IsOpened", getMapperName(vol.Id)).Return(true, nil).Times(1)
+
+	currentPath, err := lp.GetVolumePath(&vol)
+	assert.Nil(t, err)
+	assert.Equal(t, "/dev/mapper/"+getMapperName(vol.Id), currentPath)
+}
+
 func TestLVMProvisioner_getVGName_Success(t *testing.T) {
 	setupTestLVMProvisioner()
 
@@ -0,0 +1,215 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioners
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"path"
+
+	"github.com/sirupsen/logrus"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/mdadm"
+	uw "github.com/dell/csi-baremetal/pkg/node/provisioners/utilwrappers"
+)
+
+const (
+	// mdDir is a directory where named RAID arrays are placed
+	mdDir = "/dev/md"
+	// mdNamePrefix is a prefix of RAID array name of the volume
+	mdNamePrefix = "csi-"
+)
+
+// RAIDProvisioner is a implementation of Provisioner interface
+// works with software RAID arrays assembled from the whole drives
+type RAIDProvisioner struct {
+	listBlk lsblk.WrapLsblk
+	// fsOps uses for operations with file systems
+	fsOps uw.FSOperations
+	// mdOps uses for operations with RAID arrays
+	mdOps mdadm.WrapMdadm
+	// encryptor uses for operations with LUKS encrypted volumes
+	encryptor *volumeEncryptor
+
+	k8sClient *k8s.KubeClient
+
+	log *logrus.Entry
+}
+
+// NewRAIDProvisioner is a constructor for RAIDProvisioner instance
+func NewRAIDProvisioner(
+	e command.CmdExecutor,
+	k *k8s.KubeClient,
+	log *logrus.Logger) *RAIDProvisioner {
+	return &RAIDProvisioner{
+		listBlk:   lsblk.NewLSBLK(log),
+		fsOps:     uw.NewFSOperationsImpl(e, log),
+		mdOps:     mdadm.NewMdadm(e, log),
+		encryptor: newVolumeEncryptor(e, k, log),
+		k8sClient: k,
+		log:       log.WithField("component", "RAIDProvisioner"),
+	}
+}
+
+// GetRaidDevicePath returns path of the RAID array device of the volume with volumeID
+// Array name is limited by mdadm, so it is based on hash of the volume ID
+func GetRaidDevicePath(volumeID string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(volumeID))
+	return path.Join(mdDir, fmt.Sprintf("%s%016x", mdNamePrefix, h.Sum64()))
+}
+
+// PrepareVolume creates RAID array from the member drives and creates FS on it based on vol attributes.
+// Array of encrypted volume is formatted with LUKS and FS is created on the mapper device
+func (r *RAIDProvisioner) PrepareVolume(vol *api.Volume) error {
+	ll := r.log.WithFields(logrus.Fields{
+		"method":   "PrepareVolume",
+		"volumeID": vol.Id,
+	})
+	ll.Infof("Processing for volume %+v", *vol)
+
+	members, err := r.getMemberDevices(vol, false)
+	if err != nil {
+		return err
+	}
+
+	device := GetRaidDevicePath(vol.Id)
+	if _, err = r.mdOps.GetArrayState(device); err != nil {
+		// remove signatures to avoid interactive questions of mdadm
+		for _, member := range members {
+			if err = r.fsOps.WipeFS(member); err != nil {
+				return fmt.Errorf("unable to wipe member device %s: %v", member, err)
+			}
+		}
+		ll.Infof("Create %s array %s from devices %v", vol.RaidLevel, device, members)
+		if err = r.mdOps.Create(device, vol.RaidLevel, members); err != nil {
+			return err
+		}
+	}
+
+	devicePath, err := r.encryptor.setup(vol, device)
+	if err != nil {
+		return err
+	}
+
+	if vol.Mode != apiV1.ModeFS {
+		return nil
+	}
+
+	return r.fsOps.CreateFSIfNotExist(fs.FileSystem(vol.Type), devicePath, vol.MkfsOptions)
+}
+
+// ReleaseVolume stops RAID array and removes RAID metadata from the member drives.
+// Mapping of encrypted volume is closed and LUKS header is erased before that.
+// Members which aren't found on the node are skipped
+func (r *RAIDProvisioner) ReleaseVolume(vol *api.Volume, _ *api.Drive) error {
+	ll := r.log.WithFields(logrus.Fields{
+		"method":   "ReleaseVolume",
+		"volumeID": vol.Id,
+	})
+	ll.Infof("Processing for volume %+v", *vol)
+
+	members, err := r.getMemberDevices(vol, true)
+	if err != nil {
+		return err
+	}
+
+	device := GetRaidDevicePath(vol.Id)
+	if _, err = r.mdOps.GetArrayState(device); err != nil {
+		if err = r.mdOps.Assemble(device, members); err != nil {
+			ll.Warnf("Unable to assemble array: %v. Member devices will be wiped", err)
+		}
+	}
+	if err == nil {
+		if err = r.encryptor.teardown(vol, device); err != nil {
+			return err
+		}
+		if err = r.fsOps.WipeFS(device); err != nil {
+			return err
+		}
+		if err = r.mdOps.Stop(device); err != nil {
+			return fmt.Errorf("unable to stop array %s: %v", device, err)
+		}
+	}
+
+	for _, member := range members {
+		if err = r.mdOps.ZeroSuperblock(member); err != nil {
+			ll.Warnf("Unable to remove RAID metadata from %s: %v", member, err)
+		}
+		if err = r.fsOps.WipeFS(member); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetVolumePath returns path of the RAID array device, assembles array if it isn't running (e.g. after node reboot).
+// For encrypted volume opens the mapping if it isn't active and returns path of the mapper device
+func (r *RAIDProvisioner) GetVolumePath(vol *api.Volume) (string, error) {
+	device := GetRaidDevicePath(vol.Id)
+	if _, err := r.mdOps.GetArrayState(device); err != nil {
+		// array is started with missing members, so degraded volume is still accessible
+		members, err := r.getMemberDevices(vol, true)
+		if err != nil {
+			return "", err
+		}
+		if err = r.mdOps.Assemble(device, members); err != nil {
+			return "", err
+		}
+	}
+	return r.encryptor.open(vol, device)
+}
+
+// getMemberDevices returns device paths of the RAID member drives
+// If skipMissing is true drives which aren't found on the node are skipped, otherwise error is returned
+func (r *RAIDProvisioner) getMemberDevices(vol *api.Volume, skipMissing bool) ([]string, error) {
+	ll := r.log.WithFields(logrus.Fields{
+		"method":   "getMemberDevices",
+		"volumeID": vol.Id,
+	})
+
+	ctxWithID := context.WithValue(context.Background(), base.RequestUUID, vol.Id)
+	members := make([]string, 0, len(vol.RaidLocations))
+	for _, location := range vol.RaidLocations {
+		drive := &drivecrd.Drive{}
+		err := r.k8sClient.ReadCR(ctxWithID, location, "", drive)
+		if err == nil {
+			var device string
+			if device, err = r.listBlk.SearchDrivePath(&drive.Spec); err == nil {
+				members = append(members, device)
+				continue
+			}
+		}
+		if !skipMissing {
+			return nil, fmt.Errorf("unable to find device of RAID member %s: %v", location, err)
+		}
+		ll.Warnf("RAID member %s is skipped: %v", location, err)
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("there are no member devices of RAID volume %s", vol.Id)
+	}
+	return members, nil
+}
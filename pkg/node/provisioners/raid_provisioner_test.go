@@ -0,0 +1,202 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provisioners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/mdadm"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
+)
+
+var (
+	testRaidMembers = []string{"/dev/sdb", "/dev/sdc"}
+	testRaidVolume  = api.Volume{
+		Id:            testV2ID,
+		NodeId:        testNodeID,
+		Location:      testDriveCR.Name,
+		LocationType:  apiV1.LocationTypeRAID,
+		StorageClass:  apiV1.StorageClassHDD,
+		Mode:          apiV1.ModeFS,
+		Type:          "xfs",
+		RaidLevel:     apiV1.RaidLevel1,
+		RaidDevices:   2,
+		RaidLocations: []string{testDriveCR.Name, "drive2-uuid"},
+	}
+)
+
+// setupTestRAIDProvisioner creates RAIDProvisioner with two member Drive CRs and all mock fields and return them
+func setupTestRAIDProvisioner(t *testing.T) (rp *RAIDProvisioner,
+	mockLsblk *mocklu.MockWrapLsblk,
+	mockMd *mocklu.MockWrapMdadm,
+	mockFS *mockProv.MockFsOpts) {
+	fakeK8s, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+	rp = NewRAIDProvisioner(&command.Executor{}, fakeK8s, testLogger)
+	mockLsblk = &mocklu.MockWrapLsblk{}
+	mockMd = &mocklu.MockWrapMdadm{}
+	mockFS = &mockProv.MockFsOpts{}
+
+	rp.listBlk = mockLsblk
+	rp.mdOps = mockMd
+	rp.fsOps = mockFS
+
+	for i, location := range testRaidVolume.RaidLocations {
+		drive := testDriveCR.DeepCopy()
+		drive.Name = location
+		drive.Spec.UUID = location
+		drive.Spec.SerialNumber = location
+		assert.Nil(t, fakeK8s.CreateCR(testCtx, drive.Name, drive))
+		mockLsblk.On("SearchDrivePath", &drive.Spec).Return(testRaidMembers[i], nil)
+	}
+	return
+}
+
+func TestGetRaidDevicePath(t *testing.T) {
+	path := GetRaidDevicePath(testV1ID)
+	assert.Regexp(t, "^/dev/md/csi-[0-9a-f]{16}$", path)
+	assert.Equal(t, path, GetRaidDevicePath(testV1ID))
+	assert.NotEqual(t, path, GetRaidDevicePath(testV2ID))
+}
+
+func TestRAIDProvisioner_PrepareVolume(t *testing.T) {
+	device := GetRaidDevicePath(testRaidVolume.Id)
+
+	t.Run("Array is created", func(t *testing.T) {
+		rp, _, mockMd, mockFS := setupTestRAIDProvisioner(t)
+		mockMd.On("GetArrayState", device).Return(nil, errTest).Times(1)
+		mockFS.On("WipeFS", mock.Anything).Return(nil).Times(2)
+		mockMd.On("Create", device, apiV1.RaidLevel1, testRaidMembers).Return(nil).Times(1)
+		mockFS.On("CreateFSIfNotExist", fs.FileSystem(testRaidVolume.Type), device, "").Return(nil).Times(1)
+
+		assert.Nil(t, rp.PrepareVolume(&testRaidVolume))
+		mockMd.AssertExpectations(t)
+		mockFS.AssertExpectations(t)
+	})
+
+	t.Run("Array already exists", func(t *testing.T) {
+		rp, _, mockMd, mockFS := setupTestRAIDProvisioner(t)
+		mockMd.On("GetArrayState", device).Return(&mdadm.ArrayState{State: "clean"}, nil).Times(1)
+		mockFS.On("CreateFSIfNotExist", fs.FileSystem(testRaidVolume.Type), device, "").Return(nil).Times(1)
+
+		assert.Nil(t, rp.PrepareVolume(&testRaidVolume))
+		mockMd.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Member drive isn't found", func(t *testing.T) {
+		rp, _, _, _ := setupTestRAIDProvisioner(t)
+		vol := testRaidVolume
+		vol.RaidLocations = []string{testDriveCR.Name, "unknown"}
+
+		assert.NotNil(t, rp.PrepareVolume(&vol))
+	})
+
+	t.Run("Create failed", func(t *testing.T) {
+		rp, _, mockMd, mockFS := setupTestRAIDProvisioner(t)
+		mockMd.On("GetArrayState", device).Return(nil, errTest).Times(1)
+		mockFS.On("WipeFS", mock.Anything).Return(nil)
+		mockMd.On("Create", device, apiV1.RaidLevel1, testRaidMembers).Return(errTest).Times(1)
+
+		assert.Equal(t, errTest, rp.PrepareVolume(&testRaidVolume))
+	})
+}
+
+func TestRAIDProvisioner_ReleaseVolume(t *testing.T) {
+	device := GetRaidDevicePath(testRaidVolume.Id)
+
+	t.Run("Array is removed", func(t *testing.T) {
+		rp, _, mockMd, mockFS := setupTestRAIDProvisioner(t)
+		mockMd.On("GetArrayState", device).Return(&mdadm.ArrayState{State: "clean"}, nil).Times(1)
+		mockFS.On("WipeFS", device).Return(nil).Times(1)
+		mockMd.On("Stop", device).Return(nil).Times(1)
+		for _, member := range testRaidMembers {
+			mockMd.On("ZeroSuperblock", member).Return(nil).Times(1)
+			mockFS.On("WipeFS", member).Return(nil).Times(1)
+		}
+
+		assert.Nil(t, rp.ReleaseVolume(&testRaidVolume, &testAPIDrive))
+		mockMd.AssertExpectations(t)
+		mockFS.AssertExpectations(t)
+	})
+
+	t.Run("Array can't be assembled", func(t *testing.T) {
+		rp, _, mockMd, mockFS := setupTestRAIDProvisioner(t)
+		mockMd.On("GetArrayState", device).Return(nil, errTest).Times(1)
+		mockMd.On("Assemble", device, testRaidMembers).Return(errTest).Times(1)
+		for _, member := range testRaidMembers {
+			mockMd.On("ZeroSuperblock", member).Return(nil).Times(1)
+			mockFS.On("WipeFS", member).Return(nil).Times(1)
+		}
+
+		assert.Nil(t, rp.ReleaseVolume(&testRaidVolume, &testAPIDrive))
+		mockMd.AssertNotCalled(t, "Stop", device)
+	})
+
+	t.Run("Stop failed", func(t *testing.T) {
+		rp, _, mockMd, mockFS := setupTestRAIDProvisioner(t)
+		mockMd.On("GetArrayState", device).Return(&mdadm.ArrayState{State: "clean"}, nil).Times(1)
+		mockFS.On("WipeFS", device).Return(nil).Times(1)
+		mockMd.On("Stop", device).Return(errTest).Times(1)
+
+		assert.NotNil(t, rp.ReleaseVolume(&testRaidVolume, &testAPIDrive))
+	})
+}
+
+func TestRAIDProvisioner_GetVolumePath(t *testing.T) {
+	device := GetRaidDevicePath(testRaidVolume.Id)
+
+	t.Run("Array is running", func(t *testing.T) {
+		rp, _, mockMd, _ := setupTestRAIDProvisioner(t)
+		mockMd.On("GetArrayState", device).Return(&mdadm.ArrayState{State: "clean"}, nil).Times(1)
+
+		path, err := rp.GetVolumePath(&testRaidVolume)
+		assert.Nil(t, err)
+		assert.Equal(t, device, path)
+	})
+
+	t.Run("Array is assembled without missing member", func(t *testing.T) {
+		rp, _, mockMd, _ := setupTestRAIDProvisioner(t)
+		assert.Nil(t, rp.k8sClient.DeleteCR(testCtx, &drivecrd.Drive{
+			ObjectMeta: testDriveCR.ObjectMeta,
+		}))
+		mockMd.On("GetArrayState", device).Return(nil, errTest).Times(1)
+		mockMd.On("Assemble", device, testRaidMembers[1:]).Return(nil).Times(1)
+
+		path, err := rp.GetVolumePath(&testRaidVolume)
+		assert.Nil(t, err)
+		assert.Equal(t, device, path)
+	})
+
+	t.Run("Assemble failed", func(t *testing.T) {
+		rp, _, mockMd, _ := setupTestRAIDProvisioner(t)
+		mockMd.On("GetArrayState", device).Return(nil, errTest).Times(1)
+		mockMd.On("Assemble", device, testRaidMembers).Return(errTest).Times(1)
+
+		_, err := rp.GetVolumePath(&testRaidVolume)
+		assert.Equal(t, errTest, err)
+	})
+}
@@ -0,0 +1,124 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utilwrappers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/blkdiscard"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/blockcopy"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/hdparm"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/nvmecli"
+)
+
+// SanitizeCheckInterval is the interval of checking progress of NVMe sanitize operation
+var SanitizeCheckInterval = 10 * time.Second
+
+// EraseProgressFunc is called during erase with percentage of completion
+type EraseProgressFunc func(progress int32)
+
+// EraseOperations is a high-level interface that encapsulates erasing of the whole drive with different policies
+type EraseOperations interface {
+	// Erase erases device according to the policy, blocks until erase is finished
+	Erase(ctx context.Context, policy, device string, progress EraseProgressFunc) error
+}
+
+// EraseOperationsImpl is a base implementation for EraseOperations interface
+type EraseOperationsImpl struct {
+	blockCopy  blockcopy.WrapBlockCopy
+	blkdiscard blkdiscard.WrapBlkdiscard
+	nvmecli    nvmecli.WrapNvmecli
+	hdparm     hdparm.WrapHdparm
+	log        *logrus.Entry
+}
+
+// NewEraseOperationsImpl constructor for EraseOperationsImpl and returns pointer on it
+func NewEraseOperationsImpl(e command.CmdExecutor, log *logrus.Logger) *EraseOperationsImpl {
+	return &EraseOperationsImpl{
+		blockCopy:  blockcopy.NewBlockCopy(),
+		blkdiscard: blkdiscard.NewBlkdiscard(e),
+		nvmecli:    nvmecli.NewNVMECLI(e, log),
+		hdparm:     hdparm.NewHDParm(e, log),
+		log:        log.WithField("component", "EraseOperations"),
+	}
+}
+
+// Erase erases device according to the policy and reports progress if erase method supports it
+// Receives golang context, erase policy, path of the device and optional progress callback
+// Returns error if policy isn't supported or erase failed
+func (eo *EraseOperationsImpl) Erase(ctx context.Context, policy, device string, progress EraseProgressFunc) error {
+	ll := eo.log.WithFields(logrus.Fields{
+		"method": "Erase",
+		"device": device,
+	})
+	ll.Infof("Erasing device with policy %s", policy)
+
+	if progress == nil {
+		progress = func(int32) {}
+	}
+
+	switch policy {
+	case apiV1.ErasePolicyZeroFill:
+		return eo.blockCopy.ZeroFill(ctx, device, func(copied, total int64) {
+			if total > 0 {
+				progress(int32(copied * 100 / total))
+			}
+		})
+	case apiV1.ErasePolicyDiscard:
+		return eo.blkdiscard.Discard(device)
+	case apiV1.ErasePolicyNVMeFormat:
+		return eo.nvmecli.Format(device)
+	case apiV1.ErasePolicyNVMeSanitize:
+		if err := eo.nvmecli.Sanitize(device); err != nil {
+			return err
+		}
+		return eo.waitSanitize(ctx, device, progress)
+	case apiV1.ErasePolicyATASecureErase:
+		return eo.hdparm.SecurityErase(device)
+	}
+
+	return fmt.Errorf("erase policy %s isn't supported", policy)
+}
+
+// waitSanitize polls sanitize log of the device until sanitize operation is finished
+func (eo *EraseOperationsImpl) waitSanitize(ctx context.Context, device string, progress EraseProgressFunc) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("context is done while sanitize of %s is in progress", device)
+		case <-time.After(SanitizeCheckInterval):
+			status, err := eo.nvmecli.GetSanitizeStatus(device)
+			if err != nil {
+				return err
+			}
+			switch {
+			case status.Failed:
+				return fmt.Errorf("sanitize of %s failed", device)
+			case !status.InProgress:
+				progress(100)
+				return nil
+			}
+			progress(status.Progress)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utilwrappers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/nvmecli"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+)
+
+var testEraseDevice = "/dev/sda"
+
+func setupEraseOperations() (*EraseOperationsImpl, *mocklu.MockWrapBlockCopy, *mocklu.MockWrapBlkdiscard,
+	*mocklu.MockWrapNvmecli, *mocklu.MockWrapHdparm) {
+	var (
+		eo         = NewEraseOperationsImpl(&command.Executor{}, logrus.New())
+		blockCopy  = &mocklu.MockWrapBlockCopy{}
+		blkdiscard = &mocklu.MockWrapBlkdiscard{}
+		nvme       = &mocklu.MockWrapNvmecli{}
+		hdparm     = &mocklu.MockWrapHdparm{}
+	)
+	eo.blockCopy = blockCopy
+	eo.blkdiscard = blkdiscard
+	eo.nvmecli = nvme
+	eo.hdparm = hdparm
+	return eo, blockCopy, blkdiscard, nvme, hdparm
+}
+
+func TestEraseOperationsImpl_Erase(t *testing.T) {
+	testErr := errors.New("error")
+	ctx := context.Background()
+
+	eo, blockCopy, blkdiscard, nvme, hdparm := setupEraseOperations()
+	blockCopy.On("ZeroFill", testEraseDevice).Return(nil).Once()
+	blkdiscard.On("Discard", testEraseDevice).Return(nil).Once()
+	nvme.On("Format", testEraseDevice).Return(nil).Once()
+	hdparm.On("SecurityErase", testEraseDevice).Return(testErr).Once()
+
+	assert.Nil(t, eo.Erase(ctx, apiV1.ErasePolicyZeroFill, testEraseDevice, nil))
+	assert.Nil(t, eo.Erase(ctx, apiV1.ErasePolicyDiscard, testEraseDevice, nil))
+	assert.Nil(t, eo.Erase(ctx, apiV1.ErasePolicyNVMeFormat, testEraseDevice, nil))
+	assert.Equal(t, testErr, eo.Erase(ctx, apiV1.ErasePolicyATASecureErase, testEraseDevice, nil))
+	assert.NotNil(t, eo.Erase(ctx, apiV1.ErasePolicyWipeFS, testEraseDevice, nil))
+	blockCopy.AssertExpectations(t)
+	blkdiscard.AssertExpectations(t)
+	nvme.AssertExpectations(t)
+	hdparm.AssertExpectations(t)
+}
+
+func TestEraseOperationsImpl_Erase_Sanitize(t *testing.T) {
+	SanitizeCheckInterval = time.Millisecond
+	ctx := context.Background()
+
+	t.Run("Sanitize is completed", func(t *testing.T) {
+		eo, _, _, nvme, _ := setupEraseOperations()
+		nvme.On("Sanitize", testEraseDevice).Return(nil).Once()
+		nvme.On("GetSanitizeStatus", testEraseDevice).
+			Return(&nvmecli.SanitizeStatus{Progress: 50, InProgress: true}, nil).Once()
+		nvme.On("GetSanitizeStatus", testEraseDevice).
+			Return(&nvmecli.SanitizeStatus{Progress: 100}, nil).Once()
+
+		var reported []int32
+		err := eo.Erase(ctx, apiV1.ErasePolicyNVMeSanitize, testEraseDevice, func(progress int32) {
+			reported = append(reported, progress)
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []int32{50, 100}, reported)
+	})
+
+	t.Run("Sanitize failed", func(t *testing.T) {
+		eo, _, _, nvme, _ := setupEraseOperations()
+		nvme.On("Sanitize", testEraseDevice).Return(nil).Once()
+		nvme.On("GetSanitizeStatus", testEraseDevice).
+			Return(&nvmecli.SanitizeStatus{Failed: true}, nil).Once()
+
+		assert.NotNil(t, eo.Erase(ctx, apiV1.ErasePolicyNVMeSanitize, testEraseDevice, nil))
+	})
+
+	t.Run("Context is done", func(t *testing.T) {
+		eo, _, _, nvme, _ := setupEraseOperations()
+		nvme.On("Sanitize", testEraseDevice).Return(nil).Once()
+		cancelledCtx, cancelFn := context.WithCancel(ctx)
+		cancelFn()
+
+		SanitizeCheckInterval = time.Hour
+		defer func() { SanitizeCheckInterval = time.Millisecond }()
+		assert.NotNil(t, eo.Erase(cancelledCtx, apiV1.ErasePolicyNVMeSanitize, testEraseDevice, nil))
+	})
+}
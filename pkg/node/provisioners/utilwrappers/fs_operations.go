@@ -36,7 +36,7 @@ type FSOperations interface {
 	// UnmountWithCheck unmount operation
 	UnmountWithCheck(path string) error
 	// CreateFSIfNotExist checks FS and creates one if not exist
-	CreateFSIfNotExist(fsType fs.FileSystem, device string) error
+	CreateFSIfNotExist(fsType fs.FileSystem, device, options string) error
 	fs.WrapFS
 }
 
@@ -185,9 +185,9 @@ func (fsOp *FSOperationsImpl) MountFakeTmpfs(volumeID, dst string) error {
 		lsblk <device> --output FSTYPE --noheadings
 		# Check output
 
-		mkfs.<fsType> <device>
+		mkfs.<fsType> <options> <device>
 */
-func (fsOp *FSOperationsImpl) CreateFSIfNotExist(fsType fs.FileSystem, device string) error {
+func (fsOp *FSOperationsImpl) CreateFSIfNotExist(fsType fs.FileSystem, device, options string) error {
 	ll := fsOp.log.WithFields(logrus.Fields{
 		"method": "CreateFSIfNotExist",
 	})
@@ -208,7 +208,7 @@ func (fsOp *FSOperationsImpl) CreateFSIfNotExist(fsType fs.FileSystem, device st
 	}
 
 	// create FS
-	err = fsOp.CreateFS(fsType, device)
+	err = fsOp.CreateFS(fsType, device, options)
 	if err != nil {
 		ll.Errorf("Unable to create FS type %s on %s: %v", fsType, device, err)
 		return err
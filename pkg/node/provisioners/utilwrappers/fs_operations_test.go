@@ -229,9 +229,9 @@ func TestFSOperationsImpl_CreateFSIfNotExist_NoFS(t *testing.T) {
 	fsOps.WrapFS = wrapFS
 
 	wrapFS.On("GetFSType", path).Return("", nil).Once()
-	wrapFS.On("CreateFS", fs.FileSystem(fsType), path).Return(nil).Once()
+	wrapFS.On("CreateFS", fs.FileSystem(fsType), path, "").Return(nil).Once()
 
-	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path)
+	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, "")
 	assert.Nil(t, err)
 }
 
@@ -247,7 +247,7 @@ func TestFSOperationsImpl_CreateFSIfNotExist_FSExists(t *testing.T) {
 
 	wrapFS.On("GetFSType", path).Return(fsType, nil).Once()
 
-	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path)
+	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, "")
 	assert.Nil(t, err)
 }
 
@@ -263,7 +263,7 @@ func TestFSOperationsImpl_CreateFSIfNotExist_OtherFS(t *testing.T) {
 
 	wrapFS.On("GetFSType", path).Return("other_FS", nil).Once()
 
-	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path)
+	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, "")
 	assert.NotNil(t, err)
 }
 
@@ -279,7 +279,7 @@ func TestFSOperationsImpl_CreateFSIfNotExist_CheckError(t *testing.T) {
 
 	wrapFS.On("GetFSType", path).Return("", errors.New("some_error")).Once()
 
-	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path)
+	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, "")
 	assert.NotNil(t, err)
 }
 
@@ -294,8 +294,8 @@ func TestFSOperationsImpl_CreateFSIfNotExist_CreateError(t *testing.T) {
 	fsOps.WrapFS = wrapFS
 
 	wrapFS.On("GetFSType", path).Return("", nil).Once()
-	wrapFS.On("CreateFS", fs.FileSystem(fsType), path).Return(errors.New("some_error")).Once()
+	wrapFS.On("CreateFS", fs.FileSystem(fsType), path, "").Return(errors.New("some_error")).Once()
 
-	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path)
+	err = fsOps.CreateFSIfNotExist(fs.FileSystem(fsType), path, "")
 	assert.NotNil(t, err)
 }
@@ -24,12 +24,14 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/dell/csi-baremetal/pkg/base/command"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
 	ph "github.com/dell/csi-baremetal/pkg/base/linuxutils/partitionhelper"
 	"github.com/dell/csi-baremetal/pkg/metrics"
 )
 
 // PartitionOperations is a high-level interface
-// that encapsulates all low-level operations with partitions on node
+// that encapsulates all low-level operations with partitions on node,
+// persistent links of the devices, e.g. /dev/disk/by-id/wwn-<WWN>, are resolved to the current kernel names
 type PartitionOperations interface {
 	// PreparePartition is fully prepare partition on node for use
 	PreparePartition(p Partition) (*Partition, error)
@@ -97,6 +99,7 @@ func (d *PartitionOperationsImpl) PreparePartition(p Partition) (*Partition, err
 		"volumeID": p.PartUUID,
 	})
 	ll.Debugf("Processing for partition %#v", p)
+	p.Device = lsblk.ResolveDevicePath(p.Device)
 
 	exist, err := d.IsPartitionExists(p.Device, p.Num)
 	if err != nil {
@@ -152,6 +155,7 @@ func (d *PartitionOperationsImpl) ReleasePartition(p Partition) error {
 		"method":   "ReleasePartition",
 		"volumeID": p.PartUUID,
 	}).Infof("Processing for %v", p)
+	p.Device = lsblk.ResolveDevicePath(p.Device)
 
 	exist, err := d.IsPartitionExists(p.Device, p.Num)
 	if err != nil {
@@ -172,6 +176,7 @@ func (d *PartitionOperationsImpl) SearchPartName(device, partUUID string) string
 		"volumeID": partUUID,
 	})
 	ll.Debugf("Search partition number for device %s and uuid %s", device, partUUID)
+	device = lsblk.ResolveDevicePath(device)
 
 	var (
 		partName string
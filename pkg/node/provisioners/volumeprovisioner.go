@@ -31,6 +31,8 @@ const (
 	DriveBasedVolumeType VolumeType = "DriveBased"
 	// LVMBasedVolumeType represents volume that based on Volume Group
 	LVMBasedVolumeType VolumeType = "LVMBased"
+	// RAIDBasedVolumeType represents volume that based on software RAID array of several drives
+	RAIDBasedVolumeType VolumeType = "RAIDBased"
 )
 
 // Provisioner is a high-level interface that encapsulates all low-level work with volumes on node
@@ -23,11 +23,14 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	k8sError "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -47,20 +50,23 @@ import (
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/command"
 	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/blockcopy"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/cgroup"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/datadiscover"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/datadiscover/types"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lvm"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/mdadm"
 	ph "github.com/dell/csi-baremetal/pkg/base/linuxutils/partitionhelper"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	"github.com/dell/csi-baremetal/pkg/common"
 	"github.com/dell/csi-baremetal/pkg/eventing"
 	"github.com/dell/csi-baremetal/pkg/metrics"
 	metricsC "github.com/dell/csi-baremetal/pkg/metrics/common"
+	tuningconf "github.com/dell/csi-baremetal/pkg/node/blocktuning/common"
+	tuningops "github.com/dell/csi-baremetal/pkg/node/blocktuning/operations"
 	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
 	"github.com/dell/csi-baremetal/pkg/node/provisioners/utilwrappers"
-	wbtconf "github.com/dell/csi-baremetal/pkg/node/wbt/common"
-	wbtops "github.com/dell/csi-baremetal/pkg/node/wbt/operations"
 )
 
 const (
@@ -105,9 +111,9 @@ type VolumeManager struct {
 	lvmOps lvm.WrapLVM
 	// uses for running lsblk util
 	listBlk lsblk.WrapLsblk
-	// uses for disable/enable WBT
-	wbtOps    wbtops.WrapWbt
-	wbtConfig *wbtconf.WbtConfig
+	// uses for changing block device queue settings
+	tuningOps    tuningops.WrapBlockTuning
+	tuningConfig *tuningconf.Config
 
 	// uses for searching suitable Available Capacity
 	acProvider common.AvailableCapacityOperations
@@ -134,9 +140,32 @@ type VolumeManager struct {
 	// metrics
 	metricDriveMgrDuration metrics.Statistic
 	metricDriveMgrCount    prometheus.Gauge
+	metricDriveTelemetry   *driveTelemetry
 
 	// discover data on drive
 	dataDiscover types.WrapDataDiscover
+
+	// copies data for volumes created from snapshot or another volume
+	blockCopy blockcopy.WrapBlockCopy
+	// IDs of volumes which data is being copied at the moment
+	clones   map[string]struct{}
+	clonesMu sync.Mutex
+
+	// erases drives of released volumes according to volume erase policy
+	eraseOps utilwrappers.EraseOperations
+	// IDs of volumes which drive is being erased at the moment
+	erases   map[string]struct{}
+	erasesMu sync.Mutex
+
+	// uses for reading state of RAID arrays of RAID volumes
+	mdOps mdadm.WrapMdadm
+
+	// uses for applying IO limits of the volumes to cgroups of the pods
+	cgroupOps cgroup.WrapCgroup
+
+	// whether DriveManager doesn't implement WatchDrives stream, the stream isn't opened again then
+	watchUnimplemented   bool
+	watchUnimplementedMu sync.Mutex
 }
 
 // driveStates internal struct, holds info about drive updates
@@ -169,6 +198,8 @@ type updatedDrive struct {
 const (
 	// DiscoverDrivesTimeout is the timeout for Discover method
 	DiscoverDrivesTimeout = 300 * time.Second
+	// driveEventsBufferSize allows to receive the whole batch of drive events before handling of the first one
+	driveEventsBufferSize = 16
 	// VolumeOperationsTimeout is the timeout for local Volume creation/deletion
 	VolumeOperationsTimeout = 900 * time.Second
 	// amount of reconcile requests that could be processed simultaneously
@@ -197,7 +228,8 @@ func NewVolumeManager(
 		Name: "discovery_drive_count",
 		Help: "last drive count discovered",
 	})
-	for _, c := range []prometheus.Collector{driveMgrDuration.Collect(), driveMgrCount} {
+	driveTelemetry := newDriveTelemetry()
+	for _, c := range append([]prometheus.Collector{driveMgrDuration.Collect(), driveMgrCount}, driveTelemetry.collectors()...) {
 		if err := prometheus.Register(c); err != nil {
 			logger.WithField("component", "NewVolumeManager").
 				Errorf("Failed to register metric: %v", err)
@@ -207,7 +239,6 @@ func NewVolumeManager(
 	partImpl := ph.NewWrapPartitionImpl(executor, logger)
 	lvmOps := lvm.NewLVM(executor, logger)
 	fsOps := utilwrappers.NewFSOperationsImpl(executor, logger)
-	wbtOps := wbtops.NewWbt(executor)
 
 	vm := &VolumeManager{
 		k8sClient:      k8sClient,
@@ -219,12 +250,13 @@ func NewVolumeManager(
 		provisioners: map[p.VolumeType]p.Provisioner{
 			p.DriveBasedVolumeType: p.NewDriveProvisioner(executor, k8sClient, logger),
 			p.LVMBasedVolumeType:   p.NewLVMProvisioner(executor, k8sClient, logger),
+			p.RAIDBasedVolumeType:  p.NewRAIDProvisioner(executor, k8sClient, logger),
 		},
 		fsOps:                  fsOps,
 		lvmOps:                 lvmOps,
 		listBlk:                lsblk.NewLSBLK(logger),
 		partOps:                partImpl,
-		wbtOps:                 wbtOps,
+		tuningOps:              tuningops.NewBlockTuning(tuningops.DefaultSysfsRoot),
 		nodeID:                 nodeID,
 		nodeName:               nodeName,
 		log:                    logger.WithField("component", "VolumeManager"),
@@ -234,7 +266,14 @@ func NewVolumeManager(
 		systemDrivesUUIDs:      make([]string, 0),
 		metricDriveMgrDuration: driveMgrDuration,
 		metricDriveMgrCount:    driveMgrCount,
+		metricDriveTelemetry:   driveTelemetry,
 		dataDiscover:           datadiscover.NewDataDiscover(fsOps, partImpl, lvmOps),
+		blockCopy:              blockcopy.NewBlockCopy(),
+		clones:                 make(map[string]struct{}),
+		eraseOps:               utilwrappers.NewEraseOperationsImpl(executor, logger),
+		erases:                 make(map[string]struct{}),
+		mdOps:                  mdadm.NewMdadm(executor, logger),
+		cgroupOps:              cgroup.NewCgroup(cgroup.DefaultRoot),
 	}
 	return vm
 }
@@ -461,6 +500,10 @@ func (m *VolumeManager) prepareVolume(ctx context.Context, volume *volumecrd.Vol
 		"volumeID": volume.Spec.Id,
 	})
 
+	if volume.Spec.ContentSourceId != "" {
+		return m.prepareVolumeFromSource(ctx, volume)
+	}
+
 	newStatus := apiV1.Created
 
 	err := m.getProvisionerForVolume(&volume.Spec).PrepareVolume(&volume.Spec)
@@ -478,8 +521,8 @@ func (m *VolumeManager) prepareVolume(ctx context.Context, volume *volumecrd.Vol
 	return ctrl.Result{}, err
 }
 
-// handleRemovingStatus handles volume CR with removing CSIStatus - removed real storage (partition/lv) and
-// update corresponding volume CR's CSIStatus
+// handleRemovingStatus handles volume CR with removing CSIStatus - removed real storage (partition/lv),
+// starts erase of the drive if volume erase policy requires it and update corresponding volume CR's CSIStatus
 // uses as a step for Reconcile for Volume CR
 func (m *VolumeManager) handleRemovingStatus(ctx context.Context, volume *volumecrd.Volume) (ctrl.Result, error) {
 	ll := m.log.WithFields(logrus.Fields{
@@ -487,10 +530,18 @@ func (m *VolumeManager) handleRemovingStatus(ctx context.Context, volume *volume
 		"volumeID": volume.Name,
 	})
 
+	if m.isEraseInProgress(volume.Spec.Id) {
+		ll.Debug("Erase of the drive is in progress")
+		return ctrl.Result{RequeueAfter: EraseProgressCheckInterval}, nil
+	}
+
 	newStatus, err := m.performVolumeRemoving(ctx, volume)
 	if err != nil && newStatus == "" {
 		return ctrl.Result{Requeue: true}, err
 	}
+	if newStatus == apiV1.Removed && m.isDriveEraseRequired(volume) {
+		return m.startDriveErase(ctx, volume)
+	}
 
 	volume.Spec.CSIStatus = newStatus
 	if updateErr := m.k8sClient.UpdateCRWithAttempts(ctx, volume, 10); updateErr != nil {
@@ -538,6 +589,9 @@ func (m *VolumeManager) performVolumeRemoving(ctx context.Context, volume *volum
 
 // SetupWithManager registers VolumeManager to ControllerManager
 func (m *VolumeManager) SetupWithManager(mgr ctrl.Manager) error {
+	if err := m.setupSnapshotController(mgr); err != nil {
+		return err
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&volumecrd.Volume{}).
 		WithOptions(controller.Options{
@@ -587,6 +641,7 @@ func (m *VolumeManager) Discover() error {
 		return err
 	}
 	m.metricDriveMgrCount.Set(float64(len(drivesResponse.Disks)))
+	m.exportDriveTelemetry(drivesResponse.Disks)
 
 	updates, err := m.updateDrivesCRs(ctx, drivesResponse.Disks)
 	if err != nil {
@@ -609,6 +664,108 @@ func (m *VolumeManager) Discover() error {
 	return nil
 }
 
+// Monitor exports telemetry of the drives and checks state of RAID arrays and usage of thin pools.
+// It runs periodically whether drive events stream is open or not, Discover runs rarely while the stream is open
+func (m *VolumeManager) Monitor() error {
+	ctx, cancelFn := context.WithTimeout(context.Background(), DiscoverDrivesTimeout)
+	defer cancelFn()
+
+	drivesResponse, err := m.driveMgrClient.GetDrivesList(ctx, &api.DrivesRequest{NodeId: m.nodeID})
+	if err != nil {
+		return err
+	}
+	m.exportDriveTelemetry(drivesResponse.Disks)
+
+	m.updateRaidVolumesHealth(ctx)
+	m.updateThinPoolsUsage(ctx)
+	return nil
+}
+
+// WatchDriveEvents opens WatchDrives stream to DriveManager and sends received events to the returned channel
+// The channel is closed when the stream fails, e.g. DriveManager is restarted or doesn't support the stream,
+// or when ctx is done. Unimplemented error is returned once DriveManager reported that it doesn't support the stream
+func (m *VolumeManager) WatchDriveEvents(ctx context.Context) (<-chan *api.DriveEvent, error) {
+	ll := m.log.WithField("method", "WatchDriveEvents")
+	if m.isWatchUnimplemented() {
+		return nil, status.Error(codes.Unimplemented, "drive manager doesn't support watching of the drives")
+	}
+	stream, err := m.driveMgrClient.WatchDrives(ctx, &api.DrivesRequest{NodeId: m.nodeID})
+	if err != nil {
+		m.checkWatchUnimplemented(err)
+		return nil, err
+	}
+	events := make(chan *api.DriveEvent, driveEventsBufferSize)
+	go func() {
+		defer close(events)
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				// grpc reports unimplemented stream on the first Recv only
+				if m.checkWatchUnimplemented(err) {
+					ll.Infof("Drive manager doesn't support drive events: %v", err)
+					return
+				}
+				if ctx.Err() == nil {
+					ll.Warnf("Drive events stream failed: %v", err)
+				}
+				return
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// checkWatchUnimplemented remembers that DriveManager doesn't support WatchDrives stream if err is Unimplemented
+func (m *VolumeManager) checkWatchUnimplemented(err error) bool {
+	if status.Code(err) != codes.Unimplemented {
+		return false
+	}
+	m.watchUnimplementedMu.Lock()
+	m.watchUnimplemented = true
+	m.watchUnimplementedMu.Unlock()
+	return true
+}
+
+func (m *VolumeManager) isWatchUnimplemented() bool {
+	m.watchUnimplementedMu.Lock()
+	defer m.watchUnimplementedMu.Unlock()
+	return m.watchUnimplemented
+}
+
+// HandleDriveEvent updates Drive CR of the drive from DriveManager event without discovery of all drives,
+// DriveDiscovered and drive status and health events are sent straight away. Data is discovered on the new drive
+func (m *VolumeManager) HandleDriveEvent(event *api.DriveEvent) error {
+	ll := m.log.WithField("method", "HandleDriveEvent")
+	if event.Drive == nil {
+		return fmt.Errorf("drive event %s doesn't contain drive", event.Type)
+	}
+	ll.Infof("Drive %s is %s", event.Drive.SerialNumber, event.Type)
+	ctx, cancelFn := context.WithTimeout(context.Background(), DiscoverDrivesTimeout)
+	defer cancelFn()
+
+	// telemetry isn't saved in Drive CR
+	m.exportDriveEventTelemetry(event)
+	updates, err := m.updateDriveEventCR(ctx, event)
+	if err != nil {
+		return fmt.Errorf("updateDriveEventCR return error: %v", err)
+	}
+	m.handleDriveUpdates(ctx, updates)
+	for _, created := range updates.Created {
+		if created.Spec.IsSystem {
+			continue
+		}
+		if err = m.discoverDataOnDrive(created); err != nil {
+			ll.Errorf("Failed to discover data on drive %s, err: %v", created.Spec.SerialNumber, err)
+		}
+	}
+	return nil
+}
+
 // updateDrivesCRs updates Drives CRs based on provided list of Drives.
 // Receives golang context and slice of discovered api.Drive structs usually got from DriveManager
 // returns struct with information about drives updates
@@ -643,61 +800,14 @@ func (m *VolumeManager) updateDrivesCRs(ctx context.Context, drivesFromMgr []*ap
 				if searchSystemDrives && driveCR.Spec.IsSystem {
 					m.systemDrivesUUIDs = append(m.systemDrivesUUIDs, driveCR.Spec.UUID)
 				}
-				if value, ok := driveCR.GetAnnotations()[driveHealthOverrideAnnotation]; ok {
-					m.overrideDriveHealth(drivePtr, value, driveCR.Name)
-				}
-				if driveCR.Equals(drivePtr) {
-					updates.AddNotChanged(&driveCR)
-				} else {
-					previousState := driveCR.DeepCopy()
-					// copy fields which aren't reported by drive manager
-					drivePtr.UUID = driveCR.Spec.UUID
-					drivePtr.Usage = driveCR.Spec.Usage
-					drivePtr.IsSystem = driveCR.Spec.IsSystem
-					drivePtr.IsClean = driveCR.Spec.IsClean
-
-					toUpdate := driveCR
-					toUpdate.Spec = *drivePtr
-					if err := m.k8sClient.UpdateCR(ctx, &toUpdate); err != nil {
-						ll.Errorf("Failed to update drive CR (health/status) %v, error %v", toUpdate, err)
-						updates.AddNotChanged(previousState)
-					} else {
-						driveCRs[index] = toUpdate
-						updates.AddUpdated(previousState, &toUpdate)
-					}
-				}
+				driveCRs[index] = *m.updateDriveCR(ctx, &driveCR, drivePtr, updates)
 				break
 			}
 		}
-		if !exist && drivePtr.SerialNumber != "" {
-			// don't create CR for OFFLINE drives
-			// todo do we need to deprecate status field reported by drive manager?
-			// todo https://github.com/dell/csi-baremetal/issues/202
-			if drivePtr.Status == apiV1.DriveStatusOffline {
-				continue
-			}
-			// drive CR does not exist, try to create it
-			toCreateSpec := *drivePtr
-			toCreateSpec.NodeId = m.nodeID
-			toCreateSpec.UUID = uuid.New().String()
-			// TODO: what operational status should be if drivemgr reported drive with not a good health
-			toCreateSpec.Usage = apiV1.DriveUsageInUse
-			toCreateSpec.IsClean = true
-			isSystem, err := m.isDriveSystem(drivePtr.Path)
-			if err != nil {
-				ll.Errorf("Failed to determine if drive %v is system, error: %v", drivePtr, err)
+		if !exist {
+			if driveCR := m.createDriveCR(ctx, drivePtr, updates); driveCR != nil {
+				driveCRs = append(driveCRs, *driveCR)
 			}
-			if isSystem {
-				toCreateSpec.IsClean = false
-				m.systemDrivesUUIDs = append(m.systemDrivesUUIDs, toCreateSpec.UUID)
-			}
-			toCreateSpec.IsSystem = isSystem
-			driveCR := m.k8sClient.ConstructDriveCR(toCreateSpec.UUID, toCreateSpec)
-			if err := m.k8sClient.CreateCR(ctx, driveCR.Name, driveCR); err != nil {
-				ll.Errorf("Failed to create drive CR %v, error: %v", driveCR, err)
-			}
-			updates.AddCreated(driveCR)
-			driveCRs = append(driveCRs, *driveCR)
 		}
 	}
 
@@ -716,27 +826,128 @@ func (m *VolumeManager) updateDrivesCRs(ctx context.Context, drivesFromMgr []*ap
 		}
 
 		if !wasDiscovered {
-			ll.Warnf("Set status %s for drive %v", apiV1.DriveStatusOffline, d.Spec)
-			previousState := d.DeepCopy()
-			toUpdate := d
-			// TODO: which operational status should be in case when there is drive CR that doesn't have corresponding drive from drivemgr response
-			toUpdate.Spec.Status = apiV1.DriveStatusOffline
-			if value, ok := d.GetAnnotations()[driveHealthOverrideAnnotation]; ok {
-				m.overrideDriveHealth(&toUpdate.Spec, value, d.Name)
-			} else {
-				toUpdate.Spec.Health = apiV1.HealthUnknown
-			}
-			if err := m.k8sClient.UpdateCR(ctx, &toUpdate); err != nil {
-				ll.Errorf("Failed to update drive CR %v, error %v", toUpdate, err)
-				updates.AddNotChanged(previousState)
-			} else {
-				updates.AddUpdated(previousState, &toUpdate)
-			}
+			d := d
+			m.setDriveOffline(ctx, &d, updates)
 		}
 	}
 	return updates, nil
 }
 
+// updateDriveEventCR updates Drive CR of the drive from DriveManager event, Drive CR is created for the new drive
+// Returns struct with information about drive update
+func (m *VolumeManager) updateDriveEventCR(ctx context.Context, event *api.DriveEvent) (*driveUpdates, error) {
+	driveCRs, err := m.cachedCrHelper.GetDriveCRs(m.nodeID)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		updates = new(driveUpdates)
+		driveCR *drivecrd.Drive
+	)
+	for i := range driveCRs {
+		if m.drivesAreTheSame(event.Drive, &driveCRs[i].Spec) {
+			driveCR = &driveCRs[i]
+			break
+		}
+	}
+	switch {
+	case event.Type == apiV1.DriveEventRemoved:
+		if driveCR != nil && driveCR.Spec.Status != apiV1.DriveStatusOffline {
+			m.setDriveOffline(ctx, driveCR, updates)
+		}
+	case driveCR != nil:
+		m.updateDriveCR(ctx, driveCR, event.Drive, updates)
+	default:
+		m.createDriveCR(ctx, event.Drive, updates)
+	}
+	return updates, nil
+}
+
+// updateDriveCR updates Drive CR with the drive reported by DriveManager if they aren't equal
+// Returns actual state of Drive CR
+func (m *VolumeManager) updateDriveCR(ctx context.Context, driveCR *drivecrd.Drive, drivePtr *api.Drive,
+	updates *driveUpdates) *drivecrd.Drive {
+	ll := m.log.WithField("method", "updateDriveCR")
+	if value, ok := driveCR.GetAnnotations()[driveHealthOverrideAnnotation]; ok {
+		m.overrideDriveHealth(drivePtr, value, driveCR.Name)
+	}
+	if driveCR.Equals(drivePtr) {
+		updates.AddNotChanged(driveCR)
+		return driveCR
+	}
+	previousState := driveCR.DeepCopy()
+	// copy fields which aren't reported by drive manager
+	drivePtr.UUID = driveCR.Spec.UUID
+	drivePtr.Usage = driveCR.Spec.Usage
+	drivePtr.IsSystem = driveCR.Spec.IsSystem
+	drivePtr.IsClean = driveCR.Spec.IsClean
+
+	toUpdate := *driveCR
+	toUpdate.Spec = *drivePtr
+	if err := m.k8sClient.UpdateCR(ctx, &toUpdate); err != nil {
+		ll.Errorf("Failed to update drive CR (health/status) %v, error %v", toUpdate, err)
+		updates.AddNotChanged(previousState)
+		return driveCR
+	}
+	updates.AddUpdated(previousState, &toUpdate)
+	return &toUpdate
+}
+
+// createDriveCR creates Drive CR for the drive reported by DriveManager
+// Returns created Drive CR or nil if drive is OFFLINE or doesn't have serial number
+func (m *VolumeManager) createDriveCR(ctx context.Context, drivePtr *api.Drive, updates *driveUpdates) *drivecrd.Drive {
+	ll := m.log.WithField("method", "createDriveCR")
+	// don't create CR for OFFLINE drives
+	// todo do we need to deprecate status field reported by drive manager?
+	// todo https://github.com/dell/csi-baremetal/issues/202
+	if drivePtr.SerialNumber == "" || drivePtr.Status == apiV1.DriveStatusOffline {
+		return nil
+	}
+	// drive CR does not exist, try to create it
+	toCreateSpec := *drivePtr
+	toCreateSpec.NodeId = m.nodeID
+	toCreateSpec.UUID = uuid.New().String()
+	// TODO: what operational status should be if drivemgr reported drive with not a good health
+	toCreateSpec.Usage = apiV1.DriveUsageInUse
+	toCreateSpec.IsClean = true
+	isSystem, err := m.isDriveSystem(drivePtr.Path)
+	if err != nil {
+		ll.Errorf("Failed to determine if drive %v is system, error: %v", drivePtr, err)
+	}
+	if isSystem {
+		toCreateSpec.IsClean = false
+		m.systemDrivesUUIDs = append(m.systemDrivesUUIDs, toCreateSpec.UUID)
+	}
+	toCreateSpec.IsSystem = isSystem
+	driveCR := m.k8sClient.ConstructDriveCR(toCreateSpec.UUID, toCreateSpec)
+	if err := m.k8sClient.CreateCR(ctx, driveCR.Name, driveCR); err != nil {
+		ll.Errorf("Failed to create drive CR %v, error: %v", driveCR, err)
+	}
+	updates.AddCreated(driveCR)
+	return driveCR
+}
+
+// setDriveOffline sets OFFLINE status for Drive CR of the drive which isn't reported by DriveManager
+func (m *VolumeManager) setDriveOffline(ctx context.Context, d *drivecrd.Drive, updates *driveUpdates) {
+	ll := m.log.WithField("method", "setDriveOffline")
+	ll.Warnf("Set status %s for drive %v", apiV1.DriveStatusOffline, d.Spec)
+	previousState := d.DeepCopy()
+	toUpdate := *d
+	// TODO: which operational status should be in case when there is drive CR that doesn't have corresponding drive from drivemgr response
+	toUpdate.Spec.Status = apiV1.DriveStatusOffline
+	if value, ok := d.GetAnnotations()[driveHealthOverrideAnnotation]; ok {
+		m.overrideDriveHealth(&toUpdate.Spec, value, d.Name)
+	} else {
+		toUpdate.Spec.Health = apiV1.HealthUnknown
+	}
+	if err := m.k8sClient.UpdateCR(ctx, &toUpdate); err != nil {
+		ll.Errorf("Failed to update drive CR %v, error %v", toUpdate, err)
+		updates.AddNotChanged(previousState)
+	} else {
+		updates.AddUpdated(previousState, &toUpdate)
+	}
+}
+
 func (m *VolumeManager) handleDriveUpdates(ctx context.Context, updates *driveUpdates) {
 	for _, updDrive := range updates.Updated {
 		m.handleDriveStatusChange(ctx, updDrive)
@@ -784,10 +995,12 @@ func (m *VolumeManager) discoverDataOnDrives() error {
 	locations := make(map[string]struct{}, len(volumeCRs))
 	for _, v := range volumeCRs {
 		locations[v.Spec.Location] = struct{}{}
+		for _, location := range v.Spec.RaidLocations {
+			locations[location] = struct{}{}
+		}
 	}
 
 	for _, drive := range driveCRs {
-		var discoverResult *types.DiscoverResult
 		drive := drive
 		if drive.Spec.IsSystem && m.isDriveInLVG(drive.Spec) {
 			continue
@@ -798,23 +1011,32 @@ func (m *VolumeManager) discoverDataOnDrives() error {
 			}
 			continue
 		}
-		if discoverResult, err = m.dataDiscover.DiscoverData(drive.Spec.Path, drive.Spec.SerialNumber); err != nil {
+		if err = m.discoverDataOnDrive(&drive); err != nil {
 			ll.Errorf("Failed to discover data on drive %s, err: %v", drive.Spec.SerialNumber, err)
-			continue
 		}
-		if discoverResult.HasData {
-			if drive.Spec.IsClean {
-				ll.Info(discoverResult.Message)
-				m.sendEventForDrive(&drive, eventing.DriveHasData, discoverResult.Message)
-				m.changeDriveIsCleanField(&drive, false)
-			}
-			continue
-		}
-		ll.Info(discoverResult.Message)
-		if !drive.Spec.IsClean {
-			m.sendEventForDrive(&drive, eventing.DriveClean, discoverResult.Message)
-			m.changeDriveIsCleanField(&drive, true)
+	}
+	return nil
+}
+
+// discoverDataOnDrive updates IsClean field of the drive without volumes depending on data on it
+func (m *VolumeManager) discoverDataOnDrive(drive *drivecrd.Drive) error {
+	ll := m.log.WithField("method", "discoverDataOnDrive")
+	discoverResult, err := m.dataDiscover.DiscoverData(drive.Spec.Path, drive.Spec.SerialNumber)
+	if err != nil {
+		return err
+	}
+	if discoverResult.HasData {
+		if drive.Spec.IsClean {
+			ll.Info(discoverResult.Message)
+			m.sendEventForDrive(drive, eventing.DriveHasData, discoverResult.Message)
+			m.changeDriveIsCleanField(drive, false)
 		}
+		return nil
+	}
+	ll.Info(discoverResult.Message)
+	if !drive.Spec.IsClean {
+		m.sendEventForDrive(drive, eventing.DriveClean, discoverResult.Message)
+		m.changeDriveIsCleanField(drive, true)
 	}
 	return nil
 }
@@ -941,6 +1163,9 @@ func (m *VolumeManager) updateLVGAnnotation(lvg *lvgcrd.LogicalVolumeGroup, vgFr
 
 // getProvisionerForVolume returns appropriate Provisioner implementation for volume
 func (m *VolumeManager) getProvisionerForVolume(vol *api.Volume) p.Provisioner {
+	if vol.RaidLevel != "" {
+		return m.provisioners[p.RAIDBasedVolumeType]
+	}
 	if util.IsStorageClassLVG(vol.StorageClass) {
 		return m.provisioners[p.LVMBasedVolumeType]
 	}
@@ -962,56 +1187,191 @@ func (m *VolumeManager) handleDriveStatusChange(ctx context.Context, drive updat
 
 	ll.Infof("The new cur status from DriveMgr is %s", cur.Health)
 
-	// Handle resources without LogicalVolumeGroup
-	// Remove AC based on disk with health BAD, SUSPECT, UNKNOWN
 	lvg, err := m.cachedCrHelper.GetLVGByDrive(ctx, cur.UUID)
+	if err != nil {
+		ll.Errorf("Failed get LogicalVolumeGroup CR error: %v", err)
+	}
 	if lvg != nil {
-		name := lvg.Name
-		// TODO handle situation when LVG health is changing from Bad/Suspect to Good https://github.com/dell/csi-baremetal/issues/385
-		lvg.Spec.Health = cur.Health
+		m.handleLVGDriveChange(ctx, lvg, &cur, &prev)
+		return
+	}
+	// Set disk's health status to volume CR
+	volumes, _ := m.cachedCrHelper.GetVolumesByLocation(ctx, cur.UUID)
+	m.updateVolumesHealth(ctx, volumes, cur.Health, &cur)
+}
+
+// handleLVGDriveChange handles health and status change of the drive used by LogicalVolumeGroup.
+// LVG and its volumes inherit the worst health of the LVG drives, capacity of unhealthy LVG is withdrawn by
+// capacity controller and restored when LVG becomes healthy again. Health of thin LVG also depends on usage
+// of its thin pool, see updateThinPoolsUsage
+func (m *VolumeManager) handleLVGDriveChange(ctx context.Context, lvg *lvgcrd.LogicalVolumeGroup, cur, prev *api.Drive) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":  "handleLVGDriveChange",
+		"driveID": cur.UUID,
+	})
+	name := lvg.Name
+	prevHealth := lvg.Spec.Health
+	drivesHealth, inUse := m.getLVGDrivesHealth(lvg, cur)
+	health := drivesHealth
+	if lvg.Spec.Thin {
+		health = worseHealth(health, m.getThinLVGHealth(lvg))
+	}
+	if health != prevHealth {
+		ll.Infof("Setting health %s to LogicalVolumeGroup %s, previous health: %s", health, name, prevHealth)
+		lvg.Spec.Health = health
 		if err := m.k8sClient.UpdateCR(ctx, lvg); err != nil {
 			ll.Errorf("Failed to update lvg CR's %s health status: %v", name, err)
 		}
-		// check for missing disk and re-activate volume group if needed
-		if prev.Status == apiV1.DriveStatusOffline && cur.Status == apiV1.DriveStatusOnline {
-			ll.Infof("Scan volume group %s for IO errors", name)
-			m.recorder.Eventf(lvg, eventing.VolumeGroupScanInvolved, "Check for IO errors")
-			if ok, err := m.lvmOps.VGScan(name); err != nil { //nolint:gocritic
-				ll.Errorf("Failed to scan volume group %s for IO errors: %v", name, err)
-				m.recorder.Eventf(lvg, eventing.VolumeGroupScanFailed, err.Error())
-			} else if ok {
-				// IO errors detected. Need to re-activate volume group
-				ll.Errorf("IO errors detected for volume group %s", name)
-				m.recorder.Eventf(lvg, eventing.VolumeGroupReactivateInvolved,
-					"IO errors detected")
-				if err := m.lvmOps.VGReactivate(name); err != nil {
-					// need to send an event if operation failed
-					ll.Errorf("Failed to re-activate volume group %s: %v", name, err)
-					m.recorder.Eventf(lvg, eventing.VolumeGroupReactivateFailed, err.Error())
-				}
-			} else {
-				ll.Infof("No IO errors detected for volume group %s", name)
+	}
+	// check for missing disk and re-activate volume group if needed
+	if prev.Status == apiV1.DriveStatusOffline && cur.Status == apiV1.DriveStatusOnline {
+		ll.Infof("Scan volume group %s for IO errors", name)
+		m.recorder.Eventf(lvg, eventing.VolumeGroupScanInvolved, "Check for IO errors")
+		if ok, err := m.lvmOps.VGScan(name); err != nil { //nolint:gocritic
+			ll.Errorf("Failed to scan volume group %s for IO errors: %v", name, err)
+			m.recorder.Eventf(lvg, eventing.VolumeGroupScanFailed, err.Error())
+		} else if ok {
+			// IO errors detected. Need to re-activate volume group
+			ll.Errorf("IO errors detected for volume group %s", name)
+			m.recorder.Eventf(lvg, eventing.VolumeGroupReactivateInvolved,
+				"IO errors detected")
+			if err := m.lvmOps.VGReactivate(name); err != nil {
+				// need to send an event if operation failed
+				ll.Errorf("Failed to re-activate volume group %s: %v", name, err)
+				m.recorder.Eventf(lvg, eventing.VolumeGroupReactivateFailed, err.Error())
 			}
+		} else {
+			ll.Infof("No IO errors detected for volume group %s", name)
 		}
-	} else {
-		errMsg := "Failed get LogicalVolumeGroup CR"
+	}
+
+	// usage of thin pool doesn't start release of the volumes
+	volumes, _ := m.cachedCrHelper.GetVolumesByLocation(ctx, name)
+	m.updateVolumesHealth(ctx, volumes, drivesHealth, cur)
+
+	// AC of thin LVG is restored by capacity controller based on usage of the thin pool
+	if prevHealth == apiV1.HealthGood || health != apiV1.HealthGood || lvg.Spec.Thin {
+		return
+	}
+	// drive in release workflow must not get new volumes
+	if !inUse {
+		ll.Warnf("Capacity of LogicalVolumeGroup %s isn't restored, its drives aren't in %s usage",
+			name, apiV1.DriveUsageInUse)
+		return
+	}
+	if err := m.restoreLVGCapacity(ctx, lvg); err != nil {
+		ll.Errorf("Failed to restore capacity of LogicalVolumeGroup %s: %v", name, err)
+	}
+}
+
+// getLVGDrivesHealth returns the worst health of the LogicalVolumeGroup drives and whether all drives are in use,
+// drive which Drive CR isn't found is considered as drive with UNKNOWN health
+func (m *VolumeManager) getLVGDrivesHealth(lvg *lvgcrd.LogicalVolumeGroup, cur *api.Drive) (string, bool) {
+	drives := map[string]*api.Drive{cur.UUID: cur}
+	if len(lvg.Spec.Locations) > 1 {
+		driveCRs, err := m.cachedCrHelper.GetDriveCRs(m.nodeID)
 		if err != nil {
-			errMsg = fmt.Sprintf(errMsg+" error: %v", err)
+			m.log.WithField("method", "getLVGDrivesHealth").Errorf("Failed to get Drive CRs: %v", err)
+		}
+		for i := range driveCRs {
+			if driveCRs[i].Spec.UUID != cur.UUID {
+				drives[driveCRs[i].Spec.UUID] = &driveCRs[i].Spec
+			}
 		}
-		ll.Errorf(errMsg)
 	}
-	// Set disk's health status to volume CR
-	volumes, _ := m.cachedCrHelper.GetVolumesByLocation(ctx, cur.UUID)
+	var (
+		health = apiV1.HealthGood
+		inUse  = true
+	)
+	for _, location := range lvg.Spec.Locations {
+		drive, ok := drives[location]
+		if !ok {
+			health, inUse = worseHealth(health, apiV1.HealthUnknown), false
+			continue
+		}
+		health = worseHealth(health, drive.Health)
+		inUse = inUse && drive.Usage == apiV1.DriveUsageInUse
+	}
+	return health, inUse
+}
+
+// healthSeverity orders health values from the best to the worst
+var healthSeverity = map[string]int{
+	apiV1.HealthGood:    0,
+	apiV1.HealthUnknown: 1,
+	apiV1.HealthSuspect: 2,
+	apiV1.HealthBad:     3,
+}
+
+// worseHealth returns the worst of two health values
+func worseHealth(health1, health2 string) string {
+	if healthSeverity[health2] > healthSeverity[health1] {
+		return health2
+	}
+	return health1
+}
+
+// restoreLVGCapacity sets size of LogicalVolumeGroup AC to its size minus size of volumes and snapshots placed on it,
+// AC size is reset by capacity controller while LVG isn't healthy
+func (m *VolumeManager) restoreLVGCapacity(ctx context.Context, lvg *lvgcrd.LogicalVolumeGroup) error {
+	ac, err := m.cachedCrHelper.GetACByLocation(lvg.Name)
+	if err != nil {
+		return err
+	}
+	volumes, err := m.crHelper.GetVolumesByLocation(ctx, lvg.Name)
+	if err != nil {
+		return err
+	}
+	snapshots, err := m.crHelper.GetSnapshotCRs()
+	if err != nil {
+		return err
+	}
+
+	size := lvg.Spec.Size
+	for _, volume := range volumes {
+		if volume.Spec.CSIStatus != apiV1.Removed {
+			size -= volume.Spec.Size
+		}
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Spec.Location == lvg.Name && snapshot.Spec.CSIStatus != apiV1.Removed {
+			size -= snapshot.Spec.Size
+		}
+	}
+	if size < 0 {
+		size = 0
+	}
+	if ac.Spec.Size == size {
+		return nil
+	}
+	m.log.WithField("method", "restoreLVGCapacity").
+		Infof("Restoring size %d of AC %s, previous size: %d", size, ac.Name, ac.Spec.Size)
+	ac.Spec.Size = size
+	return m.k8sClient.UpdateCR(ctx, ac)
+}
+
+// updateVolumesHealth sets health inherited from the drive to the volumes, volumes with BAD or SUSPECT health
+// enter release workflow
+func (m *VolumeManager) updateVolumesHealth(ctx context.Context, volumes []*volumecrd.Volume, health string,
+	drive *api.Drive) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":  "updateVolumesHealth",
+		"driveID": drive.UUID,
+	})
 	for _, vol := range volumes {
+		// health of RAID volume depends on state of the whole array, see updateRaidVolumesHealth
+		if vol.Spec.LocationType == apiV1.LocationTypeRAID {
+			continue
+		}
 		// skip if health is not changed
-		if vol.Spec.Health == cur.Health {
-			ll.Infof("Volume %s status is already %s", vol.Name, cur.Health)
+		if vol.Spec.Health == health {
+			ll.Infof("Volume %s status is already %s", vol.Name, health)
 			continue
 		}
-		ll.Infof("Setting updated status %s to volume %s", cur.Health, vol.Name)
+		ll.Infof("Setting updated status %s to volume %s", health, vol.Name)
 		// save previous health state
 		prevHealthState := vol.Spec.Health
-		vol.Spec.Health = cur.Health
+		vol.Spec.Health = health
 		// initiate volume release
 		// TODO need to check for specific annotation instead
 		if vol.Spec.Health == apiV1.HealthBad || vol.Spec.Health == apiV1.HealthSuspect {
@@ -1024,15 +1384,17 @@ func (m *VolumeManager) handleDriveStatusChange(ctx context.Context, drive updat
 			ll.Errorf("Failed to update volume CR's %s health status: %v", vol.Name, err)
 		}
 
-		if vol.Spec.Health == apiV1.HealthBad {
+		switch vol.Spec.Health {
+		case apiV1.HealthBad:
 			m.recorder.Eventf(vol, eventing.VolumeBadHealth,
 				"Volume health transitioned from %s to %s. Inherited from %s drive on %s)",
-				prevHealthState, vol.Spec.Health, cur.Health, cur.NodeId)
+				prevHealthState, vol.Spec.Health, drive.Health, drive.NodeId)
+		case apiV1.HealthGood:
+			m.recorder.Eventf(vol, eventing.VolumeGoodHealth,
+				"Volume health transitioned from %s to %s. Inherited from %s drive on %s",
+				prevHealthState, vol.Spec.Health, drive.Health, drive.NodeId)
 		}
 	}
-	// Handle resources with LogicalVolumeGroup
-	// This is not work for the current moment because HAL doesn't monitor disks with LVM
-	// TODO: Handle disk health which are used by LVGs - https://github.com/dell/csi-baremetal/issues/88
 }
 
 // drivesAreTheSame check whether two drive represent same node drive or no
@@ -1065,6 +1427,13 @@ func (m *VolumeManager) createEventsForDriveUpdates(updates *driveUpdates) {
 			m.createEventForDriveHealthChange(
 				updDrive.CurrentState, updDrive.PreviousState.Spec.Health, updDrive.CurrentState.Spec.Health)
 		}
+		// kernel name of the device is changed, e.g. after reboot or controller reset
+		if updDrive.PreviousState.Spec.Path != "" && updDrive.CurrentState.Spec.Path != "" &&
+			updDrive.CurrentState.Spec.Path != updDrive.PreviousState.Spec.Path {
+			m.sendEventForDrive(updDrive.CurrentState, eventing.DrivePathChanged,
+				"Drive path is: %s, previous path: %s.",
+				updDrive.CurrentState.Spec.Path, updDrive.PreviousState.Spec.Path)
+		}
 	}
 }
 
@@ -1079,11 +1448,20 @@ func (m *VolumeManager) createEventForDriveHealthChange(
 		event = eventing.DriveHealthFailure
 	case apiV1.HealthSuspect:
 		event = eventing.DriveHealthSuspect
+		// drive manager predicts failure of the drive
+		if drive.Spec.HealthReason != "" {
+			event = eventing.DriveHealthFailure
+		}
 	case apiV1.HealthUnknown:
 		event = eventing.DriveHealthUnknown
 	default:
 		return
 	}
+	if drive.Spec.HealthReason != "" {
+		m.sendEventForDrive(drive, event,
+			healthMsgTemplate+" Reason: %s.", currentHealth, prevHealth, drive.Spec.HealthReason)
+		return
+	}
 	m.sendEventForDrive(drive, event,
 		healthMsgTemplate, currentHealth, prevHealth)
 }
@@ -1153,7 +1531,7 @@ func (m *VolumeManager) isRootMountpoint(devs []lsblk.BlockDevice) bool {
 
 // addVolumeStatusAnnotation add annotation with volume status to drive
 func (m *VolumeManager) addVolumeStatusAnnotation(drive *drivecrd.Drive, volumeName, status string) {
-	annotationKey := fmt.Sprintf("%s/%s", apiV1.DriveAnnotationVolumeStatusPrefix, volumeName)
+	annotationKey := getVolumeStatusAnnotationKey(volumeName)
 	// init map if empty
 	if drive.Annotations == nil {
 		drive.Annotations = make(map[string]string)
@@ -1161,14 +1539,24 @@ func (m *VolumeManager) addVolumeStatusAnnotation(drive *drivecrd.Drive, volumeN
 	drive.Annotations[annotationKey] = status
 }
 
+// getVolumeStatusAnnotationKey returns key of the drive annotation with status of the volume
+func getVolumeStatusAnnotationKey(volumeName string) string {
+	return fmt.Sprintf("%s/%s", apiV1.DriveAnnotationVolumeStatusPrefix, volumeName)
+}
+
 // handleExpandingStatus handles volume CR with Resizing status, it calls ExpandLV to expand volume
 // To get logical volume name it use LVM provisioner function GetVolumePath
+// Drive based volumes are expanded in NodeExpandVolume request, they are skipped here
 // Receive context, volume CR
 // Return ctrl.DiscoverResult, error
 func (m *VolumeManager) handleExpandingStatus(ctx context.Context, volume *volumecrd.Volume) (ctrl.Result, error) {
 	ll := m.log.WithFields(logrus.Fields{
 		"method": "handleExpandingStatus",
 	})
+	if !util.IsStorageClassLVG(volume.Spec.StorageClass) {
+		ll.Debugf("Volume %s is drive based, it will be expanded in NodeExpandVolume", volume.Name)
+		return ctrl.Result{}, nil
+	}
 	volumePath, err := m.provisioners[p.LVMBasedVolumeType].GetVolumePath(&volume.Spec)
 	if err != nil {
 		ll.Errorf("Failed to get volume path, err: %v", err)
@@ -1250,103 +1638,10 @@ func (m *VolumeManager) overrideDriveHealth(drive *api.Drive, overriddenHealth,
 	}
 }
 
-func (m *VolumeManager) setWbtValue(vol *volumecrd.Volume) error {
-	device, err := m.findDeviceName(vol)
-	if err != nil {
-		return err
-	}
-
-	err = m.wbtOps.SetValue(device, m.wbtConfig.Value)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (m *VolumeManager) restoreWbtValue(vol *volumecrd.Volume) error {
-	device, err := m.findDeviceName(vol)
-	if err != nil {
-		return err
-	}
-
-	err = m.wbtOps.RestoreDefault(device)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-func (m *VolumeManager) checkWbtChangingEnable(ctx context.Context, vol *volumecrd.Volume) bool {
-	if m.wbtConfig == nil {
-		return false
-	}
-
-	if !m.wbtConfig.Enable {
-		return false
-	}
-
-	isModeAcceptable := false
-	for _, mode := range m.wbtConfig.VolumeOptions.Modes {
-		if mode == vol.Spec.Mode {
-			isModeAcceptable = true
-			break
-		}
-	}
-	if !isModeAcceptable {
-		m.log.Infof("Skip wbt value changing: volume %s has mode %s, acceptable: %v", vol.Name, vol.Spec.Mode, m.wbtConfig.VolumeOptions.Modes)
-		return false
-	}
-
-	volumeID := vol.Name
-	pv := &corev1.PersistentVolume{}
-	if err := m.k8sClient.Get(ctx, k8sCl.ObjectKey{Name: volumeID}, pv); err != nil {
-		m.log.Errorf("Failed to get Persistent Volume %s: %v", volumeID, err)
-		return false
-	}
-	volSC := pv.Spec.StorageClassName
-
-	isSCAcceptable := false
-	for _, sc := range m.wbtConfig.VolumeOptions.StorageClasses {
-		if sc == volSC {
-			isSCAcceptable = true
-			break
-		}
-	}
-	if !isSCAcceptable {
-		m.log.Infof("Skip wbt value changing: volume %s has sc %s, acceptable: %v", vol.Name, volSC, m.wbtConfig.VolumeOptions.StorageClasses)
-		return false
-	}
-
-	return true
-}
-
-func (m *VolumeManager) findDeviceName(vol *volumecrd.Volume) (string, error) {
-	drive, err := m.crHelper.GetDriveCRByVolume(vol)
-	if err != nil {
-		return "", err
-	}
-	if drive == nil {
-		return "", fmt.Errorf("drive %s is not found", vol.Spec.Location)
-	}
-
-	// expected device path - /dev/<device>
-	splitedPath := regexp.MustCompile(`[A-Za-z0-9]+`).FindAllString(drive.Spec.Path, -1)
-	if len(splitedPath) != 2 {
-		return "", fmt.Errorf("drive path %s is not parsable as /dev/<device>", drive.Spec.Path)
-	}
-	if splitedPath[0] != "dev" {
-		return "", fmt.Errorf("drive path %s is not parsable as /dev/<device>", drive.Spec.Path)
-	}
-
-	return splitedPath[1], nil
-}
-
-// SetWbtConfig changes Wbt Config for vlmgr instance
-func (m *VolumeManager) SetWbtConfig(conf *wbtconf.WbtConfig) {
-	if m.wbtConfig == nil || !reflect.DeepEqual(*m.wbtConfig, *conf) {
-		m.log.Infof("Wbt config changed: %+v", *conf)
-		m.wbtConfig = conf
+// SetBlockTuningConfig changes block tuning profiles for vlmgr instance
+func (m *VolumeManager) SetBlockTuningConfig(conf *tuningconf.Config) {
+	if m.tuningConfig == nil || !reflect.DeepEqual(*m.tuningConfig, *conf) {
+		m.log.Infof("Block tuning config changed: %+v", *conf)
+		m.tuningConfig = conf
 	}
 }
@@ -0,0 +1,188 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+)
+
+// CloneProgressCheckInterval is the interval of requeue for volume which data is being copied
+var CloneProgressCheckInterval = 10 * time.Second
+
+const (
+	// cloneProgressStep is the minimal change of copying progress (in percents) which is stored in Volume CR
+	cloneProgressStep = 5
+	// cloneDone is the copying progress of the fully populated volume
+	cloneDone = 100
+)
+
+// prepareVolumeFromSource prepares real storage for volume which must be populated from snapshot or another volume
+// and starts copying of data in background. Copying progress is tracked in Volume.CloneProgress,
+// volume CSIStatus is set to Created or Failed when copying is finished
+// uses as a step for Reconcile for Volume CR
+func (m *VolumeManager) prepareVolumeFromSource(ctx context.Context, volume *volumecrd.Volume) (ctrl.Result, error) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "prepareVolumeFromSource",
+		"volumeID": volume.Spec.Id,
+	})
+
+	if m.isCloneInProgress(volume.Spec.Id) {
+		ll.Debugf("Copying of data is in progress: %d%%", volume.Spec.CloneProgress)
+		return ctrl.Result{RequeueAfter: CloneProgressCheckInterval}, nil
+	}
+
+	var (
+		provisioner = m.getProvisionerForVolume(&volume.Spec)
+		srcPath     string
+		dstPath     string
+		err         error
+	)
+	if volume.Spec.CloneProgress > 0 {
+		// copying was started by previous instance of the node service and can't be continued
+		err = fmt.Errorf("copying of data was interrupted at %d%%", volume.Spec.CloneProgress)
+	}
+	if err == nil {
+		srcPath, err = m.getContentSourcePath(ctx, volume)
+	}
+	if err == nil {
+		err = provisioner.PrepareVolume(&volume.Spec)
+	}
+	if err == nil {
+		dstPath, err = provisioner.GetVolumePath(&volume.Spec)
+	}
+	if err != nil {
+		ll.Errorf("Unable to populate volume from %s %s: %v. Set volume status to Failed",
+			volume.Spec.ContentSourceType, volume.Spec.ContentSourceId, err)
+		volume.Spec.CSIStatus = apiV1.Failed
+		if updateErr := m.k8sClient.UpdateCRWithAttempts(ctx, volume, 5); updateErr != nil {
+			ll.Errorf("Unable to update volume status to %s: %v", apiV1.Failed, updateErr)
+			return ctrl.Result{Requeue: true}, updateErr
+		}
+		return ctrl.Result{}, err
+	}
+
+	m.addClone(volume.Spec.Id)
+	go func() {
+		defer m.removeClone(volume.Spec.Id)
+		m.cloneVolume(context.WithValue(context.Background(), base.RequestUUID, volume.Spec.Id),
+			volume.Spec.Id, srcPath, dstPath)
+	}()
+
+	return ctrl.Result{RequeueAfter: CloneProgressCheckInterval}, nil
+}
+
+// cloneVolume copies data from srcPath to dstPath, tracks progress in the Volume CR
+// and sets volume CSIStatus to Created or Failed at the end
+func (m *VolumeManager) cloneVolume(ctx context.Context, volumeID, srcPath, dstPath string) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "cloneVolume",
+		"volumeID": volumeID,
+	})
+	ll.Infof("Copying data from %s to %s", srcPath, dstPath)
+
+	var lastProgress int32
+	err := m.blockCopy.Copy(ctx, srcPath, dstPath, func(copied, total int64) {
+		progress := int32(copied * cloneDone / total)
+		if progress < cloneDone && progress-lastProgress >= cloneProgressStep {
+			lastProgress = progress
+			m.updateCloneProgress(ctx, volumeID, func(volume *volumecrd.Volume) {
+				volume.Spec.CloneProgress = progress
+			})
+		}
+	})
+
+	newStatus := apiV1.Created
+	if err != nil {
+		ll.Errorf("Unable to copy data: %v. Set volume status to Failed", err)
+		newStatus = apiV1.Failed
+	} else {
+		ll.Info("Data was copied successfully")
+	}
+	m.updateCloneProgress(ctx, volumeID, func(volume *volumecrd.Volume) {
+		if err == nil {
+			volume.Spec.CloneProgress = cloneDone
+		}
+		volume.Spec.CSIStatus = newStatus
+	})
+}
+
+// updateCloneProgress reads actual Volume CR, applies changeFn to it and updates the CR
+func (m *VolumeManager) updateCloneProgress(ctx context.Context, volumeID string, changeFn func(*volumecrd.Volume)) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "updateCloneProgress",
+		"volumeID": volumeID,
+	})
+
+	volume, err := m.crHelper.GetVolumeByID(volumeID)
+	if err != nil {
+		ll.Errorf("Unable to read volume CR: %v", err)
+		return
+	}
+	changeFn(volume)
+	if err = m.k8sClient.UpdateCRWithAttempts(ctx, volume, 5); err != nil {
+		ll.Errorf("Unable to update volume CR: %v", err)
+	}
+}
+
+// getContentSourcePath returns path of the snapshot or volume which data must be copied to the volume
+func (m *VolumeManager) getContentSourcePath(ctx context.Context, volume *volumecrd.Volume) (string, error) {
+	switch volume.Spec.ContentSourceType {
+	case apiV1.ContentSourceSnapshot:
+		snapshot := &snapshotcrd.Snapshot{}
+		if err := m.k8sClient.ReadCR(ctx, volume.Spec.ContentSourceId, "", snapshot); err != nil {
+			return "", fmt.Errorf("unable to read source snapshot %s: %v", volume.Spec.ContentSourceId, err)
+		}
+		return m.getSnapshotPath(snapshot)
+	case apiV1.ContentSourceVolume:
+		source, err := m.crHelper.GetVolumeByID(volume.Spec.ContentSourceId)
+		if err != nil {
+			return "", fmt.Errorf("unable to read source volume %s: %v", volume.Spec.ContentSourceId, err)
+		}
+		return m.getProvisionerForVolume(&source.Spec).GetVolumePath(&source.Spec)
+	default:
+		return "", fmt.Errorf("unsupported content source type %s", volume.Spec.ContentSourceType)
+	}
+}
+
+func (m *VolumeManager) isCloneInProgress(volumeID string) bool {
+	m.clonesMu.Lock()
+	defer m.clonesMu.Unlock()
+	_, ok := m.clones[volumeID]
+	return ok
+}
+
+func (m *VolumeManager) addClone(volumeID string) {
+	m.clonesMu.Lock()
+	defer m.clonesMu.Unlock()
+	m.clones[volumeID] = struct{}{}
+}
+
+func (m *VolumeManager) removeClone(volumeID string) {
+	m.clonesMu.Lock()
+	defer m.clonesMu.Unlock()
+	delete(m.clones, volumeID)
+}
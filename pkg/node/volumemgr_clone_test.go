@@ -0,0 +1,147 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/blockcopy"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+)
+
+var testCloneName = "clone-1"
+
+// progressBlockCopy reports progress with provided steps and returns err
+type progressBlockCopy struct {
+	steps []int64
+	total int64
+	err   error
+}
+
+func (b *progressBlockCopy) Copy(_ context.Context, _, _ string, progress blockcopy.ProgressFunc) error {
+	for _, copied := range b.steps {
+		progress(copied, b.total)
+	}
+	return b.err
+}
+
+func (b *progressBlockCopy) ZeroFill(ctx context.Context, dst string, progress blockcopy.ProgressFunc) error {
+	return b.Copy(ctx, "", dst, progress)
+}
+
+func prepareCloneVolumeManager(t *testing.T, sourceType, sourceID string) (*VolumeManager, *volumecrd.Volume) {
+	vm, _ := prepareSnapshotVolumeManager(t, apiV1.Created)
+	clone := testVolumeLVGCR.DeepCopy()
+	clone.Name = testCloneName
+	clone.Spec.Id = testCloneName
+	clone.Spec.ContentSourceType = sourceType
+	clone.Spec.ContentSourceId = sourceID
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, clone.Name, clone))
+	return vm, clone
+}
+
+func readTestClone(t *testing.T, vm *VolumeManager) *volumecrd.Volume {
+	volume, err := vm.crHelper.GetVolumeByID(testCloneName)
+	assert.Nil(t, err)
+	return volume
+}
+
+func waitCloneStatus(t *testing.T, vm *VolumeManager, status string) {
+	assert.Eventually(t, func() bool {
+		return readTestClone(t, vm).Spec.CSIStatus == status && !vm.isCloneInProgress(testCloneName)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestVolumeManager_prepareVolumeFromSource(t *testing.T) {
+	t.Run("From snapshot - success", func(t *testing.T) {
+		vm, clone := prepareCloneVolumeManager(t, apiV1.ContentSourceSnapshot, testSnapshotName)
+		bc := &mocklu.MockWrapBlockCopy{}
+		bc.On("Copy", testSnapshotLVPath, testLVPath).Return(nil).Times(1)
+		vm.blockCopy = bc
+
+		res, err := vm.prepareVolume(testCtx, clone)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: CloneProgressCheckInterval}, res)
+		waitCloneStatus(t, vm, apiV1.Created)
+		assert.Equal(t, int32(cloneDone), readTestClone(t, vm).Spec.CloneProgress)
+		bc.AssertExpectations(t)
+	})
+
+	t.Run("From volume - copy failed", func(t *testing.T) {
+		vm, clone := prepareCloneVolumeManager(t, apiV1.ContentSourceVolume, volLVGName)
+		bc := &mocklu.MockWrapBlockCopy{}
+		bc.On("Copy", testLVPath, testLVPath).Return(testErr).Times(1)
+		vm.blockCopy = bc
+
+		res, err := vm.prepareVolume(testCtx, clone)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: CloneProgressCheckInterval}, res)
+		waitCloneStatus(t, vm, apiV1.Failed)
+		assert.Equal(t, int32(0), readTestClone(t, vm).Spec.CloneProgress)
+	})
+
+	t.Run("Source not found", func(t *testing.T) {
+		vm, clone := prepareCloneVolumeManager(t, apiV1.ContentSourceSnapshot, "not-found")
+
+		_, err := vm.prepareVolume(testCtx, clone)
+		assert.NotNil(t, err)
+		assert.Equal(t, apiV1.Failed, readTestClone(t, vm).Spec.CSIStatus)
+	})
+
+	t.Run("Copying is in progress", func(t *testing.T) {
+		vm, clone := prepareCloneVolumeManager(t, apiV1.ContentSourceSnapshot, testSnapshotName)
+		vm.addClone(testCloneName)
+
+		res, err := vm.prepareVolume(testCtx, clone)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: CloneProgressCheckInterval}, res)
+		assert.Equal(t, apiV1.Creating, readTestClone(t, vm).Spec.CSIStatus)
+	})
+
+	t.Run("Copying was interrupted", func(t *testing.T) {
+		vm, clone := prepareCloneVolumeManager(t, apiV1.ContentSourceSnapshot, testSnapshotName)
+		clone.Spec.CloneProgress = 50
+
+		_, err := vm.prepareVolume(testCtx, clone)
+		assert.NotNil(t, err)
+		assert.Equal(t, apiV1.Failed, readTestClone(t, vm).Spec.CSIStatus)
+	})
+}
+
+func TestVolumeManager_cloneVolume(t *testing.T) {
+	vm, _ := prepareCloneVolumeManager(t, apiV1.ContentSourceSnapshot, testSnapshotName)
+
+	// progress is stored with 5% step only
+	vm.blockCopy = &progressBlockCopy{steps: []int64{2, 30, 32}, total: 100, err: testErr}
+	vm.cloneVolume(testCtx, testCloneName, testSnapshotLVPath, testLVPath)
+	clone := readTestClone(t, vm)
+	assert.Equal(t, apiV1.Failed, clone.Spec.CSIStatus)
+	assert.Equal(t, int32(30), clone.Spec.CloneProgress)
+
+	vm.blockCopy = &progressBlockCopy{steps: []int64{50, 100}, total: 100}
+	vm.cloneVolume(testCtx, testCloneName, testSnapshotLVPath, testLVPath)
+	clone = readTestClone(t, vm)
+	assert.Equal(t, apiV1.Created, clone.Spec.CSIStatus)
+	assert.Equal(t, int32(cloneDone), clone.Spec.CloneProgress)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+)
+
+// EraseProgressCheckInterval is the interval of requeue for volume which drive is being erased
+var EraseProgressCheckInterval = 30 * time.Second
+
+const (
+	// eraseProgressStep is the minimal change of erase progress (in percents) which is reported with event
+	eraseProgressStep = 25
+	// eraseDone is the erase progress of the fully erased drive
+	eraseDone = 100
+)
+
+// isDriveEraseRequired returns whether drive of the released volume must be erased according to volume erase policy
+func (m *VolumeManager) isDriveEraseRequired(volume *volumecrd.Volume) bool {
+	return util.IsDriveErasePolicy(volume.Spec.ErasePolicy) &&
+		!util.IsStorageClassLVG(volume.Spec.StorageClass) &&
+		volume.Spec.GetOperationalStatus() != apiV1.OperationalStatusMissing
+}
+
+// startDriveErase starts erase of the drive of released volume in background,
+// volume stays in Removing status until erase is finished, so the drive doesn't become available.
+// Volume CSIStatus is set to Removed or Failed when erase is finished
+// uses as a step for Reconcile for Volume CR
+func (m *VolumeManager) startDriveErase(ctx context.Context, volume *volumecrd.Volume) (ctrl.Result, error) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "startDriveErase",
+		"volumeID": volume.Spec.Id,
+	})
+
+	drive, err := m.crHelper.GetDriveCRByVolume(volume)
+	if err != nil {
+		ll.Errorf("Unable to read drive CR %s: %v", volume.Spec.Location, err)
+		return ctrl.Result{Requeue: true}, err
+	}
+	device, err := m.listBlk.SearchDrivePath(&drive.Spec)
+	if err != nil {
+		ll.Errorf("Unable to find device for drive %s: %v", drive.Name, err)
+		m.finishDriveErase(ctx, volume, drive, err)
+		return ctrl.Result{}, err
+	}
+
+	m.addErase(volume.Spec.Id)
+	go func() {
+		defer m.removeErase(volume.Spec.Id)
+		m.eraseDrive(context.WithValue(context.Background(), base.RequestUUID, volume.Spec.Id),
+			volume.DeepCopy(), drive.DeepCopy(), device)
+	}()
+
+	return ctrl.Result{RequeueAfter: EraseProgressCheckInterval}, nil
+}
+
+// eraseDrive erases device of the drive according to volume erase policy,
+// reports progress and result with events for Volume and Drive CRs
+func (m *VolumeManager) eraseDrive(ctx context.Context, volume *volumecrd.Volume, drive *drivecrd.Drive, device string) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "eraseDrive",
+		"volumeID": volume.Spec.Id,
+	})
+	policy := volume.Spec.ErasePolicy
+	ll.Infof("Erasing device %s with policy %s", device, policy)
+	m.sendEraseEvent(volume, drive, eventing.EraseStarted, "Erase with policy %s started.", policy)
+
+	var lastProgress int32
+	err := m.eraseOps.Erase(ctx, policy, device, func(progress int32) {
+		if progress < eraseDone && progress-lastProgress >= eraseProgressStep {
+			lastProgress = progress
+			m.sendEraseEvent(volume, drive, eventing.EraseProgress, "Erase with policy %s is %d%% done.", policy, progress)
+		}
+	})
+	m.finishDriveErase(ctx, volume, drive, err)
+}
+
+// finishDriveErase sets volume CSIStatus to Removed after successful erase, otherwise sets volume CSIStatus
+// and drive Usage to Failed
+func (m *VolumeManager) finishDriveErase(ctx context.Context, volume *volumecrd.Volume, drive *drivecrd.Drive, err error) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "finishDriveErase",
+		"volumeID": volume.Spec.Id,
+	})
+
+	newStatus := apiV1.Removed
+	if err != nil {
+		ll.Errorf("Unable to erase drive: %v. Set volume status to Failed", err)
+		m.sendEraseEvent(volume, drive, eventing.EraseFailed, "Erase with policy %s failed: %v.", volume.Spec.ErasePolicy, err)
+		newStatus = apiV1.Failed
+		if currentDrive, readErr := m.crHelper.GetDriveCRByVolume(volume); readErr == nil {
+			currentDrive.Spec.Usage = apiV1.DriveUsageFailed
+			if updateErr := m.k8sClient.UpdateCRWithAttempts(ctx, currentDrive, 5); updateErr != nil {
+				ll.Errorf("Unable to change drive %s usage status to %s: %v", drive.Name, apiV1.DriveUsageFailed, updateErr)
+			}
+		} else {
+			ll.Errorf("Unable to read drive CR %s: %v", drive.Name, readErr)
+		}
+	} else {
+		ll.Info("Drive was erased successfully")
+		m.sendEraseEvent(volume, drive, eventing.EraseCompleted, "Erase with policy %s completed.", volume.Spec.ErasePolicy)
+	}
+
+	currentVolume, readErr := m.crHelper.GetVolumeByID(volume.Spec.Id)
+	if readErr != nil {
+		ll.Errorf("Unable to read volume CR: %v", readErr)
+		return
+	}
+	currentVolume.Spec.CSIStatus = newStatus
+	if updateErr := m.k8sClient.UpdateCRWithAttempts(ctx, currentVolume, 10); updateErr != nil {
+		ll.Errorf("Unable to set volume status to %s: %v", newStatus, updateErr)
+	}
+}
+
+// sendEraseEvent records the same event for Volume and Drive CRs
+func (m *VolumeManager) sendEraseEvent(volume *volumecrd.Volume, drive *drivecrd.Drive,
+	event *eventing.EventDescription, messageFmt string, args ...interface{}) {
+	m.recorder.Eventf(volume, event, messageFmt, args...)
+	m.sendEventForDrive(drive, event, messageFmt+" VolumeID='%s'.", append(args, volume.Spec.Id)...)
+}
+
+func (m *VolumeManager) isEraseInProgress(volumeID string) bool {
+	m.erasesMu.Lock()
+	defer m.erasesMu.Unlock()
+	_, ok := m.erases[volumeID]
+	return ok
+}
+
+func (m *VolumeManager) addErase(volumeID string) {
+	m.erasesMu.Lock()
+	defer m.erasesMu.Unlock()
+	m.erases[volumeID] = struct{}{}
+}
+
+func (m *VolumeManager) removeErase(volumeID string) {
+	m.erasesMu.Lock()
+	defer m.erasesMu.Unlock()
+	delete(m.erases, volumeID)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
+	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
+	"github.com/dell/csi-baremetal/pkg/node/provisioners/utilwrappers"
+)
+
+var testEraseDevice = "/dev/sda"
+
+// progressEraseOps reports progress with provided steps and returns err
+type progressEraseOps struct {
+	steps []int32
+	err   error
+}
+
+func (e *progressEraseOps) Erase(_ context.Context, _, _ string, progress utilwrappers.EraseProgressFunc) error {
+	for _, step := range e.steps {
+		progress(step)
+	}
+	return e.err
+}
+
+func prepareEraseVolumeManager(t *testing.T, policy string) (*VolumeManager, *vcrd.Volume, *mockProv.MockEraseOps) {
+	vm := prepareSuccessVolumeManager(t)
+	testVol := volCR.DeepCopy()
+	testVol.Spec.CSIStatus = apiV1.Removing
+	testVol.Spec.ErasePolicy = policy
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testVol.Name, testVol))
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testVol.Spec.Location, testDriveCR.DeepCopy()))
+	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.DriveBasedVolumeType: mockProv.GetMockProvisionerSuccess("/some/path")})
+	vm.listBlk = mocklu.GetMockWrapLsblk(testEraseDevice)
+
+	eraseOps := &mockProv.MockEraseOps{}
+	vm.eraseOps = eraseOps
+	return vm, testVol, eraseOps
+}
+
+func readTestEraseVolume(t *testing.T, vm *VolumeManager) *vcrd.Volume {
+	volume, err := vm.crHelper.GetVolumeByID(volCR.Spec.Id)
+	assert.Nil(t, err)
+	return volume
+}
+
+func waitEraseStatus(t *testing.T, vm *VolumeManager, status string) {
+	assert.Eventually(t, func() bool {
+		return readTestEraseVolume(t, vm).Spec.CSIStatus == status && !vm.isEraseInProgress(volCR.Spec.Id)
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func getRecordedEvents(vm *VolumeManager) []*eventing.EventDescription {
+	rec := vm.recorder.(*mocks.NoOpRecorder)
+	events := make([]*eventing.EventDescription, 0, len(rec.Calls))
+	for _, c := range rec.Calls {
+		events = append(events, c.Event)
+	}
+	return events
+}
+
+func TestVolumeManager_handleRemovingStatus_Erase(t *testing.T) {
+	t.Run("Erase succeeded", func(t *testing.T) {
+		vm, testVol, eraseOps := prepareEraseVolumeManager(t, apiV1.ErasePolicyZeroFill)
+		eraseOps.On("Erase", apiV1.ErasePolicyZeroFill, testEraseDevice).Return(nil).Once()
+
+		res, err := vm.handleRemovingStatus(testCtx, testVol)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: EraseProgressCheckInterval}, res)
+		waitEraseStatus(t, vm, apiV1.Removed)
+		eraseOps.AssertExpectations(t)
+
+		// started and completed events for both Volume and Drive CRs
+		assert.Equal(t, []*eventing.EventDescription{eventing.EraseStarted, eventing.EraseStarted,
+			eventing.EraseCompleted, eventing.EraseCompleted}, getRecordedEvents(vm))
+	})
+
+	t.Run("Erase failed", func(t *testing.T) {
+		vm, testVol, eraseOps := prepareEraseVolumeManager(t, apiV1.ErasePolicyNVMeSanitize)
+		eraseOps.On("Erase", apiV1.ErasePolicyNVMeSanitize, testEraseDevice).Return(testErr).Once()
+
+		_, err := vm.handleRemovingStatus(testCtx, testVol)
+		assert.Nil(t, err)
+		waitEraseStatus(t, vm, apiV1.Failed)
+
+		drive := &drivecrd.Drive{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, testVol.Spec.Location, "", drive))
+		assert.Equal(t, apiV1.DriveUsageFailed, drive.Spec.Usage)
+		assert.Contains(t, getRecordedEvents(vm), eventing.EraseFailed)
+	})
+
+	t.Run("Erase is in progress", func(t *testing.T) {
+		vm, testVol, eraseOps := prepareEraseVolumeManager(t, apiV1.ErasePolicyDiscard)
+		vm.addErase(testVol.Spec.Id)
+
+		res, err := vm.handleRemovingStatus(testCtx, testVol)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: EraseProgressCheckInterval}, res)
+		assert.Equal(t, apiV1.Removing, readTestEraseVolume(t, vm).Spec.CSIStatus)
+		eraseOps.AssertNotCalled(t, "Erase")
+	})
+
+	t.Run("Erase isn't required", func(t *testing.T) {
+		vm, testVol, eraseOps := prepareEraseVolumeManager(t, apiV1.ErasePolicyWipeFS)
+
+		res, err := vm.handleRemovingStatus(testCtx, testVol)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		assert.Equal(t, apiV1.Removed, readTestEraseVolume(t, vm).Spec.CSIStatus)
+		eraseOps.AssertNotCalled(t, "Erase")
+	})
+}
+
+func TestVolumeManager_eraseDrive_Progress(t *testing.T) {
+	vm, testVol, _ := prepareEraseVolumeManager(t, apiV1.ErasePolicyZeroFill)
+	vm.eraseOps = &progressEraseOps{steps: []int32{10, 25, 40, 60, 100}}
+	vm.eraseDrive(testCtx, testVol, testDriveCR.DeepCopy(), testEraseDevice)
+
+	// progress is reported with 25% step only, each event is sent for Volume and Drive CRs
+	assert.Equal(t, []*eventing.EventDescription{eventing.EraseStarted, eventing.EraseStarted,
+		eventing.EraseProgress, eventing.EraseProgress, eventing.EraseProgress, eventing.EraseProgress,
+		eventing.EraseCompleted, eventing.EraseCompleted}, getRecordedEvents(vm))
+	assert.Equal(t, apiV1.Removed, readTestEraseVolume(t, vm).Spec.CSIStatus)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+// ioLimitsCgroupAnnotation holds path of the pod cgroup which IO limits of the volume were applied to
+const ioLimitsCgroupAnnotation = "io-limits-cgroup"
+
+// setIOLimits applies IO limits of the volume to the cgroup of the pod with provided UID for the volume device,
+// applied limits are saved in the Volume CR spec, CR must be updated by caller
+// Returns error if limits weren't applied or were applied partially
+func (m *VolumeManager) setIOLimits(vol *volumecrd.Volume, podUID string) error {
+	if podUID == "" {
+		return fmt.Errorf("pod UID isn't provided, podInfoOnMount must be enabled for the driver")
+	}
+	cgroupPath, err := m.cgroupOps.GetPodCgroupPath(podUID)
+	if err != nil {
+		return err
+	}
+	devNumber, err := m.getVolumeDeviceNumber(vol)
+	if err != nil {
+		return err
+	}
+
+	applied, err := m.cgroupOps.SetIOLimits(cgroupPath, devNumber, vol.Spec.IoLimits)
+	if !util.IsIOLimitsEmpty(applied) {
+		if vol.Annotations == nil {
+			vol.Annotations = make(map[string]string)
+		}
+		vol.Annotations[ioLimitsCgroupAnnotation] = cgroupPath
+		vol.Spec.AppliedIoLimits = applied
+	}
+	return err
+}
+
+// removeIOLimits resets IO limits of the volume device in the pod cgroup which they were applied to,
+// applied limits are removed from the Volume CR spec, CR must be updated by caller
+func (m *VolumeManager) removeIOLimits(vol *volumecrd.Volume) error {
+	cgroupPath, ok := vol.Annotations[ioLimitsCgroupAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(vol.Annotations, ioLimitsCgroupAnnotation)
+	vol.Spec.AppliedIoLimits = nil
+
+	devNumber, err := m.getVolumeDeviceNumber(vol)
+	if err != nil {
+		return err
+	}
+	return m.cgroupOps.RemoveIOLimits(cgroupPath, devNumber)
+}
+
+// getVolumeDeviceNumber returns major:minor numbers of the volume device
+func (m *VolumeManager) getVolumeDeviceNumber(vol *volumecrd.Volume) (string, error) {
+	device, err := m.getProvisionerForVolume(&vol.Spec).GetVolumePath(&vol.Spec)
+	if err != nil {
+		return "", err
+	}
+	return m.cgroupOps.GetDeviceNumber(device)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+)
+
+// driveTelemetryLabels are labels of the drive telemetry metrics
+var driveTelemetryLabels = []string{"node", "serial_number", "type"}
+
+// driveTelemetry contains gauges with telemetry of the drives reported by drive manager
+type driveTelemetry struct {
+	temperature  *prometheus.GaugeVec
+	powerOnHours *prometheus.GaugeVec
+	endurance    *prometheus.GaugeVec
+}
+
+func newDriveTelemetry() *driveTelemetry {
+	return &driveTelemetry{
+		temperature: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "drive_temperature_celsius",
+			Help: "current temperature of the drive",
+		}, driveTelemetryLabels),
+		powerOnHours: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "drive_power_on_hours",
+			Help: "power-on time of the drive",
+		}, driveTelemetryLabels),
+		endurance: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "drive_endurance_percent",
+			Help: "remaining rated endurance of the NVMe drive",
+		}, driveTelemetryLabels),
+	}
+}
+
+// collectors returns gauges to register
+func (t *driveTelemetry) collectors() []prometheus.Collector {
+	return []prometheus.Collector{t.temperature, t.powerOnHours, t.endurance}
+}
+
+// exportDriveTelemetry replaces telemetry metrics with values of the discovered drives.
+// Telemetry changes on each discovery, so it is reset in drives to not be saved in Drive CRs
+func (m *VolumeManager) exportDriveTelemetry(drives []*api.Drive) {
+	t := m.metricDriveTelemetry
+	for _, g := range []*prometheus.GaugeVec{t.temperature, t.powerOnHours, t.endurance} {
+		g.Reset()
+	}
+	for _, d := range drives {
+		m.setDriveTelemetry(d)
+	}
+}
+
+// exportDriveEventTelemetry updates telemetry metrics of the drive from drive event, metrics of REMOVED drive are deleted.
+// Telemetry is reset in the drive to not be saved in Drive CR
+func (m *VolumeManager) exportDriveEventTelemetry(event *api.DriveEvent) {
+	d := event.Drive
+	if event.Type != apiV1.DriveEventRemoved {
+		m.setDriveTelemetry(d)
+		return
+	}
+	t := m.metricDriveTelemetry
+	labels := prometheus.Labels{"node": m.nodeID, "serial_number": d.SerialNumber, "type": d.Type}
+	for _, g := range []*prometheus.GaugeVec{t.temperature, t.powerOnHours, t.endurance} {
+		g.Delete(labels)
+	}
+	d.Temperature = 0
+	d.PowerOnHours = 0
+}
+
+// setDriveTelemetry sets telemetry metrics of the drive, metric is deleted if the drive doesn't report its value
+func (m *VolumeManager) setDriveTelemetry(d *api.Drive) {
+	t := m.metricDriveTelemetry
+	labels := prometheus.Labels{"node": m.nodeID, "serial_number": d.SerialNumber, "type": d.Type}
+	if d.Temperature != 0 {
+		t.temperature.With(labels).Set(float64(d.Temperature))
+	} else {
+		t.temperature.Delete(labels)
+	}
+	if d.PowerOnHours != 0 {
+		t.powerOnHours.With(labels).Set(float64(d.PowerOnHours))
+	} else {
+		t.powerOnHours.Delete(labels)
+	}
+	// endurance is unknown when NVMe health can't be read
+	if d.Type == apiV1.DriveTypeNVMe && d.Health != apiV1.HealthUnknown {
+		t.endurance.With(labels).Set(float64(d.Endurance))
+	} else {
+		t.endurance.Delete(labels)
+	}
+	d.Temperature = 0
+	d.PowerOnHours = 0
+}
@@ -0,0 +1,134 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/mdadm"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
+)
+
+// updateRaidVolumesHealth reads state of RAID arrays of the staged volumes and propagates it to the Volume CRs:
+// clean array - GOOD health, degraded array - SUSPECT health, failed array - BAD health
+func (m *VolumeManager) updateRaidVolumesHealth(ctx context.Context) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method": "updateRaidVolumesHealth",
+	})
+
+	volumes, err := m.cachedCrHelper.GetVolumeCRs(m.nodeID)
+	if err != nil {
+		ll.Errorf("Unable to read volume CRs: %v", err)
+		return
+	}
+
+	for _, volume := range volumes {
+		volume := volume
+		if volume.Spec.LocationType != apiV1.LocationTypeRAID {
+			continue
+		}
+		// array is assembled on the node stage and may be stopped after node reboot
+		if volume.Spec.CSIStatus != apiV1.VolumeReady && volume.Spec.CSIStatus != apiV1.Published {
+			continue
+		}
+
+		state, err := m.mdOps.GetArrayState(p.GetRaidDevicePath(volume.Spec.Id))
+		if err != nil {
+			ll.Errorf("Unable to get state of RAID array of volume %s: %v", volume.Name, err)
+			continue
+		}
+
+		m.releaseRaidMembers(ctx, &volume, state)
+
+		health := getRaidHealth(state)
+		if health == volume.Spec.Health {
+			continue
+		}
+		ll.Infof("RAID array of volume %s is %s, setting health %s", volume.Name, state.State, health)
+		prevHealth := volume.Spec.Health
+		volume.Spec.Health = health
+		if err = m.k8sClient.UpdateCR(ctx, &volume); err != nil {
+			ll.Errorf("Failed to update volume CR's %s health status: %v", volume.Name, err)
+			continue
+		}
+
+		event := eventing.VolumeGoodHealth
+		switch health {
+		case apiV1.HealthSuspect:
+			event = eventing.VolumeSuspectHealth
+		case apiV1.HealthBad:
+			event = eventing.VolumeBadHealth
+		}
+		m.recorder.Eventf(&volume, event,
+			"Volume health transitioned from %s to %s. RAID array state: %s, failed members: %v",
+			prevHealth, health, state.State, state.FailedMembers)
+	}
+}
+
+// releaseRaidMembers marks member drives in release workflow as released from RAID volume once they aren't
+// active members of the array anymore, e.g. drive is failed or removed from the degraded array or replaced
+// by another drive after rebuild. Drive annotated this way doesn't block release and removal of the drive
+func (m *VolumeManager) releaseRaidMembers(ctx context.Context, volume *volumecrd.Volume, state *mdadm.ArrayState) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":   "releaseRaidMembers",
+		"volumeID": volume.Name,
+	})
+
+	for _, location := range volume.Spec.RaidLocations {
+		drive := &drivecrd.Drive{}
+		if err := m.k8sClient.ReadCR(ctx, location, "", drive); err != nil {
+			if !k8sError.IsNotFound(err) {
+				ll.Errorf("Unable to read drive %s: %v", location, err)
+			}
+			continue
+		}
+		if drive.Spec.Usage != apiV1.DriveUsageReleasing ||
+			drive.Annotations[getVolumeStatusAnnotationKey(volume.Name)] == apiV1.VolumeUsageReleased {
+			continue
+		}
+		// drive which isn't found on the node can't be a member of the array
+		if device, err := m.listBlk.SearchDrivePath(&drive.Spec); err == nil &&
+			util.ContainsString(state.ActiveMembers, device) {
+			ll.Debugf("Drive %s is still active member %s of RAID array", location, device)
+			continue
+		}
+		ll.Infof("Drive %s isn't active member of RAID array, it is released", location)
+		m.addVolumeStatusAnnotation(drive, volume.Name, apiV1.VolumeUsageReleased)
+		if err := m.k8sClient.UpdateCR(ctx, drive); err != nil {
+			ll.Errorf("Unable to update drive %s annotations: %v", location, err)
+		}
+	}
+}
+
+// getRaidHealth converts RAID array state to the volume health
+func getRaidHealth(state *mdadm.ArrayState) string {
+	switch {
+	case state.Failed:
+		return apiV1.HealthBad
+	case state.Degraded:
+		return apiV1.HealthSuspect
+	}
+	return apiV1.HealthGood
+}
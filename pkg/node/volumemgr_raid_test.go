@@ -0,0 +1,145 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/mdadm"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
+)
+
+func prepareRaidVolumeManager(t *testing.T, csiStatus string) (*VolumeManager, *mocklu.MockWrapMdadm) {
+	vm := prepareSuccessVolumeManager(t)
+	vol := volCR.DeepCopy()
+	vol.Spec.CSIStatus = csiStatus
+	vol.Spec.Health = apiV1.HealthGood
+	vol.Spec.LocationType = apiV1.LocationTypeRAID
+	vol.Spec.RaidLevel = apiV1.RaidLevel1
+	vol.Spec.RaidDevices = 2
+	vol.Spec.RaidLocations = []string{drive1UUID, drive2UUID}
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, vol.Name, vol))
+
+	mdOps := &mocklu.MockWrapMdadm{}
+	vm.mdOps = mdOps
+	return vm, mdOps
+}
+
+func TestVolumeManager_updateRaidVolumesHealth(t *testing.T) {
+	device := p.GetRaidDevicePath(volCR.Spec.Id)
+	testCases := []struct {
+		name           string
+		state          *mdadm.ArrayState
+		expectedHealth string
+		expectedEvent  *eventing.EventDescription
+	}{
+		{"Clean array", &mdadm.ArrayState{State: "clean"}, apiV1.HealthGood, nil},
+		{"Degraded array", &mdadm.ArrayState{State: "clean, degraded", Degraded: true},
+			apiV1.HealthSuspect, eventing.VolumeSuspectHealth},
+		{"Failed array", &mdadm.ArrayState{State: "clean, FAILED", Failed: true},
+			apiV1.HealthBad, eventing.VolumeBadHealth},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm, mdOps := prepareRaidVolumeManager(t, apiV1.Published)
+			mdOps.On("GetArrayState", device).Return(tc.state, nil).Times(1)
+
+			vm.updateRaidVolumesHealth(testCtx)
+
+			vol := &vcrd.Volume{}
+			assert.Nil(t, vm.k8sClient.ReadCR(testCtx, volCR.Name, testNs, vol))
+			assert.Equal(t, tc.expectedHealth, vol.Spec.Health)
+			rec := vm.recorder.(*mocks.NoOpRecorder)
+			if tc.expectedEvent == nil {
+				assert.Empty(t, rec.Calls)
+			} else {
+				assert.Len(t, rec.Calls, 1)
+				assert.Equal(t, tc.expectedEvent, rec.Calls[0].Event)
+			}
+		})
+	}
+
+	t.Run("Array of not staged volume isn't checked", func(t *testing.T) {
+		vm, mdOps := prepareRaidVolumeManager(t, apiV1.Created)
+		vm.updateRaidVolumesHealth(testCtx)
+		mdOps.AssertNotCalled(t, "GetArrayState", device)
+	})
+
+	t.Run("Unable to get array state", func(t *testing.T) {
+		vm, mdOps := prepareRaidVolumeManager(t, apiV1.VolumeReady)
+		mdOps.On("GetArrayState", device).Return(nil, testErr).Times(1)
+
+		vm.updateRaidVolumesHealth(testCtx)
+		vol := &vcrd.Volume{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, volCR.Name, testNs, vol))
+		assert.Equal(t, apiV1.HealthGood, vol.Spec.Health)
+	})
+
+	t.Run("Releasing drive isn't active member of the array", func(t *testing.T) {
+		vm, mdOps := prepareRaidVolumeManager(t, apiV1.Published)
+		mdOps.On("GetArrayState", device).Return(&mdadm.ArrayState{
+			State: "clean, degraded", Degraded: true,
+			ActiveMembers: []string{drive1.Path}, FailedMembers: []string{drive2.Path},
+		}, nil).Times(1)
+		listBlk := &mocklu.MockWrapLsblk{}
+		for _, d := range []api.Drive{drive1, drive2} {
+			d := d
+			d.Usage = apiV1.DriveUsageReleasing
+			assert.Nil(t, vm.k8sClient.CreateCR(testCtx, d.UUID, vm.k8sClient.ConstructDriveCR(d.UUID, d)))
+			listBlk.On("SearchDrivePath", mock.MatchedBy(func(drive *api.Drive) bool {
+				return drive.UUID == d.UUID
+			})).Return(d.Path, nil)
+		}
+		vm.listBlk = listBlk
+
+		vm.updateRaidVolumesHealth(testCtx)
+
+		// first member is still in the array, the second one was failed
+		key := getVolumeStatusAnnotationKey(volCR.Name)
+		drive := &drivecrd.Drive{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1UUID, "", drive))
+		assert.Empty(t, drive.Annotations[key])
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive2UUID, "", drive))
+		assert.Equal(t, apiV1.VolumeUsageReleased, drive.Annotations[key])
+
+		// first member is replaced by another drive after rebuild
+		mdOps.On("GetArrayState", device).Return(&mdadm.ArrayState{
+			State: "clean", ActiveMembers: []string{"/dev/sdc"},
+		}, nil).Times(1)
+		vm.updateRaidVolumesHealth(testCtx)
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, drive1UUID, "", drive))
+		assert.Equal(t, apiV1.VolumeUsageReleased, drive.Annotations[key])
+	})
+}
+
+func TestVolumeManager_getProvisionerForRaidVolume(t *testing.T) {
+	vm := prepareSuccessVolumeManager(t)
+	vol := volCR.Spec
+	vol.RaidLevel = apiV1.RaidLevel0
+	assert.IsType(t, &p.RAIDProvisioner{}, vm.getProvisionerForVolume(&vol))
+}
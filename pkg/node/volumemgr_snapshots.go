@@ -0,0 +1,224 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	metricsC "github.com/dell/csi-baremetal/pkg/metrics/common"
+)
+
+// SnapshotUsageCheckInterval is the interval of checking usage of created LVM snapshots
+// LVM snapshot becomes invalid when its COW area is full, such snapshot is marked with BAD health
+var SnapshotUsageCheckInterval = time.Minute
+
+// setupSnapshotController registers reconciler of Snapshot CRs that belong to the current node
+func (m *VolumeManager) setupSnapshotController(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&snapshotcrd.Snapshot{}).
+		WithEventFilter(predicate.Funcs{
+			CreateFunc: func(e crevent.CreateEvent) bool {
+				return m.isSnapshotCorrespondedToNodePredicate(e.Object)
+			},
+			DeleteFunc: func(e crevent.DeleteEvent) bool {
+				return false
+			},
+			UpdateFunc: func(e crevent.UpdateEvent) bool {
+				return m.isSnapshotCorrespondedToNodePredicate(e.ObjectNew)
+			},
+			GenericFunc: func(e crevent.GenericEvent) bool {
+				return m.isSnapshotCorrespondedToNodePredicate(e.Object)
+			},
+		}).
+		Complete(reconcile.Func(m.ReconcileSnapshot))
+}
+
+// isSnapshotCorrespondedToNodePredicate checks is a provided obj is a Snapshot CR object
+// and that snapshot's node is and current manager node
+func (m *VolumeManager) isSnapshotCorrespondedToNodePredicate(obj runtime.Object) bool {
+	if snapshot, ok := obj.(*snapshotcrd.Snapshot); ok {
+		return snapshot.Spec.NodeId == m.nodeID
+	}
+
+	return false
+}
+
+// ReconcileSnapshot is the main Reconcile loop of Snapshot CRs. Creates LVM snapshot for Snapshot in Creating status,
+// removes LVM snapshot for Snapshot in Removing status and checks usage of already created LVM snapshot
+// Returns reconcile result as ctrl.Result or error if something went wrong
+func (m *VolumeManager) ReconcileSnapshot(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	defer metricsC.ReconcileDuration.EvaluateDurationForType("node_snapshot_controller")()
+	m.volMu.LockKey(req.Name)
+	ll := m.log.WithFields(logrus.Fields{
+		"method":     "ReconcileSnapshot",
+		"snapshotID": req.Name,
+	})
+	defer func() {
+		if err := m.volMu.UnlockKey(req.Name); err != nil {
+			ll.Warnf("Unlocking snapshot with error %s", err)
+		}
+	}()
+	ctx, cancelFn := context.WithTimeout(
+		context.WithValue(ctx, base.RequestUUID, req.Name),
+		VolumeOperationsTimeout)
+	defer cancelFn()
+
+	snapshot := &snapshotcrd.Snapshot{}
+	if err := m.k8sClient.ReadCR(ctx, req.Name, "", snapshot); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	ll.Infof("Processing for status %s", snapshot.Spec.CSIStatus)
+	switch snapshot.Spec.CSIStatus {
+	case apiV1.Creating:
+		return m.handleCreatingSnapshot(ctx, snapshot)
+	case apiV1.Removing:
+		return m.handleRemovingSnapshot(ctx, snapshot)
+	case apiV1.Created:
+		return m.checkSnapshotUsage(ctx, snapshot)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// handleCreatingSnapshot creates LVM snapshot of the source volume logical volume
+// and sets Snapshot CSIStatus to Created or Failed
+func (m *VolumeManager) handleCreatingSnapshot(ctx context.Context, snapshot *snapshotcrd.Snapshot) (ctrl.Result, error) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":     "handleCreatingSnapshot",
+		"snapshotID": snapshot.Spec.Id,
+	})
+
+	newStatus := apiV1.Created
+	originPath, err := m.getSnapshotOriginPath(snapshot)
+	if err == nil {
+		// prepare size in megabytes for the argument
+		size, _ := util.ToSizeUnit(snapshot.Spec.Size, util.BYTE, util.MBYTE)
+		err = m.lvmOps.LVSnapshotCreate(snapshot.Spec.Id, strconv.FormatInt(size, 10)+"m", originPath)
+	}
+	if err != nil {
+		ll.Errorf("Unable to create snapshot: %v", err)
+		newStatus = apiV1.Failed
+	}
+
+	return m.updateSnapshotStatus(ctx, snapshot, newStatus)
+}
+
+// handleRemovingSnapshot removes LVM snapshot and sets Snapshot CSIStatus to Removed or Failed
+func (m *VolumeManager) handleRemovingSnapshot(ctx context.Context, snapshot *snapshotcrd.Snapshot) (ctrl.Result, error) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":     "handleRemovingSnapshot",
+		"snapshotID": snapshot.Spec.Id,
+	})
+
+	newStatus := apiV1.Removed
+	snapshotPath, err := m.getSnapshotPath(snapshot)
+	if err == nil {
+		err = m.lvmOps.LVSnapshotRemove(snapshotPath)
+	}
+	if err != nil {
+		ll.Errorf("Unable to remove snapshot: %v", err)
+		newStatus = apiV1.Failed
+	}
+
+	return m.updateSnapshotStatus(ctx, snapshot, newStatus)
+}
+
+// checkSnapshotUsage marks Snapshot with BAD health when its COW area is full
+// and requeue request for the next check
+func (m *VolumeManager) checkSnapshotUsage(ctx context.Context, snapshot *snapshotcrd.Snapshot) (ctrl.Result, error) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method":     "checkSnapshotUsage",
+		"snapshotID": snapshot.Spec.Id,
+	})
+
+	if snapshot.Spec.Health == apiV1.HealthBad {
+		return ctrl.Result{}, nil
+	}
+
+	snapshotPath, err := m.getSnapshotPath(snapshot)
+	if err != nil {
+		ll.Errorf("Unable to determine snapshot path: %v", err)
+		return ctrl.Result{RequeueAfter: SnapshotUsageCheckInterval}, nil
+	}
+	usage, err := m.lvmOps.GetLVSnapshotUsage(snapshotPath)
+	if err != nil {
+		ll.Errorf("Unable to get snapshot usage: %v", err)
+		return ctrl.Result{RequeueAfter: SnapshotUsageCheckInterval}, nil
+	}
+
+	if usage >= 100 {
+		ll.Warnf("Snapshot is full and became invalid, set health to %s", apiV1.HealthBad)
+		snapshot.Spec.Health = apiV1.HealthBad
+		if err = m.k8sClient.UpdateCR(ctx, snapshot); err != nil {
+			ll.Errorf("Unable to update snapshot CR: %v", err)
+			return ctrl.Result{Requeue: true, RequeueAfter: base.DefaultRequeueForVolume}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: SnapshotUsageCheckInterval}, nil
+}
+
+// getSnapshotOriginPath returns path of the logical volume that snapshot was taken from
+func (m *VolumeManager) getSnapshotOriginPath(snapshot *snapshotcrd.Snapshot) (string, error) {
+	volume, err := m.crHelper.GetVolumeByID(snapshot.Spec.VolumeId)
+	if err != nil {
+		return "", fmt.Errorf("unable to read source volume %s: %v", snapshot.Spec.VolumeId, err)
+	}
+
+	return m.getProvisionerForVolume(&volume.Spec).GetVolumePath(&volume.Spec)
+}
+
+// getSnapshotPath returns path of the LVM snapshot, snapshot is placed in the same volume group as its origin
+func (m *VolumeManager) getSnapshotPath(snapshot *snapshotcrd.Snapshot) (string, error) {
+	originPath, err := m.getSnapshotOriginPath(snapshot)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(originPath), snapshot.Spec.Id), nil
+}
+
+// updateSnapshotStatus sets CSIStatus of the Snapshot CR, requeue request if CR wasn't updated
+func (m *VolumeManager) updateSnapshotStatus(ctx context.Context, snapshot *snapshotcrd.Snapshot,
+	newStatus string) (ctrl.Result, error) {
+	snapshot.Spec.CSIStatus = newStatus
+	if err := m.k8sClient.UpdateCR(ctx, snapshot); err != nil {
+		m.log.WithField("snapshotID", snapshot.Spec.Id).
+			Errorf("Unable to update snapshot status to %s: %v", newStatus, err)
+		return ctrl.Result{Requeue: true, RequeueAfter: base.DefaultRequeueForVolume}, err
+	}
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,172 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
+	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
+)
+
+var (
+	testSnapshotName   = "snapshot-1"
+	testLVPath         = "/dev/vg/" + volLVGName
+	testSnapshotLVPath = "/dev/vg/" + testSnapshotName
+)
+
+func prepareSnapshotVolumeManager(t *testing.T, csiStatus string) (*VolumeManager, *mocklu.MockWrapLVM) {
+	vm := prepareSuccessVolumeManager(t)
+	vol := testVolumeLVGCR.DeepCopy()
+	vol.Spec.CSIStatus = apiV1.Created
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, vol.Name, vol))
+
+	snapshot := vm.k8sClient.ConstructSnapshotCR(testSnapshotName, api.Snapshot{
+		Id:           testSnapshotName,
+		VolumeId:     vol.Spec.Id,
+		NodeId:       nodeID,
+		Location:     vol.Spec.Location,
+		StorageClass: vol.Spec.StorageClass,
+		Size:         vol.Spec.Size,
+		CSIStatus:    csiStatus,
+		Health:       apiV1.HealthGood,
+	})
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, snapshot.Name, snapshot))
+
+	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.LVMBasedVolumeType: mockProv.GetMockProvisionerSuccess(testLVPath)})
+	lvmOps := &mocklu.MockWrapLVM{}
+	vm.lvmOps = lvmOps
+	return vm, lvmOps
+}
+
+func readTestSnapshot(t *testing.T, vm *VolumeManager) *snapshotcrd.Snapshot {
+	snapshot := &snapshotcrd.Snapshot{}
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, testSnapshotName, "", snapshot))
+	return snapshot
+}
+
+func TestVolumeManager_ReconcileSnapshot(t *testing.T) {
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: testSnapshotName}}
+
+	t.Run("Snapshot not found", func(t *testing.T) {
+		vm := prepareSuccessVolumeManager(t)
+		res, err := vm.ReconcileSnapshot(testCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+	})
+
+	t.Run("Creating - success", func(t *testing.T) {
+		vm, lvmOps := prepareSnapshotVolumeManager(t, apiV1.Creating)
+		lvmOps.On("LVSnapshotCreate", testSnapshotName, "153600m", testLVPath).Return(nil).Times(1)
+
+		res, err := vm.ReconcileSnapshot(testCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		assert.Equal(t, apiV1.Created, readTestSnapshot(t, vm).Spec.CSIStatus)
+		lvmOps.AssertExpectations(t)
+	})
+
+	t.Run("Creating - lvcreate failed", func(t *testing.T) {
+		vm, lvmOps := prepareSnapshotVolumeManager(t, apiV1.Creating)
+		lvmOps.On("LVSnapshotCreate", mock.Anything, mock.Anything, mock.Anything).Return(testErr).Times(1)
+
+		res, err := vm.ReconcileSnapshot(testCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		assert.Equal(t, apiV1.Failed, readTestSnapshot(t, vm).Spec.CSIStatus)
+	})
+
+	t.Run("Creating - source volume not found", func(t *testing.T) {
+		vm, _ := prepareSnapshotVolumeManager(t, apiV1.Creating)
+		vol := testVolumeLVGCR.DeepCopy()
+		assert.Nil(t, vm.k8sClient.DeleteCR(testCtx, vol))
+
+		_, err := vm.ReconcileSnapshot(testCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, apiV1.Failed, readTestSnapshot(t, vm).Spec.CSIStatus)
+	})
+
+	t.Run("Removing - success", func(t *testing.T) {
+		vm, lvmOps := prepareSnapshotVolumeManager(t, apiV1.Removing)
+		lvmOps.On("LVSnapshotRemove", testSnapshotLVPath).Return(nil).Times(1)
+
+		res, err := vm.ReconcileSnapshot(testCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		assert.Equal(t, apiV1.Removed, readTestSnapshot(t, vm).Spec.CSIStatus)
+		lvmOps.AssertExpectations(t)
+	})
+
+	t.Run("Removing - lvremove failed", func(t *testing.T) {
+		vm, lvmOps := prepareSnapshotVolumeManager(t, apiV1.Removing)
+		lvmOps.On("LVSnapshotRemove", testSnapshotLVPath).Return(testErr).Times(1)
+
+		_, err := vm.ReconcileSnapshot(testCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, apiV1.Failed, readTestSnapshot(t, vm).Spec.CSIStatus)
+	})
+
+	t.Run("Created - snapshot has free space", func(t *testing.T) {
+		vm, lvmOps := prepareSnapshotVolumeManager(t, apiV1.Created)
+		lvmOps.On("GetLVSnapshotUsage", testSnapshotLVPath).Return(float64(10), nil).Times(1)
+
+		res, err := vm.ReconcileSnapshot(testCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: SnapshotUsageCheckInterval}, res)
+		assert.Equal(t, apiV1.HealthGood, readTestSnapshot(t, vm).Spec.Health)
+	})
+
+	t.Run("Created - snapshot is full", func(t *testing.T) {
+		vm, lvmOps := prepareSnapshotVolumeManager(t, apiV1.Created)
+		lvmOps.On("GetLVSnapshotUsage", testSnapshotLVPath).Return(float64(100), nil).Times(1)
+
+		res, err := vm.ReconcileSnapshot(testCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{}, res)
+		assert.Equal(t, apiV1.HealthBad, readTestSnapshot(t, vm).Spec.Health)
+	})
+
+	t.Run("Created - unable to get usage", func(t *testing.T) {
+		vm, lvmOps := prepareSnapshotVolumeManager(t, apiV1.Created)
+		lvmOps.On("GetLVSnapshotUsage", testSnapshotLVPath).Return(float64(-1), testErr).Times(1)
+
+		res, err := vm.ReconcileSnapshot(testCtx, req)
+		assert.Nil(t, err)
+		assert.Equal(t, ctrl.Result{RequeueAfter: SnapshotUsageCheckInterval}, res)
+		assert.Equal(t, apiV1.HealthGood, readTestSnapshot(t, vm).Spec.Health)
+	})
+}
+
+func TestVolumeManager_isSnapshotCorrespondedToNodePredicate(t *testing.T) {
+	vm := prepareSuccessVolumeManager(t)
+	snapshot := &snapshotcrd.Snapshot{Spec: api.Snapshot{NodeId: nodeID}}
+	assert.True(t, vm.isSnapshotCorrespondedToNodePredicate(snapshot))
+
+	snapshot.Spec.NodeId = "another-node"
+	assert.False(t, vm.isSnapshotCorrespondedToNodePredicate(snapshot))
+
+	assert.False(t, vm.isSnapshotCorrespondedToNodePredicate(testVolumeLVGCR.DeepCopy()))
+}
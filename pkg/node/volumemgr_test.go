@@ -26,9 +26,13 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	k8sError "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -46,14 +50,15 @@ import (
 	dataDiscover "github.com/dell/csi-baremetal/pkg/base/linuxutils/datadiscover/types"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/fs"
 	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lsblk"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lvm"
 	"github.com/dell/csi-baremetal/pkg/base/logger/objects"
 	"github.com/dell/csi-baremetal/pkg/base/util"
 	"github.com/dell/csi-baremetal/pkg/eventing"
 	"github.com/dell/csi-baremetal/pkg/mocks"
 	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
 	mockProv "github.com/dell/csi-baremetal/pkg/mocks/provisioners"
+	tuningcommon "github.com/dell/csi-baremetal/pkg/node/blocktuning/common"
 	p "github.com/dell/csi-baremetal/pkg/node/provisioners"
-	wbtcommon "github.com/dell/csi-baremetal/pkg/node/wbt/common"
 )
 
 // TODO: refactor these UTs - https://github.com/dell/csi-baremetal/issues/90
@@ -925,6 +930,85 @@ func TestVolumeManager_handleDriveStatusChange(t *testing.T) {
 	assert.Equal(t, apiV1.HealthBad, updatedLVG.Spec.Health)
 }
 
+func TestVolumeManager_handleDriveStatusChangeLVG(t *testing.T) {
+	var (
+		vm       = prepareSuccessVolumeManagerWithDrives(nil, t)
+		lvmOps   = &mocklu.MockWrapLVM{}
+		lvg      = testLVGCR.DeepCopy()
+		vol      = testVolumeLVGCR.DeepCopy()
+		ac       = acCR.DeepCopy()
+		drive    = drive1
+		readLVG  = &lvgcrd.LogicalVolumeGroup{}
+		readVol  = &vcrd.Volume{}
+		setDrive = func(health, usage string) updatedDrive {
+			prev := &drivecrd.Drive{Spec: drive}
+			drive.Health, drive.Usage = health, usage
+			return updatedDrive{PreviousState: prev, CurrentState: &drivecrd.Drive{Spec: drive}}
+		}
+	)
+	vm.lvmOps = lvmOps
+	drive.UUID, drive.Usage = driveUUID, apiV1.DriveUsageInUse
+	lvg.Spec.Locations = []string{driveUUID}
+	lvg.Spec.Health = apiV1.HealthGood
+	vol.Spec.Health, vol.Spec.Usage = apiV1.HealthGood, apiV1.VolumeUsageInUse
+	ac.Name, ac.Spec.Location, ac.Spec.Size = "lvg-ac", lvg.Name, 0
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, lvg.Name, lvg))
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, vol.Name, vol))
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, ac.Name, ac))
+	// capacity of removed volume and snapshot is already returned to AC
+	removedVol := vol.DeepCopy()
+	removedVol.ResourceVersion = ""
+	removedVol.Name, removedVol.Spec.Id, removedVol.Spec.CSIStatus = "removed-volume", "removed-volume", apiV1.Removed
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, removedVol.Name, removedVol))
+	snapshotSize := int64(10 * util.GBYTE)
+	for name, csiStatus := range map[string]string{"snapshot": apiV1.Created, "removed-snapshot": apiV1.Removed} {
+		snapshot := vm.k8sClient.ConstructSnapshotCR(name, api.Snapshot{
+			Id: name, VolumeId: vol.Spec.Id, NodeId: nodeID, Location: lvg.Name, Size: snapshotSize, CSIStatus: csiStatus,
+		})
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, snapshot.Name, snapshot))
+	}
+
+	// LVG volumes inherit health of the drive and enter release workflow
+	vm.handleDriveStatusChange(testCtx, setDrive(apiV1.HealthBad, apiV1.DriveUsageInUse))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, lvg.Name, "", readLVG))
+	assert.Equal(t, apiV1.HealthBad, readLVG.Spec.Health)
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, vol.Namespace, readVol))
+	assert.Equal(t, apiV1.HealthBad, readVol.Spec.Health)
+	assert.Equal(t, apiV1.VolumeUsageReleasing, readVol.Spec.Usage)
+
+	// health and capacity are restored when drive recovers, space of volume in Creating status stays reserved
+	vm.handleDriveStatusChange(testCtx, setDrive(apiV1.HealthGood, apiV1.DriveUsageInUse))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, lvg.Name, "", readLVG))
+	assert.Equal(t, apiV1.HealthGood, readLVG.Spec.Health)
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, vol.Namespace, readVol))
+	assert.Equal(t, apiV1.HealthGood, readVol.Spec.Health)
+	readAC, err := vm.crHelper.GetACByLocation(lvg.Name)
+	assert.Nil(t, err)
+	assert.Equal(t, lvg.Spec.Size-vol.Spec.Size-snapshotSize, readAC.Spec.Size)
+	lvmOps.AssertNotCalled(t, "GetVgFreeSpace", lvg.Spec.Name)
+
+	// capacity isn't restored for the drive in release workflow
+	readAC.Spec.Size = 0
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, readAC))
+	vm.handleDriveStatusChange(testCtx, setDrive(apiV1.HealthSuspect, apiV1.DriveUsageReleasing))
+	vm.handleDriveStatusChange(testCtx, setDrive(apiV1.HealthGood, apiV1.DriveUsageReleasing))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, lvg.Name, "", readLVG))
+	assert.Equal(t, apiV1.HealthGood, readLVG.Spec.Health)
+	readAC, err = vm.crHelper.GetACByLocation(lvg.Name)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), readAC.Spec.Size)
+
+	// LVG health is the worst health of its drives
+	lvgDrive2 := drive2
+	lvgDrive2.Health = apiV1.HealthBad
+	addDriveCRs(vm.k8sClient, vm.k8sClient.ConstructDriveCR(lvgDrive2.UUID, lvgDrive2))
+	readLVG.Spec.Locations = append(readLVG.Spec.Locations, lvgDrive2.UUID)
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, readLVG))
+	vm.handleDriveStatusChange(testCtx, setDrive(apiV1.HealthGood, apiV1.DriveUsageInUse))
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, lvg.Name, "", readLVG))
+	assert.Equal(t, apiV1.HealthBad, readLVG.Spec.Health)
+}
+
 func Test_discoverLVGOnSystemDrive_LVGAlreadyExists(t *testing.T) {
 	var (
 		m     = prepareSuccessVolumeManager(t)
@@ -1102,6 +1186,23 @@ func TestVolumeManager_createEventsForDriveUpdates(t *testing.T) {
 		assert.True(t, expectEvent(drive1CR, eventing.DriveHealthUnknown))
 	})
 
+	t.Run("Drive failure predicted", func(t *testing.T) {
+		init()
+		modifiedDrive := drive1CR.DeepCopy()
+		modifiedDrive.Spec.Health = apiV1.HealthSuspect
+		modifiedDrive.Spec.HealthReason = "pending_sectors is 8, threshold 0"
+
+		upd := &driveUpdates{
+			Updated: []updatedDrive{{
+				PreviousState: drive1CR,
+				CurrentState:  modifiedDrive}},
+		}
+		mgr.createEventsForDriveUpdates(upd)
+		assert.True(t, expectEvent(drive1CR, eventing.DriveHealthFailure))
+		assert.False(t, expectEvent(drive1CR, eventing.DriveHealthSuspect))
+		assert.Contains(t, rec.Calls[0].Args, modifiedDrive.Spec.HealthReason)
+	})
+
 	t.Run("Drive health overriden", func(t *testing.T) {
 		init()
 		modifiedDrive := drive1CR.DeepCopy()
@@ -1118,6 +1219,23 @@ func TestVolumeManager_createEventsForDriveUpdates(t *testing.T) {
 		assert.True(t, expectEvent(drive1CR, eventing.DriveHealthFailure))
 	})
 
+	t.Run("Drive path changed", func(t *testing.T) {
+		init()
+		previousDrive := drive1CR.DeepCopy()
+		previousDrive.Spec.Path = "/dev/sda"
+		modifiedDrive := drive1CR.DeepCopy()
+		modifiedDrive.Spec.Path = "/dev/sdc"
+
+		upd := &driveUpdates{
+			Updated: []updatedDrive{{
+				PreviousState: previousDrive,
+				CurrentState:  modifiedDrive}},
+		}
+		mgr.createEventsForDriveUpdates(upd)
+		assert.True(t, expectEvent(drive1CR, eventing.DrivePathChanged))
+		assert.Contains(t, rec.Calls[0].Args, "/dev/sdc")
+	})
+
 	t.Run("Drive removed", func(t *testing.T) {
 		init()
 		modifiedDrive := drive1CR.DeepCopy()
@@ -1192,6 +1310,13 @@ func TestVolumeManager_handleExpandingStatus(t *testing.T) {
 	)
 
 	vm = prepareSuccessVolumeManager(t)
+	// drive based volume is expanded in NodeExpandVolume
+	driveVol := testVolumeCR1.DeepCopy()
+	res, err = vm.handleExpandingStatus(testCtx, driveVol)
+	assert.Nil(t, err)
+	assert.Equal(t, ctrl.Result{}, res)
+
+	testVol.Spec.StorageClass = apiV1.StorageClassHDDLVG
 	pMock = &mockProv.MockProvisioner{}
 	pMock.On("GetVolumePath", &testVol.Spec).Return("path", testErr)
 	vm.SetProvisioners(map[p.VolumeType]p.Provisioner{p.LVMBasedVolumeType: pMock})
@@ -1332,246 +1457,280 @@ func TestVolumeManager_discoverDataOnDrives(t *testing.T) {
 	})
 }
 
-func TestVolumeManager_WbtConfiguration(t *testing.T) {
-	// setWbtValue UT
-	t.Run("setWbtValue: success", func(t *testing.T) {
-		var (
-			testVol          = volCR.DeepCopy()
-			testDrive        = testDriveCR.DeepCopy()
-			wbtValue  uint32 = 0
-			mockWbt          = &mocklu.MockWrapWbt{}
-			device           = "sda" //testDrive.Spec.Path = "/dev/sda"
-		)
+func TestVolumeManager_BlockTuning(t *testing.T) {
+	var (
+		volumeSC  = "csi-baremetal-sc-hdd"
+		device    = "sda" //testDrive.Spec.Path = "/dev/sda"
+		nrRequest = int64(256)
+		conf      = &tuningcommon.Config{
+			Profiles: []tuningcommon.Profile{
+				{
+					Name:       "nvme",
+					DriveTypes: []string{apiV1.DriveTypeNVMe},
+					Settings:   tuningcommon.Settings{Scheduler: "none"},
+				},
+				{
+					Name:           "hdd",
+					StorageClasses: []string{volumeSC},
+					DriveTypes:     []string{apiV1.DriveTypeHDD},
+					Modes:          []string{apiV1.ModeFS},
+					Settings:       tuningcommon.Settings{Scheduler: "mq-deadline", NrRequests: &nrRequest},
+				},
+			},
+		}
+		settings = []tuningcommon.Setting{
+			{Attribute: tuningcommon.Scheduler, Value: "mq-deadline"},
+			{Attribute: tuningcommon.NrRequests, Value: "256"},
+		}
+	)
+
+	prepare := func(t *testing.T, sc string) (*VolumeManager, *mocklu.MockWrapBlockTuning) {
 		vm := prepareSuccessVolumeManager(t)
-		vm.wbtOps = mockWbt
-		vm.wbtConfig = &wbtcommon.WbtConfig{Value: wbtValue}
+		mockTuning := &mocklu.MockWrapBlockTuning{}
+		vm.tuningOps = mockTuning
+		vm.tuningConfig = conf
 
-		err := vm.k8sClient.CreateCR(testCtx, testDrive.Name, testDrive)
-		assert.Nil(t, err)
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testDriveCR.Name, testDriveCR.DeepCopy()))
+		pv := &corev1.PersistentVolume{}
+		pv.Name = volCR.Name
+		pv.Spec.StorageClassName = sc
+		assert.Nil(t, vm.k8sClient.Create(testCtx, pv))
+		return vm, mockTuning
+	}
 
-		mockWbt.On("SetValue", device, wbtValue).Return(nil)
+	// applyBlockTuning UT
+	t.Run("applyBlockTuning: success", func(t *testing.T) {
+		testVol := volCR.DeepCopy()
+		vm, mockTuning := prepare(t, volumeSC)
+		mockTuning.On("Apply", device, settings).Return(map[string]string{tuningcommon.NrRequests: "64"}, nil)
 
-		err = vm.setWbtValue(testVol)
+		profile, err := vm.applyBlockTuning(testCtx, testVol)
 		assert.Nil(t, err)
+		assert.Equal(t, "hdd", profile)
+		assert.Equal(t, &api.BlockTuning{
+			Profile:  "hdd",
+			Device:   device,
+			Applied:  map[string]string{tuningcommon.Scheduler: "mq-deadline", tuningcommon.NrRequests: "256"},
+			Previous: map[string]string{tuningcommon.NrRequests: "64"},
+		}, testVol.Spec.BlockTuning)
 	})
-	t.Run("setWbtValue: findDeviceName failed", func(t *testing.T) {
-		var (
-			testVol   = volCR.DeepCopy()
-			testDrive = testDriveCR.DeepCopy()
-		)
-		vm := prepareSuccessVolumeManager(t)
-
-		testDrive.Spec.Path = "/dev"
+	t.Run("applyBlockTuning: device renamed", func(t *testing.T) {
+		testVol := volCR.DeepCopy()
+		vm, mockTuning := prepare(t, volumeSC)
+		// drive path in CR is outdated, device is searched by drive identifiers
+		vm.listBlk = mocklu.GetMockWrapLsblk("/dev/sdb")
+		mockTuning.On("Apply", "sdb", settings).Return(map[string]string{tuningcommon.NrRequests: "64"}, nil)
 
-		err := vm.k8sClient.CreateCR(testCtx, testDrive.Name, testDrive)
+		_, err := vm.applyBlockTuning(testCtx, testVol)
 		assert.Nil(t, err)
-
-		err = vm.setWbtValue(testVol)
-		assert.NotNil(t, err)
+		assert.NotNil(t, testVol.Spec.BlockTuning)
+		assert.Equal(t, "sdb", testVol.Spec.BlockTuning.Device)
+		mockTuning.AssertNotCalled(t, "Apply", device, settings)
 	})
-	t.Run("setWbtValue: wbtOps.SetValue failed", func(t *testing.T) {
-		var (
-			testVol          = volCR.DeepCopy()
-			testDrive        = testDriveCR.DeepCopy()
-			wbtValue  uint32 = 0
-			mockWbt          = &mocklu.MockWrapWbt{}
-			device           = "sda" //testDrive.Spec.Path = "/dev/sda"
-			mockErr          = fmt.Errorf("some error")
-		)
-		vm := prepareSuccessVolumeManager(t)
-		vm.wbtOps = mockWbt
-		vm.wbtConfig = &wbtcommon.WbtConfig{Value: wbtValue}
+	t.Run("applyBlockTuning: profile isn't matched", func(t *testing.T) {
+		testVol := volCR.DeepCopy()
+		vm, mockTuning := prepare(t, apiV1.StorageClassHDD)
 
-		err := vm.k8sClient.CreateCR(testCtx, testDrive.Name, testDrive)
+		_, err := vm.applyBlockTuning(testCtx, testVol)
 		assert.Nil(t, err)
-
-		mockWbt.On("SetValue", device, wbtValue).Return(mockErr)
-
-		err = vm.setWbtValue(testVol)
-		assert.NotNil(t, err)
-		assert.Equal(t, mockErr, err)
+		assert.Nil(t, testVol.Spec.BlockTuning)
+		mockTuning.AssertNotCalled(t, "Apply", mock.Anything, mock.Anything)
 	})
-
-	// restoreWbtValue UT
-	t.Run("restoreWbtValue: success", func(t *testing.T) {
-		var (
-			testVol   = volCR.DeepCopy()
-			testDrive = testDriveCR.DeepCopy()
-			mockWbt   = &mocklu.MockWrapWbt{}
-			device    = "sda" //testDrive.Spec.Path = "/dev/sda"
-		)
+	t.Run("applyBlockTuning: config is empty", func(t *testing.T) {
+		testVol := volCR.DeepCopy()
 		vm := prepareSuccessVolumeManager(t)
-		vm.wbtOps = mockWbt
 
-		err := vm.k8sClient.CreateCR(testCtx, testDrive.Name, testDrive)
-		assert.Nil(t, err)
-
-		mockWbt.On("RestoreDefault", device).Return(nil)
-
-		err = vm.restoreWbtValue(testVol)
+		_, err := vm.applyBlockTuning(testCtx, testVol)
 		assert.Nil(t, err)
+		assert.Nil(t, testVol.Spec.BlockTuning)
 	})
-	t.Run("restoreWbtValue: findDeviceName failed", func(t *testing.T) {
-		var (
-			testVol   = volCR.DeepCopy()
-			testDrive = testDriveCR.DeepCopy()
-		)
+	t.Run("applyBlockTuning: PV not found", func(t *testing.T) {
+		testVol := volCR.DeepCopy()
 		vm := prepareSuccessVolumeManager(t)
+		vm.tuningConfig = conf
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, testDriveCR.Name, testDriveCR.DeepCopy()))
 
-		testDrive.Spec.Path = "/dev"
-
-		err := vm.k8sClient.CreateCR(testCtx, testDrive.Name, testDrive)
-		assert.Nil(t, err)
-
-		err = vm.restoreWbtValue(testVol)
+		_, err := vm.applyBlockTuning(testCtx, testVol)
 		assert.NotNil(t, err)
+		assert.Nil(t, testVol.Spec.BlockTuning)
 	})
-	t.Run("restoreWbtValue: wbtOps.RestoreDefault failed", func(t *testing.T) {
-		var (
-			testVol   = volCR.DeepCopy()
-			testDrive = testDriveCR.DeepCopy()
-			mockWbt   = &mocklu.MockWrapWbt{}
-			device    = "sda" //testDrive.Spec.Path = "/dev/sda"
-			mockErr   = fmt.Errorf("some error")
-		)
+	t.Run("applyBlockTuning: drive not found", func(t *testing.T) {
+		testVol := volCR.DeepCopy()
 		vm := prepareSuccessVolumeManager(t)
-		vm.wbtOps = mockWbt
+		vm.tuningConfig = conf
 
-		err := vm.k8sClient.CreateCR(testCtx, testDrive.Name, testDrive)
-		assert.Nil(t, err)
-
-		mockWbt.On("RestoreDefault", device).Return(mockErr)
+		_, err := vm.applyBlockTuning(testCtx, testVol)
+		assert.NotNil(t, err)
+		assert.Nil(t, testVol.Spec.BlockTuning)
+	})
+	t.Run("applyBlockTuning: applying failed", func(t *testing.T) {
+		testVol := volCR.DeepCopy()
+		vm, mockTuning := prepare(t, volumeSC)
+		mockTuning.On("Apply", device, settings).Return(map[string]string{}, fmt.Errorf("error"))
 
-		err = vm.restoreWbtValue(testVol)
+		profile, err := vm.applyBlockTuning(testCtx, testVol)
 		assert.NotNil(t, err)
-		assert.Equal(t, mockErr, err)
+		assert.Equal(t, "hdd", profile)
+		assert.Nil(t, testVol.Spec.BlockTuning)
 	})
 
-	// checkWbtChangingEnable
-	t.Run("checkWbtChangingEnable: success", func(t *testing.T) {
+	// restoreBlockTuning UT
+	t.Run("restoreBlockTuning: success", func(t *testing.T) {
 		var (
-			testVol    = volCR.DeepCopy()
-			volumeMode = apiV1.ModeFS
-			volumeSC   = "csi-baremetal-sc-hdd"
-			wbtConf    = &wbtcommon.WbtConfig{
-				Enable: true,
-				VolumeOptions: wbtcommon.VolumeOptions{
-					Modes:          []string{volumeMode},
-					StorageClasses: []string{volumeSC},
-				},
-			}
+			testVol  = volCR.DeepCopy()
+			previous = map[string]string{tuningcommon.NrRequests: "64"}
 		)
-		vm := prepareSuccessVolumeManager(t)
-		vm.wbtConfig = wbtConf
+		vm, mockTuning := prepare(t, volumeSC)
+		testVol.Spec.BlockTuning = &api.BlockTuning{Profile: "hdd", Device: device, Previous: previous}
+		mockTuning.On("Restore", device, previous).Return(nil)
 
-		pv := &corev1.PersistentVolume{}
-		pv.Name = testVol.Name
-		pv.Spec.StorageClassName = volumeSC
-		err := vm.k8sClient.Create(testCtx, pv)
+		err := vm.restoreBlockTuning(testCtx, testVol)
 		assert.Nil(t, err)
-
-		result := vm.checkWbtChangingEnable(testCtx, testVol)
-		assert.True(t, result)
+		assert.Nil(t, testVol.Spec.BlockTuning)
 	})
-	t.Run("checkWbtChangingEnable: wbtConf is nil", func(t *testing.T) {
+	t.Run("restoreBlockTuning: nothing to restore", func(t *testing.T) {
 		var (
-			testVol = volCR.DeepCopy()
+			testVol    = volCR.DeepCopy()
+			mockTuning = &mocklu.MockWrapBlockTuning{}
 		)
 		vm := prepareSuccessVolumeManager(t)
-		vm.wbtConfig = nil
+		vm.tuningOps = mockTuning
+		testVol.Spec.BlockTuning = &api.BlockTuning{Profile: "hdd", Device: device}
 
-		result := vm.checkWbtChangingEnable(testCtx, testVol)
-		assert.False(t, result)
+		err := vm.restoreBlockTuning(testCtx, testVol)
+		assert.Nil(t, err)
+		assert.Nil(t, testVol.Spec.BlockTuning)
+		mockTuning.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
 	})
-	t.Run("checkWbtChangingEnable: wbt disabled", func(t *testing.T) {
+	t.Run("restoreBlockTuning: restoring failed", func(t *testing.T) {
 		var (
-			testVol    = volCR.DeepCopy()
-			volumeMode = apiV1.ModeFS
-			volumeSC   = "csi-baremetal-sc-hdd"
-			wbtConf    = &wbtcommon.WbtConfig{
-				Enable: false,
-				VolumeOptions: wbtcommon.VolumeOptions{
-					Modes:          []string{volumeMode},
-					StorageClasses: []string{volumeSC},
-				},
-			}
+			testVol  = volCR.DeepCopy()
+			previous = map[string]string{tuningcommon.NrRequests: "64"}
 		)
-		vm := prepareSuccessVolumeManager(t)
-		vm.wbtConfig = wbtConf
+		vm, mockTuning := prepare(t, volumeSC)
+		testVol.Spec.BlockTuning = &api.BlockTuning{Profile: "hdd", Device: device, Previous: previous}
+		mockTuning.On("Restore", device, previous).Return(fmt.Errorf("error"))
 
-		result := vm.checkWbtChangingEnable(testCtx, testVol)
-		assert.False(t, result)
+		err := vm.restoreBlockTuning(testCtx, testVol)
+		assert.NotNil(t, err)
+		assert.Nil(t, testVol.Spec.BlockTuning)
 	})
-	t.Run("checkWbtChangingEnable: wrong Mode", func(t *testing.T) {
+	t.Run("restoreBlockTuning: device renamed", func(t *testing.T) {
 		var (
-			testVol   = volCR.DeepCopy()
-			wrongMode = apiV1.ModeRAW
-			volumeSC  = "csi-baremetal-sc-hdd"
-			wbtConf   = &wbtcommon.WbtConfig{
-				Enable: true,
-				VolumeOptions: wbtcommon.VolumeOptions{
-					Modes:          []string{wrongMode},
-					StorageClasses: []string{volumeSC},
-				},
-			}
+			testVol  = volCR.DeepCopy()
+			previous = map[string]string{tuningcommon.NrRequests: "64"}
 		)
-		vm := prepareSuccessVolumeManager(t)
-		vm.wbtConfig = wbtConf
+		vm, mockTuning := prepare(t, volumeSC)
+		vm.listBlk = mocklu.GetMockWrapLsblk("/dev/sdb")
+		testVol.Spec.BlockTuning = &api.BlockTuning{Profile: "hdd", Device: device, Previous: previous}
+		mockTuning.On("Restore", "sdb", previous).Return(nil)
 
-		result := vm.checkWbtChangingEnable(testCtx, testVol)
-		assert.False(t, result)
+		err := vm.restoreBlockTuning(testCtx, testVol)
+		assert.Nil(t, err)
+		assert.Nil(t, testVol.Spec.BlockTuning)
+		mockTuning.AssertNotCalled(t, "Restore", device, previous)
 	})
-	t.Run("checkWbtChangingEnable: wrong SC", func(t *testing.T) {
+	t.Run("restoreBlockTuning: drive not found", func(t *testing.T) {
 		var (
 			testVol    = volCR.DeepCopy()
-			volumeMode = apiV1.ModeFS
-			volumeSC   = "csi-baremetal-sc-hdd"
-			wrongSC    = "csi-baremetal-sc-hddlvg"
-			wbtConf    = &wbtcommon.WbtConfig{
-				Enable: true,
-				VolumeOptions: wbtcommon.VolumeOptions{
-					Modes:          []string{volumeMode},
-					StorageClasses: []string{wrongSC},
-				},
-			}
+			previous   = map[string]string{tuningcommon.NrRequests: "64"}
+			mockTuning = &mocklu.MockWrapBlockTuning{}
 		)
 		vm := prepareSuccessVolumeManager(t)
-		vm.wbtConfig = wbtConf
+		vm.tuningOps = mockTuning
+		testVol.Spec.BlockTuning = &api.BlockTuning{Profile: "hdd", Device: device, Previous: previous}
 
-		pv := &corev1.PersistentVolume{}
-		pv.Name = testVol.Name
-		pv.Spec.StorageClassName = volumeSC
-		err := vm.k8sClient.Create(testCtx, pv)
+		err := vm.restoreBlockTuning(testCtx, testVol)
+		assert.NotNil(t, err)
+		assert.Nil(t, testVol.Spec.BlockTuning)
+		mockTuning.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+	t.Run("applyBlockTuning: device is tuned for other volume", func(t *testing.T) {
+		testVol := volCR.DeepCopy()
+		vm, mockTuning := prepare(t, volumeSC)
+		otherVol := volCR.DeepCopy()
+		otherVol.Name = "other-volume"
+		otherVol.Spec.Id = otherVol.Name
+		otherVol.Spec.BlockTuning = &api.BlockTuning{Profile: "hdd", Device: device,
+			Previous: map[string]string{tuningcommon.NrRequests: "64", tuningcommon.Scheduler: "none"}}
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, otherVol.Name, otherVol))
+		// scheduler is already changed for other volume, nr_requests isn't changed by its profile
+		mockTuning.On("Apply", device, settings).Return(map[string]string{tuningcommon.Scheduler: "mq-deadline"}, nil)
+
+		_, err := vm.applyBlockTuning(testCtx, testVol)
 		assert.Nil(t, err)
-
-		result := vm.checkWbtChangingEnable(testCtx, testVol)
-		assert.False(t, result)
+		assert.NotNil(t, testVol.Spec.BlockTuning)
+		// values which device had before tuning are saved
+		assert.Equal(t, map[string]string{tuningcommon.NrRequests: "64", tuningcommon.Scheduler: "none"},
+			testVol.Spec.BlockTuning.Previous)
 	})
-	t.Run("checkWbtChangingEnable: PV not found", func(t *testing.T) {
+	t.Run("restoreBlockTuning: device is tuned for other volume", func(t *testing.T) {
 		var (
-			testVol    = volCR.DeepCopy()
-			volumeMode = apiV1.ModeFS
-			volumeSC   = "csi-baremetal-sc-hdd"
-			wrongSC    = "csi-baremetal-sc-hddlvg"
-			wbtConf    = &wbtcommon.WbtConfig{
-				Enable: true,
-				VolumeOptions: wbtcommon.VolumeOptions{
-					Modes:          []string{volumeMode},
-					StorageClasses: []string{wrongSC},
-				},
-			}
+			testVol  = volCR.DeepCopy()
+			previous = map[string]string{tuningcommon.NrRequests: "64", tuningcommon.Scheduler: "none"}
 		)
-		vm := prepareSuccessVolumeManager(t)
-		vm.wbtConfig = wbtConf
+		vm, mockTuning := prepare(t, volumeSC)
+		otherVol := volCR.DeepCopy()
+		otherVol.Name = "other-volume"
+		otherVol.Spec.Id = otherVol.Name
+		otherVol.Spec.BlockTuning = &api.BlockTuning{Profile: "nvme", Device: device,
+			Previous: map[string]string{tuningcommon.Scheduler: "mq-deadline"}}
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, otherVol.Name, otherVol))
+		// volume on another device doesn't prevent restoring
+		anotherDeviceVol := volCR.DeepCopy()
+		anotherDeviceVol.Name = "another-device-volume"
+		anotherDeviceVol.Spec.Id = anotherDeviceVol.Name
+		anotherDeviceVol.Spec.BlockTuning = &api.BlockTuning{Profile: "hdd", Device: "sdb", Previous: previous}
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, anotherDeviceVol.Name, anotherDeviceVol))
+		testVol.Spec.BlockTuning = &api.BlockTuning{Profile: "hdd", Device: device, Previous: previous}
+
+		err := vm.restoreBlockTuning(testCtx, testVol)
+		assert.Nil(t, err)
+		assert.Nil(t, testVol.Spec.BlockTuning)
+		mockTuning.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+		// missed previous values are passed to the volume which is still staged
+		updatedVol := &vcrd.Volume{}
+		assert.Nil(t, vm.k8sClient.ReadCR(testCtx, otherVol.Name, testNs, updatedVol))
+		assert.Equal(t, map[string]string{tuningcommon.NrRequests: "64", tuningcommon.Scheduler: "mq-deadline"},
+			updatedVol.Spec.BlockTuning.Previous)
+
+		// the last volume restores values
+		lastPrevious := updatedVol.Spec.BlockTuning.Previous
+		mockTuning.On("Restore", device, lastPrevious).Return(nil)
+		err = vm.restoreBlockTuning(testCtx, updatedVol)
+		assert.Nil(t, err)
+		mockTuning.AssertCalled(t, "Restore", device, lastPrevious)
+	})
+	t.Run("restoreBlockTuning: legacy WBT annotation", func(t *testing.T) {
+		testVol := volCR.DeepCopy()
+		vm, mockTuning := prepare(t, volumeSC)
+		testVol.Annotations = map[string]string{wbtChangedVolumeAnnotation: wbtChangedVolumeKey}
+		mockTuning.On("Restore", device,
+			map[string]string{tuningcommon.WbtLatUsec: tuningcommon.DefaultWbtLatUsec}).Return(nil)
 
-		pv := &corev1.PersistentVolume{}
-		pv.Name = "some_name"
-		pv.Spec.StorageClassName = volumeSC
-		err := vm.k8sClient.Create(testCtx, pv)
+		err := vm.restoreBlockTuning(testCtx, testVol)
 		assert.Nil(t, err)
+		_, ok := testVol.Annotations[wbtChangedVolumeAnnotation]
+		assert.False(t, ok)
+	})
+	t.Run("restoreBlockTuning: legacy WBT annotation, drive not found", func(t *testing.T) {
+		testVol := volCR.DeepCopy()
+		vm := prepareSuccessVolumeManager(t)
+		testVol.Annotations = map[string]string{wbtChangedVolumeAnnotation: wbtChangedVolumeKey}
 
-		result := vm.checkWbtChangingEnable(testCtx, testVol)
-		assert.False(t, result)
+		err := vm.restoreBlockTuning(testCtx, testVol)
+		assert.NotNil(t, err)
+	})
+	t.Run("isLegacyWbtTuning", func(t *testing.T) {
+		testVol := volCR.DeepCopy()
+		assert.False(t, isLegacyWbtTuning(testVol))
+		testVol.Spec.BlockTuning = &api.BlockTuning{Profile: "hdd"}
+		assert.False(t, isLegacyWbtTuning(testVol))
+		testVol.Spec.BlockTuning.Profile = tuningcommon.LegacyWbtProfileName
+		assert.True(t, isLegacyWbtTuning(testVol))
+		testVol.Spec.BlockTuning = nil
+		testVol.Annotations = map[string]string{wbtChangedVolumeAnnotation: wbtChangedVolumeKey}
+		assert.True(t, isLegacyWbtTuning(testVol))
 	})
 
 	// findDeviceName
@@ -1590,6 +1749,22 @@ func TestVolumeManager_WbtConfiguration(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t, expectedDevice, device)
 	})
+	t.Run("findDeviceName: kernel name is changed", func(t *testing.T) {
+		var (
+			testVol   = volCR.DeepCopy()
+			testDrive = testDriveCR.DeepCopy()
+		)
+		vm := prepareSuccessVolumeManager(t)
+		vm.listBlk = mocklu.GetMockWrapLsblk("/dev/sdc")
+		testDrive.Spec.ByIdPath = "/dev/disk/by-id/wwn-0x5000c500a0b1c2d3"
+
+		err := vm.k8sClient.CreateCR(testCtx, testDrive.Name, testDrive)
+		assert.Nil(t, err)
+
+		device, err := vm.findDeviceName(testVol)
+		assert.Nil(t, err)
+		assert.Equal(t, "sdc", device)
+	})
 	t.Run("findDeviceName: GetDriveCRByVolume failed", func(t *testing.T) {
 		var (
 			testVol = volCR.DeepCopy()
@@ -1647,38 +1822,93 @@ func TestVolumeManager_WbtConfiguration(t *testing.T) {
 		assert.Equal(t, "", device)
 	})
 
-	// SetWbtConfig UT
-	t.Run("SetWbtConfig: success", func(t *testing.T) {
-		var (
-			volumeMode = apiV1.ModeFS
-			volumeSC   = apiV1.StorageClassHDD
-			wbtConf    = &wbtcommon.WbtConfig{
-				Enable: true,
-				VolumeOptions: wbtcommon.VolumeOptions{
-					Modes:          []string{volumeMode},
-					StorageClasses: []string{volumeSC},
-				},
-			}
-		)
-
+	// SetBlockTuningConfig UT
+	t.Run("SetBlockTuningConfig: success", func(t *testing.T) {
 		// nil
 		vm := prepareSuccessVolumeManager(t)
-		vm.SetWbtConfig(wbtConf)
-		assert.Equal(t, wbtConf, vm.wbtConfig)
+		vm.SetBlockTuningConfig(conf)
+		assert.Equal(t, conf, vm.tuningConfig)
 
 		// changed
-		changedWbtConf := &wbtcommon.WbtConfig{
-			Enable: true,
-			VolumeOptions: wbtcommon.VolumeOptions{
-				Modes:          []string{volumeMode},
-				StorageClasses: []string{volumeSC},
-			},
-		}
-		vm.SetWbtConfig(changedWbtConf)
-		assert.Equal(t, changedWbtConf, vm.wbtConfig)
+		changedConf := &tuningcommon.Config{}
+		vm.SetBlockTuningConfig(changedConf)
+		assert.Equal(t, changedConf, vm.tuningConfig)
 	})
 }
 
+func TestVolumeManager_ExportDriveTelemetry(t *testing.T) {
+	vm := prepareSuccessVolumeManager(t)
+	drives := []*api.Drive{
+		{SerialNumber: "hdd1", Type: apiV1.DriveTypeHDD, Health: apiV1.HealthGood, Temperature: 35, PowerOnHours: 1000},
+		{SerialNumber: "nvme1", Type: apiV1.DriveTypeNVMe, Health: apiV1.HealthGood, Temperature: 40, Endurance: 88},
+		{SerialNumber: "nvme2", Type: apiV1.DriveTypeNVMe, Health: apiV1.HealthUnknown},
+	}
+	vm.exportDriveTelemetry(drives)
+
+	telemetry := vm.metricDriveTelemetry
+	assert.Equal(t, float64(35), testutil.ToFloat64(telemetry.temperature.With(
+		prometheus.Labels{"node": nodeID, "serial_number": "hdd1", "type": apiV1.DriveTypeHDD})))
+	assert.Equal(t, float64(88), testutil.ToFloat64(telemetry.endurance.With(
+		prometheus.Labels{"node": nodeID, "serial_number": "nvme1", "type": apiV1.DriveTypeNVMe})))
+	assert.Equal(t, 2, testutil.CollectAndCount(telemetry.temperature))
+	assert.Equal(t, 1, testutil.CollectAndCount(telemetry.powerOnHours))
+	assert.Equal(t, 1, testutil.CollectAndCount(telemetry.endurance))
+	// telemetry isn't saved in Drive CRs
+	for _, d := range drives {
+		assert.Equal(t, int64(0), d.Temperature)
+		assert.Equal(t, int64(0), d.PowerOnHours)
+	}
+
+	// removed drives aren't exported
+	vm.exportDriveTelemetry(drives[2:])
+	assert.Equal(t, 0, testutil.CollectAndCount(telemetry.temperature))
+	assert.Equal(t, 0, testutil.CollectAndCount(telemetry.endurance))
+}
+
+func TestVolumeManager_ExportDriveEventTelemetry(t *testing.T) {
+	vm := prepareSuccessVolumeManager(t)
+	telemetry := vm.metricDriveTelemetry
+	labels := prometheus.Labels{"node": nodeID, "serial_number": "hdd1", "type": apiV1.DriveTypeHDD}
+	vm.exportDriveTelemetry([]*api.Drive{
+		{SerialNumber: "hdd2", Type: apiV1.DriveTypeHDD, Temperature: 30},
+	})
+
+	// telemetry of other drives is kept
+	drive := &api.Drive{SerialNumber: "hdd1", Type: apiV1.DriveTypeHDD, Temperature: 35, PowerOnHours: 1000}
+	vm.exportDriveEventTelemetry(&api.DriveEvent{Type: apiV1.DriveEventChanged, Drive: drive})
+	assert.Equal(t, float64(35), testutil.ToFloat64(telemetry.temperature.With(labels)))
+	assert.Equal(t, 2, testutil.CollectAndCount(telemetry.temperature))
+	assert.Equal(t, int64(0), drive.Temperature)
+	assert.Equal(t, int64(0), drive.PowerOnHours)
+
+	drive = &api.Drive{SerialNumber: "hdd1", Type: apiV1.DriveTypeHDD, Temperature: 36}
+	vm.exportDriveEventTelemetry(&api.DriveEvent{Type: apiV1.DriveEventChanged, Drive: drive})
+	assert.Equal(t, float64(36), testutil.ToFloat64(telemetry.temperature.With(labels)))
+	assert.Equal(t, 0, testutil.CollectAndCount(telemetry.powerOnHours))
+
+	drive = &api.Drive{SerialNumber: "hdd1", Type: apiV1.DriveTypeHDD, Temperature: 36}
+	vm.exportDriveEventTelemetry(&api.DriveEvent{Type: apiV1.DriveEventRemoved, Drive: drive})
+	assert.Equal(t, 1, testutil.CollectAndCount(telemetry.temperature))
+	assert.Equal(t, int64(0), drive.Temperature)
+}
+
+func TestVolumeManager_Monitor(t *testing.T) {
+	vm, lvmOps := prepareThinVolumeManager(t, apiV1.HealthGood, apiV1.HealthGood)
+	drive := drive1
+	drive.Temperature = 35
+	vm.driveMgrClient = mocks.NewMockDriveMgrClient([]*api.Drive{&drive})
+	lvmOps.On("GetThinPoolUsage", fmt.Sprintf("/dev/%s/%s", testLVGCR.Spec.Name, lvm.ThinPoolName)).
+		Return(float64(85), float64(2), nil).Times(1)
+
+	assert.Nil(t, vm.Monitor())
+	assert.Equal(t, float64(35), testutil.ToFloat64(vm.metricDriveTelemetry.temperature.With(
+		prometheus.Labels{"node": nodeID, "serial_number": drive.SerialNumber, "type": drive.Type})))
+	assert.Equal(t, apiV1.HealthSuspect, readTestLVG(t, vm).Spec.Health)
+
+	vm.driveMgrClient = &mocks.MockDriveMgrClientFail{}
+	assert.NotNil(t, vm.Monitor())
+}
+
 func prepareSuccessVolumeManager(t *testing.T) *VolumeManager {
 	c := mocks.NewMockDriveMgrClient(nil)
 	// create map of commands which must be mocked
@@ -1760,3 +1990,82 @@ func TestVolumeManager_isDriveSystem(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Equal(t, isSystem, false)
 }
+
+func TestVolumeManager_WatchDriveEvents(t *testing.T) {
+	vm := prepareSuccessVolumeManager(t)
+	driveEvents := make(chan *api.DriveEvent, 1)
+	vm.driveMgrClient.(*mocks.MockDriveMgrClient).SetDriveEvents(driveEvents)
+	events, err := vm.WatchDriveEvents(context.Background())
+	assert.Nil(t, err)
+
+	driveEvents <- &api.DriveEvent{Type: apiV1.DriveEventRemoved, Drive: &api.Drive{SerialNumber: "hdd1"}}
+	event := <-events
+	assert.Equal(t, apiV1.DriveEventRemoved, event.Type)
+	assert.Equal(t, "hdd1", event.Drive.SerialNumber)
+
+	// channel is closed when stream fails
+	close(driveEvents)
+	_, ok := <-events
+	assert.False(t, ok)
+
+	// channel is closed when context is done
+	vm.driveMgrClient.(*mocks.MockDriveMgrClient).SetDriveEvents(make(chan *api.DriveEvent))
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err = vm.WatchDriveEvents(ctx)
+	assert.Nil(t, err)
+	cancel()
+	_, ok = <-events
+	assert.False(t, ok)
+
+	// stream isn't opened again when drive manager doesn't support it
+	driveEvents = make(chan *api.DriveEvent)
+	vm.driveMgrClient.(*mocks.MockDriveMgrClient).SetDriveEvents(driveEvents)
+	vm.driveMgrClient.(*mocks.MockDriveMgrClient).SetDriveEventsError(
+		status.Error(codes.Unimplemented, "method WatchDrives not implemented"))
+	events, err = vm.WatchDriveEvents(context.Background())
+	assert.Nil(t, err)
+	close(driveEvents)
+	_, ok = <-events
+	assert.False(t, ok)
+	_, err = vm.WatchDriveEvents(context.Background())
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+	vm = prepareSuccessVolumeManager(t)
+	_, err = vm.WatchDriveEvents(context.Background())
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	assert.True(t, vm.isWatchUnimplemented())
+}
+
+func TestVolumeManager_HandleDriveEvent(t *testing.T) {
+	vm := prepareSuccessVolumeManager(t)
+	discoverData := &mocklu.MockWrapDataDiscover{}
+	discoverData.On("DiscoverData", drive2.Path, drive2.SerialNumber).
+		Return(&dataDiscover.DiscoverResult{HasData: false}, nil).Once()
+	vm.dataDiscover = discoverData
+
+	// drive without data is discovered as clean
+	added := drive2
+	added.UUID, added.IsSystem = "", false
+	assert.Nil(t, vm.HandleDriveEvent(&api.DriveEvent{Type: apiV1.DriveEventAdded, Drive: &added}))
+	driveCRs, err := vm.crHelper.GetDriveCRs(vm.nodeID)
+	assert.Nil(t, err)
+	assert.Len(t, driveCRs, 1)
+	assert.True(t, driveCRs[0].Spec.IsClean)
+	discoverData.AssertExpectations(t)
+
+	changed := drive2
+	changed.Health = apiV1.HealthBad
+	assert.Nil(t, vm.HandleDriveEvent(&api.DriveEvent{Type: apiV1.DriveEventChanged, Drive: &changed}))
+	driveCRs, err = vm.crHelper.GetDriveCRs(vm.nodeID)
+	assert.Nil(t, err)
+	assert.Len(t, driveCRs, 1)
+	assert.Equal(t, apiV1.HealthBad, driveCRs[0].Spec.Health)
+
+	removed := drive2
+	assert.Nil(t, vm.HandleDriveEvent(&api.DriveEvent{Type: apiV1.DriveEventRemoved, Drive: &removed}))
+	driveCRs, err = vm.crHelper.GetDriveCRs(vm.nodeID)
+	assert.Nil(t, err)
+	assert.Equal(t, apiV1.DriveStatusOffline, driveCRs[0].Spec.Status)
+
+	assert.NotNil(t, vm.HandleDriveEvent(&api.DriveEvent{Type: apiV1.DriveEventAdded}))
+}
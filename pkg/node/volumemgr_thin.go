@@ -0,0 +1,135 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/sirupsen/logrus"
+
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lvm"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+)
+
+// ThinPoolUsageThreshold is a percent of thin pool data or metadata usage which makes LVG health SUSPECT,
+// LVG with full thin pool gets BAD health. AC of LVG with unhealthy pool is reset and new volumes aren't placed there
+var ThinPoolUsageThreshold = 80.0
+
+// updateThinPoolsUsage reads data and metadata usage of the thin pools on the node and propagates it to the LVG CRs.
+// Health of LVG is changed according to usage only when all drives of LVG are healthy
+func (m *VolumeManager) updateThinPoolsUsage(ctx context.Context) {
+	ll := m.log.WithFields(logrus.Fields{
+		"method": "updateThinPoolsUsage",
+	})
+
+	lvgs, err := m.cachedCrHelper.GetLVGCRs(m.nodeID)
+	if err != nil {
+		ll.Errorf("Unable to read LVG CRs: %v", err)
+		return
+	}
+	drives, err := m.cachedCrHelper.GetDriveCRs(m.nodeID)
+	if err != nil {
+		ll.Errorf("Unable to read drive CRs: %v", err)
+		return
+	}
+	drivesHealth := make(map[string]string, len(drives))
+	for _, drive := range drives {
+		drivesHealth[drive.Spec.UUID] = drive.Spec.Health
+	}
+
+	for _, lvg := range lvgs {
+		lvg := lvg
+		if !lvg.Spec.Thin || lvg.Spec.Status != apiV1.Created {
+			continue
+		}
+
+		data, metadata, err := m.lvmOps.GetThinPoolUsage(fmt.Sprintf("/dev/%s/%s", lvg.Spec.Name, lvm.ThinPoolName))
+		if err != nil {
+			ll.Errorf("Unable to get usage of thin pool in LVG %s: %v", lvg.Name, err)
+			continue
+		}
+
+		health := lvg.Spec.Health
+		drivesGood := true
+		for _, location := range lvg.Spec.Locations {
+			if drivesHealth[location] != apiV1.HealthGood {
+				drivesGood = false
+				break
+			}
+		}
+		if drivesGood {
+			health = getThinPoolHealth(data, metadata)
+		}
+
+		dataUsage, metadataUsage := int32(math.Round(data)), int32(math.Round(metadata))
+		if health == lvg.Spec.Health && dataUsage == lvg.Spec.DataUsage && metadataUsage == lvg.Spec.MetadataUsage {
+			continue
+		}
+		prevHealth := lvg.Spec.Health
+		lvg.Spec.Health = health
+		lvg.Spec.DataUsage = dataUsage
+		lvg.Spec.MetadataUsage = metadataUsage
+		if err = m.k8sClient.UpdateCR(ctx, &lvg); err != nil {
+			ll.Errorf("Failed to update LVG CR %s: %v", lvg.Name, err)
+			continue
+		}
+		if health == prevHealth {
+			continue
+		}
+
+		ll.Infof("Thin pool of LVG %s uses %.2f%% of data and %.2f%% of metadata, setting health %s",
+			lvg.Name, data, metadata, health)
+		event := eventing.ThinPoolUsageNormal
+		switch health {
+		case apiV1.HealthSuspect:
+			event = eventing.ThinPoolUsageHigh
+		case apiV1.HealthBad:
+			event = eventing.ThinPoolFull
+		}
+		m.recorder.Eventf(&lvg, event,
+			"LVG health transitioned from %s to %s. Thin pool data usage: %.2f%%, metadata usage: %.2f%%",
+			prevHealth, health, data, metadata)
+	}
+}
+
+// getThinLVGHealth returns health of thin LVG according to the current usage of its thin pool,
+// usage saved in LVG CR is used if thin pool can't be read
+func (m *VolumeManager) getThinLVGHealth(lvg *lvgcrd.LogicalVolumeGroup) string {
+	data, metadata, err := m.lvmOps.GetThinPoolUsage(fmt.Sprintf("/dev/%s/%s", lvg.Spec.Name, lvm.ThinPoolName))
+	if err != nil {
+		m.log.WithField("method", "getThinLVGHealth").
+			Errorf("Unable to get usage of thin pool in LVG %s: %v", lvg.Name, err)
+		data, metadata = float64(lvg.Spec.DataUsage), float64(lvg.Spec.MetadataUsage)
+	}
+	return getThinPoolHealth(data, metadata)
+}
+
+// getThinPoolHealth converts usage of the thin pool to the LVG health
+func getThinPoolHealth(data, metadata float64) string {
+	usage := math.Max(data, metadata)
+	switch {
+	case usage >= 100:
+		return apiV1.HealthBad
+	case usage >= ThinPoolUsageThreshold:
+		return apiV1.HealthSuspect
+	}
+	return apiV1.HealthGood
+}
@@ -0,0 +1,168 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	apiV1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/lvgcrd"
+	vcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base/linuxutils/lvm"
+	"github.com/dell/csi-baremetal/pkg/eventing"
+	"github.com/dell/csi-baremetal/pkg/mocks"
+	mocklu "github.com/dell/csi-baremetal/pkg/mocks/linuxutils"
+)
+
+func prepareThinVolumeManager(t *testing.T, driveHealth, lvgHealth string) (*VolumeManager, *mocklu.MockWrapLVM) {
+	drive := drive1
+	drive.Health = driveHealth
+	vm := prepareSuccessVolumeManagerWithDrives([]*api.Drive{&drive}, t)
+	lvg := testLVGCR.DeepCopy()
+	lvg.Spec.Thin = true
+	lvg.Spec.Health = lvgHealth
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, lvg.Name, lvg))
+
+	lvmOps := &mocklu.MockWrapLVM{}
+	vm.lvmOps = lvmOps
+	return vm, lvmOps
+}
+
+func readTestLVG(t *testing.T, vm *VolumeManager) *lvgcrd.LogicalVolumeGroup {
+	lvg := &lvgcrd.LogicalVolumeGroup{}
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, testLVGCR.Name, "", lvg))
+	return lvg
+}
+
+func TestVolumeManager_updateThinPoolsUsage(t *testing.T) {
+	poolPath := fmt.Sprintf("/dev/%s/%s", testLVGCR.Spec.Name, lvm.ThinPoolName)
+	testCases := []struct {
+		name           string
+		data           float64
+		metadata       float64
+		prevHealth     string
+		expectedHealth string
+		expectedEvent  *eventing.EventDescription
+	}{
+		{"Pool usage is low", 10.4, 2, apiV1.HealthGood, apiV1.HealthGood, nil},
+		{"Data usage is high", 85, 2, apiV1.HealthGood, apiV1.HealthSuspect, eventing.ThinPoolUsageHigh},
+		{"Metadata usage is high", 10, 90, apiV1.HealthGood, apiV1.HealthSuspect, eventing.ThinPoolUsageHigh},
+		{"Pool is full", 100, 2, apiV1.HealthSuspect, apiV1.HealthBad, eventing.ThinPoolFull},
+		{"Pool usage returned to normal", 50, 2, apiV1.HealthSuspect, apiV1.HealthGood, eventing.ThinPoolUsageNormal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			vm, lvmOps := prepareThinVolumeManager(t, apiV1.HealthGood, tc.prevHealth)
+			lvmOps.On("GetThinPoolUsage", poolPath).Return(tc.data, tc.metadata, nil).Times(1)
+
+			vm.updateThinPoolsUsage(testCtx)
+
+			lvg := readTestLVG(t, vm)
+			assert.Equal(t, tc.expectedHealth, lvg.Spec.Health)
+			assert.Equal(t, int32(tc.data+0.5), lvg.Spec.DataUsage)
+			assert.Equal(t, int32(tc.metadata+0.5), lvg.Spec.MetadataUsage)
+			rec := vm.recorder.(*mocks.NoOpRecorder)
+			if tc.expectedEvent == nil {
+				assert.Empty(t, rec.Calls)
+			} else {
+				assert.Len(t, rec.Calls, 1)
+				assert.Equal(t, tc.expectedEvent, rec.Calls[0].Event)
+			}
+		})
+	}
+
+	t.Run("Health of LVG with unhealthy drive isn't changed", func(t *testing.T) {
+		vm, lvmOps := prepareThinVolumeManager(t, apiV1.HealthBad, apiV1.HealthBad)
+		lvmOps.On("GetThinPoolUsage", poolPath).Return(float64(10), float64(2), nil).Times(1)
+
+		vm.updateThinPoolsUsage(testCtx)
+		lvg := readTestLVG(t, vm)
+		assert.Equal(t, apiV1.HealthBad, lvg.Spec.Health)
+		assert.Equal(t, int32(10), lvg.Spec.DataUsage)
+		assert.Empty(t, vm.recorder.(*mocks.NoOpRecorder).Calls)
+	})
+
+	t.Run("Unable to get pool usage", func(t *testing.T) {
+		vm, lvmOps := prepareThinVolumeManager(t, apiV1.HealthGood, apiV1.HealthGood)
+		lvmOps.On("GetThinPoolUsage", poolPath).Return(float64(-1), float64(-1), testErr).Times(1)
+
+		vm.updateThinPoolsUsage(testCtx)
+		lvg := readTestLVG(t, vm)
+		assert.Equal(t, apiV1.HealthGood, lvg.Spec.Health)
+		assert.Equal(t, int32(0), lvg.Spec.DataUsage)
+	})
+
+	t.Run("Thick LVG isn't checked", func(t *testing.T) {
+		vm := prepareSuccessVolumeManager(t)
+		lvg := testLVGCR.DeepCopy()
+		assert.Nil(t, vm.k8sClient.CreateCR(testCtx, lvg.Name, lvg))
+		lvmOps := &mocklu.MockWrapLVM{}
+		vm.lvmOps = lvmOps
+
+		vm.updateThinPoolsUsage(testCtx)
+		lvmOps.AssertNotCalled(t, "GetThinPoolUsage", poolPath)
+	})
+}
+
+func TestVolumeManager_handleDriveStatusChangeThinLVG(t *testing.T) {
+	var (
+		poolPath   = fmt.Sprintf("/dev/%s/%s", testLVGCR.Spec.Name, lvm.ThinPoolName)
+		vm, lvmOps = prepareThinVolumeManager(t, apiV1.HealthGood, apiV1.HealthSuspect)
+		vol        = testVolumeLVGCR.DeepCopy()
+		readVol    = &vcrd.Volume{}
+		drive      = drive1
+		setDrive   = func(health string) updatedDrive {
+			prev := &drivecrd.Drive{Spec: drive}
+			drive.Health = health
+			return updatedDrive{PreviousState: prev, CurrentState: &drivecrd.Drive{Spec: drive}}
+		}
+	)
+	drive.Usage = apiV1.DriveUsageInUse
+	vol.Spec.Health, vol.Spec.Usage = apiV1.HealthGood, apiV1.VolumeUsageInUse
+	assert.Nil(t, vm.k8sClient.CreateCR(testCtx, vol.Name, vol))
+
+	// healthy drive doesn't reset health of nearly full thin pool, volumes aren't released because of pool usage
+	lvmOps.On("GetThinPoolUsage", poolPath).Return(float64(85), float64(2), nil).Times(1)
+	vm.handleDriveStatusChange(testCtx, setDrive(apiV1.HealthGood))
+	assert.Equal(t, apiV1.HealthSuspect, readTestLVG(t, vm).Spec.Health)
+	assert.Nil(t, vm.k8sClient.ReadCR(testCtx, vol.Name, vol.Namespace, readVol))
+	assert.Equal(t, apiV1.HealthGood, readVol.Spec.Health)
+	assert.Equal(t, apiV1.VolumeUsageInUse, readVol.Spec.Usage)
+
+	// the worst of drive and pool health is used
+	lvmOps.On("GetThinPoolUsage", poolPath).Return(float64(85), float64(2), nil).Times(1)
+	vm.handleDriveStatusChange(testCtx, setDrive(apiV1.HealthBad))
+	assert.Equal(t, apiV1.HealthBad, readTestLVG(t, vm).Spec.Health)
+
+	// usage saved in LVG CR is used when thin pool can't be read
+	lvg := readTestLVG(t, vm)
+	lvg.Spec.DataUsage = 90
+	assert.Nil(t, vm.k8sClient.UpdateCR(testCtx, lvg))
+	lvmOps.On("GetThinPoolUsage", poolPath).Return(float64(-1), float64(-1), testErr).Times(1)
+	vm.handleDriveStatusChange(testCtx, setDrive(apiV1.HealthGood))
+	assert.Equal(t, apiV1.HealthSuspect, readTestLVG(t, vm).Spec.Health)
+
+	lvmOps.On("GetThinPoolUsage", poolPath).Return(float64(10), float64(2), nil).Times(1)
+	vm.handleDriveStatusChange(testCtx, setDrive(apiV1.HealthGood))
+	assert.Equal(t, apiV1.HealthGood, readTestLVG(t, vm).Spec.Health)
+}
@@ -0,0 +1,230 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/api/v1/drivecrd"
+	"github.com/dell/csi-baremetal/api/v1/volumecrd"
+	tuningconf "github.com/dell/csi-baremetal/pkg/node/blocktuning/common"
+)
+
+const (
+	// wbtChangedVolumeAnnotation is set on volumes tuned by the previous versions which changed only WBT value,
+	// default WBT value is restored for such volumes on unstage
+	wbtChangedVolumeAnnotation = "wbt-changed"
+	wbtChangedVolumeKey        = "yes"
+)
+
+// applyBlockTuning applies settings of the first profile matched the volume to the block device of the volume drive,
+// applied and previous values are saved in the Volume CR spec, CR must be updated by caller.
+// Device might be already tuned for other volumes (e.g. LVG volumes on the same drive), their previous values
+// are saved as well since they are the values which device had before tuning.
+// Returns name of the matched profile and error if settings weren't applied or were applied partially
+func (m *VolumeManager) applyBlockTuning(ctx context.Context, vol *volumecrd.Volume) (string, error) {
+	conf := m.tuningConfig
+	if conf == nil || len(conf.Profiles) == 0 {
+		return "", nil
+	}
+
+	drive, err := m.crHelper.GetDriveCRByVolume(vol)
+	if err != nil {
+		return "", err
+	}
+	if drive == nil {
+		return "", fmt.Errorf("drive %s is not found", vol.Spec.Location)
+	}
+	pv := &corev1.PersistentVolume{}
+	if err = m.k8sClient.Get(ctx, k8sCl.ObjectKey{Name: vol.Name}, pv); err != nil {
+		return "", fmt.Errorf("failed to get Persistent Volume %s: %v", vol.Name, err)
+	}
+
+	profile := conf.SelectProfile(pv.Spec.StorageClassName, drive.Spec.Type, vol.Spec.Mode)
+	if profile == nil {
+		m.log.Debugf("Skip block tuning: no profile for volume %s with sc %s, drive type %s and mode %s",
+			vol.Name, pv.Spec.StorageClassName, drive.Spec.Type, vol.Spec.Mode)
+		return "", nil
+	}
+	device, err := m.findDriveDeviceName(drive)
+	if err != nil {
+		return profile.Name, err
+	}
+
+	tunedVolumes, err := m.getTunedVolumes(vol, device)
+	if err != nil {
+		return profile.Name, err
+	}
+
+	m.log.Infof("Applying block tuning profile %s to device %s of volume %s", profile.Name, device, vol.Name)
+	settings := profile.Settings.Values()
+	previous, err := m.tuningOps.Apply(device, settings)
+	applied := make(map[string]string, len(settings))
+	for _, s := range settings {
+		// only changed attributes are known to be applied on failure
+		if _, ok := previous[s.Attribute]; ok || err == nil {
+			applied[s.Attribute] = s.Value
+		}
+	}
+	if len(applied) > 0 {
+		for _, tuned := range tunedVolumes {
+			for attr, value := range tuned.Spec.BlockTuning.Previous {
+				if previous == nil {
+					previous = make(map[string]string)
+				}
+				previous[attr] = value
+			}
+		}
+		vol.Spec.BlockTuning = &api.BlockTuning{
+			Profile:  profile.Name,
+			Device:   device,
+			Applied:  applied,
+			Previous: previous,
+		}
+	}
+	return profile.Name, err
+}
+
+// isLegacyWbtTuning checks whether the volume is tuned by the legacy WBT profile or by the previous versions
+// which changed only WBT value
+func isLegacyWbtTuning(vol *volumecrd.Volume) bool {
+	return vol.Annotations[wbtChangedVolumeAnnotation] == wbtChangedVolumeKey ||
+		(vol.Spec.BlockTuning != nil && vol.Spec.BlockTuning.Profile == tuningconf.LegacyWbtProfileName)
+}
+
+// restoreBlockTuning restores values which block device queue attributes had before applying of the profile,
+// device is searched again since its kernel name might be changed after applying,
+// tuning is removed from the Volume CR spec, CR must be updated by caller.
+// Values aren't restored while device is tuned for other volumes, previous values are passed to them instead
+func (m *VolumeManager) restoreBlockTuning(ctx context.Context, vol *volumecrd.Volume) error {
+	if vol.Annotations[wbtChangedVolumeAnnotation] == wbtChangedVolumeKey {
+		delete(vol.Annotations, wbtChangedVolumeAnnotation)
+		device, err := m.findDeviceName(vol)
+		if err == nil {
+			err = m.tuningOps.Restore(device, map[string]string{tuningconf.WbtLatUsec: tuningconf.DefaultWbtLatUsec})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	tuning := vol.Spec.BlockTuning
+	if tuning == nil {
+		return nil
+	}
+	vol.Spec.BlockTuning = nil
+	if len(tuning.Previous) == 0 {
+		return nil
+	}
+	tunedVolumes, err := m.getTunedVolumes(vol, tuning.Device)
+	if err != nil {
+		return err
+	}
+	if len(tunedVolumes) > 0 {
+		m.log.Infof("Skip restoring block tuning of device %s for volume %s: device is used by %d more volumes",
+			tuning.Device, vol.Name, len(tunedVolumes))
+		return m.passPreviousTuning(ctx, tuning.Previous, tunedVolumes)
+	}
+	device, err := m.findDeviceName(vol)
+	if err != nil {
+		return fmt.Errorf("unable to find device of volume %s: %v", vol.Name, err)
+	}
+	if device != tuning.Device {
+		m.log.Warnf("Device of volume %s was renamed from %s to %s", vol.Name, tuning.Device, device)
+	}
+	m.log.Infof("Restoring block tuning profile %s of device %s for volume %s", tuning.Profile, device, vol.Name)
+	return m.tuningOps.Restore(device, tuning.Previous)
+}
+
+// getTunedVolumes returns volumes of the node except vol which have block tuning applied to the device
+func (m *VolumeManager) getTunedVolumes(vol *volumecrd.Volume, device string) ([]volumecrd.Volume, error) {
+	volumes, err := m.crHelper.GetVolumeCRs(vol.Spec.NodeId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read volumes: %v", err)
+	}
+	tuned := make([]volumecrd.Volume, 0)
+	for _, v := range volumes {
+		if v.Name != vol.Name && v.Spec.BlockTuning != nil && v.Spec.BlockTuning.Device == device {
+			tuned = append(tuned, v)
+		}
+	}
+	return tuned, nil
+}
+
+// passPreviousTuning adds previous values missed in tuning of the volumes, volumes CRs are updated
+func (m *VolumeManager) passPreviousTuning(ctx context.Context, previous map[string]string,
+	volumes []volumecrd.Volume) error {
+	for i := range volumes {
+		tuning := volumes[i].Spec.BlockTuning
+		updated := false
+		for attr, value := range previous {
+			if _, ok := tuning.Previous[attr]; ok {
+				continue
+			}
+			if tuning.Previous == nil {
+				tuning.Previous = make(map[string]string)
+			}
+			tuning.Previous[attr] = value
+			updated = true
+		}
+		if !updated {
+			continue
+		}
+		if err := m.k8sClient.UpdateCR(ctx, &volumes[i]); err != nil {
+			return fmt.Errorf("failed to update block tuning of volume %s: %v", volumes[i].Name, err)
+		}
+	}
+	return nil
+}
+
+func (m *VolumeManager) findDeviceName(vol *volumecrd.Volume) (string, error) {
+	drive, err := m.crHelper.GetDriveCRByVolume(vol)
+	if err != nil {
+		return "", err
+	}
+	if drive == nil {
+		return "", fmt.Errorf("drive %s is not found", vol.Spec.Location)
+	}
+	return m.findDriveDeviceName(drive)
+}
+
+// findDriveDeviceName returns current kernel name of the drive device
+func (m *VolumeManager) findDriveDeviceName(drive *drivecrd.Drive) (string, error) {
+	// kernel name of the device might be changed after reboot or hot-plug, drive path might be outdated
+	path, err := m.listBlk.SearchDrivePath(&drive.Spec)
+	if err != nil {
+		return "", err
+	}
+
+	return parseDeviceName(path)
+}
+
+// parseDeviceName returns device name from drive path, expected drive path - /dev/<device>
+func parseDeviceName(path string) (string, error) {
+	splitedPath := regexp.MustCompile(`[A-Za-z0-9]+`).FindAllString(path, -1)
+	if len(splitedPath) != 2 || splitedPath[0] != "dev" {
+		return "", fmt.Errorf("drive path %s is not parsable as /dev/<device>", path)
+	}
+
+	return splitedPath[1], nil
+}
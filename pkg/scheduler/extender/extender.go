@@ -32,11 +32,15 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	schedulerapi "k8s.io/kube-scheduler/extender/v1"
+	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
 
 	genV1 "github.com/dell/csi-baremetal/api/generated/v1"
 	v1 "github.com/dell/csi-baremetal/api/v1"
 	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
+	"github.com/dell/csi-baremetal/api/v1/snapshotcrd"
 	volcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
 	"github.com/dell/csi-baremetal/pkg/base"
 	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
@@ -47,6 +51,15 @@ import (
 	annotations "github.com/dell/csi-baremetal/pkg/crcontrollers/node/common"
 )
 
+const (
+	pvcKind                   = "PersistentVolumeClaim"
+	volumeSnapshotGroup       = "snapshot.storage.k8s.io"
+	volumeSnapshotKind        = "VolumeSnapshot"
+	volumeSnapshotContentKind = "VolumeSnapshotContent"
+)
+
+var volumeSnapshotGroupVersion = schema.GroupVersion{Group: volumeSnapshotGroup, Version: "v1"}
+
 // Extender holds http handlers for scheduler extender endpoints and implements logic for nodes filtering
 // based on pod volumes requirements and Available Capacities
 type Extender struct {
@@ -61,6 +74,10 @@ type Extender struct {
 	sync.Mutex
 	logger                 *logrus.Entry
 	capacityManagerBuilder capacityplanner.CapacityManagerBuilder
+	// default strategy of nodes scoring
+	scoringStrategy string
+	// path to the file with scoring config mounted from ConfigMap, overrides scoringStrategy
+	scoringConfigPath string
 }
 
 // NewExtender returns new instance of Extender struct
@@ -75,6 +92,7 @@ func NewExtender(logger *logrus.Logger, kubeClient *k8s.KubeClient,
 		nodeSelector:           nodeselector,
 		logger:                 logger.WithField("component", "Extender"),
 		capacityManagerBuilder: &capacityplanner.DefaultCapacityManagerBuilder{},
+		scoringStrategy:        VolumeCountStrategy,
 	}, nil
 }
 
@@ -145,8 +163,8 @@ func (e *Extender) FilterHandler(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
-// PrioritizeHandler helps with even distribution of the volumes across the nodes.
-// It will set priority based on the formula:
+// PrioritizeHandler scores nodes according to the scoring strategy. By default, it helps with even
+// distribution of the volumes across the nodes and sets priority based on the formula:
 // rank of node X = max number of volumes - number of volume on node X.
 func (e *Extender) PrioritizeHandler(w http.ResponseWriter, req *http.Request) {
 	sessionUUID := uuid.New().String()
@@ -173,7 +191,8 @@ func (e *Extender) PrioritizeHandler(w http.ResponseWriter, req *http.Request) {
 	e.Lock()
 	defer e.Unlock()
 
-	hostPriority, err := e.score(extenderArgs.Nodes.Items)
+	ctxWithVal := context.WithValue(req.Context(), base.RequestUUID, sessionUUID)
+	hostPriority, err := e.score(ctxWithVal, extenderArgs.Pod, extenderArgs.Nodes.Items)
 	if err != nil {
 		ll.Errorf("Unable to score %v", err)
 		return
@@ -219,6 +238,12 @@ func (e *Extender) BindHandler(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// GatherCapacityRequests returns capacity requests for all volumes of the pod which should be provisioned
+// by the driver, baseerr.ErrorNotFound is returned when some of related k8s objects aren't ready yet
+func (e *Extender) GatherCapacityRequests(ctx context.Context, pod *coreV1.Pod) ([]*genV1.CapacityRequest, error) {
+	return e.gatherCapacityRequestsByProvisioner(ctx, pod)
+}
+
 // gatherCapacityRequestsByProvisioner search all volumes in pod' spec that should be provisioned
 // by provisioner e.provisioner and construct genV1.Volume struct for each of such volume
 func (e *Extender) gatherCapacityRequestsByProvisioner(ctx context.Context, pod *coreV1.Pod) ([]*genV1.CapacityRequest, error) {
@@ -267,12 +292,21 @@ func (e *Extender) gatherCapacityRequestsByProvisioner(ctx context.Context, pod
 				ll.Infof("SC %s is not provisioned by CSI Baremetal driver, skip this volume", *claimSpec.StorageClassName)
 				continue
 			case managedSC:
-				requests = append(requests, createRequestFromPVCSpec(
+				request := createRequestFromPVCSpec(
 					generateEphemeralVolumeName(pod.GetName(), v.Name),
 					storageType,
 					claimSpec.Resources,
 					ll,
-				))
+				)
+				scs.setRaidParameters(*claimSpec.StorageClassName, request)
+				if claimSpec.DataSource != nil {
+					if request.NodeId, err = e.getDataSourceNodeID(ctx, pod.Namespace, claimSpec.DataSource); err != nil {
+						ll.Errorf("Unable to determine node of the data source for volume %s: %v", v.Name, err)
+						// data source might be not ready yet
+						return nil, baseerr.ErrorNotFound
+					}
+				}
+				requests = append(requests, request)
 			default:
 				return nil, fmt.Errorf("scChecker return code is unfound: %d", scType)
 			}
@@ -317,12 +351,21 @@ func (e *Extender) gatherCapacityRequestsByProvisioner(ctx context.Context, pod
 				ll.Infof("SC %s is not provisioned by CSI Baremetal driver, skip PVC %s", *pvc.Spec.StorageClassName, pvc.Name)
 				continue
 			case managedSC:
-				requests = append(requests, createRequestFromPVCSpec(
+				request := createRequestFromPVCSpec(
 					pvc.Name,
 					storageType,
 					pvc.Spec.Resources,
 					ll,
-				))
+				)
+				scs.setRaidParameters(*pvc.Spec.StorageClassName, request)
+				if pvc.Spec.DataSource != nil {
+					if request.NodeId, err = e.getDataSourceNodeID(ctx, pvc.Namespace, pvc.Spec.DataSource); err != nil {
+						ll.Errorf("Unable to determine node of the data source for PVC %s: %v", pvc.Name, err)
+						// data source might be not ready yet
+						return nil, baseerr.ErrorNotFound
+					}
+				}
+				requests = append(requests, request)
 			default:
 				return nil, fmt.Errorf("scChecker return code is unfound: %d", scType)
 			}
@@ -362,11 +405,75 @@ func (e *Extender) createCapacityRequest(ctx context.Context, podName string, vo
 	}
 	request.Size = size
 
+	if request.RaidLevel, request.RaidDevices, err = util.ParseRaidParameters(v.VolumeAttributes); err != nil {
+		return request, err
+	}
+
 	ll.Debugf("Request %s with %s SC and %d size created", request.Name, request.StorageClass, request.Size)
 
 	return request, nil
 }
 
+// getDataSourceNodeID returns ID of the node where data source of the PVC is located.
+// Volume which is populated from PVC or VolumeSnapshot must be placed on the same node as its data source.
+// Returns empty string for data sources which aren't supported by the driver
+func (e *Extender) getDataSourceNodeID(ctx context.Context, namespace string,
+	dataSource *coreV1.TypedLocalObjectReference) (string, error) {
+	apiGroup := ""
+	if dataSource.APIGroup != nil {
+		apiGroup = *dataSource.APIGroup
+	}
+
+	switch {
+	case apiGroup == "" && dataSource.Kind == pvcKind:
+		pvc := &coreV1.PersistentVolumeClaim{}
+		if err := e.k8sCache.ReadCR(ctx, dataSource.Name, namespace, pvc); err != nil {
+			return "", err
+		}
+		if pvc.Spec.VolumeName == "" {
+			return "", fmt.Errorf("source PVC %s is not bound", pvc.Name)
+		}
+		volumes := &volcrd.VolumeList{}
+		if err := e.k8sCache.ReadList(ctx, volumes); err != nil {
+			return "", err
+		}
+		for _, volume := range volumes.Items {
+			if volume.Spec.Id == pvc.Spec.VolumeName {
+				return volume.Spec.NodeId, nil
+			}
+		}
+		return "", fmt.Errorf("volume %s of the source PVC %s is not found", pvc.Spec.VolumeName, pvc.Name)
+	case apiGroup == volumeSnapshotGroup && dataSource.Kind == volumeSnapshotKind:
+		volumeSnapshot := &unstructured.Unstructured{}
+		volumeSnapshot.SetGroupVersionKind(volumeSnapshotGroupVersion.WithKind(volumeSnapshotKind))
+		if err := e.k8sClient.Get(ctx, k8sCl.ObjectKey{Name: dataSource.Name, Namespace: namespace}, volumeSnapshot); err != nil {
+			return "", err
+		}
+		contentName, _, _ := unstructured.NestedString(volumeSnapshot.Object, "status", "boundVolumeSnapshotContentName")
+		if contentName == "" {
+			return "", fmt.Errorf("VolumeSnapshot %s is not bound", dataSource.Name)
+		}
+
+		content := &unstructured.Unstructured{}
+		content.SetGroupVersionKind(volumeSnapshotGroupVersion.WithKind(volumeSnapshotContentKind))
+		if err := e.k8sClient.Get(ctx, k8sCl.ObjectKey{Name: contentName}, content); err != nil {
+			return "", err
+		}
+		snapshotID, _, _ := unstructured.NestedString(content.Object, "status", "snapshotHandle")
+		if snapshotID == "" {
+			return "", fmt.Errorf("VolumeSnapshotContent %s is not ready", contentName)
+		}
+
+		snapshot := &snapshotcrd.Snapshot{}
+		if err := e.k8sClient.ReadCR(ctx, snapshotID, "", snapshot); err != nil {
+			return "", err
+		}
+		return snapshot.Spec.NodeId, nil
+	}
+
+	return "", nil
+}
+
 // filter is an algorithm for defining whether requested volumes could be provisioned on particular node or no
 // nodes - list of node candidate, volumes - requested volumes
 // returns: matchedNodes - list of nodes on which volumes could be provisioned
@@ -379,7 +486,7 @@ func (e *Extender) filter(ctx context.Context, pod *coreV1.Pod, nodes []coreV1.N
 	}
 
 	// construct ACR name
-	reservationName := getReservationName(pod)
+	reservationName := GetReservationName(pod)
 	// read reservation
 	reservation := &acrcrd.AvailableCapacityReservation{}
 	err = e.k8sClient.ReadCR(ctx, reservationName, "", reservation)
@@ -401,7 +508,8 @@ func (e *Extender) filter(ctx context.Context, pod *coreV1.Pod, nodes []coreV1.N
 	return e.handleReservation(ctx, reservation, nodes)
 }
 
-func getReservationName(pod *coreV1.Pod) string {
+// GetReservationName returns name of AvailableCapacityReservation CR for the pod
+func GetReservationName(pod *coreV1.Pod) string {
 	namespace := pod.Namespace
 	if namespace == "" {
 		namespace = "default"
@@ -538,13 +646,25 @@ func (e *Extender) resendReservationRequest(ctx context.Context, reservation *ac
 	return nil
 }
 
-func (e *Extender) score(nodes []coreV1.Node) ([]schedulerapi.HostPriority, error) {
+// score sets priority of nodes using capacity based strategy if it is configured and pod has volumes,
+// otherwise nodes are ranked by the number of their volumes
+func (e *Extender) score(ctx context.Context, pod *coreV1.Pod, nodes []coreV1.Node) ([]schedulerapi.HostPriority, error) {
 	ll := e.logger.WithFields(logrus.Fields{
 		"method": "score",
 	})
 
+	if strategy := e.getScoringStrategy(); capacityplanner.IsCapacityScoringStrategy(strategy) && pod != nil {
+		requests, err := e.gatherCapacityRequestsByProvisioner(ctx, pod)
+		switch {
+		case err != nil:
+			ll.Warnf("Unable to gather capacity requests, nodes will be ranked by volumes: %v", err)
+		case len(requests) > 0:
+			return e.scoreByCapacity(ctx, capacityplanner.ScoringStrategy(strategy), pod, nodes, requests)
+		}
+	}
+
 	var volumeList = &volcrd.VolumeList{}
-	if err := e.k8sCache.ReadList(context.Background(), volumeList); err != nil {
+	if err := e.k8sCache.ReadList(ctx, volumeList); err != nil {
 		err = fmt.Errorf("unable to read volumes list: %v", err)
 		return nil, err
 	}
@@ -634,6 +754,14 @@ func createRequestFromPVCSpec(volumeName, storageType string, resourceRequiremen
 type scChecker struct {
 	managedSCs   map[string]string
 	unmanagedSCs map[string]bool
+	// RAID parameters of the managed SCs which request RAID volumes
+	raidSCs map[string]raidParameters
+}
+
+// raidParameters holds RAID level and number of member drives requested by SC
+type raidParameters struct {
+	level   string
+	devices int32
 }
 
 // buildSCChecker creates an instance of scChecker
@@ -643,8 +771,9 @@ func (e *Extender) buildSCChecker(ctx context.Context, log *logrus.Entry) (*scCh
 	})
 
 	var (
-		result = &scChecker{managedSCs: map[string]string{}, unmanagedSCs: map[string]bool{}}
-		scs    = storageV1.StorageClassList{}
+		result = &scChecker{managedSCs: map[string]string{}, unmanagedSCs: map[string]bool{},
+			raidSCs: map[string]raidParameters{}}
+		scs = storageV1.StorageClassList{}
 	)
 
 	if err := e.k8sCache.ReadList(ctx, &scs); err != nil {
@@ -654,6 +783,15 @@ func (e *Extender) buildSCChecker(ctx context.Context, log *logrus.Entry) (*scCh
 	for _, sc := range scs.Items {
 		if sc.Provisioner == e.provisioner {
 			result.managedSCs[sc.Name] = strings.ToUpper(sc.Parameters[base.StorageTypeKey])
+			level, devices, err := util.ParseRaidParameters(sc.Parameters)
+			if err != nil {
+				// volume will be rejected in CreateVolume, reserve capacity as for usual volume
+				ll.Warningf("SC %s has wrong RAID parameters: %v", sc.Name, err)
+				continue
+			}
+			if level != "" {
+				result.raidSCs[sc.Name] = raidParameters{level: level, devices: devices}
+			}
 		} else {
 			result.unmanagedSCs[sc.Name] = true
 		}
@@ -691,3 +829,11 @@ func (ch *scChecker) check(name string) (string, int) {
 
 	return "", unknown
 }
+
+// setRaidParameters fills RAID parameters of the request if SC with the provided name requests RAID volumes
+func (ch *scChecker) setRaidParameters(name string, request *genV1.CapacityRequest) {
+	if params, ok := ch.raidSCs[name]; ok {
+		request.RaidLevel = params.level
+		request.RaidDevices = params.devices
+	}
+}
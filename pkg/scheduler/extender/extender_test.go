@@ -30,6 +30,7 @@ import (
 	storageV1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	k8sCl "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -288,6 +289,69 @@ func TestExtender_gatherVolumesByProvisioner_Fail(t *testing.T) {
 	assert.Equal(t, int64(0), volumes[0].Size)
 }
 
+func TestExtender_gatherVolumesByProvisioner_DataSource(t *testing.T) {
+	var (
+		sourceNode     = "source-node"
+		sourceVolumeID = "pvc-source"
+		snapshotID     = "snapshot-source"
+		snapshotGroup  = volumeSnapshotGroup
+	)
+
+	e := setup(t)
+	sourcePVC := testPVC2.DeepCopy()
+	sourcePVC.Name = "source-pvc"
+	sourcePVC.Spec.VolumeName = sourceVolumeID
+	sourceVolume := e.k8sClient.ConstructVolumeCR(sourceVolumeID, testNs, nil,
+		genV1.Volume{Id: sourceVolumeID, NodeId: sourceNode})
+	snapshot := e.k8sClient.ConstructSnapshotCR(snapshotID, genV1.Snapshot{Id: snapshotID, NodeId: sourceNode})
+
+	volumeSnapshot := &unstructured.Unstructured{}
+	volumeSnapshot.SetGroupVersionKind(volumeSnapshotGroupVersion.WithKind(volumeSnapshotKind))
+	volumeSnapshot.SetName("volume-snapshot")
+	volumeSnapshot.SetNamespace(testNs)
+	assert.Nil(t, unstructured.SetNestedField(volumeSnapshot.Object, "content", "status", "boundVolumeSnapshotContentName"))
+	content := &unstructured.Unstructured{}
+	content.SetGroupVersionKind(volumeSnapshotGroupVersion.WithKind(volumeSnapshotContentKind))
+	content.SetName("content")
+	assert.Nil(t, unstructured.SetNestedField(content.Object, snapshotID, "status", "snapshotHandle"))
+
+	clonePVC := testPVC1.DeepCopy()
+	clonePVC.Spec.DataSource = &coreV1.TypedLocalObjectReference{Kind: pvcKind, Name: sourcePVC.Name}
+	restorePVC := testPVC1.DeepCopy()
+	restorePVC.Name = "restore-pvc"
+	restorePVC.Spec.DataSource = &coreV1.TypedLocalObjectReference{
+		APIGroup: &snapshotGroup, Kind: volumeSnapshotKind, Name: volumeSnapshot.GetName()}
+
+	applyObjs(t, e.k8sClient, testSC1.DeepCopy(), sourcePVC, clonePVC, restorePVC, volumeSnapshot)
+
+	pod := testPod.DeepCopy()
+	for _, pvcName := range []string{clonePVC.Name, restorePVC.Name} {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, coreV1.Volume{
+			VolumeSource: coreV1.VolumeSource{
+				PersistentVolumeClaim: &coreV1.PersistentVolumeClaimVolumeSource{ClaimName: pvcName},
+			},
+		})
+	}
+
+	// source volume and snapshot content don't exist yet
+	requests, err := e.gatherCapacityRequestsByProvisioner(testCtx, pod)
+	assert.Nil(t, requests)
+	assert.Equal(t, baseerr.ErrorNotFound, err)
+
+	applyObjs(t, e.k8sClient, sourceVolume)
+	requests, err = e.gatherCapacityRequestsByProvisioner(testCtx, pod)
+	assert.Nil(t, requests)
+	assert.Equal(t, baseerr.ErrorNotFound, err)
+
+	applyObjs(t, e.k8sClient, content, snapshot)
+	requests, err = e.gatherCapacityRequestsByProvisioner(testCtx, pod)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(requests))
+	for _, request := range requests {
+		assert.Equal(t, sourceNode, request.NodeId)
+	}
+}
+
 func TestExtender_constructVolumeFromCSISource_Success(t *testing.T) {
 	e := setup(t)
 	expectedSize, err := util.StrToBytes(testSizeStr)
@@ -377,7 +441,7 @@ func TestExtender_filterCases(t *testing.T) {
 		{Status: v1.ReservationCancelled, Err: errors.New("unsupported reservation status: CANCELLED")},
 	} {
 		reservation := *e.k8sClient.ConstructACRCR(
-			getReservationName(pod),
+			GetReservationName(pod),
 			genV1.AvailableCapacityReservation{
 				Status:       tt.Status,
 				NodeRequests: &genV1.NodeRequests{Requested: []string{node1Name}},
@@ -643,6 +707,30 @@ func TestExtender_getSCNameStorageType_Success(t *testing.T) {
 	assert.Equal(t, m.managedSCs[testSCName1], testStorageType)
 }
 
+func TestExtender_buildSCChecker_Raid(t *testing.T) {
+	e := setup(t)
+	raidSC := testSC1.DeepCopy()
+	raidSC.Parameters[base.RaidLevelKey] = v1.RaidLevel1
+	raidSC.Parameters[base.RaidDevicesKey] = "2"
+	wrongSC := testSC1.DeepCopy()
+	wrongSC.Name = "wrong-raid"
+	wrongSC.Parameters = map[string]string{base.StorageTypeKey: testStorageType, base.RaidLevelKey: "raid5"}
+	applyObjs(t, e.k8sClient, raidSC, wrongSC)
+
+	m, err := e.buildSCChecker(testCtx, testLogger.WithField("test", "buildSCChecker"))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(m.managedSCs))
+
+	request := &genV1.CapacityRequest{}
+	m.setRaidParameters(testSCName1, request)
+	assert.Equal(t, v1.RaidLevel1, request.RaidLevel)
+	assert.Equal(t, int32(2), request.RaidDevices)
+
+	request = &genV1.CapacityRequest{}
+	m.setRaidParameters(wrongSC.Name, request)
+	assert.Empty(t, request.RaidLevel)
+}
+
 func TestExtender_buildSCChecker_Fail(t *testing.T) {
 	e := setup(t)
 
@@ -683,6 +771,7 @@ func setup(t *testing.T) *Extender {
 		provisioner:            testProvisioner,
 		logger:                 testLogger.WithField("component", "Extender"),
 		capacityManagerBuilder: &capacityplanner.DefaultCapacityManagerBuilder{},
+		scoringStrategy:        VolumeCountStrategy,
 	}
 }
 
@@ -759,7 +848,7 @@ func Test_Score(t *testing.T) {
 		},
 	}
 
-	_, err := e.score(nodes)
+	_, err := e.score(testCtx, testPod.DeepCopy(), nodes)
 	assert.Nil(t, err)
 }
 
@@ -864,11 +953,11 @@ func Test_reservationName(t *testing.T) {
 	podName := "mypod-0"
 	namespace := "mynamespace"
 	pod := &coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: podName, Namespace: namespace}}
-	name := getReservationName(pod)
+	name := GetReservationName(pod)
 	assert.Equal(t, namespace+"-"+podName, name)
 
 	pod = &coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: podName, Namespace: ""}}
-	name = getReservationName(pod)
+	name = GetReservationName(pod)
 	assert.Equal(t, "default-"+podName, name)
 
 }
@@ -878,7 +967,7 @@ func Test_createReservation(t *testing.T) {
 	namespace := "test"
 	podName := "mypod-0"
 	pod := &coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: podName, Namespace: namespace}}
-	name := getReservationName(pod)
+	name := GetReservationName(pod)
 	// volumes
 	capacityRequests := []*genV1.CapacityRequest{{Name: "pvc-1", Size: 100, StorageClass: "HDD"}}
 	// nodes
@@ -902,7 +991,7 @@ func Test_createReservation(t *testing.T) {
 	// empty namespace
 	namespace = ""
 	pod = &coreV1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: podName, Namespace: namespace}}
-	name = getReservationName(pod)
+	name = GetReservationName(pod)
 	err = e.createReservation(testCtx, namespace, name, nodes, capacityRequests)
 	assert.Nil(t, err)
 
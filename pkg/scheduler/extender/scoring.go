@@ -0,0 +1,141 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/yaml.v2"
+	coreV1 "k8s.io/api/core/v1"
+	schedulerapi "k8s.io/kube-scheduler/extender/v1"
+
+	genV1 "github.com/dell/csi-baremetal/api/generated/v1"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+	annotations "github.com/dell/csi-baremetal/pkg/crcontrollers/node/common"
+)
+
+// VolumeCountStrategy is the default scoring strategy which prefers nodes with less volumes
+const VolumeCountStrategy = "volume-count"
+
+// ScoringConfig is a content of scoring config file mounted from ConfigMap
+type ScoringConfig struct {
+	Strategy string `yaml:"strategy"`
+}
+
+// SetScoringStrategy sets default strategy of nodes scoring and path to the scoring config file.
+// Strategy from the config file is used if file exists and contains valid strategy
+func (e *Extender) SetScoringStrategy(strategy, configPath string) error {
+	if !isValidScoringStrategy(strategy) {
+		return fmt.Errorf("unknown scoring strategy %s", strategy)
+	}
+	e.scoringStrategy = strategy
+	e.scoringConfigPath = configPath
+	return nil
+}
+
+// getScoringStrategy returns strategy from scoring config file or default strategy if file can't be used
+func (e *Extender) getScoringStrategy() string {
+	if e.scoringConfigPath == "" {
+		return e.scoringStrategy
+	}
+	ll := e.logger.WithField("method", "getScoringStrategy")
+
+	data, err := ioutil.ReadFile(e.scoringConfigPath)
+	if err != nil {
+		// ConfigMap might be not created, default strategy is used in this case
+		if !os.IsNotExist(err) {
+			ll.Warningf("Unable to read scoring config %s: %v", e.scoringConfigPath, err)
+		}
+		return e.scoringStrategy
+	}
+	config := &ScoringConfig{}
+	if err = yaml.Unmarshal(data, config); err != nil {
+		ll.Warningf("Unable to parse scoring config %s: %v", e.scoringConfigPath, err)
+		return e.scoringStrategy
+	}
+	if !isValidScoringStrategy(config.Strategy) {
+		ll.Warningf("Unknown scoring strategy %s in config, use %s", config.Strategy, e.scoringStrategy)
+		return e.scoringStrategy
+	}
+	return config.Strategy
+}
+
+// scoreByCapacity sets priority of nodes based on their available capacity according to the strategy.
+// Nodes which don't have capacity for all requests get the lowest priority.
+// Reservation of the pod itself isn't taken into account since its capacity is requested by the pod
+func (e *Extender) scoreByCapacity(ctx context.Context, strategy capacityplanner.ScoringStrategy, pod *coreV1.Pod,
+	nodes []coreV1.Node, requests []*genV1.CapacityRequest) ([]schedulerapi.HostPriority, error) {
+	ll := util.AddCommonFields(ctx, e.logger, "scoreByCapacity")
+
+	nodeIDs := make(map[string]string, len(nodes))
+	nodeIDList := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		node := node
+		nodeID, err := annotations.GetNodeID(&node, e.annotationKey, e.nodeSelector, e.featureChecker)
+		if err != nil {
+			ll.Errorf("failed to get NodeID: %s", err)
+			continue
+		}
+		if nodeID == "" {
+			continue
+		}
+		nodeIDs[node.GetName()] = nodeID
+		nodeIDList = append(nodeIDList, nodeID)
+	}
+
+	volumes := make([]*genV1.Volume, len(requests))
+	for i, capacity := range requests {
+		volumes[i] = &genV1.Volume{Id: capacity.Name, Size: capacity.Size, StorageClass: capacity.StorageClass,
+			NodeId: capacity.NodeId, RaidLevel: capacity.RaidLevel, RaidDevices: capacity.RaidDevices}
+	}
+
+	scorer, err := capacityplanner.NewCapacityScorer(e.logger, strategy,
+		capacityplanner.NewACReader(e.k8sClient, e.logger, true),
+		capacityplanner.NewSkipReservationReader(
+			capacityplanner.NewACRReader(e.k8sClient, e.logger, true), GetReservationName(pod)),
+		capacityplanner.NewDriveEnclosureReader(e.k8sClient, e.logger))
+	if err != nil {
+		return nil, err
+	}
+	scores, err := scorer.ScoreNodes(ctx, volumes, nodeIDList, schedulerapi.MaxExtenderPriority)
+	if err != nil {
+		return nil, err
+	}
+	ll.Debugf("nodes were ranked by %s strategy %+v", strategy, scores)
+
+	hostPriority := make([]schedulerapi.HostPriority, 0, len(nodeIDs))
+	for _, node := range nodes {
+		nodeID, ok := nodeIDs[node.GetName()]
+		if !ok {
+			continue
+		}
+		hostPriority = append(hostPriority, schedulerapi.HostPriority{
+			Host:  node.GetName(),
+			Score: scores[nodeID],
+		})
+	}
+	return hostPriority, nil
+}
+
+// isValidScoringStrategy checks whether strategy is supported by extender
+func isValidScoringStrategy(strategy string) bool {
+	return strategy == VolumeCountStrategy || capacityplanner.IsCapacityScoringStrategy(strategy)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extender
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	schedulerapi "k8s.io/kube-scheduler/extender/v1"
+
+	genV1 "github.com/dell/csi-baremetal/api/generated/v1"
+	v1 "github.com/dell/csi-baremetal/api/v1"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	"github.com/dell/csi-baremetal/pkg/base/util"
+)
+
+func TestExtender_SetScoringStrategy(t *testing.T) {
+	e := setup(t)
+	assert.Nil(t, e.SetScoringStrategy(string(capacityplanner.BestFitStrategy), ""))
+	assert.Equal(t, string(capacityplanner.BestFitStrategy), e.getScoringStrategy())
+
+	assert.NotNil(t, e.SetScoringStrategy("unknown", ""))
+	assert.Equal(t, string(capacityplanner.BestFitStrategy), e.getScoringStrategy())
+}
+
+func TestExtender_getScoringStrategy(t *testing.T) {
+	e := setup(t)
+	configPath := filepath.Join(t.TempDir(), "scoring.yaml")
+	assert.Nil(t, e.SetScoringStrategy(VolumeCountStrategy, configPath))
+
+	// config file doesn't exist
+	assert.Equal(t, VolumeCountStrategy, e.getScoringStrategy())
+
+	assert.Nil(t, ioutil.WriteFile(configPath, []byte("strategy: most-allocated\n"), 0600))
+	assert.Equal(t, string(capacityplanner.MostAllocatedStrategy), e.getScoringStrategy())
+
+	assert.Nil(t, ioutil.WriteFile(configPath, []byte("strategy: unknown\n"), 0600))
+	assert.Equal(t, VolumeCountStrategy, e.getScoringStrategy())
+
+	assert.Nil(t, ioutil.WriteFile(configPath, []byte("strategy: [\n"), 0600))
+	assert.Equal(t, VolumeCountStrategy, e.getScoringStrategy())
+}
+
+func TestExtender_scoreByCapacity(t *testing.T) {
+	e := setup(t)
+	nodes := []coreV1.Node{
+		{ObjectMeta: metaV1.ObjectMeta{Name: "node-1", UID: types.UID("node-1-uid")}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "node-2", UID: types.UID("node-2-uid")}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "node-3", UID: types.UID("node-3-uid")}},
+	}
+	size, err := util.StrToBytes(testSizeStr)
+	assert.Nil(t, err)
+	acs := []genV1.AvailableCapacity{
+		{Location: "drive-1", NodeId: "node-1-uid", StorageClass: testStorageType, Size: size},
+		{Location: "drive-2", NodeId: "node-2-uid", StorageClass: testStorageType, Size: size * 2},
+	}
+	for _, ac := range acs {
+		acCR := e.k8sClient.ConstructACCR(ac.Location, ac)
+		assert.Nil(t, e.k8sClient.CreateCR(testCtx, acCR.Name, acCR))
+	}
+
+	applyObjs(t, e.k8sClient, testSC1.DeepCopy())
+	pod := testPod.DeepCopy()
+	pod.Spec.Volumes = append(pod.Spec.Volumes, coreV1.Volume{
+		VolumeSource: coreV1.VolumeSource{CSI: &testCSIVolumeSrc},
+	})
+
+	assert.Nil(t, e.SetScoringStrategy(string(capacityplanner.LeastAllocatedStrategy), ""))
+	hostPriority, err := e.score(testCtx, pod, nodes)
+	assert.Nil(t, err)
+	assert.Equal(t, []schedulerapi.HostPriority{
+		{Host: "node-1", Score: 0},
+		{Host: "node-2", Score: schedulerapi.MaxExtenderPriority},
+		{Host: "node-3", Score: 0},
+	}, hostPriority)
+
+	assert.Nil(t, e.SetScoringStrategy(string(capacityplanner.BestFitStrategy), ""))
+	hostPriority, err = e.score(testCtx, pod, nodes)
+	assert.Nil(t, err)
+	assert.Equal(t, []schedulerapi.HostPriority{
+		{Host: "node-1", Score: schedulerapi.MaxExtenderPriority},
+		{Host: "node-2", Score: 0},
+		{Host: "node-3", Score: 0},
+	}, hostPriority)
+}
+
+func TestExtender_scoreByCapacityWithPodReservation(t *testing.T) {
+	e := setup(t)
+	nodes := []coreV1.Node{
+		{ObjectMeta: metaV1.ObjectMeta{Name: "node-1", UID: types.UID("node-1-uid")}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "node-2", UID: types.UID("node-2-uid")}},
+	}
+	size, err := util.StrToBytes(testSizeStr)
+	assert.Nil(t, err)
+	acs := []genV1.AvailableCapacity{
+		{Location: "drive-1", NodeId: "node-1-uid", StorageClass: testStorageType, Size: size},
+		{Location: "drive-2", NodeId: "node-2-uid", StorageClass: testStorageType, Size: size * 2},
+	}
+	for _, ac := range acs {
+		acCR := e.k8sClient.ConstructACCR(ac.Location, ac)
+		assert.Nil(t, e.k8sClient.CreateCR(testCtx, acCR.Name, acCR))
+	}
+
+	applyObjs(t, e.k8sClient, testSC1.DeepCopy())
+	pod := testPod.DeepCopy()
+	pod.Spec.Volumes = append(pod.Spec.Volumes, coreV1.Volume{
+		VolumeSource: coreV1.VolumeSource{CSI: &testCSIVolumeSrc},
+	})
+
+	// reservation created by Filter for this pod must not hide the capacity it reserved
+	reservation := e.k8sClient.ConstructACRCR(GetReservationName(pod), genV1.AvailableCapacityReservation{
+		Status:       v1.ReservationConfirmed,
+		NodeRequests: &genV1.NodeRequests{Reserved: []string{"node-1-uid"}},
+		ReservationRequests: []*genV1.ReservationRequest{
+			{
+				CapacityRequest: &genV1.CapacityRequest{StorageClass: testStorageType, Size: size},
+				Reservations:    []string{"drive-1"},
+			},
+		},
+	})
+	assert.Nil(t, e.k8sClient.CreateCR(testCtx, reservation.Name, reservation))
+
+	assert.Nil(t, e.SetScoringStrategy(string(capacityplanner.BestFitStrategy), ""))
+	hostPriority, err := e.score(testCtx, pod, nodes)
+	assert.Nil(t, err)
+	assert.Equal(t, []schedulerapi.HostPriority{
+		{Host: "node-1", Score: schedulerapi.MaxExtenderPriority},
+		{Host: "node-2", Score: 0},
+	}, hostPriority)
+}
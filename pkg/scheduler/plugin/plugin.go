@@ -14,29 +14,88 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Package plugin contains implementation of kubernetes scheduler framework plugin for CSI Baremetal
 package plugin
 
-/*
 import (
 	"context"
+	"fmt"
 
-	v1 "k8s.io/api/core/v1"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	coreV1 "k8s.io/api/core/v1"
+	storageV1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	framework "k8s.io/kubernetes/pkg/scheduler/framework/v1alpha1"
-	schedulernodeinfo "k8s.io/kubernetes/pkg/scheduler/nodeinfo"
-)
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	frameworkruntime "k8s.io/kubernetes/pkg/scheduler/framework/runtime"
 
-// CSISchedulerPlugin is a plugin that does placement decision based on information in AC CRD
-type CSISchedulerPlugin struct {
-	frameworkHandle framework.FrameworkHandle
-}
+	genV1 "github.com/dell/csi-baremetal/api/generated/v1"
+	v1 "github.com/dell/csi-baremetal/api/v1"
+	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
+	volcrd "github.com/dell/csi-baremetal/api/v1/volumecrd"
+	"github.com/dell/csi-baremetal/pkg/base"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	baseerr "github.com/dell/csi-baremetal/pkg/base/error"
+	fc "github.com/dell/csi-baremetal/pkg/base/featureconfig"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/base/logger"
+	"github.com/dell/csi-baremetal/pkg/base/logger/objects"
+	annotations "github.com/dell/csi-baremetal/pkg/crcontrollers/node/common"
+	"github.com/dell/csi-baremetal/pkg/scheduler/extender"
+)
 
 const (
 	// Name is the name of the plugin used in Registry and configurations.
 	Name = "CSISchedulerPlugin"
+
+	stateKey framework.StateKey = Name
 )
 
+// Args holds configuration of the plugin which is passed through pluginConfig of KubeSchedulerConfiguration
+type Args struct {
+	// Namespace in which CSI Baremetal CRs are located
+	Namespace string `json:"namespace"`
+	// Provisioner name which storage classes plugin will be observing
+	Provisioner string `json:"provisioner"`
+	// NodeSelector contains key=value pair to deploy node components on specific k8sNodes
+	NodeSelector string `json:"nodeSelector"`
+	// UseNodeAnnotation defines whether plugin should read id from node annotation or not
+	UseNodeAnnotation bool `json:"useNodeAnnotation"`
+	// UseExternalAnnotation defines whether plugin should read id from external annotation
+	UseExternalAnnotation bool `json:"useExternalAnnotation"`
+	// NodeIDAnnotation is a custom node annotation name
+	NodeIDAnnotation string `json:"nodeIDAnnotation"`
+	// LogLevel is a level of the plugin logs
+	LogLevel string `json:"logLevel"`
+}
+
+// capacityRequestsGatherer collects capacity requests of the pod volumes which should be provisioned by the driver
+type capacityRequestsGatherer interface {
+	GatherCapacityRequests(ctx context.Context, pod *coreV1.Pod) ([]*genV1.CapacityRequest, error)
+}
+
+// CSISchedulerPlugin is a plugin that does placement decision based on information in AC CRD
+type CSISchedulerPlugin struct {
+	frameworkHandle framework.Handle
+	k8sClient       *k8s.KubeClient
+	k8sCache        k8s.CRReader
+
+	requestsGatherer       capacityRequestsGatherer
+	capacityManagerBuilder capacityplanner.CapacityManagerBuilder
+
+	featureChecker fc.FeatureChecker
+	annotationKey  string
+	nodeSelector   string
+
+	logger *logrus.Entry
+}
+
 // please refer to https://kubernetes.io/docs/concepts/scheduling-eviction/scheduling-framework/ for details
+// PreFilter plugin
+var _ framework.PreFilterPlugin = &CSISchedulerPlugin{}
+
 // Filter plugin
 var _ framework.FilterPlugin = &CSISchedulerPlugin{}
 
@@ -46,42 +105,362 @@ var _ framework.ScorePlugin = &CSISchedulerPlugin{}
 // Reserve plugin
 var _ framework.ReservePlugin = &CSISchedulerPlugin{}
 
-// Unreserve plugin
-var _ framework.UnreservePlugin = &CSISchedulerPlugin{}
+// stateData holds information which is calculated in PreFilter and used in the next extension points
+type stateData struct {
+	// capacity requests of the pod volumes
+	requests []*genV1.CapacityRequest
+	// node name to node ID mapping
+	nodeIDs map[string]string
+	// placing plan of the pod volumes on all nodes
+	plan *capacityplanner.VolumesPlacingPlan
+	// node ID to rank mapping, nodes with less volumes have higher rank
+	ranks map[string]int64
+	// rank of nodes without volumes
+	maxRank int64
+}
+
+// Clone returns the same stateData since it isn't changed after PreFilter
+func (s *stateData) Clone() framework.StateData {
+	return s
+}
+
+// New initializes a new plugin and returns it.
+func New(configuration runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	args := &Args{LogLevel: logger.InfoLevel}
+	if err := frameworkruntime.DecodeInto(configuration, args); err != nil {
+		return nil, err
+	}
+
+	log, err := logger.InitLogger("", args.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	featureConf := fc.NewFeatureConfig()
+	featureConf.Update(fc.FeatureNodeIDFromAnnotation, args.UseNodeAnnotation)
+	featureConf.Update(fc.FeatureExternalAnnotationForNode, args.UseExternalAnnotation)
+
+	k8sClient, err := k8s.GetK8SClient()
+	if err != nil {
+		return nil, err
+	}
+	kubeClient := k8s.NewKubeClient(k8sClient, log, objects.NewObjectLogger(), args.Namespace)
+
+	// cache lives as long as scheduler process
+	kubeCache, err := k8s.InitKubeCache(context.Background(), log,
+		&coreV1.PersistentVolumeClaim{},
+		&storageV1.StorageClass{},
+		&volcrd.Volume{})
+	if err != nil {
+		return nil, fmt.Errorf("fail to init kubeCache: %v", err)
+	}
+
+	requestsGatherer, err := extender.NewExtender(log, kubeClient, kubeCache, args.Provisioner, featureConf,
+		args.NodeIDAnnotation, args.NodeSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CSISchedulerPlugin{
+		frameworkHandle:        handle,
+		k8sClient:              kubeClient,
+		k8sCache:               kubeCache,
+		requestsGatherer:       requestsGatherer,
+		capacityManagerBuilder: &capacityplanner.DefaultCapacityManagerBuilder{},
+		featureChecker:         featureConf,
+		annotationKey:          args.NodeIDAnnotation,
+		nodeSelector:           args.NodeSelector,
+		logger:                 log.WithField("component", Name),
+	}, nil
+}
 
 // Name returns name of plugin
-func (c CSISchedulerPlugin) Name() string {
+func (c *CSISchedulerPlugin) Name() string {
 	return Name
 }
 
-// New initializes a new plugin and returns it.
-func New(configuration *runtime.Unknown, handle framework.FrameworkHandle) (framework.Plugin, error) {
-	sp := &CSISchedulerPlugin{frameworkHandle: handle}
-	return sp, nil
+// PreFilter collects capacity requests of the pod and plans placing of its volumes on all nodes
+func (c *CSISchedulerPlugin) PreFilter(ctx context.Context, state *framework.CycleState, pod *coreV1.Pod) *framework.Status {
+	sessionUUID := uuid.New().String()
+	ll := c.logger.WithFields(logrus.Fields{
+		"sessionUUID": sessionUUID,
+		"method":      "PreFilter",
+		"pod":         pod.Name,
+	})
+	ctx = context.WithValue(ctx, base.RequestUUID, sessionUUID)
+
+	requests, err := c.requestsGatherer.GatherCapacityRequests(ctx, pod)
+	if err != nil {
+		// not found error is re-triable
+		if err == baseerr.ErrorNotFound {
+			return framework.NewStatus(framework.Unschedulable, "Volumes of the pod are not ready yet")
+		}
+		return framework.AsStatus(err)
+	}
+	data := &stateData{requests: requests}
+	state.Write(stateKey, data)
+	if len(requests) == 0 {
+		return nil
+	}
+	ll.Debugf("Required capacity: %v", requests)
+
+	nodeInfos, err := c.frameworkHandle.SnapshotSharedLister().NodeInfos().List()
+	if err != nil {
+		return framework.AsStatus(err)
+	}
+	data.nodeIDs = make(map[string]string, len(nodeInfos))
+	nodes := make([]string, 0, len(nodeInfos))
+	for _, nodeInfo := range nodeInfos {
+		nodeID, err := annotations.GetNodeID(nodeInfo.Node(), c.annotationKey, c.nodeSelector, c.featureChecker)
+		if err != nil {
+			ll.Errorf("node:%s cant get NodeID error: %s", nodeInfo.Node().Name, err)
+			continue
+		}
+		if nodeID == "" {
+			continue
+		}
+		data.nodeIDs[nodeInfo.Node().Name] = nodeID
+		nodes = append(nodes, nodeID)
+	}
+
+	plan, err := c.planVolumesPlacing(ctx, pod, requests, nodes)
+	if err == baseerr.ErrorRejectReservationRequest {
+		return framework.NewStatus(framework.Unschedulable, "Waiting for another reservation of LVG based volumes")
+	}
+	if err != nil {
+		return framework.AsStatus(err)
+	}
+	if plan == nil {
+		return framework.NewStatus(framework.Unschedulable, "No available capacity found for the pod volumes")
+	}
+	data.plan = plan
+
+	if data.ranks, data.maxRank, err = c.rankNodes(ctx); err != nil {
+		return framework.AsStatus(err)
+	}
+
+	return nil
+}
+
+// PreFilterExtensions returns nil since pod volumes don't depend on other pods
+func (c *CSISchedulerPlugin) PreFilterExtensions() framework.PreFilterExtensions {
+	return nil
 }
 
 // Filter filters out nodes which don't have ACs match to PVCs
-func (c CSISchedulerPlugin) Filter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeInfo *schedulernodeinfo.NodeInfo) *framework.Status {
-	panic("implement me")
+func (c *CSISchedulerPlugin) Filter(_ context.Context, state *framework.CycleState, _ *coreV1.Pod,
+	nodeInfo *framework.NodeInfo) *framework.Status {
+	data, err := getStateData(state)
+	if err != nil {
+		return framework.AsStatus(err)
+	}
+	if len(data.requests) == 0 {
+		return nil
+	}
+
+	name := nodeInfo.Node().Name
+	if data.plan.GetVolumesToACMapping(data.nodeIDs[name]) == nil {
+		return framework.NewStatus(framework.Unschedulable, fmt.Sprintf("No available capacity found on the node %s", name))
+	}
+	return nil
 }
 
-// Score does balancing across the nodes for better performance. Nodes with more ACs should have highest scores
-func (c CSISchedulerPlugin) Score(ctx context.Context, state *framework.CycleState, p *v1.Pod, nodeName string) (int64, *framework.Status) {
-	panic("implement me")
+// Score does balancing across the nodes for better performance. Nodes with less volumes have highest scores
+func (c *CSISchedulerPlugin) Score(_ context.Context, state *framework.CycleState, _ *coreV1.Pod,
+	nodeName string) (int64, *framework.Status) {
+	data, err := getStateData(state)
+	if err != nil {
+		return 0, framework.AsStatus(err)
+	}
+	if len(data.requests) == 0 {
+		return 0, nil
+	}
+
+	if rank, ok := data.ranks[data.nodeIDs[nodeName]]; ok {
+		return rank, nil
+	}
+	// set the highest rank if node doesn't have any volumes
+	return data.maxRank, nil
 }
 
-// ScoreExtensions returns a ScoreExtensions interface if it implements one, or nil if does not.
-func (c CSISchedulerPlugin) ScoreExtensions() framework.ScoreExtensions {
-	panic("implement me")
+// ScoreExtensions returns ScoreExtensions interface to normalize node ranks
+func (c *CSISchedulerPlugin) ScoreExtensions() framework.ScoreExtensions {
+	return c
 }
 
-// Reserve does reservation of ACs
-func (c CSISchedulerPlugin) Reserve(ctx context.Context, state *framework.CycleState, p *v1.Pod, nodeName string) *framework.Status {
-	panic("implement me")
+// NormalizeScore scales node ranks to the range [0, framework.MaxNodeScore]
+func (c *CSISchedulerPlugin) NormalizeScore(_ context.Context, _ *framework.CycleState, _ *coreV1.Pod,
+	scores framework.NodeScoreList) *framework.Status {
+	var maxScore int64
+	for _, score := range scores {
+		if score.Score > maxScore {
+			maxScore = score.Score
+		}
+	}
+	if maxScore == 0 {
+		return nil
+	}
+	for i := range scores {
+		scores[i].Score = scores[i].Score * framework.MaxNodeScore / maxScore
+	}
+	return nil
 }
 
-// Unreserve un-reserver ACs
-func (c CSISchedulerPlugin) Unreserve(ctx context.Context, state *framework.CycleState, p *v1.Pod, nodeName string) {
-	panic("implement me")
+// Reserve does reservation of ACs on the selected node by creating AvailableCapacityReservation CR
+func (c *CSISchedulerPlugin) Reserve(ctx context.Context, state *framework.CycleState, pod *coreV1.Pod,
+	nodeName string) *framework.Status {
+	sessionUUID := uuid.New().String()
+	ll := c.logger.WithFields(logrus.Fields{
+		"sessionUUID": sessionUUID,
+		"method":      "Reserve",
+		"pod":         pod.Name,
+		"node":        nodeName,
+	})
+	ctx = context.WithValue(ctx, base.RequestUUID, sessionUUID)
+
+	data, err := getStateData(state)
+	if err != nil {
+		return framework.AsStatus(err)
+	}
+	if len(data.requests) == 0 {
+		return nil
+	}
+
+	// capacity might be changed after PreFilter, so placing is planned again for the selected node
+	nodeID := data.nodeIDs[nodeName]
+	plan, err := c.planVolumesPlacing(ctx, pod, data.requests, []string{nodeID})
+	if err != nil && err != baseerr.ErrorRejectReservationRequest {
+		return framework.AsStatus(err)
+	}
+	if plan == nil || plan.GetVolumesToACMapping(nodeID) == nil {
+		return framework.NewStatus(framework.Unschedulable, fmt.Sprintf("No available capacity found on the node %s", nodeName))
+	}
+
+	reservationHelper := capacityplanner.NewReservationHelper(c.logger, c.k8sClient, nil)
+	name := extender.GetReservationName(pod)
+	reservation := &acrcrd.AvailableCapacityReservation{}
+	err = c.k8sClient.ReadCR(ctx, name, "", reservation)
+	switch {
+	case err == nil:
+		fillReservation(reservation, pod, data.requests, nodeID)
+		err = reservationHelper.UpdateReservation(ctx, plan, []string{nodeID}, reservation)
+	case k8serrors.IsNotFound(err):
+		reservation = &acrcrd.AvailableCapacityReservation{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       v1.AvailableCapacityReservationKind,
+				APIVersion: v1.APIV1Version,
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+		}
+		fillReservation(reservation, pod, data.requests, nodeID)
+		err = reservationHelper.CreateReservation(ctx, plan, []string{nodeID}, reservation)
+	}
+	if err != nil {
+		ll.Errorf("Unable to reserve capacity: %v", err)
+		return framework.AsStatus(err)
+	}
+
+	ll.Infof("Capacity was reserved in %s", name)
+	return nil
+}
+
+// Unreserve removes AvailableCapacityReservation CR of the pod
+func (c *CSISchedulerPlugin) Unreserve(ctx context.Context, state *framework.CycleState, pod *coreV1.Pod, nodeName string) {
+	sessionUUID := uuid.New().String()
+	ll := c.logger.WithFields(logrus.Fields{
+		"sessionUUID": sessionUUID,
+		"method":      "Unreserve",
+		"pod":         pod.Name,
+		"node":        nodeName,
+	})
+	ctx = context.WithValue(ctx, base.RequestUUID, sessionUUID)
+
+	if data, err := getStateData(state); err == nil && len(data.requests) == 0 {
+		return
+	}
+
+	name := extender.GetReservationName(pod)
+	reservation := &acrcrd.AvailableCapacityReservation{}
+	if err := c.k8sClient.ReadCR(ctx, name, "", reservation); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			ll.Errorf("Unable to read reservation %s: %v", name, err)
+		}
+		return
+	}
+
+	reservationHelper := capacityplanner.NewReservationHelper(c.logger, c.k8sClient, nil)
+	if err := reservationHelper.RemoveReservation(ctx, reservation); err != nil {
+		ll.Errorf("Unable to release reservation %s: %v", name, err)
+	}
+}
+
+// planVolumesPlacing plans placing of the pod volumes on nodes, reservation of the pod itself isn't taken
+// into account since it will be replaced in Reserve
+func (c *CSISchedulerPlugin) planVolumesPlacing(ctx context.Context, pod *coreV1.Pod,
+	requests []*genV1.CapacityRequest, nodes []string) (*capacityplanner.VolumesPlacingPlan, error) {
+	volumes := make([]*genV1.Volume, len(requests))
+	for i, capacity := range requests {
+		volumes[i] = &genV1.Volume{Id: capacity.Name, Size: capacity.Size, StorageClass: capacity.StorageClass,
+			NodeId: capacity.NodeId, RaidLevel: capacity.RaidLevel, RaidDevices: capacity.RaidDevices}
+	}
+
+	acReader := capacityplanner.NewACReader(c.k8sClient, c.logger, true)
+	acrReader := capacityplanner.NewSkipReservationReader(
+		capacityplanner.NewACRReader(c.k8sClient, c.logger, true), extender.GetReservationName(pod))
+	capManager := c.capacityManagerBuilder.GetCapacityManager(c.logger, acReader, acrReader)
+
+	return capManager.PlanVolumesPlacing(ctx, volumes, nodes)
+}
+
+// rankNodes sets rank for nodes based on the formula:
+// rank of node X = max number of volumes - number of volume on node X.
+// Returns node ID to rank mapping and the maximum rank
+func (c *CSISchedulerPlugin) rankNodes(ctx context.Context) (map[string]int64, int64, error) {
+	volumeList := &volcrd.VolumeList{}
+	if err := c.k8sCache.ReadList(ctx, volumeList); err != nil {
+		return nil, 0, fmt.Errorf("unable to read volumes list: %v", err)
+	}
+
+	volumesCount := make(map[string]int64)
+	var maxCount int64
+	for _, volume := range volumeList.Items {
+		volumesCount[volume.Spec.NodeId]++
+		if volumesCount[volume.Spec.NodeId] > maxCount {
+			maxCount = volumesCount[volume.Spec.NodeId]
+		}
+	}
+
+	ranks := make(map[string]int64, len(volumesCount))
+	for node, count := range volumesCount {
+		ranks[node] = maxCount - count
+	}
+	return ranks, maxCount, nil
+}
+
+// fillReservation sets pod namespace, capacity requests and requested node in reservation
+func fillReservation(reservation *acrcrd.AvailableCapacityReservation, pod *coreV1.Pod,
+	requests []*genV1.CapacityRequest, nodeID string) {
+	namespace := pod.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	reservation.Spec.Namespace = namespace
+	reservation.Spec.ReservationRequests = make([]*genV1.ReservationRequest, len(requests))
+	for i, capacity := range requests {
+		reservation.Spec.ReservationRequests[i] = &genV1.ReservationRequest{CapacityRequest: capacity}
+	}
+	reservation.Spec.NodeRequests = &genV1.NodeRequests{Requested: []string{nodeID}}
+}
+
+// getStateData reads stateData written in PreFilter
+func getStateData(state *framework.CycleState) (*stateData, error) {
+	data, err := state.Read(stateKey)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := data.(*stateData)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type of %s state data: %T", Name, data)
+	}
+	return s, nil
 }
-*/
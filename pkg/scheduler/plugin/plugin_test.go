@@ -0,0 +1,217 @@
+/*
+Copyright © 2022 Dell Inc. or its subsidiaries. All Rights Reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	coreV1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	fakeframework "k8s.io/kubernetes/pkg/scheduler/framework/fake"
+
+	genV1 "github.com/dell/csi-baremetal/api/generated/v1"
+	v1 "github.com/dell/csi-baremetal/api/v1"
+	acrcrd "github.com/dell/csi-baremetal/api/v1/acreservationcrd"
+	"github.com/dell/csi-baremetal/pkg/base/capacityplanner"
+	baseerr "github.com/dell/csi-baremetal/pkg/base/error"
+	fc "github.com/dell/csi-baremetal/pkg/base/featureconfig"
+	"github.com/dell/csi-baremetal/pkg/base/k8s"
+	"github.com/dell/csi-baremetal/pkg/scheduler/extender"
+)
+
+var (
+	testLogger = logrus.New()
+	testCtx    = context.Background()
+	testNs     = "default"
+
+	testNode1Name = "node-1"
+	testNode1ID   = "node-1-uid"
+	testNode2Name = "node-2"
+	testNode2ID   = "node-2-uid"
+
+	testSize int64 = 1024 * 1024 * 1024
+
+	testPod = &coreV1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: testNs}}
+
+	testRequests = []*genV1.CapacityRequest{
+		{Name: "pvc-1", Size: testSize, StorageClass: v1.StorageClassHDD},
+	}
+)
+
+// fakeGatherer returns predefined capacity requests
+type fakeGatherer struct {
+	requests []*genV1.CapacityRequest
+	err      error
+}
+
+func (f *fakeGatherer) GatherCapacityRequests(_ context.Context, _ *coreV1.Pod) ([]*genV1.CapacityRequest, error) {
+	return f.requests, f.err
+}
+
+// fakeHandle is a framework.Handle which provides only node snapshot
+type fakeHandle struct {
+	framework.Handle
+	nodes framework.NodeInfoLister
+}
+
+func (f *fakeHandle) SnapshotSharedLister() framework.SharedLister {
+	return f
+}
+
+func (f *fakeHandle) NodeInfos() framework.NodeInfoLister {
+	return f.nodes
+}
+
+func getTestNodes() []*coreV1.Node {
+	return []*coreV1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: testNode1Name, UID: types.UID(testNode1ID)}},
+		{ObjectMeta: metav1.ObjectMeta{Name: testNode2Name, UID: types.UID(testNode2ID)}},
+	}
+}
+
+// setupPlugin creates plugin with fake k8s client which contains AC only on the first node
+func setupPlugin(t *testing.T, gatherer capacityRequestsGatherer) *CSISchedulerPlugin {
+	kubeClient, err := k8s.GetFakeKubeClient(testNs, testLogger)
+	assert.Nil(t, err)
+
+	ac := kubeClient.ConstructACCR("ac-1", genV1.AvailableCapacity{
+		Location:     "drive-1",
+		NodeId:       testNode1ID,
+		StorageClass: v1.StorageClassHDD,
+		Size:         testSize * 2,
+	})
+	assert.Nil(t, kubeClient.CreateCR(testCtx, ac.Name, ac))
+
+	return &CSISchedulerPlugin{
+		frameworkHandle:        &fakeHandle{nodes: getNodeInfoLister(getTestNodes())},
+		k8sClient:              kubeClient,
+		k8sCache:               kubeClient,
+		requestsGatherer:       gatherer,
+		capacityManagerBuilder: &capacityplanner.DefaultCapacityManagerBuilder{},
+		featureChecker:         fc.NewFeatureConfig(),
+		logger:                 testLogger.WithField("component", Name),
+	}
+}
+
+func getNodeInfoLister(nodes []*coreV1.Node) framework.NodeInfoLister {
+	nodeInfos := make(fakeframework.NodeInfoLister, 0, len(nodes))
+	for _, node := range nodes {
+		nodeInfos = append(nodeInfos, getNodeInfo(node))
+	}
+	return nodeInfos
+}
+
+func getNodeInfo(node *coreV1.Node) *framework.NodeInfo {
+	nodeInfo := framework.NewNodeInfo()
+	nodeInfo.SetNode(node)
+	return nodeInfo
+}
+
+func TestCSISchedulerPlugin_PreFilter(t *testing.T) {
+	t.Run("Pod without volumes", func(t *testing.T) {
+		p := setupPlugin(t, &fakeGatherer{})
+		state := framework.NewCycleState()
+
+		assert.True(t, p.PreFilter(testCtx, state, testPod).IsSuccess())
+		for _, node := range getTestNodes() {
+			assert.True(t, p.Filter(testCtx, state, testPod, getNodeInfo(node)).IsSuccess())
+		}
+	})
+
+	t.Run("Volumes aren't ready", func(t *testing.T) {
+		p := setupPlugin(t, &fakeGatherer{err: baseerr.ErrorNotFound})
+		status := p.PreFilter(testCtx, framework.NewCycleState(), testPod)
+		assert.Equal(t, framework.Unschedulable, status.Code())
+	})
+
+	t.Run("Not enough capacity", func(t *testing.T) {
+		requests := []*genV1.CapacityRequest{{Name: "pvc-1", Size: testSize * 3, StorageClass: v1.StorageClassHDD}}
+		p := setupPlugin(t, &fakeGatherer{requests: requests})
+		status := p.PreFilter(testCtx, framework.NewCycleState(), testPod)
+		assert.Equal(t, framework.Unschedulable, status.Code())
+	})
+
+	t.Run("Nodes are filtered and scored", func(t *testing.T) {
+		p := setupPlugin(t, &fakeGatherer{requests: testRequests})
+		state := framework.NewCycleState()
+
+		assert.True(t, p.PreFilter(testCtx, state, testPod).IsSuccess())
+
+		nodes := getTestNodes()
+		assert.True(t, p.Filter(testCtx, state, testPod, getNodeInfo(nodes[0])).IsSuccess())
+		assert.Equal(t, framework.Unschedulable, p.Filter(testCtx, state, testPod, getNodeInfo(nodes[1])).Code())
+
+		score, status := p.Score(testCtx, state, testPod, testNode1Name)
+		assert.True(t, status.IsSuccess())
+		assert.Equal(t, int64(0), score)
+	})
+}
+
+func TestCSISchedulerPlugin_Score(t *testing.T) {
+	p := setupPlugin(t, &fakeGatherer{requests: testRequests})
+	state := framework.NewCycleState()
+	state.Write(stateKey, &stateData{
+		requests: testRequests,
+		nodeIDs:  map[string]string{testNode1Name: testNode1ID, testNode2Name: testNode2ID},
+		ranks:    map[string]int64{testNode1ID: 0},
+		maxRank:  2,
+	})
+
+	score, status := p.Score(testCtx, state, testPod, testNode1Name)
+	assert.True(t, status.IsSuccess())
+	assert.Equal(t, int64(0), score)
+
+	score, status = p.Score(testCtx, state, testPod, testNode2Name)
+	assert.True(t, status.IsSuccess())
+	assert.Equal(t, int64(2), score)
+
+	scores := framework.NodeScoreList{{Name: testNode1Name, Score: 0}, {Name: testNode2Name, Score: 2}}
+	assert.True(t, p.NormalizeScore(testCtx, state, testPod, scores).IsSuccess())
+	assert.Equal(t, int64(0), scores[0].Score)
+	assert.Equal(t, framework.MaxNodeScore, scores[1].Score)
+}
+
+func TestCSISchedulerPlugin_Reserve(t *testing.T) {
+	p := setupPlugin(t, &fakeGatherer{requests: testRequests})
+	state := framework.NewCycleState()
+	assert.True(t, p.PreFilter(testCtx, state, testPod).IsSuccess())
+
+	name := extender.GetReservationName(testPod)
+	assert.True(t, p.Reserve(testCtx, state, testPod, testNode1Name).IsSuccess())
+	reservation := &acrcrd.AvailableCapacityReservation{}
+	assert.Nil(t, p.k8sClient.ReadCR(testCtx, name, "", reservation))
+	assert.Equal(t, v1.ReservationConfirmed, reservation.Spec.Status)
+	assert.Equal(t, testNs, reservation.Spec.Namespace)
+	assert.Equal(t, []string{testNode1ID}, reservation.Spec.NodeRequests.Reserved)
+	assert.Equal(t, []string{"ac-1"}, reservation.Spec.ReservationRequests[0].Reservations)
+
+	// existing reservation of the pod is updated and isn't taken into account
+	assert.True(t, p.Reserve(testCtx, state, testPod, testNode1Name).IsSuccess())
+
+	// node without capacity
+	assert.Equal(t, framework.Unschedulable, p.Reserve(testCtx, state, testPod, testNode2Name).Code())
+
+	p.Unreserve(testCtx, state, testPod, testNode1Name)
+	err := p.k8sClient.ReadCR(testCtx, name, "", reservation)
+	assert.True(t, k8serrors.IsNotFound(err))
+}